@@ -9,8 +9,11 @@ import (
 	kafkago "github.com/segmentio/kafka-go"
 	"github.com/spf13/viper"
 
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
 )
 
 func initConfig() error {
@@ -46,10 +49,48 @@ func RedisConfig() *redis.Config {
 	}
 }
 
-func KafkaConfig() kafkago.WriterConfig {
+// OutboxKafkaConfig leaves the topic to each message and balances by key, so
+// messages with the same key stay in one partition.
+func OutboxKafkaConfig() kafkago.WriterConfig {
 	return kafkago.WriterConfig{
 		Brokers:      []string{viper.GetString("kafka.brokers")},
-		Topic:        viper.GetString("kafka.topic"),
+		Balancer:     &kafkago.Hash{},
 		BatchTimeout: 1 * time.Millisecond,
 	}
 }
+
+// OutboxConfig maps the categories of domain events to their topics, the
+// mail to the topic of the mail sender and the mention and watch events to
+// their own topics.
+func OutboxConfig() *services.OutboxConfig {
+	topics := viper.GetStringMapString("kafka.event-topics")
+	topics[models.MailCategory] = viper.GetString("kafka.topic")
+	topics[models.MentionCategory] = viper.GetString("kafka.mention-topic")
+	topics[models.WatchCategory] = viper.GetString("kafka.watch-topic")
+
+	return &services.OutboxConfig{
+		Topics:          topics,
+		BatchSize:       viper.GetInt("outbox.batch-size"),
+		Lease:           viper.GetDuration("outbox.lease"),
+		RetryBackoff:    viper.GetDuration("outbox.retry-backoff"),
+		MaxRetryBackoff: viper.GetDuration("outbox.max-retry-backoff"),
+	}
+}
+
+func StorageConfig() *storage.Config {
+	return &storage.Config{
+		Backend: viper.GetString("storage.backend"),
+		Path:    viper.GetString("storage.path"),
+		S3: storage.S3Config{
+			Endpoint:        viper.GetString("storage.s3.endpoint"),
+			Region:          viper.GetString("storage.s3.region"),
+			Bucket:          viper.GetString("storage.s3.bucket"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		},
+		Quota: storage.Quota{
+			MaxFileSize:    viper.GetInt64("storage.max-file-size"),
+			MaxProjectSize: viper.GetInt64("storage.max-project-size"),
+		},
+	}
+}
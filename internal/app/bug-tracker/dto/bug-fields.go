@@ -0,0 +1,11 @@
+package dto
+
+type BugFieldsDto struct {
+	Severity         string `json:"severity" validate:"omitempty,oneof=trivial minor major critical blocker"`
+	Environment      string `json:"environment" validate:"max=255"`
+	AffectedVersion  string `json:"affectedVersion" validate:"max=64"`
+	StepsToReproduce string `json:"stepsToReproduce" validate:"max=10000"`
+	ExpectedResult   string `json:"expectedResult" validate:"max=10000"`
+	ActualResult     string `json:"actualResult" validate:"max=10000"`
+	Reproducibility  string `json:"reproducibility" validate:"omitempty,oneof=always sometimes rarely unable"`
+}
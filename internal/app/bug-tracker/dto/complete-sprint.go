@@ -0,0 +1,9 @@
+package dto
+
+// CompleteSprintDto closes the active sprint. Its tasks that are not done yet
+// move to the sprint MoveToSprintID or, when it is not set, to the backlog.
+type CompleteSprintDto struct {
+	ProjectID      uint64 `json:"projectId" validate:"required"`
+	SprintID       uint64 `json:"sprintId" validate:"required"`
+	MoveToSprintID uint64 `json:"moveToSprintId" validate:"omitempty,nefield=SprintID"`
+}
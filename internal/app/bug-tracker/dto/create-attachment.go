@@ -0,0 +1,11 @@
+package dto
+
+// CreateAttachmentDto describes an uploaded file. ContentType and StorageKey
+// are filled in by the service, not taken from the request.
+type CreateAttachmentDto struct {
+	TaskID      uint64 `validate:"required"`
+	FileName    string `validate:"required,max=255"`
+	Size        int64  `validate:"min=0"`
+	ContentType string
+	StorageKey  string
+}
@@ -0,0 +1,7 @@
+package dto
+
+type CreateCommentDto struct {
+	TaskID   uint64 `json:"-"`
+	ParentID uint64 `json:"parentId"`
+	Body     string `json:"body" validate:"required,max=20000"`
+}
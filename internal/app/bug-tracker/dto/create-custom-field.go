@@ -0,0 +1,10 @@
+package dto
+
+type CreateCustomFieldDto struct {
+	ProjectID uint64   `json:"projectId" validate:"required"`
+	Name      string   `json:"name" validate:"required,max=64"`
+	Type      string   `json:"type" validate:"required,oneof=text number date select multiselect user"`
+	Options   []string `json:"options" validate:"max=100,unique,dive,required,max=64"`
+	Required  bool     `json:"required"`
+	Position  int      `json:"position" validate:"gte=0"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type CreateLabelDto struct {
+	ProjectID   uint64 `json:"projectId" validate:"required"`
+	Name        string `json:"name" validate:"required,max=64"`
+	Color       string `json:"color" validate:"omitempty,hexcolor,len=7"`
+	Description string `json:"description" validate:"max=1000"`
+}
@@ -1,7 +1,10 @@
 package dto
 
 type CreateProjectDto struct {
-	Name        string `json:"name" validate:"required,min=2"`
-	Description string `json:"description"`
-	AdminID     uint64 `json:"adminId" validate:"required"`
+	Name            string `json:"name" validate:"required,min=2"`
+	Key             string `json:"key" validate:"required,min=2,max=10,alphanum,uppercase"`
+	Description     string `json:"description"`
+	AdminID         uint64 `json:"adminId" validate:"required"`
+	Visibility      string `json:"visibility" validate:"omitempty,oneof=private public"`
+	DefaultPriority string `json:"defaultPriority" validate:"omitempty,oneof=low medium high"`
 }
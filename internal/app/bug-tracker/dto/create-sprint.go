@@ -0,0 +1,9 @@
+package dto
+
+type CreateSprintDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	Name      string `json:"name" validate:"required,max=64"`
+	Goal      string `json:"goal" validate:"max=1000"`
+	StartDate string `json:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"omitempty,datetime=2006-01-02"`
+}
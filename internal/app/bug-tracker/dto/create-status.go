@@ -0,0 +1,9 @@
+package dto
+
+type CreateStatusDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	Name      string `json:"name" validate:"required,max=64"`
+	Category  string `json:"category" validate:"required,oneof=todo in_progress done"`
+	Position  int    `json:"position" validate:"gte=0"`
+	Initial   bool   `json:"initial"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type CreateTaskLinkDto struct {
+	TaskID    uint64 `json:"-"`
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	TargetID  uint64 `json:"targetId" validate:"required,nefield=TaskID"`
+	Type      string `json:"type" validate:"required,oneof=blocks is-blocked-by duplicates relates-to caused-by"`
+}
@@ -1,10 +1,18 @@
 package dto
 
 type CreateTaskDto struct {
-	Name         string `json:"name" validate:"required,min=2"`
-	Description  string `json:"description"`
-	TaskPriority string `json:"taskPriority" validate:"required"`
-	ProjectID    uint64 `json:"projectId" validate:"required"`
-	TaskType     string `json:"taskType" validate:"required"`
-	PerformTo    string `json:"performTo"`
+	Name            string                `json:"name" validate:"required,min=2"`
+	Description     string                `json:"description"`
+	TaskPriority    string                `json:"taskPriority" validate:"required"`
+	ProjectID       uint64                `json:"projectId" validate:"required"`
+	StatusID        uint64                `json:"statusId"`
+	KindID          uint64                `json:"kindId"`
+	ComponentID     uint64                `json:"componentId"`
+	AssigneeID      uint64                `json:"assigneeId"`
+	ParentID        uint64                `json:"parentId"`
+	PerformTo       string                `json:"performTo"`
+	CustomFields    []CustomFieldValueDto `json:"customFields" validate:"dive"`
+	AffectsVersions []uint64              `json:"affectsVersions" validate:"max=50,dive,required"`
+	FixVersions     []uint64              `json:"fixVersions" validate:"max=50,dive,required"`
+	BugFieldsDto
 }
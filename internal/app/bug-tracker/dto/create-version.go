@@ -0,0 +1,8 @@
+package dto
+
+type CreateVersionDto struct {
+	ProjectID   uint64 `json:"projectId" validate:"required"`
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description" validate:"max=1000"`
+	ReleaseDate string `json:"releaseDate" validate:"omitempty,datetime=2006-01-02"`
+}
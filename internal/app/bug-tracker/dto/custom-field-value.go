@@ -0,0 +1,9 @@
+package dto
+
+import "encoding/json"
+
+// CustomFieldValueDto sets a custom field of a task. A null value clears it.
+type CustomFieldValueDto struct {
+	FieldID uint64          `json:"fieldId" validate:"required"`
+	Value   json.RawMessage `json:"value"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteComponentDto struct {
+	ProjectID   uint64 `json:"projectId" validate:"required"`
+	ComponentID uint64 `json:"componentId" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteCustomFieldDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	FieldID   uint64 `json:"fieldId" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteIssueKindDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	KindID    uint64 `json:"kindId" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteLabelDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	LabelID   uint64 `json:"labelId" validate:"required"`
+}
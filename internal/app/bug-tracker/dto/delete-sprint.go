@@ -0,0 +1,6 @@
+package dto
+
+type DeleteSprintDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	SprintID  uint64 `json:"sprintId" validate:"required"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteStatusDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	StatusID  uint64 `json:"statusId" validate:"required"`
+}
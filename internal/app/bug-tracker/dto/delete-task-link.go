@@ -0,0 +1,7 @@
+package dto
+
+type DeleteTaskLinkDto struct {
+	TaskID    uint64 `json:"-"`
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	LinkID    uint64 `json:"linkId" validate:"required"`
+}
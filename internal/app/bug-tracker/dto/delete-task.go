@@ -1,6 +1,7 @@
 package dto
 
 type DeleteTaskDto struct {
-	TaskID    uint64 `json:"taskId" validate:"required"`
+	TaskID    uint64 `json:"taskId" validate:"required_without=TaskKey"`
+	TaskKey   string `json:"taskKey"`
 	ProjectID uint64 `json:"projectId" validate:"required"`
 }
@@ -0,0 +1,6 @@
+package dto
+
+type DeleteVersionDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	VersionID uint64 `json:"versionId" validate:"required"`
+}
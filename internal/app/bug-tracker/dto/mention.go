@@ -0,0 +1,10 @@
+package dto
+
+// MentionDto is the text mentions are looked for in. A zero CommentID means
+// the description of the task.
+type MentionDto struct {
+	TaskID    uint64
+	CommentID uint64
+	AuthorID  uint64
+	Text      string
+}
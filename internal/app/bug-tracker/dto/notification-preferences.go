@@ -0,0 +1,10 @@
+package dto
+
+// NotificationPreferencesDto changes the notification preferences of a user.
+// Channels maps notification types to their new channel; the types, the
+// digest time and the time zone that are left out keep their value.
+type NotificationPreferencesDto struct {
+	Channels   map[string]string `json:"channels" validate:"dive,keys,oneof=assignment comment status mention,endkeys,oneof=email digest in_app none"`
+	DigestTime string            `json:"digestTime" validate:"omitempty,datetime=15:04"`
+	TimeZone   string            `json:"timeZone" validate:"omitempty,timezone"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+// NotificationDto is a notification sent to every user in UserIDs. A zero
+// CommentID means the notification is not about a comment.
+type NotificationDto struct {
+	UserIDs   []uint64
+	Type      string
+	TaskID    uint64
+	CommentID uint64
+	ActorID   uint64
+}
+
+// NotificationFilterDto selects a page of the inbox, only the unread
+// notifications when Unread is set.
+type NotificationFilterDto struct {
+	PageDto
+	Unread bool `query:"unread"`
+}
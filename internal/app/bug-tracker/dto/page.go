@@ -0,0 +1,10 @@
+package dto
+
+const DefaultPageLimit = 20
+
+// PageDto selects a page of a list. A missing page means the first one and a
+// missing limit means DefaultPageLimit items.
+type PageDto struct {
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// ReleaseNotesTemplateDto sets the text/template a project renders its
+// Markdown release notes with. An empty template restores the default one.
+type ReleaseNotesTemplateDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	Template  string `json:"template" validate:"max=20000"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ReleaseVersionDto marks a version released. When MoveToVersionID is set the
+// tasks fixed in the version that are not done yet move on to that version.
+type ReleaseVersionDto struct {
+	ProjectID       uint64 `json:"projectId" validate:"required"`
+	VersionID       uint64 `json:"versionId" validate:"required"`
+	MoveToVersionID uint64 `json:"moveToVersionId" validate:"omitempty,nefield=VersionID"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// SprintTasksDto adds the tasks to the sprint or takes them out of it back to
+// the backlog.
+type SprintTasksDto struct {
+	ProjectID uint64   `json:"projectId" validate:"required"`
+	SprintID  uint64   `json:"sprintId" validate:"required"`
+	TaskIDs   []uint64 `json:"taskIds" validate:"required,min=1,max=100,dive,required"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+type TaskFilterDto struct {
+	Kind            string `query:"kind"`
+	Status          string `query:"status"`
+	StatusCategory  string `query:"statusCategory" validate:"omitempty,oneof=todo in_progress done"`
+	Severity        string `query:"severity" validate:"omitempty,oneof=trivial minor major critical blocker"`
+	Reproducibility string `query:"reproducibility" validate:"omitempty,oneof=always sometimes rarely unable"`
+	AffectedVersion string `query:"affectedVersion"`
+	Component       uint64 `query:"component"`
+	AffectsVersion  uint64 `query:"affectsVersion"`
+	FixVersion      uint64 `query:"fixVersion"`
+	Reporter        uint64 `query:"reporter"`
+	UpdatedBy       uint64 `query:"updatedBy"`
+	// UpdatedAfter and UpdatedBefore bound the last edit of the task itself,
+	// see models.Task.UpdatedAt, given as RFC 3339 timestamps.
+	UpdatedAfter  string `query:"updatedAfter" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	UpdatedBefore string `query:"updatedBefore" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Sprint holds a sprint id, Backlog asks for the tasks out of any sprint.
+	Sprint  uint64 `query:"sprint"`
+	Backlog bool   `query:"backlog" validate:"excluded_with=Sprint"`
+	// Labels holds label ids, LabelMatch tells whether a task needs any or all
+	// of them and defaults to any.
+	Labels     []uint64 `query:"label"`
+	LabelMatch string   `query:"labelMatch" validate:"omitempty,oneof=any all"`
+	// CustomFields holds "fieldId:value" pairs, a task has to match all of them.
+	CustomFields []string `query:"cf"`
+	// Sort is id, number, priority, createdAt, performTo, reporter, updatedAt,
+	// updatedBy or cf:<fieldId>, prefixed with "-" for descending order.
+	Sort string `query:"sort"`
+}
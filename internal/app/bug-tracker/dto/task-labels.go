@@ -0,0 +1,9 @@
+package dto
+
+// TaskLabelsDto adds or removes every label in LabelIDs on every task in
+// TaskIDs.
+type TaskLabelsDto struct {
+	ProjectID uint64   `json:"projectId" validate:"required"`
+	TaskIDs   []uint64 `json:"taskIds" validate:"required,min=1,max=100,dive,required"`
+	LabelIDs  []uint64 `json:"labelIds" validate:"required,min=1,max=50,dive,required"`
+}
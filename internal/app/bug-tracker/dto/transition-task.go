@@ -0,0 +1,8 @@
+package dto
+
+type TransitionTaskDto struct {
+	TaskID     uint64 `json:"-"`
+	ProjectID  uint64 `json:"projectId" validate:"required"`
+	StatusID   uint64 `json:"statusId" validate:"required"`
+	Resolution string `json:"resolution"`
+}
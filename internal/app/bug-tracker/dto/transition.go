@@ -0,0 +1,8 @@
+package dto
+
+type TransitionDto struct {
+	ProjectID    uint64   `json:"projectId" validate:"required"`
+	FromStatusID uint64   `json:"from" validate:"required"`
+	ToStatusID   uint64   `json:"to" validate:"required,nefield=FromStatusID"`
+	Guards       []string `json:"guards" validate:"dive,oneof=assignee_required resolution_required assignee_only reviewer_only admin_only"`
+}
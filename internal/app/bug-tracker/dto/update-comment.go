@@ -0,0 +1,7 @@
+package dto
+
+type UpdateCommentDto struct {
+	TaskID    uint64 `json:"-"`
+	CommentID uint64 `json:"-"`
+	Body      string `json:"body" validate:"required,max=20000"`
+}
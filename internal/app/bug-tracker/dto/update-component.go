@@ -0,0 +1,10 @@
+package dto
+
+type UpdateComponentDto struct {
+	ProjectID         uint64 `json:"projectId" validate:"required"`
+	ComponentID       uint64 `json:"componentId" validate:"required"`
+	Name              string `json:"name" validate:"required,max=64"`
+	Description       string `json:"description" validate:"max=1000"`
+	LeadID            uint64 `json:"leadId"`
+	DefaultAssigneeID uint64 `json:"defaultAssigneeId"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type UpdateCustomFieldDto struct {
+	ProjectID uint64   `json:"projectId" validate:"required"`
+	FieldID   uint64   `json:"fieldId" validate:"required"`
+	Name      string   `json:"name" validate:"required,max=64"`
+	Options   []string `json:"options" validate:"max=100,unique,dive,required,max=64"`
+	Required  bool     `json:"required"`
+	Position  int      `json:"position" validate:"gte=0"`
+}
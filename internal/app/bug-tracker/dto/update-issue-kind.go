@@ -0,0 +1,11 @@
+package dto
+
+type UpdateIssueKindDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	KindID    uint64 `json:"kindId" validate:"required"`
+	Name      string `json:"name" validate:"required,max=64"`
+	Icon      string `json:"icon" validate:"max=64"`
+	Position  int    `json:"position" validate:"gte=0"`
+	Default   bool   `json:"default"`
+	Bug       bool   `json:"bug"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type UpdateLabelDto struct {
+	ProjectID   uint64 `json:"projectId" validate:"required"`
+	LabelID     uint64 `json:"labelId" validate:"required"`
+	Name        string `json:"name" validate:"required,max=64"`
+	Color       string `json:"color" validate:"omitempty,hexcolor,len=7"`
+	Description string `json:"description" validate:"max=1000"`
+}
@@ -1,6 +1,12 @@
 package dto
 
 type UpdateProjectDto struct {
-	ProjectID   uint64 `json:"projectId"`
-	Description string `json:"description"`
+	ProjectID         uint64    `json:"projectId" validate:"required"`
+	Name              *string   `json:"name" validate:"omitempty,min=2"`
+	Description       *string   `json:"description"`
+	Key               *string   `json:"key" validate:"omitempty,min=2,max=10,alphanum,uppercase"`
+	Visibility        *string   `json:"visibility" validate:"omitempty,oneof=private public"`
+	DefaultPriority   *string   `json:"defaultPriority" validate:"omitempty,oneof=low medium high"`
+	RequiredBugFields *[]string `json:"requiredBugFields" validate:"omitempty,dive,oneof=severity environment affectedVersion stepsToReproduce expectedResult actualResult reproducibility"`
+	MaxTaskDepth      *int      `json:"maxTaskDepth" validate:"omitempty,min=1,max=10"`
 }
@@ -0,0 +1,10 @@
+package dto
+
+type UpdateStatusDto struct {
+	ProjectID uint64 `json:"projectId" validate:"required"`
+	StatusID  uint64 `json:"statusId" validate:"required"`
+	Name      string `json:"name" validate:"required,max=64"`
+	Category  string `json:"category" validate:"required,oneof=todo in_progress done"`
+	Position  int    `json:"position" validate:"gte=0"`
+	Initial   bool   `json:"initial"`
+}
@@ -1,11 +1,22 @@
 package dto
 
 type UpdateTaskDto struct {
-	Name         string `json:"name" validate:"required,min=2"`
-	Description  string `json:"description" validate:"required"`
-	TaskPriority string `json:"taskPriority" validate:"required"`
-	TaskID       uint64 `json:"taskId" validate:"required"`
-	ProjectID    uint64 `json:"projectId" validate:"required"`
-	TaskType     string `json:"taskType" validate:"required"`
-	PerformTo    string `json:"performTo"`
+	Name         string                `json:"name" validate:"required,min=2"`
+	Description  string                `json:"description" validate:"required"`
+	TaskPriority string                `json:"taskPriority" validate:"required"`
+	TaskID       uint64                `json:"taskId" validate:"required_without=TaskKey"`
+	TaskKey      string                `json:"taskKey"`
+	ProjectID    uint64                `json:"projectId" validate:"required"`
+	StatusID     uint64                `json:"statusId"`
+	KindID       uint64                `json:"kindId"`
+	ComponentID  uint64                `json:"componentId"`
+	ReviewerID   uint64                `json:"reviewerId"`
+	ParentID     uint64                `json:"parentId"`
+	PerformTo    string                `json:"performTo"`
+	CustomFields []CustomFieldValueDto `json:"customFields" validate:"dive"`
+	// AffectsVersions and FixVersions replace the versions of the task when
+	// they are given, an empty list clears them and a missing one keeps them.
+	AffectsVersions []uint64 `json:"affectsVersions" validate:"max=50,dive,required"`
+	FixVersions     []uint64 `json:"fixVersions" validate:"max=50,dive,required"`
+	BugFieldsDto
 }
@@ -0,0 +1,10 @@
+package dto
+
+type UpdateVersionDto struct {
+	ProjectID   uint64 `json:"projectId" validate:"required"`
+	VersionID   uint64 `json:"versionId" validate:"required"`
+	Name        string `json:"name" validate:"required,max=64"`
+	Description string `json:"description" validate:"max=1000"`
+	ReleaseDate string `json:"releaseDate" validate:"omitempty,datetime=2006-01-02"`
+	Archived    bool   `json:"archived"`
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func activityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getActivities(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	page := new(dto.PageDto)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	if err := c.Validate(page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	activities, err := h.service.Activity.GetActivities(id, userData.UserID, page)
+	if err != nil {
+		return c.JSON(activityErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, activities)
+}
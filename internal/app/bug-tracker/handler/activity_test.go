@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getActivities(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		query              string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid page",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log, params: params}
+			},
+			query:              "?limit=500",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error task not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				activity := mock_services.NewMockActivity(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				activity.EXPECT().GetActivities(uint64(1), uint64(1), &dto.PageDto{}).Return(nil, repository.ErrTaskNotFound)
+
+				serv := &services.Service{Activity: activity}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				activity := mock_services.NewMockActivity(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				activity.EXPECT().GetActivities(uint64(1), uint64(1), &dto.PageDto{Page: 1, Limit: 10}).Return(&models.ActivityPage{
+					Count: 1,
+					Page:  1,
+					Limit: 10,
+					Activities: []*models.Activity{{
+						ID:        3,
+						TaskID:    1,
+						ActorID:   sql.NullInt64{Int64: 1, Valid: true},
+						Kind:      models.ActivityAssignment,
+						Field:     sql.NullString{String: "assignee", Valid: true},
+						OldValue:  json.RawMessage("null"),
+						NewValue:  json.RawMessage("2"),
+						CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					}},
+				}, nil)
+
+				serv := &services.Service{Activity: activity}
+
+				return &Handler{serv, nil, params}
+			},
+			query:              "?page=1&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"count":1,"page":1,"limit":10,"activities":[{"id":3,"taskId":1,"actorId":{"Int64":1,"Valid":true},"kind":"assignment","field":{"String":"assignee","Valid":true},"oldValue":null,"newValue":2,"createdAt":"2023-01-01T00:00:00Z"}]}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskActivity)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getActivities(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
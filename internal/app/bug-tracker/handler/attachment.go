@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+)
+
+func attachmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrAttachmentNotFound),
+		errors.Is(err, repository.ErrTaskNotFound),
+		errors.Is(err, storage.ErrObjectNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrAttachmentTooLarge),
+		errors.Is(err, repository.ErrAttachmentQuota):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getAttachments(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	attachments, err := h.service.Attachment.GetAttachments(id, userData.UserID)
+	if err != nil {
+		return c.JSON(attachmentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, attachments)
+}
+
+func (h *Handler) uploadAttachment(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidAttachment))
+	}
+
+	attachmentData := &dto.CreateAttachmentDto{TaskID: id, FileName: file.Filename, Size: file.Size}
+	if err := c.Validate(attachmentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidAttachment))
+	}
+
+	body, err := file.Open()
+	if err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(errInternalServerError))
+	}
+	defer body.Close()
+
+	attachmentID, err := h.service.Attachment.CreateAttachment(c.Request().Context(), attachmentData, body, userData.UserID)
+	if err != nil {
+		return c.JSON(attachmentErrorStatus(err), newErrorMessage(err))
+	}
+
+	h.notifyWatchers(id, userData.UserID, models.ActivityAttachment)
+
+	return c.JSON(http.StatusOK, attachmentID)
+}
+
+// downloadAttachment always serves the file as a download with the sniffed
+// type, so an uploaded page is never rendered by the browser.
+func (h *Handler) downloadAttachment(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	attachmentID, err := h.params.GetAttachmentIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	attachment, body, err := h.service.Attachment.GetAttachment(c.Request().Context(), id, attachmentID, userData.UserID)
+	if err != nil {
+		return c.JSON(attachmentErrorStatus(err), newErrorMessage(err))
+	}
+	defer body.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, body)
+}
+
+func (h *Handler) deleteAttachment(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	attachmentID, err := h.params.GetAttachmentIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := h.service.Attachment.DeleteAttachment(c.Request().Context(), id, attachmentID, userData.UserID); err != nil {
+		return c.JSON(attachmentErrorStatus(err), newErrorMessage(err))
+	}
+
+	h.notifyWatchers(id, userData.UserID, models.ActivityAttachment)
+
+	return c.JSON(http.StatusOK, true)
+}
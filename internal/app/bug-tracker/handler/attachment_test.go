@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_uploadAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		withFile           bool
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error no file",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log, params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidAttachment.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error file is too large",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				attachment.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any(), uint64(1)).
+					Return(uint64(0), services.ErrAttachmentTooLarge)
+
+				serv := &services.Service{Attachment: attachment}
+
+				return &Handler{serv, nil, params}
+			},
+			withFile:           true,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedReturnBody: `{"message":"` + services.ErrAttachmentTooLarge.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				attachment.EXPECT().CreateAttachment(gomock.Any(), &dto.CreateAttachmentDto{TaskID: 1, FileName: "notes.txt", Size: 5}, gomock.Any(), uint64(1)).
+					DoAndReturn(func(_ interface{}, _ *dto.CreateAttachmentDto, body io.Reader, _ uint64) (uint64, error) {
+						content, err := io.ReadAll(body)
+						require.NoError(t, err)
+						require.Equal(t, "hello", string(content))
+
+						return 3, nil
+					})
+				watcher.EXPECT().NotifyWatchers(uint64(1), uint64(1), models.ActivityAttachment).Return(nil)
+
+				serv := &services.Service{Attachment: attachment, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			withFile:           true,
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "3\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			if test.withFile {
+				part, err := writer.CreateFormFile("file", "notes.txt")
+				require.NoError(t, err)
+				_, err = part.Write([]byte("hello"))
+				require.NoError(t, err)
+			}
+			require.NoError(t, writer.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskFiles)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.uploadAttachment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_downloadAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name                string
+		mockBehaviour       mockBehaviour
+		expectedStatusCode  int
+		expectedReturnBody  string
+		expectedDisposition string
+	}{
+		{
+			name: "Error attachment is not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetAttachmentIdParam(ctx).Return(uint64(3), nil)
+				attachment.EXPECT().GetAttachment(gomock.Any(), uint64(1), uint64(3), uint64(1)).
+					Return(nil, nil, repository.ErrAttachmentNotFound)
+
+				serv := &services.Service{Attachment: attachment}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrAttachmentNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetAttachmentIdParam(ctx).Return(uint64(3), nil)
+				attachment.EXPECT().GetAttachment(gomock.Any(), uint64(1), uint64(3), uint64(1)).Return(
+					&models.Attachment{ID: 3, TaskID: 1, FileName: "page.html", ContentType: "text/html; charset=utf-8", Size: 6},
+					io.NopCloser(strings.NewReader("<html>")),
+					nil,
+				)
+
+				serv := &services.Service{Attachment: attachment}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedReturnBody:  "<html>",
+			expectedDisposition: `attachment; filename=page.html`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskFile)
+			echoCtx.SetParamNames("id", "aid")
+			echoCtx.SetParamValues("1", "3")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.downloadAttachment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+			if test.expectedDisposition != "" {
+				require.Equal(t, test.expectedDisposition, rec.Header().Get(echo.HeaderContentDisposition))
+				require.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+				require.Equal(t, "6", rec.Header().Get(echo.HeaderContentLength))
+			}
+		})
+	}
+}
+
+func Test_deleteAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetAttachmentIdParam(ctx).Return(uint64(3), nil)
+				attachment.EXPECT().DeleteAttachment(gomock.Any(), uint64(1), uint64(3), uint64(1)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Attachment: attachment}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				attachment := mock_services.NewMockAttachment(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetAttachmentIdParam(ctx).Return(uint64(3), nil)
+				attachment.EXPECT().DeleteAttachment(gomock.Any(), uint64(1), uint64(3), uint64(1)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), uint64(1), models.ActivityAttachment).Return(nil)
+
+				serv := &services.Service{Attachment: attachment, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskFile)
+			echoCtx.SetParamNames("id", "aid")
+			echoCtx.SetParamValues("1", "3")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteAttachment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
@@ -53,12 +53,7 @@ func (h *Handler) verifyEmail(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
 	}
 
-	message := verifyEmail.Email
-	err := h.kafka.Write(message)
-	if err == nil {
-		h.log.Infof("[Kafka] Sent message: %s", message)
-	} else {
-		h.log.Error(err)
+	if err := h.service.Outbox.QueueMail(verifyEmail.Email); err != nil {
 		return c.JSON(http.StatusInternalServerError, newErrorMessage(errInternalServerError))
 	}
 
@@ -16,7 +16,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
-	mock_kafka "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka/mocks"
 	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
@@ -41,7 +40,7 @@ func Test_signUp(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			userData:           nil,
 			userDataJSON:       `{"invalid"}`,
@@ -55,7 +54,7 @@ func Test_signUp(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			userData:           nil,
 			userDataJSON:       `{"name": "Name", "email": "email", "password": "password"}`,
@@ -71,7 +70,7 @@ func Test_signUp(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignUpDto{
 				Name:     "Name",
@@ -93,7 +92,7 @@ func Test_signUp(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignUpDto{
 				Name:     "Name",
@@ -118,7 +117,7 @@ func Test_signUp(t *testing.T) {
 
 				serv := &services.Service{User: user, Redis: redis}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignUpDto{
 				Name:     "Name",
@@ -144,7 +143,7 @@ func Test_signUp(t *testing.T) {
 
 				serv := &services.Service{User: user, Redis: redis}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignUpDto{
 				Name:     "Name",
@@ -170,7 +169,7 @@ func Test_signUp(t *testing.T) {
 
 				serv := &services.Service{User: user, Redis: redis}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignUpDto{
 				Name:     "Name",
@@ -231,7 +230,7 @@ func Test_verifyEmail(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			verifyEmail:        nil,
 			verifyEmailJSON:    `{"invalid"}`,
@@ -239,15 +238,13 @@ func Test_verifyEmail(t *testing.T) {
 			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
 		},
 		{
-			name: "Error in kafka",
+			name: "Error in QueueMail",
 			mockBehaviour: func(c *gomock.Controller, verifyEmail *dto.VerifyEmail) *Handler {
-				log := mock_log.NewMockLog(c)
-				kafka := mock_kafka.NewMockKafka(c)
+				outbox := mock_services.NewMockOutbox(c)
 
-				kafka.EXPECT().Write(verifyEmail.Email).Return(errors.New("error"))
-				log.EXPECT().Error(gomock.Any()).Return()
+				outbox.EXPECT().QueueMail(verifyEmail.Email).Return(errors.New("error"))
 
-				return &Handler{nil, log, kafka, nil}
+				return &Handler{&services.Service{Outbox: outbox}, nil, nil}
 			},
 			verifyEmail:        &dto.VerifyEmail{Email: "email@gmail.com"},
 			verifyEmailJSON:    `{"email": "email@gmail.com"}`,
@@ -257,13 +254,11 @@ func Test_verifyEmail(t *testing.T) {
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, verifyEmail *dto.VerifyEmail) *Handler {
-				log := mock_log.NewMockLog(c)
-				kafka := mock_kafka.NewMockKafka(c)
+				outbox := mock_services.NewMockOutbox(c)
 
-				kafka.EXPECT().Write(verifyEmail.Email).Return(nil)
-				log.EXPECT().Infof("[Kafka] Sent message: %s", verifyEmail.Email)
+				outbox.EXPECT().QueueMail(verifyEmail.Email).Return(nil)
 
-				return &Handler{nil, log, kafka, nil}
+				return &Handler{&services.Service{Outbox: outbox}, nil, nil}
 			},
 			verifyEmailJSON:    `{"email": "email@gmail.com"}`,
 			verifyEmail:        &dto.VerifyEmail{Email: "email@gmail.com"},
@@ -319,7 +314,7 @@ func Test_setEmail(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			verifyEmail:        nil,
 			verifyEmailJSON:    `{"invalid"}`,
@@ -335,7 +330,7 @@ func Test_setEmail(t *testing.T) {
 
 				redis.EXPECT().Set(ctx, verifyEmail.Email, "verified", time.Minute*10).Return(errors.New("error"))
 
-				return &Handler{&services.Service{Redis: redis}, log, nil, nil}
+				return &Handler{&services.Service{Redis: redis}, log, nil}
 			},
 			verifyEmail:        &dto.VerifyEmail{Email: "email@gmail.com"},
 			verifyEmailJSON:    `{"email": "email@gmail.com"}`,
@@ -350,7 +345,7 @@ func Test_setEmail(t *testing.T) {
 
 				redis.EXPECT().Set(ctx, verifyEmail.Email, "verified", time.Minute*10).Return(nil)
 
-				return &Handler{&services.Service{Redis: redis}, nil, nil, nil}
+				return &Handler{&services.Service{Redis: redis}, nil, nil}
 			},
 			verifyEmail:        &dto.VerifyEmail{Email: "email@gmail.com"},
 			verifyEmailJSON:    `{"email": "email@gmail.com"}`,
@@ -406,7 +401,7 @@ func Test_signIn(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			userData:           nil,
 			userDataJSON:       `{"invalid"}`,
@@ -420,7 +415,7 @@ func Test_signIn(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			userData:           nil,
 			userDataJSON:       `{"email": "email", "password": "password"}`,
@@ -438,7 +433,7 @@ func Test_signIn(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, log, nil, nil}
+				return &Handler{serv, log, nil}
 			},
 			userData: &dto.SignInDto{
 				Email:    "email@gmail.com",
@@ -460,7 +455,7 @@ func Test_signIn(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &dto.SignInDto{
 				Email:    "email@gmail.com",
@@ -521,7 +516,7 @@ func Test_refresh(t *testing.T) {
 		{
 			name: "No refresh token",
 			mockBehaviour: func(c *gomock.Controller, refreshToken string) *Handler {
-				return &Handler{nil, nil, nil, nil}
+				return &Handler{nil, nil, nil}
 			},
 			refreshToken:       "",
 			refreshTokenCookie: &http.Cookie{},
@@ -535,7 +530,7 @@ func Test_refresh(t *testing.T) {
 
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(nil, errors.New("error"))
 
-				return &Handler{&services.Service{Auth: auth}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -564,7 +559,7 @@ func Test_refresh(t *testing.T) {
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(tokenData, nil)
 				redis.EXPECT().GetRefreshToken(ctx, key).Return("", errors.New("error"))
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -593,7 +588,7 @@ func Test_refresh(t *testing.T) {
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(tokenData, nil)
 				redis.EXPECT().GetRefreshToken(ctx, key).Return("token", nil)
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -658,7 +653,7 @@ func Test_logout(t *testing.T) {
 		{
 			name: "No refresh token",
 			mockBehaviour: func(c *gomock.Controller, refreshToken string) *Handler {
-				return &Handler{nil, nil, nil, nil}
+				return &Handler{nil, nil, nil}
 			},
 			refreshToken:       "",
 			refreshTokenCookie: &http.Cookie{},
@@ -672,7 +667,7 @@ func Test_logout(t *testing.T) {
 
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(nil, errors.New("error"))
 
-				return &Handler{&services.Service{Auth: auth}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -701,7 +696,7 @@ func Test_logout(t *testing.T) {
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(tokenData, nil)
 				redis.EXPECT().DeleteRefreshToken(ctx, key).Return(errors.New("error"))
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -730,7 +725,7 @@ func Test_logout(t *testing.T) {
 				auth.EXPECT().ParseRefreshToken(refreshToken).Return(tokenData, nil)
 				redis.EXPECT().DeleteRefreshToken(ctx, key).Return(nil)
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, nil, nil}
 			},
 			refreshToken: "token",
 			refreshTokenCookie: &http.Cookie{
@@ -795,7 +790,7 @@ func Test_createTokens(t *testing.T) {
 				auth.EXPECT().GenerateAccessToken(username, userID).Return("", errors.New("error"))
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{&services.Service{Auth: auth}, log, nil, nil}
+				return &Handler{&services.Service{Auth: auth}, log, nil}
 			},
 			username:           "username",
 			userID:             uint64(1),
@@ -812,7 +807,7 @@ func Test_createTokens(t *testing.T) {
 				auth.EXPECT().GenerateRefreshToken(username, userID).Return(&services.RefreshTokenData{}, errors.New("error"))
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{&services.Service{Auth: auth}, log, nil, nil}
+				return &Handler{&services.Service{Auth: auth}, log, nil}
 			},
 			username:           "username",
 			userID:             uint64(1),
@@ -837,7 +832,7 @@ func Test_createTokens(t *testing.T) {
 				key := fmt.Sprintf("%s:%s", username, refreshTokenData.ID)
 				redis.EXPECT().SetRefreshToken(context.Background(), key, refreshTokenData.RefreshToken).Return(errors.New("error"))
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, log, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, log, nil}
 			},
 			username:           "username",
 			userID:             uint64(1),
@@ -863,7 +858,7 @@ func Test_createTokens(t *testing.T) {
 				redis.EXPECT().SetRefreshToken(context.Background(), key, refreshTokenData.RefreshToken).Return(nil)
 				auth.EXPECT().GetRefreshTokenTTL().Return(time.Minute)
 
-				return &Handler{&services.Service{Auth: auth, Redis: redis}, log, nil, nil}
+				return &Handler{&services.Service{Auth: auth, Redis: redis}, log, nil}
 			},
 			username:           "username",
 			userID:             uint64(1),
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func commentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrCommentNotFound),
+		errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrCommentDeleted),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getComments(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	page := new(dto.PageDto)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	if err := c.Validate(page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	comments, err := h.service.Comment.GetComments(id, userData.UserID, page)
+	if err != nil {
+		return c.JSON(commentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, comments)
+}
+
+func (h *Handler) createComment(c echo.Context) error {
+	commentData := new(dto.CreateCommentDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := c.Bind(commentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+	commentData.TaskID = id
+
+	if err := c.Validate(commentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidCommentData))
+	}
+
+	commentID, err := h.service.Comment.CreateComment(commentData, userData.UserID)
+	if err != nil {
+		return c.JSON(commentErrorStatus(err), newErrorMessage(err))
+	}
+
+	h.notifyMentions(&dto.MentionDto{
+		TaskID:    id,
+		CommentID: commentID,
+		AuthorID:  userData.UserID,
+		Text:      commentData.Body,
+	})
+	h.notifyWatchers(id, userData.UserID, models.ActivityComment)
+
+	return c.JSON(http.StatusOK, commentID)
+}
+
+func (h *Handler) updateComment(c echo.Context) error {
+	commentData := new(dto.UpdateCommentDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	commentID, err := h.params.GetCommentIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(commentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+	commentData.TaskID = id
+	commentData.CommentID = commentID
+
+	if err := c.Validate(commentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidCommentData))
+	}
+
+	if err := h.service.Comment.UpdateComment(commentData, userData.UserID); err != nil {
+		return c.JSON(commentErrorStatus(err), newErrorMessage(err))
+	}
+
+	h.notifyMentions(&dto.MentionDto{
+		TaskID:    id,
+		CommentID: commentID,
+		AuthorID:  userData.UserID,
+		Text:      commentData.Body,
+	})
+	h.notifyWatchers(id, userData.UserID, models.ActivityComment)
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteComment(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	commentID, err := h.params.GetCommentIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := h.service.Comment.DeleteComment(id, commentID, userData.UserID); err != nil {
+		return c.JSON(commentErrorStatus(err), newErrorMessage(err))
+	}
+
+	h.notifyWatchers(id, userData.UserID, models.ActivityComment)
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) getCommentHistory(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	commentID, err := h.params.GetCommentIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	revisions, err := h.service.Comment.GetCommentHistory(id, commentID, userData.UserID)
+	if err != nil {
+		return c.JSON(commentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
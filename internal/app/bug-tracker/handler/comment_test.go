@@ -0,0 +1,417 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getComments(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		query              string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid page",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log, params: params}
+			},
+			query:              "?limit=500",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				comment.EXPECT().GetComments(uint64(1), uint64(1), &dto.PageDto{}).Return(nil, repository.ErrNoRights)
+
+				serv := &services.Service{Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				comment.EXPECT().GetComments(uint64(1), uint64(1), &dto.PageDto{Page: 2, Limit: 10}).Return(&models.CommentPage{
+					Count:   1,
+					Threads: 11,
+					Page:    2,
+					Limit:   10,
+					Comments: []*models.Comment{{
+						ID:        2,
+						TaskID:    1,
+						Body:      "body",
+						CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					}},
+				}, nil)
+
+				serv := &services.Service{Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			query:              "?page=2&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"count":1,"threads":11,"page":2,"limit":10,"comments":[{"id":2,"taskId":1,"parentId":{"Int64":0,"Valid":false},"authorId":{"Int64":0,"Valid":false},"body":"body","createdAt":"2023-01-01T00:00:00Z","editedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskComments)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getComments(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context, data *dto.CreateCommentDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateCommentDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateCommentDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid comment data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateCommentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log, params: params}
+			},
+			dataJSON:           `{"body": ""}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidCommentData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error parent is deleted",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateCommentDto, userID uint64) *Handler {
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				comment.EXPECT().CreateComment(data, userID).Return(uint64(0), repository.ErrCommentDeleted)
+
+				serv := &services.Service{Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.CreateCommentDto{TaskID: 1, ParentID: 2, Body: "body"},
+			dataJSON:           `{"parentId": 2, "body": "body"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrCommentDeleted.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateCommentDto, userID uint64) *Handler {
+				comment := mock_services.NewMockComment(c)
+				mention := mock_services.NewMockMention(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				comment.EXPECT().CreateComment(data, userID).Return(uint64(3), nil)
+				mention.EXPECT().SaveMentions(&dto.MentionDto{TaskID: 1, CommentID: 3, AuthorID: userID, Text: data.Body}).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityComment).Return(nil)
+
+				serv := &services.Service{Comment: comment, Mention: mention, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.CreateCommentDto{TaskID: 1, Body: "**body**"},
+			dataJSON:           `{"body": "**body**"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `3` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetPath(taskComments)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx, test.data, userID)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createComment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context, data *dto.UpdateCommentDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateCommentDto
+		dataJSON           string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid comment id",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.UpdateCommentDto, userID uint64) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetCommentIdParam(ctx).Return(uint64(0), errInvalidParam)
+
+				return &Handler{params: params}
+			},
+			dataJSON:           `{"body": "body"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error not the author",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.UpdateCommentDto, userID uint64) *Handler {
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetCommentIdParam(ctx).Return(uint64(2), nil)
+				comment.EXPECT().UpdateComment(data, userID).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.UpdateCommentDto{TaskID: 1, CommentID: 2, Body: "body"},
+			dataJSON:           `{"body": "body"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.UpdateCommentDto, userID uint64) *Handler {
+				comment := mock_services.NewMockComment(c)
+				mention := mock_services.NewMockMention(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetCommentIdParam(ctx).Return(uint64(2), nil)
+				comment.EXPECT().UpdateComment(data, userID).Return(nil)
+				mention.EXPECT().SaveMentions(&dto.MentionDto{TaskID: 1, CommentID: 2, AuthorID: userID, Text: data.Body}).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityComment).Return(nil)
+
+				serv := &services.Service{Comment: comment, Mention: mention, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.UpdateCommentDto{TaskID: 1, CommentID: 2, Body: "body"},
+			dataJSON:           `{"body": "body"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskComment)
+			echoCtx.SetParamNames("id", "cid")
+			echoCtx.SetParamValues("1", "2")
+
+			handler := test.mockBehaviour(c, echoCtx, test.data, 1)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateComment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error comment is not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetCommentIdParam(ctx).Return(uint64(2), nil)
+				comment.EXPECT().DeleteComment(uint64(1), uint64(2), uint64(1)).Return(repository.ErrCommentNotFound)
+
+				serv := &services.Service{Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrCommentNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				comment := mock_services.NewMockComment(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				params.EXPECT().GetCommentIdParam(ctx).Return(uint64(2), nil)
+				comment.EXPECT().DeleteComment(uint64(1), uint64(2), uint64(1)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), uint64(1), models.ActivityComment).Return(nil)
+
+				serv := &services.Service{Comment: comment, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskComment)
+			echoCtx.SetParamNames("id", "cid")
+			echoCtx.SetParamValues("1", "2")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteComment(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
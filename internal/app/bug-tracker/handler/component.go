@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func componentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrComponentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrComponentNameTaken),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrComponentUserNotMember):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getComponents(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	components, err := h.service.Component.GetComponents(id, userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, components)
+}
+
+func (h *Handler) createComponent(c echo.Context) error {
+	componentData := new(dto.CreateComponentDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidComponentData))
+	}
+
+	id, err := h.service.Component.CreateComponent(componentData, userData.UserID)
+	if err != nil {
+		return c.JSON(componentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, id)
+}
+
+func (h *Handler) updateComponent(c echo.Context) error {
+	componentData := new(dto.UpdateComponentDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidComponentData))
+	}
+
+	if err := h.service.Component.UpdateComponent(componentData, userData.UserID); err != nil {
+		return c.JSON(componentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteComponent(c echo.Context) error {
+	componentData := new(dto.DeleteComponentDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(componentData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidComponentData))
+	}
+
+	if err := h.service.Component.DeleteComponent(componentData, userData.UserID); err != nil {
+		return c.JSON(componentErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,501 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getComponents(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get components",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				component := mock_services.NewMockComponent(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				component.EXPECT().GetComponents(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				component := mock_services.NewMockComponent(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				component.EXPECT().GetComponents(projectID, userID).Return([]*models.Component{{ID: 1, ProjectID: 1, Name: "API", Lead: sql.NullInt64{Int64: 2, Valid: true}}}, nil)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"name":"API","description":"","lead":{"Int64":2,"Valid":true},"defaultAssignee":{"Int64":0,"Valid":false}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getComponents)
+
+			echoCtx.SetPath(components)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getComponents(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateComponentDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid component data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": ""}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidComponentData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error component name taken",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().CreateComponent(data, userID).Return(uint64(0), repository.ErrComponentNameTaken)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateComponentDto{ProjectID: 1, Name: "API", LeadID: 2},
+			dataJSON:           `{"projectId": 1, "name": "API", "leadId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrComponentNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().CreateComponent(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateComponentDto{ProjectID: 1, Name: "API", LeadID: 2},
+			dataJSON:           `{"projectId": 1, "name": "API", "leadId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, component, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createComponent(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateComponentDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid component data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "Web"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidComponentData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error component not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().UpdateComponent(data, userID).Return(repository.ErrComponentNotFound)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateComponentDto{ProjectID: 1, ComponentID: 2, Name: "Web"},
+			dataJSON:           `{"projectId": 1, "componentId": 2, "name": "Web"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrComponentNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().UpdateComponent(data, userID).Return(nil)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateComponentDto{ProjectID: 1, ComponentID: 2, Name: "Web"},
+			dataJSON:           `{"projectId": 1, "componentId": 2, "name": "Web"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, component, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateComponent(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteComponentDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid component data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidComponentData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error component not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().DeleteComponent(data, userID).Return(repository.ErrComponentNotFound)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteComponentDto{ProjectID: 1, ComponentID: 2},
+			dataJSON:           `{"projectId": 1, "componentId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrComponentNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *Handler {
+				component := mock_services.NewMockComponent(c)
+
+				component.EXPECT().DeleteComponent(data, userID).Return(nil)
+
+				serv := &services.Service{Component: component}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteComponentDto{ProjectID: 1, ComponentID: 2},
+			dataJSON:           `{"projectId": 1, "componentId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, component, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteComponent(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
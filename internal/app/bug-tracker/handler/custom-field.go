@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func customFieldErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrCustomFieldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrCustomFieldNameTaken),
+		errors.Is(err, repository.ErrCustomFieldOptionInUse),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrCustomFieldOptions):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getCustomFields(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	fields, err := h.service.CustomField.GetCustomFields(id, userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, fields)
+}
+
+func (h *Handler) createCustomField(c echo.Context) error {
+	fieldData := new(dto.CreateCustomFieldDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidCustomFieldData))
+	}
+
+	id, err := h.service.CustomField.CreateCustomField(fieldData, userData.UserID)
+	if err != nil {
+		return c.JSON(customFieldErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, id)
+}
+
+func (h *Handler) updateCustomField(c echo.Context) error {
+	fieldData := new(dto.UpdateCustomFieldDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidCustomFieldData))
+	}
+
+	if err := h.service.CustomField.UpdateCustomField(fieldData, userData.UserID); err != nil {
+		return c.JSON(customFieldErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteCustomField(c echo.Context) error {
+	fieldData := new(dto.DeleteCustomFieldDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(fieldData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidCustomFieldData))
+	}
+
+	if err := h.service.CustomField.DeleteCustomField(fieldData, userData.UserID); err != nil {
+		return c.JSON(customFieldErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
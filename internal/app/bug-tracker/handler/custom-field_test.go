@@ -0,0 +1,500 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getCustomFields(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get custom fields",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				field := mock_services.NewMockCustomField(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				field.EXPECT().GetCustomFields(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				field := mock_services.NewMockCustomField(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				field.EXPECT().GetCustomFields(projectID, userID).Return([]*models.CustomField{{ID: 1, ProjectID: 1, Name: "customer", Type: "text", Options: []string{}, Required: true}}, nil)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"name":"customer","type":"text","options":[],"required":true,"position":0}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getCustomFields)
+
+			echoCtx.SetPath(customFields)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getCustomFields(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateCustomFieldDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid custom field data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "customer", "type": "color"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidCustomFieldData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error custom field name taken",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().CreateCustomField(data, userID).Return(uint64(0), repository.ErrCustomFieldNameTaken)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateCustomFieldDto{ProjectID: 1, Name: "platform", Type: "select", Options: []string{"web", "ios"}},
+			dataJSON:           `{"projectId": 1, "name": "platform", "type": "select", "options": ["web", "ios"]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrCustomFieldNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().CreateCustomField(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateCustomFieldDto{ProjectID: 1, Name: "platform", Type: "select", Options: []string{"web", "ios"}},
+			dataJSON:           `{"projectId": 1, "name": "platform", "type": "select", "options": ["web", "ios"]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, customField, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createCustomField(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateCustomFieldDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid custom field data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "client"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidCustomFieldData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error custom field not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().UpdateCustomField(data, userID).Return(repository.ErrCustomFieldNotFound)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateCustomFieldDto{ProjectID: 1, FieldID: 2, Name: "client"},
+			dataJSON:           `{"projectId": 1, "fieldId": 2, "name": "client"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrCustomFieldNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().UpdateCustomField(data, userID).Return(nil)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateCustomFieldDto{ProjectID: 1, FieldID: 2, Name: "client"},
+			dataJSON:           `{"projectId": 1, "fieldId": 2, "name": "client"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, customField, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateCustomField(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteCustomFieldDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid custom field data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidCustomFieldData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error custom field not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().DeleteCustomField(data, userID).Return(repository.ErrCustomFieldNotFound)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteCustomFieldDto{ProjectID: 1, FieldID: 2},
+			dataJSON:           `{"projectId": 1, "fieldId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrCustomFieldNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *Handler {
+				field := mock_services.NewMockCustomField(c)
+
+				field.EXPECT().DeleteCustomField(data, userID).Return(nil)
+
+				serv := &services.Service{CustomField: field}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteCustomFieldDto{ProjectID: 1, FieldID: 2},
+			dataJSON:           `{"projectId": 1, "fieldId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, customField, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteCustomField(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
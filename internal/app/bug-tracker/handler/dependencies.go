@@ -9,12 +9,14 @@ import (
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
 )
 
 type Dependencies struct {
-	db    *sql.DB
-	redis redis.Redis
-	kafka kafka.Kafka
+	db      *sql.DB
+	redis   redis.Redis
+	outbox  kafka.Kafka
+	storage storage.Storage
 }
 
 var (
@@ -22,6 +24,7 @@ var (
 	newRedisClient = redis.NewClient
 	newRedis       = redis.NewRedis
 	newKafkaWriter = kafka.NewKafkaWriter
+	newStorage     = storage.New
 )
 
 func createDependencies(logger log.Log) (*Dependencies, func()) {
@@ -41,10 +44,24 @@ func createDependencies(logger log.Log) (*Dependencies, func()) {
 
 	redisRepo := newRedis(redisClient, logger)
 
-	k := newKafkaWriter(configs.KafkaConfig(), logger)
+	store, err := newStorage(configs.StorageConfig())
+	if err != nil {
+		logger.Fatal(err)
+		return nil, func() {}
+	}
+	logger.Info("Storage opened")
+
+	outbox := newKafkaWriter(configs.OutboxKafkaConfig(), logger)
 	logger.Info("Kafka started")
 
-	return &Dependencies{db: db, redis: redisRepo, kafka: k}, func() {
+	dep := &Dependencies{
+		db:      db,
+		redis:   redisRepo,
+		outbox:  outbox,
+		storage: store,
+	}
+
+	return dep, func() {
 		if err := db.Close(); err != nil {
 			logger.Error(err)
 		}
@@ -55,7 +72,7 @@ func createDependencies(logger log.Log) (*Dependencies, func()) {
 		}
 		logger.Info("Redis connection closed")
 
-		if err := k.Close(); err != nil {
+		if err := outbox.Close(); err != nil {
 			logger.Error(err)
 		}
 		logger.Info("Kafka connection closed")
@@ -20,6 +20,8 @@ import (
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis"
 	mock_redis "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis/mocks"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+	mock_storage "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage/mocks"
 )
 
 func Test_createDependencies(t *testing.T) {
@@ -69,6 +71,36 @@ func Test_createDependencies(t *testing.T) {
 				return logger
 			},
 		},
+		{
+			name: "Error in newStorage",
+			mockBehaviour: func(c *gomock.Controller) log.Log {
+				logger := mock_log.NewMockLog(c)
+
+				openPostgres = func(cfg *repository.PostgresConfig) (*sql.DB, error) {
+					return db, nil
+				}
+
+				logger.EXPECT().Info("Open PostgreSQL db connection").Return()
+
+				newRedisClient = func(ctx context.Context, cfg *redis.Config) (*redisgo.Client, error) {
+					return redisDB, nil
+				}
+
+				logger.EXPECT().Info("Redis started").Return()
+
+				newRedis = func(client *redisgo.Client, log log.Log) redis.Redis {
+					return mock_redis.NewMockRedis(c)
+				}
+
+				newStorage = func(cfg *storage.Config) (storage.Storage, error) {
+					return nil, err
+				}
+
+				logger.EXPECT().Fatal(err)
+
+				return logger
+			},
+		},
 		{
 			name: "Error in close func",
 			mockBehaviour: func(c *gomock.Controller) log.Log {
@@ -86,6 +118,12 @@ func Test_createDependencies(t *testing.T) {
 
 				logger.EXPECT().Info("Redis started").Return()
 
+				newStorage = func(cfg *storage.Config) (storage.Storage, error) {
+					return mock_storage.NewMockStorage(c), nil
+				}
+
+				logger.EXPECT().Info("Storage opened").Return()
+
 				kafkaMock := mock_kafka.NewMockKafka(c)
 				newKafkaWriter = func(cfg kafkago.WriterConfig, log log.Log) kafka.Kafka {
 					return kafkaMock
@@ -134,6 +172,12 @@ func Test_createDependencies(t *testing.T) {
 					return redisMock
 				}
 
+				newStorage = func(cfg *storage.Config) (storage.Storage, error) {
+					return mock_storage.NewMockStorage(c), nil
+				}
+
+				logger.EXPECT().Info("Storage opened").Return()
+
 				kafkaMock := mock_kafka.NewMockKafka(c)
 				newKafkaWriter = func(cfg kafkago.WriterConfig, log log.Log) kafka.Kafka {
 					return kafkaMock
@@ -163,11 +207,13 @@ func Test_createDependencies(t *testing.T) {
 			oldNewRedisClient := newRedisClient
 			oldNewKafkaWriter := newKafkaWriter
 			oldNewRedis := newRedis
+			oldNewStorage := newStorage
 			defer func() {
 				openPostgres = oldOpenPostgres
 				newRedisClient = oldNewRedisClient
 				newRedis = oldNewRedis
 				newKafkaWriter = oldNewKafkaWriter
+				newStorage = oldNewStorage
 			}()
 
 			logger := test.mockBehaviour(c)
@@ -177,7 +223,8 @@ func Test_createDependencies(t *testing.T) {
 
 			if test.name == "OK" {
 				require.NotNil(t, dp.db)
-				require.NotNil(t, dp.kafka)
+				require.NotNil(t, dp.outbox)
+				require.NotNil(t, dp.storage)
 				require.NotNil(t, dp.redis)
 			}
 		})
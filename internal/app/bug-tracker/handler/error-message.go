@@ -1,5 +1,10 @@
 package handler
 
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
 type errorMessage struct {
 	Message string `json:"message"`
 }
@@ -7,3 +12,21 @@ type errorMessage struct {
 func newErrorMessage(err error) *errorMessage {
 	return &errorMessage{Message: err.Error()}
 }
+
+type guardErrorMessage struct {
+	Message string                `json:"message"`
+	Guards  []*models.FailedGuard `json:"guards"`
+}
+
+func newGuardErrorMessage(err *repository.GuardError) *guardErrorMessage {
+	return &guardErrorMessage{Message: err.Error(), Guards: err.Failed}
+}
+
+type requiredFieldsErrorMessage struct {
+	Message string   `json:"message"`
+	Fields  []string `json:"fields"`
+}
+
+func newRequiredFieldsErrorMessage(err *repository.RequiredFieldsError) *requiredFieldsErrorMessage {
+	return &requiredFieldsErrorMessage{Message: err.Error(), Fields: err.Fields}
+}
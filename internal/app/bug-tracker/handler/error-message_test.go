@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 )
 
 func Test_newErrorMessage(t *testing.T) {
@@ -13,3 +16,17 @@ func Test_newErrorMessage(t *testing.T) {
 
 	require.Equal(t, &errorMessage{Message: str}, msg)
 }
+
+func Test_newGuardErrorMessage(t *testing.T) {
+	failed := []*models.FailedGuard{{Guard: models.GuardReviewerOnly, Message: "only the reviewer may perform this transition"}}
+	msg := newGuardErrorMessage(&repository.GuardError{Failed: failed})
+
+	require.Equal(t, &guardErrorMessage{Message: "error transition guards failed", Guards: failed}, msg)
+}
+
+func Test_newRequiredFieldsErrorMessage(t *testing.T) {
+	fields := []string{models.BugFieldSeverity, models.BugFieldStepsToReproduce}
+	msg := newRequiredFieldsErrorMessage(&repository.RequiredFieldsError{Fields: fields})
+
+	require.Equal(t, &requiredFieldsErrorMessage{Message: "error required fields are missing", Fields: fields}, msg)
+}
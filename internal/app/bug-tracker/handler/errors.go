@@ -25,4 +25,18 @@ var (
 
 	errInvalidTaskData = errors.New("error invalid task data")
 	errTaskNotFound    = errors.New("error task is not found")
+
+	errInvalidWorkflowData    = errors.New("error invalid workflow data")
+	errInvalidIssueKindData   = errors.New("error invalid issue kind data")
+	errInvalidTaskFilter      = errors.New("error invalid task filter")
+	errInvalidCustomFieldData = errors.New("error invalid custom field data")
+	errInvalidLabelData       = errors.New("error invalid label data")
+	errInvalidComponentData   = errors.New("error invalid component data")
+	errInvalidVersionData     = errors.New("error invalid version data")
+	errInvalidNotesTemplate   = errors.New("error invalid release notes template data")
+	errInvalidSprintData      = errors.New("error invalid sprint data")
+	errInvalidTaskLinkData    = errors.New("error invalid task link data")
+	errInvalidCommentData     = errors.New("error invalid comment data")
+	errInvalidAttachment      = errors.New("error invalid attachment")
+	errInvalidPreferences     = errors.New("error invalid notification preferences")
 )
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// correlationID returns the id of the request, set by the RequestID
+// middleware or sent by the client.
+func correlationID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+// newEvent builds the event of a change made by the request. It is saved to
+// the outbox together with the change, so it is sent only if the change is
+// committed.
+func newEvent(c echo.Context, eventType string, actorID, projectID, taskID uint64, payload interface{}) *models.Event {
+	event := models.NewEvent(eventType, actorID, projectID, taskID, payload)
+	event.CorrelationID = correlationID(c)
+
+	return event
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+type eventTypeMatcher string
+
+func (m eventTypeMatcher) Matches(x interface{}) bool {
+	event, ok := x.(*models.Event)
+
+	return ok && event.Type == string(m)
+}
+
+func (m eventTypeMatcher) String() string {
+	return "is an event of type " + string(m)
+}
+
+// eventOfType matches the events of the type passed to a service with the
+// change they tell about.
+func eventOfType(eventType string) gomock.Matcher {
+	return eventTypeMatcher(eventType)
+}
+
+func Test_newEvent(t *testing.T) {
+	e := echo.New()
+	defer e.Close()
+
+	echoCtx := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+	echoCtx.Response().Header().Set(echo.HeaderXRequestID, "request")
+
+	payload := &models.AssigneePayload{AssigneeID: 1}
+	event := newEvent(echoCtx, models.EventTaskAssigned, 1, 2, 3, payload)
+
+	require.Equal(t, models.EventVersion, event.Version)
+	require.NotEmpty(t, event.ID)
+	require.Equal(t, models.EventTaskAssigned, event.Type)
+	require.Equal(t, uint64(1), event.ActorID)
+	require.Equal(t, uint64(2), event.ProjectID)
+	require.Equal(t, uint64(3), event.TaskID)
+	require.Equal(t, payload, event.Payload)
+	require.Equal(t, "request", event.CorrelationID)
+}
+
+func Test_correlationID(t *testing.T) {
+	e := echo.New()
+	defer e.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "client")
+	echoCtx := e.NewContext(req, httptest.NewRecorder())
+
+	require.Equal(t, "client", correlationID(echoCtx))
+
+	echoCtx.Response().Header().Set(echo.HeaderXRequestID, "server")
+	require.Equal(t, "server", correlationID(echoCtx))
+}
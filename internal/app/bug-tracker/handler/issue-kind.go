@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func issueKindErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrIssueKindNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrIssueKindNameTaken),
+		errors.Is(err, repository.ErrIssueKindInUse),
+		errors.Is(err, repository.ErrIssueKindDefault),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getIssueKinds(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	kinds, err := h.service.IssueKind.GetIssueKinds(id, userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, kinds)
+}
+
+func (h *Handler) createIssueKind(c echo.Context) error {
+	kindData := new(dto.CreateIssueKindDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidIssueKindData))
+	}
+
+	id, err := h.service.IssueKind.CreateIssueKind(kindData, userData.UserID)
+	if err != nil {
+		return c.JSON(issueKindErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, id)
+}
+
+func (h *Handler) updateIssueKind(c echo.Context) error {
+	kindData := new(dto.UpdateIssueKindDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidIssueKindData))
+	}
+
+	if err := h.service.IssueKind.UpdateIssueKind(kindData, userData.UserID); err != nil {
+		return c.JSON(issueKindErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteIssueKind(c echo.Context) error {
+	kindData := new(dto.DeleteIssueKindDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(kindData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidIssueKindData))
+	}
+
+	if err := h.service.IssueKind.DeleteIssueKind(kindData, userData.UserID); err != nil {
+		return c.JSON(issueKindErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
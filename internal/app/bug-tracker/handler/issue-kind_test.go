@@ -0,0 +1,500 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getIssueKinds(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get issue kinds",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				kind.EXPECT().GetIssueKinds(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				kind.EXPECT().GetIssueKinds(projectID, userID).Return([]*models.IssueKind{{ID: 1, ProjectID: 1, Name: "bug", Icon: "bug", Default: false}}, nil)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"name":"bug","icon":"bug","position":0,"default":false,"bug":false}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getIssueKinds)
+
+			echoCtx.SetPath(kinds)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getIssueKinds(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateIssueKindDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid issue kind data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "spike", "position": -1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidIssueKindData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error issue kind name taken",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().CreateIssueKind(data, userID).Return(uint64(0), repository.ErrIssueKindNameTaken)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateIssueKindDto{ProjectID: 1, Name: "spike", Icon: "flask"},
+			dataJSON:           `{"projectId": 1, "name": "spike", "icon": "flask"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrIssueKindNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().CreateIssueKind(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateIssueKindDto{ProjectID: 1, Name: "spike", Icon: "flask"},
+			dataJSON:           `{"projectId": 1, "name": "spike", "icon": "flask"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, kind, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createIssueKind(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateIssueKindDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid issue kind data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "defect"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidIssueKindData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error issue kind not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().UpdateIssueKind(data, userID).Return(repository.ErrIssueKindNotFound)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateIssueKindDto{ProjectID: 1, KindID: 2, Name: "defect", Icon: "bug"},
+			dataJSON:           `{"projectId": 1, "kindId": 2, "name": "defect", "icon": "bug"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrIssueKindNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().UpdateIssueKind(data, userID).Return(nil)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateIssueKindDto{ProjectID: 1, KindID: 2, Name: "defect", Icon: "bug"},
+			dataJSON:           `{"projectId": 1, "kindId": 2, "name": "defect", "icon": "bug"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, kind, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateIssueKind(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteIssueKindDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid issue kind data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidIssueKindData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error issue kind in use",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().DeleteIssueKind(data, userID).Return(repository.ErrIssueKindInUse)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteIssueKindDto{ProjectID: 1, KindID: 2},
+			dataJSON:           `{"projectId": 1, "kindId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrIssueKindInUse.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *Handler {
+				kind := mock_services.NewMockIssueKind(c)
+
+				kind.EXPECT().DeleteIssueKind(data, userID).Return(nil)
+
+				serv := &services.Service{IssueKind: kind}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteIssueKindDto{ProjectID: 1, KindID: 2},
+			dataJSON:           `{"projectId": 1, "kindId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, kind, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteIssueKind(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
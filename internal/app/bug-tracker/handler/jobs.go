@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"time"
+)
+
+// defaultJobInterval is used when a job interval is missing from the config
+// or is not positive, since time.NewTicker panics on such durations.
+const defaultJobInterval = time.Minute
+
+func runJob(ctx context.Context, interval time.Duration, job func()) {
+	if interval <= 0 {
+		interval = defaultJobInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job()
+		}
+	}
+}
+
+func (h *Handler) purgeTrash(retention time.Duration) func() {
+	return func() {
+		count, err := h.service.Project.PurgeTrash(retention)
+		if err != nil {
+			h.log.Error(err)
+			return
+		}
+
+		if count > 0 {
+			h.log.Infof("Purged %d projects from trash", count)
+		}
+	}
+}
+
+func (h *Handler) relayOutbox(ctx context.Context) func() {
+	return func() {
+		count, err := h.service.Outbox.RelayOutbox(ctx)
+		if err != nil {
+			h.log.Error(err)
+		}
+
+		if count > 0 {
+			h.log.Infof("Relayed %d outbox messages", count)
+		}
+	}
+}
+
+func (h *Handler) purgeOutbox(retention time.Duration) func() {
+	return func() {
+		count, err := h.service.Outbox.PurgeOutbox(retention)
+		if err != nil {
+			h.log.Error(err)
+			return
+		}
+
+		if count > 0 {
+			h.log.Infof("Purged %d sent outbox messages", count)
+		}
+	}
+}
+
+func (h *Handler) sendDigests(limit int) func() {
+	return func() {
+		count, err := h.service.Notification.SendDigests(limit)
+		if err != nil {
+			h.log.Error(err)
+		}
+
+		if count > 0 {
+			h.log.Infof("Sent %d digests", count)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_runJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 1)
+	done := make(chan struct{})
+
+	go func() {
+		runJob(ctx, time.Millisecond, func() {
+			select {
+			case calls <- struct{}{}:
+			default:
+			}
+		})
+		close(done)
+	}()
+
+	<-calls
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runJob did not stop after context cancellation")
+	}
+}
+
+func Test_runJobZeroInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		runJob(ctx, 0, func() {})
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runJob did not stop after context cancellation")
+	}
+}
+
+func Test_purgeTrash(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, retention time.Duration) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		retention     time.Duration
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				project := mock_services.NewMockProject(c)
+				log := mock_log.NewMockLog(c)
+
+				project.EXPECT().PurgeTrash(retention).Return(int64(0), err)
+				log.EXPECT().Error(err).Return()
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, log, nil}
+			},
+			retention: time.Hour,
+		},
+		{
+			name: "Nothing to purge",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().PurgeTrash(retention).Return(int64(0), nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			retention: time.Hour,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				project := mock_services.NewMockProject(c)
+				log := mock_log.NewMockLog(c)
+
+				project.EXPECT().PurgeTrash(retention).Return(int64(2), nil)
+				log.EXPECT().Infof("Purged %d projects from trash", int64(2)).Return()
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, log, nil}
+			},
+			retention: time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			handler := test.mockBehaviour(c, test.retention)
+			handler.purgeTrash(test.retention)()
+		})
+	}
+}
+
+func Test_relayOutbox(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx context.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, ctx context.Context) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+				log := mock_log.NewMockLog(c)
+
+				outbox.EXPECT().RelayOutbox(ctx).Return(0, err)
+				log.EXPECT().Error(err).Return()
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, log, nil}
+			},
+		},
+		{
+			name: "Error after sending some",
+			mockBehaviour: func(c *gomock.Controller, ctx context.Context) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+				log := mock_log.NewMockLog(c)
+
+				outbox.EXPECT().RelayOutbox(ctx).Return(2, err)
+				log.EXPECT().Error(err).Return()
+				log.EXPECT().Infof("Relayed %d outbox messages", 2).Return()
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, log, nil}
+			},
+		},
+		{
+			name: "Nothing to relay",
+			mockBehaviour: func(c *gomock.Controller, ctx context.Context) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+
+				outbox.EXPECT().RelayOutbox(ctx).Return(0, nil)
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, nil, nil}
+			},
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx context.Context) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+				log := mock_log.NewMockLog(c)
+
+				outbox.EXPECT().RelayOutbox(ctx).Return(3, nil)
+				log.EXPECT().Infof("Relayed %d outbox messages", 3).Return()
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, log, nil}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			handler := test.mockBehaviour(c, ctx)
+			handler.relayOutbox(ctx)()
+		})
+	}
+}
+
+func Test_purgeOutbox(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, retention time.Duration) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		retention     time.Duration
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+				log := mock_log.NewMockLog(c)
+
+				outbox.EXPECT().PurgeOutbox(retention).Return(int64(0), err)
+				log.EXPECT().Error(err).Return()
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, log, nil}
+			},
+			retention: time.Hour,
+		},
+		{
+			name: "Nothing to purge",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+
+				outbox.EXPECT().PurgeOutbox(retention).Return(int64(0), nil)
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, nil, nil}
+			},
+			retention: time.Hour,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, retention time.Duration) *Handler {
+				outbox := mock_services.NewMockOutbox(c)
+				log := mock_log.NewMockLog(c)
+
+				outbox.EXPECT().PurgeOutbox(retention).Return(int64(2), nil)
+				log.EXPECT().Infof("Purged %d sent outbox messages", int64(2)).Return()
+
+				serv := &services.Service{Outbox: outbox}
+
+				return &Handler{serv, log, nil}
+			},
+			retention: time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			handler := test.mockBehaviour(c, test.retention)
+			handler.purgeOutbox(test.retention)()
+		})
+	}
+}
+
+func Test_sendDigests(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+	}{
+		{
+			name: "Error after sending some",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+				log := mock_log.NewMockLog(c)
+
+				notification.EXPECT().SendDigests(100).Return(2, err)
+				log.EXPECT().Error(err).Return()
+				log.EXPECT().Infof("Sent %d digests", 2).Return()
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, log, nil}
+			},
+		},
+		{
+			name: "Nothing to send",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().SendDigests(100).Return(0, nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+				log := mock_log.NewMockLog(c)
+
+				notification.EXPECT().SendDigests(100).Return(3, nil)
+				log.EXPECT().Infof("Sent %d digests", 3).Return()
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, log, nil}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			handler := test.mockBehaviour(c)
+			handler.sendDigests(100)()
+		})
+	}
+}
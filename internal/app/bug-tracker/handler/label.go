@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrLabelNotFound),
+		errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrLabelNameTaken),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getLabels(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	labels, err := h.service.Label.GetLabels(id, userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, labels)
+}
+
+func (h *Handler) createLabel(c echo.Context) error {
+	labelData := new(dto.CreateLabelDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidLabelData))
+	}
+
+	id, err := h.service.Label.CreateLabel(labelData, userData.UserID)
+	if err != nil {
+		return c.JSON(labelErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, id)
+}
+
+func (h *Handler) updateLabel(c echo.Context) error {
+	labelData := new(dto.UpdateLabelDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidLabelData))
+	}
+
+	if err := h.service.Label.UpdateLabel(labelData, userData.UserID); err != nil {
+		return c.JSON(labelErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteLabel(c echo.Context) error {
+	labelData := new(dto.DeleteLabelDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(labelData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidLabelData))
+	}
+
+	if err := h.service.Label.DeleteLabel(labelData, userData.UserID); err != nil {
+		return c.JSON(labelErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) addTaskLabels(c echo.Context) error {
+	labelsData := new(dto.TaskLabelsDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(labelsData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(labelsData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidLabelData))
+	}
+
+	if err := h.service.Label.AddTaskLabels(labelsData, userData.UserID); err != nil {
+		return c.JSON(labelErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) removeTaskLabels(c echo.Context) error {
+	labelsData := new(dto.TaskLabelsDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(labelsData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(labelsData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidLabelData))
+	}
+
+	if err := h.service.Label.RemoveTaskLabels(labelsData, userData.UserID); err != nil {
+		return c.JSON(labelErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
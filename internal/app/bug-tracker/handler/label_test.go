@@ -0,0 +1,736 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get labels",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				label := mock_services.NewMockLabel(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				label.EXPECT().GetLabels(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				label := mock_services.NewMockLabel(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				label.EXPECT().GetLabels(projectID, userID).Return([]*models.Label{{ID: 1, ProjectID: 1, Name: "backend", Color: "#808080"}}, nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"name":"backend","color":"#808080","description":""}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getLabels)
+
+			echoCtx.SetPath(labels)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getLabels(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateLabelDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid label data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "backend", "color": "grey"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidLabelData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error label name taken",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().CreateLabel(data, userID).Return(uint64(0), repository.ErrLabelNameTaken)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateLabelDto{ProjectID: 1, Name: "ui", Color: "#ff0000"},
+			dataJSON:           `{"projectId": 1, "name": "ui", "color": "#ff0000"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrLabelNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().CreateLabel(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateLabelDto{ProjectID: 1, Name: "ui", Color: "#ff0000"},
+			dataJSON:           `{"projectId": 1, "name": "ui", "color": "#ff0000"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, label, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createLabel(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateLabelDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid label data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "frontend"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidLabelData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error label not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().UpdateLabel(data, userID).Return(repository.ErrLabelNotFound)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateLabelDto{ProjectID: 1, LabelID: 2, Name: "frontend"},
+			dataJSON:           `{"projectId": 1, "labelId": 2, "name": "frontend"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrLabelNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().UpdateLabel(data, userID).Return(nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateLabelDto{ProjectID: 1, LabelID: 2, Name: "frontend"},
+			dataJSON:           `{"projectId": 1, "labelId": 2, "name": "frontend"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, label, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateLabel(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteLabelDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid label data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidLabelData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error label not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().DeleteLabel(data, userID).Return(repository.ErrLabelNotFound)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteLabelDto{ProjectID: 1, LabelID: 2},
+			dataJSON:           `{"projectId": 1, "labelId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrLabelNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().DeleteLabel(data, userID).Return(nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteLabelDto{ProjectID: 1, LabelID: 2},
+			dataJSON:           `{"projectId": 1, "labelId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, label, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteLabel(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_addTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.TaskLabelsDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid label data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "taskIds": [], "labelIds": [3]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidLabelData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error label not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().AddTaskLabels(data, userID).Return(repository.ErrLabelNotFound)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2}, LabelIDs: []uint64{3}},
+			dataJSON:           `{"projectId": 1, "taskIds": [1, 2], "labelIds": [3]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrLabelNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().AddTaskLabels(data, userID).Return(nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2}, LabelIDs: []uint64{3}},
+			dataJSON:           `{"projectId": 1, "taskIds": [1, 2], "labelIds": [3]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, task+taskLabels, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.addTaskLabels(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_removeTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.TaskLabelsDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid label data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "taskIds": [], "labelIds": [3]}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidLabelData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error label not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().RemoveTaskLabels(data, userID).Return(repository.ErrLabelNotFound)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2}, LabelIDs: []uint64{3}},
+			dataJSON:           `{"projectId": 1, "taskIds": [1, 2], "labelIds": [3]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrLabelNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *Handler {
+				label := mock_services.NewMockLabel(c)
+
+				label.EXPECT().RemoveTaskLabels(data, userID).Return(nil)
+
+				serv := &services.Service{Label: label}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2}, LabelIDs: []uint64{3}},
+			dataJSON:           `{"projectId": 1, "taskIds": [1, 2], "labelIds": [3]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, task+taskLabels, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.removeTaskLabels(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
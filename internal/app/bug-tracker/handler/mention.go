@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+)
+
+// notifyMentions is called once a text is saved, so a failure to handle its
+// mentions does not fail the request and is only logged.
+func (h *Handler) notifyMentions(mentionData *dto.MentionDto) {
+	if err := h.service.Mention.SaveMentions(mentionData); err != nil {
+		h.log.Error(err)
+	}
+}
+
+func (h *Handler) getUserMentions(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	page := new(dto.PageDto)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	if err := c.Validate(page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	mentions, err := h.service.Mention.GetMentions(userData.UserID, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, mentions)
+}
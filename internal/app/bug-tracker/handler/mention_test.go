@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getUserMentions(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		query              string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid page",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			query:              "?page=0x",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error in Mention.GetMentions",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				mention := mock_services.NewMockMention(c)
+
+				mention.EXPECT().GetMentions(uint64(2), &dto.PageDto{}).Return(nil, err)
+
+				serv := &services.Service{Mention: mention}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				mention := mock_services.NewMockMention(c)
+
+				mention.EXPECT().GetMentions(uint64(2), &dto.PageDto{Page: 1, Limit: 5}).Return([]*models.Mention{{
+					ID:        7,
+					Task:      &models.LinkedTask{ID: 1, Key: "KEY-1", Name: "Login", Status: "To Do", Category: "todo"},
+					CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				}}, nil)
+
+				serv := &services.Service{Mention: mention}
+
+				return &Handler{serv, nil, nil}
+			},
+			query:              "?page=1&limit=5",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":7,"task":{"id":1,"key":"KEY-1","name":"Login","status":"To Do","statusCategory":"todo"},"commentId":{"Int64":0,"Valid":false},"mentionedBy":{"Int64":0,"Valid":false},"createdAt":"2023-01-01T00:00:00Z"}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetPath(userMentions)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getUserMentions(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
@@ -34,6 +34,36 @@ func (m *MockParams) EXPECT() *MockParamsMockRecorder {
 	return m.recorder
 }
 
+// GetAttachmentIdParam mocks base method.
+func (m *MockParams) GetAttachmentIdParam(c echo.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentIdParam", c)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentIdParam indicates an expected call of GetAttachmentIdParam.
+func (mr *MockParamsMockRecorder) GetAttachmentIdParam(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentIdParam", reflect.TypeOf((*MockParams)(nil).GetAttachmentIdParam), c)
+}
+
+// GetCommentIdParam mocks base method.
+func (m *MockParams) GetCommentIdParam(c echo.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentIdParam", c)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentIdParam indicates an expected call of GetCommentIdParam.
+func (mr *MockParamsMockRecorder) GetCommentIdParam(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentIdParam", reflect.TypeOf((*MockParams)(nil).GetCommentIdParam), c)
+}
+
 // GetIdParam mocks base method.
 func (m *MockParams) GetIdParam(c echo.Context) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +93,18 @@ func (mr *MockParamsMockRecorder) GetUsernameParam(c interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsernameParam", reflect.TypeOf((*MockParams)(nil).GetUsernameParam), c)
 }
+
+// GetVersionIdParam mocks base method.
+func (m *MockParams) GetVersionIdParam(c echo.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersionIdParam", c)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersionIdParam indicates an expected call of GetVersionIdParam.
+func (mr *MockParamsMockRecorder) GetVersionIdParam(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersionIdParam", reflect.TypeOf((*MockParams)(nil).GetVersionIdParam), c)
+}
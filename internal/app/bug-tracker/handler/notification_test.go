@@ -0,0 +1,574 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getNotifications(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		query              string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid filter",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			query:              "?unread=maybe",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid page",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			query:              "?limit=1000",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error in Notification.GetNotifications",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetNotifications(uint64(2), &dto.NotificationFilterDto{}).Return(nil, err)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetNotifications(uint64(2), &dto.NotificationFilterDto{
+					PageDto: dto.PageDto{Page: 1, Limit: 5},
+					Unread:  true,
+				}).Return([]*models.Notification{{
+					ID:        7,
+					Type:      models.NotificationStatus,
+					Task:      &models.LinkedTask{ID: 1, Key: "KEY-1", Name: "Login", Status: "To Do", Category: "todo"},
+					CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				}}, nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			query:              "?page=1&limit=5&unread=true",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":7,"type":"status","task":{"id":1,"key":"KEY-1","name":"Login","status":"To Do","statusCategory":"todo"},"commentId":{"Int64":0,"Valid":false},"actorId":{"Int64":0,"Valid":false},"createdAt":"2023-01-01T00:00:00Z","readAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetPath(notifications)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getNotifications(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_getUnreadNotificationCount(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in Notification.GetUnreadCount",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetUnreadCount(gomock.Any(), uint64(2)).Return(0, err)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetUnreadCount(gomock.Any(), uint64(2)).Return(3, nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"count":3}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 2})
+			echoCtx.SetPath(unreadCount)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getUnreadNotificationCount(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_markNotificationRead(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid id",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+
+				return &Handler{nil, nil, params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error notification not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				notification := mock_services.NewMockNotification(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(5), nil)
+				notification.EXPECT().MarkRead(gomock.Any(), uint64(5), uint64(2)).
+					Return(repository.ErrNotificationNotFound)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrNotificationNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				notification := mock_services.NewMockNotification(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(5), nil)
+				notification.EXPECT().MarkRead(gomock.Any(), uint64(5), uint64(2)).Return(nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 2})
+			echoCtx.SetPath(readNotification)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("5")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.markNotificationRead(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_markAllNotificationsRead(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in Notification.MarkAllRead",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().MarkAllRead(gomock.Any(), uint64(2)).Return(err)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().MarkAllRead(gomock.Any(), uint64(2)).Return(nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 2})
+			echoCtx.SetPath(readAll)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.markAllNotificationsRead(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_getNotificationPreferences(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in Notification.GetPreferences",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetPreferences(uint64(2)).Return(nil, err)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().GetPreferences(uint64(2)).Return(models.NewNotificationPreferences(), nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"channels":{"assignment":"in_app","comment":"in_app","mention":"in_app","status":"in_app"},"digestTime":"09:00","timeZone":"UTC"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 2})
+			echoCtx.SetPath(preferences)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getNotificationPreferences(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateNotificationPreferences(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		dataJSON           string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid json",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			dataJSON:           `{"channels":`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error unknown type",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			dataJSON:           `{"channels":{"watch":"email"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidPreferences.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error unknown channel",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			dataJSON:           `{"channels":{"mention":"sms"}}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidPreferences.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid digest time",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			dataJSON:           `{"digestTime":"25:00"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidPreferences.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid time zone",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			dataJSON:           `{"timeZone":"Mars/Olympus"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidPreferences.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error in Notification.UpdatePreferences",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				notification.EXPECT().UpdatePreferences(uint64(2), &dto.NotificationPreferencesDto{
+					TimeZone: "Europe/Berlin",
+				}).Return(nil, err)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			dataJSON:           `{"timeZone":"Europe/Berlin"}`,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				notification := mock_services.NewMockNotification(c)
+
+				preferences := models.NewNotificationPreferences()
+				preferences.Channels[models.NotificationMention] = models.ChannelEmail
+				preferences.Channels[models.NotificationStatus] = models.ChannelDigest
+				preferences.DigestTime = "18:30"
+
+				notification.EXPECT().UpdatePreferences(uint64(2), &dto.NotificationPreferencesDto{
+					Channels: map[string]string{
+						models.NotificationMention: models.ChannelEmail,
+						models.NotificationStatus:  models.ChannelDigest,
+					},
+					DigestTime: "18:30",
+				}).Return(preferences, nil)
+
+				serv := &services.Service{Notification: notification}
+
+				return &Handler{serv, nil, nil}
+			},
+			dataJSON:           `{"channels":{"mention":"email","status":"digest"},"digestTime":"18:30"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"channels":{"assignment":"in_app","comment":"in_app","mention":"email","status":"digest"},"digestTime":"18:30","timeZone":"UTC"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 2})
+			echoCtx.SetPath(preferences)
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateNotificationPreferences(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 type Params interface {
 	GetIdParam(c echo.Context) (uint64, error)
 	GetUsernameParam(c echo.Context) (string, error)
+	GetVersionIdParam(c echo.Context) (uint64, error)
+	GetCommentIdParam(c echo.Context) (uint64, error)
+	GetAttachmentIdParam(c echo.Context) (uint64, error)
 }
 
 type params struct{}
@@ -30,6 +33,51 @@ func (p *params) GetIdParam(c echo.Context) (uint64, error) {
 	return uint64ID, nil
 }
 
+func (p *params) GetVersionIdParam(c echo.Context) (uint64, error) {
+	id := c.Param("vid")
+
+	if id == "" {
+		return 0, errInvalidParam
+	}
+
+	uint64ID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, errInvalidParam
+	}
+
+	return uint64ID, nil
+}
+
+func (p *params) GetCommentIdParam(c echo.Context) (uint64, error) {
+	id := c.Param("cid")
+
+	if id == "" {
+		return 0, errInvalidParam
+	}
+
+	uint64ID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, errInvalidParam
+	}
+
+	return uint64ID, nil
+}
+
+func (p *params) GetAttachmentIdParam(c echo.Context) (uint64, error) {
+	id := c.Param("aid")
+
+	if id == "" {
+		return 0, errInvalidParam
+	}
+
+	uint64ID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, errInvalidParam
+	}
+
+	return uint64ID, nil
+}
+
 func (p *params) GetUsernameParam(c echo.Context) (string, error) {
 	username := c.Param("username")
 
@@ -115,3 +115,171 @@ func Test_GetUsernameParam(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetVersionIdParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		paramId        string
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:           "Error empty param",
+			paramId:        "",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "Error invalid param",
+			paramId:        "1b",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "OK",
+			paramId:        "1",
+			expectedResult: 1,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetPath("/:vid")
+			echoCtx.SetParamNames("vid")
+			echoCtx.SetParamValues(test.paramId)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			p := &params{}
+			id, err := p.GetVersionIdParam(echoCtx)
+
+			require.Equal(t, test.expectedResult, id)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetCommentIdParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		paramId        string
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:           "Error empty param",
+			paramId:        "",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "Error invalid param",
+			paramId:        "1b",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "OK",
+			paramId:        "1",
+			expectedResult: 1,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetPath("/:cid")
+			echoCtx.SetParamNames("cid")
+			echoCtx.SetParamValues(test.paramId)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			p := &params{}
+			id, err := p.GetCommentIdParam(echoCtx)
+
+			require.Equal(t, test.expectedResult, id)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetAttachmentIdParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		paramId        string
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:           "Error empty param",
+			paramId:        "",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "Error invalid param",
+			paramId:        "1b",
+			expectedResult: 0,
+			expectedError:  errInvalidParam,
+		},
+		{
+			name:           "OK",
+			paramId:        "1",
+			expectedResult: 1,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetPath("/:aid")
+			echoCtx.SetParamNames("aid")
+			echoCtx.SetParamValues(test.paramId)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			p := &params{}
+			id, err := p.GetAttachmentIdParam(echoCtx)
+
+			require.Equal(t, test.expectedResult, id)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
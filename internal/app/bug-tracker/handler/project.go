@@ -1,13 +1,31 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 )
 
+func projectErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrProjectNotFound),
+		errors.Is(err, repository.ErrProjectNotInTrash):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrProjectArchived),
+		errors.Is(err, repository.ErrProjectNameTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (h *Handler) createProject(c echo.Context) error {
 	projectData := new(dto.CreateProjectDto)
 
@@ -21,7 +39,11 @@ func (h *Handler) createProject(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidProjectData))
 	}
 
-	id, err := h.service.Project.CreateProject(projectData)
+	event := newEvent(c, models.EventProjectCreated, projectData.AdminID, 0, 0, projectData)
+	id, err := h.service.Project.CreateProject(projectData, event)
+	if errors.Is(err, repository.ErrProjectKeyTaken) || errors.Is(err, repository.ErrProjectNameTaken) {
+		return c.JSON(http.StatusConflict, newErrorMessage(err))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, newErrorMessage(errInternalServerError))
 	}
@@ -49,12 +71,26 @@ func (h *Handler) getProjectByIdWithTasks(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
 	}
 
+	filter := new(dto.TaskFilterDto)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, filter); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskFilter))
+	}
+
+	if err := c.Validate(filter); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskFilter))
+	}
+
 	project, err := h.service.Project.GetProjectById(id)
 	if err != nil {
 		return c.JSON(http.StatusNotFound, newErrorMessage(err))
 	}
 
-	tasks, err := h.service.Task.GetTasksByProjectId(id)
+	tasks, err := h.service.Task.GetTasksByProjectId(id, filter)
+	if errors.Is(err, repository.ErrInvalidTaskFilter) {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskFilter))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
 	}
@@ -76,8 +112,9 @@ func (h *Handler) deleteProject(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
 	}
 
-	if err := h.service.Project.DeleteProject(id, userData.UserID); err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	event := newEvent(c, models.EventProjectDeleted, userData.UserID, id, 0, nil)
+	if err := h.service.Project.DeleteProject(id, userData.UserID, event); err != nil {
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
 	}
 
 	return c.JSON(http.StatusOK, true)
@@ -95,7 +132,17 @@ func (h *Handler) updateProject(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
 	}
 
-	err = h.service.Project.UpdateProject(projectData, userData.UserID)
+	if err := c.Validate(projectData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidProjectData))
+	}
+
+	event := newEvent(c, models.EventProjectUpdated, userData.UserID, projectData.ProjectID, 0, projectData)
+	err = h.service.Project.UpdateProject(projectData, userData.UserID, event)
+	if errors.Is(err, repository.ErrProjectKeyTaken) || errors.Is(err, repository.ErrProjectNameTaken) ||
+		errors.Is(err, repository.ErrProjectArchived) {
+		return c.JSON(http.StatusConflict, newErrorMessage(err))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, newErrorMessage(errInternalServerError))
 	}
@@ -119,9 +166,11 @@ func (h *Handler) addMember(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidOperation))
 	}
 
-	err = h.service.Project.AddMember(memberData, userData.UserID)
+	member := &models.MemberPayload{MemberID: memberData.MemberID}
+	event := newEvent(c, models.EventMemberAdded, userData.UserID, memberData.ProjectID, 0, member)
+	err = h.service.Project.AddMember(memberData, userData.UserID, event)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
 	}
 
 	return c.JSON(http.StatusOK, true)
@@ -143,9 +192,11 @@ func (h *Handler) deleteMember(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidOperation))
 	}
 
-	err = h.service.Project.DeleteMember(memberData, userData.UserID)
+	member := &models.MemberPayload{MemberID: memberData.MemberID}
+	event := newEvent(c, models.EventMemberRemoved, userData.UserID, memberData.ProjectID, 0, member)
+	err = h.service.Project.DeleteMember(memberData, userData.UserID, event)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
 	}
 
 	return c.JSON(http.StatusOK, true)
@@ -170,7 +221,7 @@ func (h *Handler) getMembers(c echo.Context) error {
 	return c.JSON(http.StatusOK, members)
 }
 
-func (h *Handler) leaveProject(c echo.Context) error {
+func (h *Handler) getProjectAudit(c echo.Context) error {
 	id, err := h.params.GetIdParam(c)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
@@ -181,11 +232,31 @@ func (h *Handler) leaveProject(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
 	}
 
-	err = h.service.Project.LeaveProject(id, userData.UserID)
+	entries, err := h.service.Project.GetProjectAudit(id, userData.UserID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
 	}
 
+	return c.JSON(http.StatusOK, entries)
+}
+
+func (h *Handler) leaveProject(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	event := newEvent(c, models.EventMemberLeft, userData.UserID, id, 0, &models.MemberPayload{MemberID: userData.UserID})
+	err = h.service.Project.LeaveProject(id, userData.UserID, event)
+	if err != nil {
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
+	}
+
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -201,10 +272,89 @@ func (h *Handler) setNewAdmin(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
 	}
 
-	err = h.service.Project.SetNewAdmin(newAdminData, userData.UserID)
+	event := newEvent(
+		c,
+		models.EventProjectAdminChanged,
+		userData.UserID,
+		newAdminData.ProjectID,
+		0,
+		&models.AdminPayload{AdminID: newAdminData.NewAdminID},
+	)
+	err = h.service.Project.SetNewAdmin(newAdminData, userData.UserID, event)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) archiveProject(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	event := newEvent(c, models.EventProjectArchived, userData.UserID, id, 0, nil)
+	if err := h.service.Project.ArchiveProject(id, userData.UserID, event); err != nil {
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) unarchiveProject(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	event := newEvent(c, models.EventProjectUnarchived, userData.UserID, id, 0, nil)
+	if err := h.service.Project.UnarchiveProject(id, userData.UserID, event); err != nil {
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
 	}
 
 	return c.JSON(http.StatusOK, true)
 }
+
+func (h *Handler) restoreProject(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	event := newEvent(c, models.EventProjectRestored, userData.UserID, id, 0, nil)
+	if err := h.service.Project.RestoreProject(id, userData.UserID, event); err != nil {
+		return c.JSON(projectErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) getTrash(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	projects, err := h.service.Project.GetTrash(userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, projects)
+}
@@ -16,6 +16,7 @@ import (
 	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
 	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
 	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
 )
@@ -39,7 +40,7 @@ func Test_createProject(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			projectData:        nil,
 			projectDataJSON:    `{"invalid"}`,
@@ -53,7 +54,7 @@ func Test_createProject(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			projectData:        nil,
 			projectDataJSON:    `{"name": "N"}`,
@@ -65,38 +66,61 @@ func Test_createProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().CreateProject(projectData).Return(uint64(0), err)
+				project.EXPECT().CreateProject(projectData, eventOfType(models.EventProjectCreated)).Return(uint64(0), err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			projectData: &dto.CreateProjectDto{
 				Name:        "name",
+				Key:         "KEY",
 				Description: "description",
 				AdminID:     1,
 			},
-			projectDataJSON:    `{"name": "name", "description": "description", "adminId": 1}`,
+			projectDataJSON:    `{"name": "name", "key": "KEY", "description": "description", "adminId": 1}`,
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + errInternalServerError.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error project key taken",
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().CreateProject(projectData, eventOfType(models.EventProjectCreated)).Return(uint64(0), repository.ErrProjectKeyTaken)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			projectData: &dto.CreateProjectDto{
+				Name:        "name",
+				Key:         "KEY",
+				Description: "description",
+				AdminID:     1,
+			},
+			projectDataJSON:    `{"name": "name", "key": "KEY", "description": "description", "adminId": 1}`,
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectKeyTaken.Error() + `"}` + "\n",
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().CreateProject(projectData).Return(uint64(1), nil)
+				project.EXPECT().CreateProject(projectData, eventOfType(models.EventProjectCreated)).Return(uint64(1), nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			projectData: &dto.CreateProjectDto{
 				Name:        "name",
+				Key:         "KEY",
 				Description: "description",
 				AdminID:     1,
 			},
-			projectDataJSON:    `{"name": "name", "description": "description", "adminId": 1}`,
+			projectDataJSON:    `{"name": "name", "key": "KEY", "description": "description", "adminId": 1}`,
 			expectedStatusCode: http.StatusOK,
 			expectedReturnBody: "1" + "\n",
 		},
@@ -168,7 +192,7 @@ func Test_getProjectById(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
@@ -187,12 +211,12 @@ func Test_getProjectById(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
 			expectedStatusCode: http.StatusFound,
-			expectedReturnBody: `{"id":1,"name":"name","description":"","admin":1}` + "\n",
+			expectedReturnBody: `{"id":1,"name":"name","key":"","description":"","admin":1,"visibility":"","defaultPriority":"","requiredBugFields":null,"maxTaskDepth":0,"archivedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}` + "\n",
 		},
 	}
 
@@ -239,6 +263,7 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 		mockBehaviour      mockBehaviour
 		id                 uint64
 		paramId            string
+		query              string
 		expectedStatusCode int
 		expectedReturnBody string
 	}{
@@ -255,6 +280,40 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error invalid task filter",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{nil, log, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "?statusCategory=archived",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidTaskFilter.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid updated after",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{nil, log, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "?updatedAfter=yesterday",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidTaskFilter.Error() + `"}` + "\n",
+		},
 		{
 			name: "Error cannot get project",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
@@ -267,7 +326,7 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
@@ -284,17 +343,43 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
 				project.EXPECT().GetProjectById(id).Return(&models.Project{ID: 1, Name: "name", AdminID: 1}, nil)
-				tasks.EXPECT().GetTasksByProjectId(id).Return(nil, err)
+				tasks.EXPECT().GetTasksByProjectId(id, &dto.TaskFilterDto{Kind: "bug", StatusCategory: "todo"}).Return(nil, err)
 
 				serv := &services.Service{Project: project, Task: tasks}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			query:              "?kind=bug&statusCategory=todo",
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error invalid task sort",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				tasks := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+
+				project.EXPECT().GetProjectById(id).Return(&models.Project{ID: 1, Name: "name", AdminID: 1}, nil)
+				tasks.EXPECT().GetTasksByProjectId(id, &dto.TaskFilterDto{
+					CustomFields: []string{"3:acme"},
+					Sort:         "cf:x",
+				}).Return(nil, repository.ErrInvalidTaskFilter)
+
+				serv := &services.Service{Project: project, Task: tasks}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "?cf=3:acme&sort=cf:x",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidTaskFilter.Error() + `"}` + "\n",
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
@@ -305,16 +390,17 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
 				project.EXPECT().GetProjectById(id).Return(&models.Project{ID: 1, Name: "name", AdminID: 1}, nil)
-				tasks.EXPECT().GetTasksByProjectId(id).Return([]*models.Task{{ID: 1}}, nil)
+				tasks.EXPECT().GetTasksByProjectId(id, &dto.TaskFilterDto{Kind: "bug", StatusCategory: "todo"}).Return([]*models.Task{{ID: 1}}, nil)
 
 				serv := &services.Service{Project: project, Task: tasks}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			query:              "?kind=bug&statusCategory=todo",
 			expectedStatusCode: http.StatusFound,
-			expectedReturnBody: `{"project":{"id":1,"name":"name","description":"","admin":1},"tasks":[{"id":1,"name":"","description":"","priority":"","projectId":0,"taskType":"","assignee":{"Int64":0,"Valid":false},"createdAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"performTo":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]}` + "\n",
+			expectedReturnBody: `{"project":{"id":1,"name":"name","key":"","description":"","admin":1,"visibility":"","defaultPriority":"","requiredBugFields":null,"maxTaskDepth":0,"archivedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}},"tasks":[{"id":1,"key":"","number":0,"name":"","description":"","priority":"","projectId":0,"statusId":0,"status":"","statusCategory":"","kindId":0,"kind":"","kindIcon":"","assignee":{"Int64":0,"Valid":false},"reviewer":{"Int64":0,"Valid":false},"resolution":{"String":"","Valid":false},"createdAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"performTo":{"Time":"0001-01-01T00:00:00Z","Valid":false},"reporter":{"Int64":0,"Valid":false},"updatedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"updatedBy":{"Int64":0,"Valid":false},"severity":{"String":"","Valid":false},"environment":{"String":"","Valid":false},"affectedVersion":{"String":"","Valid":false},"stepsToReproduce":{"String":"","Valid":false},"expectedResult":{"String":"","Valid":false},"actualResult":{"String":"","Valid":false},"reproducibility":{"String":"","Valid":false},"componentId":{"Int64":0,"Valid":false},"component":{"String":"","Valid":false},"sprintId":{"Int64":0,"Valid":false},"parentId":{"Int64":0,"Valid":false},"progress":null,"affectsVersions":null,"fixVersions":null,"labels":null,"customFields":null}]}` + "\n",
 		},
 	}
 
@@ -329,7 +415,7 @@ func Test_getProjectByIdWithTasks(t *testing.T) {
 			validator := validator.New()
 			e.Validator = newValidator(validator)
 
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 
@@ -398,11 +484,11 @@ func Test_deleteProject(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
 
-				project.EXPECT().DeleteProject(projectID, userID).Return(err)
+				project.EXPECT().DeleteProject(projectID, userID, eventOfType(models.EventProjectDeleted)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -412,6 +498,28 @@ func Test_deleteProject(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().DeleteProject(projectID, userID, eventOfType(models.EventProjectDeleted)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
@@ -420,11 +528,11 @@ func Test_deleteProject(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
 
-				project.EXPECT().DeleteProject(projectID, userID).Return(nil)
+				project.EXPECT().DeleteProject(projectID, userID, eventOfType(models.EventProjectDeleted)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -479,6 +587,8 @@ func Test_deleteProject(t *testing.T) {
 func Test_updateProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *Handler
 	err := errors.New("error")
+	name := "new name"
+	description := "description"
 
 	tests := []struct {
 		name               string
@@ -516,13 +626,13 @@ func Test_updateProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().UpdateProject(projectData, userID).Return(err)
+				project.EXPECT().UpdateProject(projectData, userID, eventOfType(models.EventProjectUpdated)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
-			projectData:     &dto.UpdateProjectDto{Description: "description", ProjectID: 1},
+			projectData:     &dto.UpdateProjectDto{Description: &description, ProjectID: 1},
 			projectDataJSON: `{"projectId": 1, "description": "description"}`,
 			userData: &services.TokenData{
 				UserID: 1,
@@ -530,18 +640,51 @@ func Test_updateProject(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + errInternalServerError.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error invalid project data",
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			projectDataJSON:    `{"projectId": 1, "visibility": "hidden"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidProjectData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error project name taken",
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().UpdateProject(projectData, userID, eventOfType(models.EventProjectUpdated)).Return(repository.ErrProjectNameTaken)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			projectData:     &dto.UpdateProjectDto{Name: &name, ProjectID: 1},
+			projectDataJSON: `{"projectId": 1, "name": "new name"}`,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectNameTaken.Error() + `"}` + "\n",
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().UpdateProject(projectData, userID).Return(nil)
+				project.EXPECT().UpdateProject(projectData, userID, eventOfType(models.EventProjectUpdated)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
-			projectData:     &dto.UpdateProjectDto{Description: "description", ProjectID: 1},
+			projectData:     &dto.UpdateProjectDto{Description: &description, ProjectID: 1},
 			projectDataJSON: `{"projectId": 1, "description": "description"}`,
 			userData: &services.TokenData{
 				UserID: 1,
@@ -637,11 +780,11 @@ func Test_addMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().AddMember(memberData, userID).Return(err)
+				project.EXPECT().AddMember(memberData, userID, eventOfType(models.EventMemberAdded)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			memberData:     &dto.AddMemberDto{MemberID: 2, ProjectID: 1},
 			memberDataJSON: `{"projectId": 1, "memberId": 2}`,
@@ -656,11 +799,11 @@ func Test_addMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().AddMember(memberData, userID).Return(nil)
+				project.EXPECT().AddMember(memberData, userID, eventOfType(models.EventMemberAdded)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			memberData:     &dto.AddMemberDto{MemberID: 2, ProjectID: 1},
 			memberDataJSON: `{"projectId": 1, "memberId": 2}`,
@@ -758,11 +901,11 @@ func Test_deleteMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().DeleteMember(memberData, userID).Return(err)
+				project.EXPECT().DeleteMember(memberData, userID, eventOfType(models.EventMemberRemoved)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			memberData:     &dto.AddMemberDto{MemberID: 2, ProjectID: 1},
 			memberDataJSON: `{"projectId": 1, "memberId": 2}`,
@@ -777,11 +920,11 @@ func Test_deleteMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().DeleteMember(memberData, userID).Return(nil)
+				project.EXPECT().DeleteMember(memberData, userID, eventOfType(models.EventMemberRemoved)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			memberData:     &dto.AddMemberDto{MemberID: 2, ProjectID: 1},
 			memberDataJSON: `{"projectId": 1, "memberId": 2}`,
@@ -878,7 +1021,7 @@ func Test_getMembers(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -900,7 +1043,7 @@ func Test_getMembers(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -952,6 +1095,130 @@ func Test_getMembers(t *testing.T) {
 	}
 }
 
+func Test_getProjectAudit(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get audit",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().GetProjectAudit(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().GetProjectAudit(projectID, userID).Return([]*models.ProjectAudit{{ID: 1, ProjectID: 1, Field: "name"}}, nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"actorId":{"Int64":0,"Valid":false},"field":"name","oldValue":{"String":"","Valid":false},"newValue":{"String":"","Valid":false},"changedAt":"0001-01-01T00:00:00Z"}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getProjectAudit)
+
+			echoCtx.SetPath(audit)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getProjectAudit(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
 func Test_leaveProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
 	err := errors.New("error")
@@ -998,11 +1265,11 @@ func Test_leaveProject(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
 
-				project.EXPECT().LeaveProject(projectID, userID).Return(err)
+				project.EXPECT().LeaveProject(projectID, userID, eventOfType(models.EventMemberLeft)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -1020,11 +1287,11 @@ func Test_leaveProject(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
 
-				project.EXPECT().LeaveProject(projectID, userID).Return(nil)
+				project.EXPECT().LeaveProject(projectID, userID, eventOfType(models.EventMemberLeft)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id: 1,
 			userData: &services.TokenData{
@@ -1116,11 +1383,11 @@ func Test_setNewAdmin(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, newAdminData *dto.NewAdminDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().SetNewAdmin(newAdminData, userID).Return(err)
+				project.EXPECT().SetNewAdmin(newAdminData, userID, eventOfType(models.EventProjectAdminChanged)).Return(err)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			newAdminData:     &dto.NewAdminDto{ProjectID: 1, NewAdminID: 2},
 			newAdminDataJSON: `{"projectId": 1, "newAdminId": 2}`,
@@ -1135,11 +1402,11 @@ func Test_setNewAdmin(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, newAdminData *dto.NewAdminDto, userID uint64) *Handler {
 				project := mock_services.NewMockProject(c)
 
-				project.EXPECT().SetNewAdmin(newAdminData, userID).Return(nil)
+				project.EXPECT().SetNewAdmin(newAdminData, userID, eventOfType(models.EventProjectAdminChanged)).Return(nil)
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			newAdminData:     &dto.NewAdminDto{ProjectID: 1, NewAdminID: 2},
 			newAdminDataJSON: `{"projectId": 1, "newAdminId": 2}`,
@@ -1185,3 +1452,597 @@ func Test_setNewAdmin(t *testing.T) {
 		})
 	}
 }
+
+func Test_archiveProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot archive project",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().ArchiveProject(projectID, userID, eventOfType(models.EventProjectArchived)).Return(err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().ArchiveProject(projectID, userID, eventOfType(models.EventProjectArchived)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error project is archived",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().ArchiveProject(projectID, userID, eventOfType(models.EventProjectArchived)).Return(repository.ErrProjectArchived)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectArchived.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().ArchiveProject(projectID, userID, eventOfType(models.EventProjectArchived)).Return(nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.POST(archive, handler.archiveProject)
+
+			echoCtx.SetPath("/:id")
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.archiveProject(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_unarchiveProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot unarchive project",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, eventOfType(models.EventProjectUnarchived)).Return(err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, eventOfType(models.EventProjectUnarchived)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error project is not found",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, eventOfType(models.EventProjectUnarchived)).Return(repository.ErrProjectNotFound)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, eventOfType(models.EventProjectUnarchived)).Return(nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.POST(unarchive, handler.unarchiveProject)
+
+			echoCtx.SetPath("/:id")
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.unarchiveProject(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_restoreProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot restore project",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().RestoreProject(projectID, userID, eventOfType(models.EventProjectRestored)).Return(err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error project is not in trash",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().RestoreProject(projectID, userID, eventOfType(models.EventProjectRestored)).Return(repository.ErrProjectNotInTrash)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectNotInTrash.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error project name taken",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().RestoreProject(projectID, userID, eventOfType(models.EventProjectRestored)).Return(repository.ErrProjectNameTaken)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				project := mock_services.NewMockProject(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				project.EXPECT().RestoreProject(projectID, userID, eventOfType(models.EventProjectRestored)).Return(nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.POST(restore, handler.restoreProject)
+
+			echoCtx.SetPath("/:id")
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.restoreProject(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_getTrash(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, userID uint64) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get trash",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().GetTrash(userID).Return(nil, err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().GetTrash(userID).Return([]*models.Project{{ID: 1}}, nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"name":"","key":"","description":"","admin":0,"visibility":"","defaultPriority":"","requiredBugFields":null,"maxTaskDepth":0,"archivedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+			e.GET(trash, handler.getTrash)
+
+			req := httptest.NewRequest(http.MethodGet, trash, nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getTrash(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+)
+
+var releaseNotesContentTypes = map[string]string{
+	services.ReleaseNotesMarkdown: "text/markdown; charset=UTF-8",
+	services.ReleaseNotesHTML:     echo.MIMETextHTMLCharsetUTF8,
+	services.ReleaseNotesJSON:     echo.MIMEApplicationJSONCharsetUTF8,
+}
+
+func releaseNotesErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrVersionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidReleaseNotesFormat),
+		errors.Is(err, services.ErrInvalidReleaseNotesTemplate):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// getReleaseNotes renders the changelog of a version as Markdown, HTML or
+// JSON, picked by the format query param. Markdown is the default.
+func (h *Handler) getReleaseNotes(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	versionID, err := h.params.GetVersionIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = services.ReleaseNotesMarkdown
+	}
+
+	contentType, ok := releaseNotesContentTypes[format]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(services.ErrInvalidReleaseNotesFormat))
+	}
+
+	notes, err := h.service.ReleaseNotes.GetReleaseNotes(id, versionID, userData.UserID)
+	if err != nil {
+		return c.JSON(releaseNotesErrorStatus(err), newErrorMessage(err))
+	}
+
+	body, err := h.service.ReleaseNotes.RenderReleaseNotes(notes, format)
+	if err != nil {
+		return c.JSON(releaseNotesErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+func (h *Handler) setReleaseNotesTemplate(c echo.Context) error {
+	templateData := new(dto.ReleaseNotesTemplateDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(templateData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(templateData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidNotesTemplate))
+	}
+
+	err = h.service.ReleaseNotes.SetReleaseNotesTemplate(templateData, userData.UserID)
+	if err != nil {
+		return c.JSON(releaseNotesErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getReleaseNotes(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+	notes := &models.ReleaseNotes{Version: &models.Version{ID: 3, ProjectID: 1, Name: "1.2.0"}}
+
+	tests := []struct {
+		name                string
+		mockBehaviour       mockBehaviour
+		format              string
+		userData            *services.TokenData
+		expectedStatusCode  int
+		expectedContentType string
+		expectedReturnBody  string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error in params.GetVersionIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(uint64(0), errInvalidParam)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid format",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				return &Handler{params: params}
+			},
+			format:              "pdf",
+			userData:            &services.TokenData{UserID: 1},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + services.ErrInvalidReleaseNotesFormat.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error version not found",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(nil, repository.ErrVersionNotFound)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:            &services.TokenData{UserID: 1},
+			expectedStatusCode:  http.StatusNotFound,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + repository.ErrVersionNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error broken project template",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(notes, nil)
+				releaseNotes.EXPECT().RenderReleaseNotes(notes, services.ReleaseNotesMarkdown).Return(nil, services.ErrInvalidReleaseNotesTemplate)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:            &services.TokenData{UserID: 1},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expectedReturnBody:  `{"message":"` + services.ErrInvalidReleaseNotesTemplate.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK markdown by default",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(notes, nil)
+				releaseNotes.EXPECT().RenderReleaseNotes(notes, services.ReleaseNotesMarkdown).Return([]byte("# 1.2.0\n"), nil)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:            &services.TokenData{UserID: 1},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/markdown; charset=UTF-8",
+			expectedReturnBody:  "# 1.2.0\n",
+		},
+		{
+			name: "OK html",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64, ctx echo.Context) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+				params.EXPECT().GetVersionIdParam(ctx).Return(versionID, nil)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(notes, nil)
+				releaseNotes.EXPECT().RenderReleaseNotes(notes, services.ReleaseNotesHTML).Return([]byte("<h1>1.2.0</h1>\n"), nil)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, params}
+			},
+			format:              "html",
+			userData:            &services.TokenData{UserID: 1},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: echo.MIMETextHTMLCharsetUTF8,
+			expectedReturnBody:  "<h1>1.2.0</h1>\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			target := "/"
+			if test.format != "" {
+				target += "?format=" + test.format
+			}
+
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, 1, 3, userID, echoCtx)
+
+			echoCtx.SetPath(releaseNotes)
+			echoCtx.SetParamNames("id", "vid")
+			echoCtx.SetParamValues("1", "3")
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getReleaseNotes(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_setReleaseNotesTemplate(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.ReleaseNotesTemplateDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid template data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"template": "# {{.Version.Name}}"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidNotesTemplate.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error template does not parse",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().SetReleaseNotesTemplate(data, userID).Return(services.ErrInvalidReleaseNotesTemplate)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.ReleaseNotesTemplateDto{ProjectID: 1, Template: "{{range .Groups}}"},
+			dataJSON:           `{"projectId": 1, "template": "{{range .Groups}}"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + services.ErrInvalidReleaseNotesTemplate.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *Handler {
+				releaseNotes := mock_services.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().SetReleaseNotesTemplate(data, userID).Return(nil)
+
+				serv := &services.Service{ReleaseNotes: releaseNotes}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.ReleaseNotesTemplateDto{ProjectID: 1, Template: "# {{.Version.Name}}"},
+			dataJSON:           `{"projectId": 1, "template": "# {{.Version.Name}}"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, notesTmpl, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.setReleaseNotesTemplate(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
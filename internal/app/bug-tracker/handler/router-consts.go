@@ -21,13 +21,59 @@ const (
 	setAdmin     = "/set-admin"
 	withTasks    = "/with-tasks" + id
 	members      = "/members" + id
+	archive      = "/archive" + id
+	unarchive    = "/unarchive" + id
+	restore      = "/restore" + id
+	trash        = "/trash"
+	audit        = "/audit" + id
+	workflow     = "/workflow" + id
+	status       = "/status"
+	transition   = "/transition"
+	kinds        = "/kinds" + id
+	kind         = "/kind"
+	customFields = "/custom-fields" + id
+	customField  = "/custom-field"
+	labels       = "/labels" + id
+	label        = "/label"
+	components   = "/components" + id
+	component    = "/component"
+	versions     = "/versions" + id
+	version      = "/version"
+	release      = version + "/release"
+	releaseNotes = id + "/versions/:vid/release-notes"
+	notesTmpl    = "/release-notes-template"
+	sprints      = "/sprints" + id
+	sprint       = "/sprint"
+	sprintStart  = sprint + "/start"
+	sprintEnd    = sprint + "/complete"
+	sprintTasks  = sprint + "/tasks"
 
 	task           = "/task"
 	workOnTask     = "/work-on-task"
 	stopWorkOnTask = "/stop-work-on-task"
 	withAssignee   = "/with-assignee" + id
+	transitionTask = id + "/transition"
+	taskLabels     = "/labels"
+	taskLinks      = id + "/links"
+	taskGraph      = id + "/graph"
+	taskComments   = id + "/comments"
+	taskComment    = taskComments + "/:cid"
+	commentEdits   = taskComment + "/history"
+	taskFiles      = id + "/attachments"
+	taskFile       = taskFiles + "/:aid"
+	taskActivity   = id + "/activity"
+	taskWatchers   = id + "/watchers"
 
-	user     = "/user"
-	username = "/:username"
-	projects = "/projects"
+	user         = "/user"
+	username     = "/:username"
+	projects     = "/projects"
+	archived     = "/projects/archived"
+	userMentions = "/mentions"
+	userWatching = "/watching"
+
+	notifications    = "/notifications"
+	unreadCount      = notifications + "/unread-count"
+	readAll          = notifications + "/read"
+	readNotification = notifications + id + "/read"
+	preferences      = notifications + "/preferences"
 )
@@ -38,6 +38,48 @@ func Test_setRoutes(t *testing.T) {
 		project.GET(members, h.getMembers)
 		project.GET(leave, h.leaveProject)
 		project.POST(setAdmin, h.setNewAdmin)
+		project.POST(archive, h.archiveProject)
+		project.POST(unarchive, h.unarchiveProject)
+		project.POST(restore, h.restoreProject)
+		project.GET(trash, h.getTrash)
+		project.GET(audit, h.getProjectAudit)
+		project.GET(workflow, h.getWorkflow)
+		project.POST(status, h.createStatus)
+		project.PUT(status, h.updateStatus)
+		project.DELETE(status, h.deleteStatus)
+		project.POST(transition, h.addTransition)
+		project.DELETE(transition, h.deleteTransition)
+		project.GET(kinds, h.getIssueKinds)
+		project.POST(kind, h.createIssueKind)
+		project.PUT(kind, h.updateIssueKind)
+		project.DELETE(kind, h.deleteIssueKind)
+		project.GET(customFields, h.getCustomFields)
+		project.POST(customField, h.createCustomField)
+		project.PUT(customField, h.updateCustomField)
+		project.DELETE(customField, h.deleteCustomField)
+		project.GET(labels, h.getLabels)
+		project.POST(label, h.createLabel)
+		project.PUT(label, h.updateLabel)
+		project.DELETE(label, h.deleteLabel)
+		project.GET(components, h.getComponents)
+		project.POST(component, h.createComponent)
+		project.PUT(component, h.updateComponent)
+		project.DELETE(component, h.deleteComponent)
+		project.GET(versions, h.getVersions)
+		project.POST(version, h.createVersion)
+		project.PUT(version, h.updateVersion)
+		project.DELETE(version, h.deleteVersion)
+		project.POST(release, h.releaseVersion)
+		project.GET(releaseNotes, h.getReleaseNotes)
+		project.PUT(notesTmpl, h.setReleaseNotesTemplate)
+		project.GET(sprints, h.getSprints)
+		project.POST(sprint, h.createSprint)
+		project.PUT(sprint, h.updateSprint)
+		project.DELETE(sprint, h.deleteSprint)
+		project.POST(sprintStart, h.startSprint)
+		project.POST(sprintEnd, h.completeSprint)
+		project.POST(sprintTasks, h.addSprintTasks)
+		project.DELETE(sprintTasks, h.removeSprintTasks)
 	}
 
 	task := expected.Group(task, h.isAuthorized)
@@ -46,6 +88,25 @@ func Test_setRoutes(t *testing.T) {
 		task.POST(workOnTask, h.workOnTask)
 		task.POST(stopWorkOnTask, h.stopWorkOnTask)
 		task.PUT(update, h.updateTask)
+		task.POST(transitionTask, h.transitionTask)
+		task.POST(taskLabels, h.addTaskLabels)
+		task.DELETE(taskLabels, h.removeTaskLabels)
+		task.GET(taskLinks, h.getTaskLinks)
+		task.POST(taskLinks, h.createTaskLink)
+		task.DELETE(taskLinks, h.deleteTaskLink)
+		task.GET(taskGraph, h.getTaskGraph)
+		task.GET(taskComments, h.getComments)
+		task.POST(taskComments, h.createComment)
+		task.PUT(taskComment, h.updateComment)
+		task.DELETE(taskComment, h.deleteComment)
+		task.GET(commentEdits, h.getCommentHistory)
+		task.GET(taskFiles, h.getAttachments)
+		task.POST(taskFiles, h.uploadAttachment)
+		task.GET(taskFile, h.downloadAttachment)
+		task.DELETE(taskFile, h.deleteAttachment)
+		task.GET(taskActivity, h.getActivities)
+		task.POST(taskWatchers, h.watchTask)
+		task.DELETE(taskWatchers, h.unwatchTask)
 		task.GET(id, h.getTaskById)
 		task.GET(withAssignee, h.getTaskByIdWithAssignee)
 		task.DELETE(empty, h.deleteTask)
@@ -56,6 +117,15 @@ func Test_setRoutes(t *testing.T) {
 		user.GET(id, h.getUserById)
 		user.GET(username, h.getUserByUsername)
 		user.GET(projects, h.getUserProjects)
+		user.GET(archived, h.getUserArchivedProjects)
+		user.GET(userMentions, h.getUserMentions)
+		user.GET(userWatching, h.getWatchedTasks)
+		user.GET(notifications, h.getNotifications)
+		user.GET(unreadCount, h.getUnreadNotificationCount)
+		user.POST(readAll, h.markAllNotificationsRead)
+		user.POST(readNotification, h.markNotificationRead)
+		user.GET(preferences, h.getNotificationPreferences)
+		user.PUT(preferences, h.updateNotificationPreferences)
 	}
 
 	e = setRoutes(e, h)
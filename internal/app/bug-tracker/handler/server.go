@@ -13,6 +13,7 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/spf13/viper"
 
+	"github.com/samuraivf/bug-tracker/configs"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
@@ -28,15 +29,31 @@ func CreateServer() {
 	defer close()
 
 	repo := repository.NewRepository(dep.db, logger)
-	s := services.NewService(repo, dep.redis)
+	s := services.NewService(
+		repo,
+		dep.redis,
+		dep.outbox,
+		configs.OutboxConfig(),
+		dep.storage,
+		configs.StorageConfig().Quota,
+	)
 	p := &params{}
 
-	h := NewHandler(s, logger, dep.kafka, p)
+	h := NewHandler(s, logger, p)
 
+	e.Use(middleware.RequestID())
 	e.Use(Logger(logger))
 	e.Use(middleware.Recover())
 	e = setRoutes(e, h)
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go runJob(jobsCtx, viper.GetDuration("trash.purge-interval"), h.purgeTrash(viper.GetDuration("trash.retention")))
+	go runJob(jobsCtx, viper.GetDuration("outbox.relay-interval"), h.relayOutbox(jobsCtx))
+	go runJob(jobsCtx, viper.GetDuration("outbox.purge-interval"), h.purgeOutbox(viper.GetDuration("outbox.retention")))
+	go runJob(jobsCtx, viper.GetDuration("digest.interval"), h.sendDigests(viper.GetInt("digest.batch-size")))
+
 	go func() {
 		if err := e.Start(":" + viper.GetString("server-port")); err != nil && err != http.ErrServerClosed {
 			e.Logger.Fatal("shutting down the server")
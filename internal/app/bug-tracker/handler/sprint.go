@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func sprintErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrSprintNotFound),
+		errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrSprintDates),
+		errors.Is(err, repository.ErrSprintMoveToSelf):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrSprintNameTaken),
+		errors.Is(err, repository.ErrSprintAlreadyActive),
+		errors.Is(err, repository.ErrSprintNotPlanned),
+		errors.Is(err, repository.ErrSprintNotActive),
+		errors.Is(err, repository.ErrSprintActive),
+		errors.Is(err, repository.ErrSprintClosed),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getSprints(c echo.Context) error {
+	id, err := h.params.GetIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	sprints, err := h.service.Sprint.GetSprints(id, userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, sprints)
+}
+
+func (h *Handler) createSprint(c echo.Context) error {
+	sprintData := new(dto.CreateSprintDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	id, err := h.service.Sprint.CreateSprint(sprintData, userData.UserID)
+	if err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, id)
+}
+
+func (h *Handler) updateSprint(c echo.Context) error {
+	sprintData := new(dto.UpdateSprintDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	if err := h.service.Sprint.UpdateSprint(sprintData, userData.UserID); err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) deleteSprint(c echo.Context) error {
+	sprintData := new(dto.DeleteSprintDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	if err := h.service.Sprint.DeleteSprint(sprintData, userData.UserID); err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) startSprint(c echo.Context) error {
+	sprintData := new(dto.StartSprintDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	if err := h.service.Sprint.StartSprint(sprintData, userData.UserID); err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) completeSprint(c echo.Context) error {
+	sprintData := new(dto.CompleteSprintDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(sprintData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	moved, err := h.service.Sprint.CompleteSprint(sprintData, userData.UserID)
+	if err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, moved)
+}
+
+func (h *Handler) addSprintTasks(c echo.Context) error {
+	tasksData := new(dto.SprintTasksDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(tasksData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(tasksData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	if err := h.service.Sprint.AddSprintTasks(tasksData, userData.UserID); err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) removeSprintTasks(c echo.Context) error {
+	tasksData := new(dto.SprintTasksDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	if err := c.Bind(tasksData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(tasksData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidSprintData))
+	}
+
+	if err := h.service.Sprint.RemoveSprintTasks(tasksData, userData.UserID); err != nil {
+		return c.JSON(sprintErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
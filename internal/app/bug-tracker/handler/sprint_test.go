@@ -0,0 +1,871 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getSprints(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		id                 uint64
+		userData           *services.TokenData
+		paramId            string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error in params.GetIdParam",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+
+				return &Handler{params: params}
+			},
+			paramId:            "1b",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				return &Handler{params: params}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get sprints",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				sprint.EXPECT().GetSprints(projectID, userID).Return(nil, err)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64, ctx echo.Context) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(projectID, nil)
+
+				sprint.EXPECT().GetSprints(projectID, userID).Return([]*models.Sprint{{ID: 1, ProjectID: 1, Name: "Sprint 1", State: models.SprintPlanned}}, nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, params}
+			},
+			id: 1,
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":1,"projectId":1,"name":"Sprint 1","goal":"","startDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"endDate":{"Time":"0001-01-01T00:00:00Z","Valid":false},"state":"planned","completedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			echoCtx := e.NewContext(req, rec)
+
+			handler := test.mockBehaviour(c, test.id, userID, echoCtx)
+			e.GET(id, handler.getSprints)
+
+			echoCtx.SetPath(sprints)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getSprints(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateSprintDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": ""}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint name taken",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().CreateSprint(data, userID).Return(uint64(0), repository.ErrSprintNameTaken)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1"},
+			dataJSON:           `{"projectId": 1, "name": "Sprint 1"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintNameTaken.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CreateSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().CreateSprint(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1"},
+			dataJSON:           `{"projectId": 1, "name": "Sprint 1"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, sprint, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createSprint(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_updateSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.UpdateSprintDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "name": "Sprint 2"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().UpdateSprint(data, userID).Return(repository.ErrSprintNotFound)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateSprintDto{ProjectID: 1, SprintID: 2, Name: "Sprint 2"},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "name": "Sprint 2"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().UpdateSprint(data, userID).Return(nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.UpdateSprintDto{ProjectID: 1, SprintID: 2, Name: "Sprint 2"},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "name": "Sprint 2"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPut, sprint, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.updateSprint(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteSprintDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint not found",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().DeleteSprint(data, userID).Return(repository.ErrSprintNotFound)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteSprintDto{ProjectID: 1, SprintID: 2},
+			dataJSON:           `{"projectId": 1, "sprintId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().DeleteSprint(data, userID).Return(nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.DeleteSprintDto{ProjectID: 1, SprintID: 2},
+			dataJSON:           `{"projectId": 1, "sprintId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, sprint, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteSprint(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_completeSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CompleteSprintDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "moveToSprintId": 2}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error tasks move to the same sprint",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().CompleteSprint(data, userID).Return(int64(0), repository.ErrSprintMoveToSelf)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CompleteSprintDto{ProjectID: 1, SprintID: 2, MoveToSprintID: 3},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "moveToSprintId": 3}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintMoveToSelf.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint is not active",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().CompleteSprint(data, userID).Return(int64(0), repository.ErrSprintNotActive)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CompleteSprintDto{ProjectID: 1, SprintID: 2, MoveToSprintID: 3},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "moveToSprintId": 3}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintNotActive.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().CompleteSprint(data, userID).Return(int64(2), nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.CompleteSprintDto{ProjectID: 1, SprintID: 2, MoveToSprintID: 3},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "moveToSprintId": 3}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `2` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, sprintEnd, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.completeSprint(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_startSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.StartSprintDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint is not planned",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().StartSprint(data, userID).Return(repository.ErrSprintNotPlanned)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.StartSprintDto{ProjectID: 1, SprintID: 2},
+			dataJSON:           `{"projectId": 1, "sprintId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintNotPlanned.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().StartSprint(data, userID).Return(nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.StartSprintDto{ProjectID: 1, SprintID: 2},
+			dataJSON:           `{"projectId": 1, "sprintId": 2}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, sprintStart, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.startSprint(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_addSprintTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.SprintTasksDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid JSON",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           "{invalid}",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid sprint data",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "taskIds": []}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidSprintData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error sprint is closed",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().AddSprintTasks(data, userID).Return(repository.ErrSprintClosed)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "taskIds": [5]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrSprintClosed.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *Handler {
+				sprint := mock_services.NewMockSprint(c)
+
+				sprint.EXPECT().AddSprintTasks(data, userID).Return(nil)
+
+				serv := &services.Service{Sprint: sprint}
+
+				return &Handler{serv, nil, nil}
+			},
+			data:               &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			dataJSON:           `{"projectId": 1, "sprintId": 2, "taskIds": [5]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, test.data, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, sprintTasks, strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.addSprintTasks(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func taskLinkErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrTaskLinkNotFound),
+		errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	case errors.Is(err, repository.ErrTaskLinkExists),
+		errors.Is(err, repository.ErrTaskLinkCycle),
+		errors.Is(err, repository.ErrProjectArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *Handler) getTaskLinks(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	links, err := h.service.TaskLink.GetTaskLinks(id, userData.UserID)
+	if err != nil {
+		return c.JSON(taskLinkErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, links)
+}
+
+func (h *Handler) createTaskLink(c echo.Context) error {
+	linkData := new(dto.CreateTaskLinkDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := c.Bind(linkData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+	linkData.TaskID = id
+
+	if err := c.Validate(linkData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskLinkData))
+	}
+
+	linkID, err := h.service.TaskLink.CreateTaskLink(linkData, userData.UserID)
+	if err != nil {
+		return c.JSON(taskLinkErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, linkID)
+}
+
+func (h *Handler) deleteTaskLink(c echo.Context) error {
+	linkData := new(dto.DeleteTaskLinkDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := c.Bind(linkData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+	linkData.TaskID = id
+
+	if err := c.Validate(linkData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskLinkData))
+	}
+
+	if err := h.service.TaskLink.DeleteTaskLink(linkData, userData.UserID); err != nil {
+		return c.JSON(taskLinkErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) getTaskGraph(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	graph, err := h.service.TaskLink.GetTaskGraph(id, userData.UserID)
+	if err != nil {
+		return c.JSON(taskLinkErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, graph)
+}
@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_getTaskLinks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error task is not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+				task.EXPECT().GetTaskIdByKey("KEY-1").Return(uint64(0), uint64(0), repository.ErrTaskNotFound)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().GetTaskLinks(uint64(1), uint64(1)).Return(nil, repository.ErrNoRights)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().GetTaskLinks(uint64(1), uint64(1)).Return([]*models.TaskLink{{
+					ID:        5,
+					Type:      models.LinkBlocks,
+					Direction: models.LinkInward,
+					Task:      &models.LinkedTask{ID: 2, Key: "KEY-2", Name: "Login", Status: "To Do", Category: "todo"},
+				}}, nil)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"id":5,"type":"blocks","direction":"inward","task":{"id":2,"key":"KEY-2","name":"Login","status":"To Do","statusCategory":"todo"}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetPath(taskLinks)
+			echoCtx.SetParamNames("id")
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetParamValues("KEY-1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getTaskLinks(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_createTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context, data *dto.CreateTaskLinkDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.CreateTaskLinkDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateTaskLinkDto, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid task link data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateTaskLinkDto, userID uint64) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log, params: params}
+			},
+			dataJSON:           `{"projectId": 1, "targetId": 1, "type": "blocks"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidTaskLinkData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cycle",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateTaskLinkDto, userID uint64) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().CreateTaskLink(data, userID).Return(uint64(0), repository.ErrTaskLinkCycle)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlocks},
+			dataJSON:           `{"projectId": 1, "targetId": 2, "type": "blocks"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrTaskLinkCycle.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.CreateTaskLinkDto, userID uint64) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().CreateTaskLink(data, userID).Return(uint64(5), nil)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlockedBy},
+			dataJSON:           `{"projectId": 1, "targetId": 2, "type": "is-blocked-by"}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetPath(taskLinks)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx, test.data, userID)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.createTaskLink(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_deleteTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context, data *dto.DeleteTaskLinkDto, userID uint64) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		data               *dto.DeleteTaskLinkDto
+		dataJSON           string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error link is not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.DeleteTaskLinkDto, userID uint64) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().DeleteTaskLink(data, userID).Return(repository.ErrTaskLinkNotFound)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.DeleteTaskLinkDto{TaskID: 1, ProjectID: 1, LinkID: 5},
+			dataJSON:           `{"projectId": 1, "linkId": 5}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrTaskLinkNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context, data *dto.DeleteTaskLinkDto, userID uint64) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().DeleteTaskLink(data, userID).Return(nil)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			data:               &dto.DeleteTaskLinkDto{TaskID: 1, ProjectID: 1, LinkID: 5},
+			dataJSON:           `{"projectId": 1, "linkId": 5}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `true` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodDelete, "/", strings.NewReader(test.dataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetPath(taskLinks)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx, test.data, test.userData.UserID)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.deleteTaskLink(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_getTaskGraph(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error task is not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().GetTaskGraph(uint64(1), uint64(1)).Return(nil, repository.ErrTaskNotFound)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				taskLink := mock_services.NewMockTaskLink(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				taskLink.EXPECT().GetTaskGraph(uint64(1), uint64(1)).Return(&models.TaskGraph{
+					Nodes: []*models.LinkedTask{
+						{ID: 1, Key: "KEY-1", Name: "API", Status: "Done", Category: "done"},
+						{ID: 2, Key: "KEY-2", Name: "Login", Status: "To Do", Category: "todo"},
+					},
+					Edges: []*models.TaskGraphEdge{{LinkID: 5, From: 1, To: 2}},
+				}, nil)
+
+				serv := &services.Service{TaskLink: taskLink}
+
+				return &Handler{serv, nil, params}
+			},
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `{"nodes":[{"id":1,"key":"KEY-1","name":"API","status":"Done","statusCategory":"done"},` +
+				`{"id":2,"key":"KEY-2","name":"Login","status":"To Do","statusCategory":"todo"}],` +
+				`"edges":[{"linkId":5,"from":1,"to":2}]}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.SetPath(taskGraph)
+			echoCtx.SetParamNames("id")
+			echoCtx.Set(userDataCtx, test.userData)
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getTaskGraph(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
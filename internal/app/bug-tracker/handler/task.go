@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 )
 
 func (h *Handler) createTask(c echo.Context) error {
@@ -24,11 +28,18 @@ func (h *Handler) createTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
 	}
 
-	id, err := h.service.Task.CreateTask(taskData, userData.UserID)
+	event := newEvent(c, models.EventTaskCreated, userData.UserID, taskData.ProjectID, 0, taskData)
+	id, err := h.service.Task.CreateTask(taskData, userData.UserID, event)
+	if errors.Is(err, repository.ErrAssigneeNotMember) {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return h.taskDataError(c, err)
 	}
 
+	h.notifyMentions(&dto.MentionDto{TaskID: id, AuthorID: userData.UserID, Text: taskData.Description})
+	h.notifyWatchers(id, userData.UserID, models.ActivityCreated)
+
 	return c.JSON(http.StatusOK, id)
 }
 
@@ -49,11 +60,25 @@ func (h *Handler) workOnTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
 	}
 
-	err = h.service.WorkOnTask(workOnTaskData, userData.UserID)
+	if err := h.resolveTaskKey(&workOnTaskData.TaskID, workOnTaskData.ProjectID, workOnTaskData.TaskKey); err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	event := newEvent(
+		c,
+		models.EventTaskAssigned,
+		userData.UserID,
+		workOnTaskData.ProjectID,
+		workOnTaskData.TaskID,
+		&models.AssigneePayload{AssigneeID: userData.UserID},
+	)
+	err = h.service.WorkOnTask(workOnTaskData, userData.UserID, event)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return h.transitionError(c, err)
 	}
 
+	h.notifyWatchers(workOnTaskData.TaskID, userData.UserID, models.ActivityAssignment)
+
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -74,11 +99,18 @@ func (h *Handler) stopWorkOnTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
 	}
 
-	err = h.service.StopWorkOnTask(workOnTaskData, userData.UserID)
+	if err := h.resolveTaskKey(&workOnTaskData.TaskID, workOnTaskData.ProjectID, workOnTaskData.TaskKey); err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	event := newEvent(c, models.EventTaskUnassigned, userData.UserID, workOnTaskData.ProjectID, workOnTaskData.TaskID, nil)
+	err = h.service.StopWorkOnTask(workOnTaskData, userData.UserID, event)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return h.transitionError(c, err)
 	}
 
+	h.notifyWatchers(workOnTaskData.TaskID, userData.UserID, models.ActivityAssignment)
+
 	return c.JSON(http.StatusOK, true)
 }
 
@@ -99,42 +131,167 @@ func (h *Handler) updateTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
 	}
 
-	id, err := h.service.Task.UpdateTask(taskData, userData.UserID)
+	if err := h.resolveTaskKey(&taskData.TaskID, taskData.ProjectID, taskData.TaskKey); err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	event := newEvent(c, models.EventTaskUpdated, userData.UserID, taskData.ProjectID, taskData.TaskID, taskData)
+	id, kind, err := h.service.Task.UpdateTask(taskData, userData.UserID, event)
+	if errors.Is(err, repository.ErrReviewerNotMember) {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		return h.taskDataError(c, err)
 	}
 
+	h.notifyMentions(&dto.MentionDto{TaskID: taskData.TaskID, AuthorID: userData.UserID, Text: taskData.Description})
+	h.notifyWatchers(taskData.TaskID, userData.UserID, kind)
+
 	return c.JSON(http.StatusOK, id)
 }
 
+func (h *Handler) transitionTask(c echo.Context) error {
+	taskData := new(dto.TransitionTaskDto)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := c.Bind(taskData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidJSON))
+	}
+
+	if err := c.Validate(taskData); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
+	}
+	taskData.TaskID = id
+
+	event := newEvent(c, models.EventTaskTransitioned, userData.UserID, taskData.ProjectID, id, taskData)
+	if err := h.service.Task.TransitionTask(taskData, userData.UserID, event); err != nil {
+		return h.transitionError(c, err)
+	}
+
+	h.notifyWatchers(id, userData.UserID, models.ActivityStatus)
+
+	return c.JSON(http.StatusOK, true)
+}
+
+// taskDataError answers errors caused by the fields a task was created or
+// updated with, leaving the rest to transitionError.
+func (h *Handler) taskDataError(c echo.Context, err error) error {
+	var requiredErr *repository.RequiredFieldsError
+	var customErr *repository.CustomFieldError
+
+	switch {
+	case errors.Is(err, repository.ErrStatusNotFound),
+		errors.Is(err, repository.ErrIssueKindNotFound),
+		errors.Is(err, repository.ErrCustomFieldNotFound),
+		errors.Is(err, repository.ErrComponentNotFound),
+		errors.Is(err, repository.ErrVersionNotFound),
+		errors.Is(err, repository.ErrParentNotFound):
+		return c.JSON(http.StatusNotFound, newErrorMessage(err))
+	case errors.Is(err, repository.ErrParentOtherProject),
+		errors.Is(err, repository.ErrTaskHierarchyCycle),
+		errors.Is(err, repository.ErrTaskDepthExceeded):
+		return c.JSON(http.StatusUnprocessableEntity, newErrorMessage(err))
+	case errors.As(err, &requiredErr):
+		return c.JSON(http.StatusUnprocessableEntity, newRequiredFieldsErrorMessage(requiredErr))
+	case errors.As(err, &customErr):
+		return c.JSON(http.StatusUnprocessableEntity, newErrorMessage(err))
+	default:
+		return h.transitionError(c, err)
+	}
+}
+
+func (h *Handler) transitionError(c echo.Context, err error) error {
+	var guardErr *repository.GuardError
+
+	switch {
+	case errors.As(err, &guardErr):
+		return c.JSON(http.StatusUnprocessableEntity, newGuardErrorMessage(guardErr))
+	case errors.Is(err, repository.ErrTransitionNotAllowed),
+		errors.Is(err, repository.ErrTaskBlocked),
+		errors.Is(err, repository.ErrProjectArchived):
+		return c.JSON(http.StatusConflict, newErrorMessage(err))
+	case errors.Is(err, repository.ErrTaskNotFound):
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	case errors.Is(err, repository.ErrNoRights):
+		return c.JSON(http.StatusForbidden, newErrorMessage(err))
+	default:
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+}
+
 func (h *Handler) getTaskById(c echo.Context) error {
-	id, err := h.params.GetIdParam(c)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
 	}
 
-	task, err := h.service.Task.GetTaskById(id)
+	withChildren := false
+	if param := c.QueryParam("children"); param != "" {
+		if withChildren, err = strconv.ParseBool(param); err != nil {
+			return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+		}
+	}
+
+	task, err := h.service.Task.GetTaskById(id, userData.UserID)
+	if errors.Is(err, repository.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, newErrorMessage(err))
+	}
 	if err != nil {
 		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
 	}
 
+	if withChildren {
+		if task.Children, err = h.service.Task.GetTaskChildren(id); err != nil {
+			return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+		}
+	}
+
 	return c.JSON(http.StatusFound, task)
 }
 
 func (h *Handler) getTaskByIdWithAssignee(c echo.Context) error {
-	id, err := h.params.GetIdParam(c)
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
 	}
 
-	task, err := h.service.Task.GetTaskById(id)
+	task, err := h.service.Task.GetTaskById(id, userData.UserID)
+	if errors.Is(err, repository.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, newErrorMessage(err))
+	}
 	if err != nil {
 		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
 	}
 
+	commentCount, err := h.service.Comment.GetCommentCount(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
 	if !task.Assignee.Valid {
 		return c.JSON(http.StatusFound, dto.TaskByIdWithAssignee{
-			Task: task,
+			Task:         task,
+			CommentCount: commentCount,
 		})
 	}
 
@@ -144,8 +301,9 @@ func (h *Handler) getTaskByIdWithAssignee(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusFound, dto.TaskByIdWithAssignee{
-		Task:     task,
-		Assignee: assignee,
+		Task:         task,
+		Assignee:     assignee,
+		CommentCount: commentCount,
 	})
 }
 
@@ -166,9 +324,46 @@ func (h *Handler) deleteTask(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidTaskData))
 	}
 
-	if err := h.service.Task.DeleteTask(taskData, userData.UserID); err != nil {
-		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	if err := h.resolveTaskKey(&taskData.TaskID, taskData.ProjectID, taskData.TaskKey); err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	event := newEvent(c, models.EventTaskDeleted, userData.UserID, taskData.ProjectID, taskData.TaskID, nil)
+	if err := h.service.Task.DeleteTask(taskData, userData.UserID, event); err != nil {
+		return h.transitionError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, true)
 }
+
+func (h *Handler) getTaskIdParam(c echo.Context) (uint64, error) {
+	id, err := h.params.GetIdParam(c)
+	if err == nil {
+		return id, nil
+	}
+
+	id, _, err = h.service.Task.GetTaskIdByKey(c.Param("id"))
+
+	return id, err
+}
+
+// resolveTaskKey sets the task id from the task key when only the key is
+// given. A key of a task in another project than the one the request is for
+// does not resolve.
+func (h *Handler) resolveTaskKey(taskID *uint64, projectID uint64, taskKey string) error {
+	if *taskID != 0 || taskKey == "" {
+		return nil
+	}
+
+	id, keyProjectID, err := h.service.Task.GetTaskIdByKey(taskKey)
+	if err != nil {
+		return err
+	}
+
+	if keyProjectID != projectID {
+		return repository.ErrTaskNotFound
+	}
+	*taskID = id
+
+	return nil
+}
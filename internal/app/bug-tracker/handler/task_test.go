@@ -2,6 +2,7 @@ package handler
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -18,6 +19,7 @@ import (
 	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
 	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
 	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
 )
@@ -50,7 +52,7 @@ func Test_createTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"invalid"}`,
@@ -65,7 +67,7 @@ func Test_createTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"name": "N"}`,
@@ -78,43 +80,159 @@ func Test_createTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().CreateTask(taskData, userID).Return(uint64(0), err)
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(uint64(0), err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.CreateTaskDto{
 				Name:         "name",
 				Description:  "description",
 				TaskPriority: "high",
 				ProjectID:    1,
-				TaskType:     "TO DO",
+				StatusID:     1,
 			},
-			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "projectId": 1, "taskType": "TO DO"}`,
+			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "projectId": 1, "statusId": 1}`,
 			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
 		{
-			name: "OK",
+			name: "Error required bug fields are missing",
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().CreateTask(taskData, userID).Return(uint64(1), nil)
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(
+					uint64(0),
+					&repository.RequiredFieldsError{Fields: []string{"severity", "stepsToReproduce"}},
+				)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.CreateTaskDto{
 				Name:         "name",
-				Description:  "description",
 				TaskPriority: "high",
 				ProjectID:    1,
-				TaskType:     "TO DO",
+				KindID:       1,
+			},
+			taskDataJSON:       `{"name": "name", "taskPriority": "high", "projectId": 1, "kindId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedReturnBody: `{"message":"error required fields are missing","fields":["severity","stepsToReproduce"]}` + "\n",
+		},
+		{
+			name: "Error invalid custom field value",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(
+					uint64(0),
+					&repository.CustomFieldError{Field: "points", Reason: "expected a number"},
+				)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				TaskPriority: "high",
+				ProjectID:    1,
+				CustomFields: []dto.CustomFieldValueDto{{FieldID: 2, Value: json.RawMessage(`"three"`)}},
+			},
+			taskDataJSON:       `{"name": "name", "taskPriority": "high", "projectId": 1, "customFields": [{"fieldId": 2, "value": "three"}]}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedReturnBody: `{"message":"error invalid value for custom field points: expected a number"}` + "\n",
+		},
+		{
+			name: "Error assignee is not a member",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(uint64(0), repository.ErrAssigneeNotMember)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData:           &dto.CreateTaskDto{Name: "name", TaskPriority: "high", ProjectID: 1, AssigneeID: 7},
+			taskDataJSON:       `{"name": "name", "taskPriority": "high", "projectId": 1, "assigneeId": 7}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + repository.ErrAssigneeNotMember.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error component not found",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(uint64(0), repository.ErrComponentNotFound)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData:           &dto.CreateTaskDto{Name: "name", TaskPriority: "high", ProjectID: 1, ComponentID: 5},
+			taskDataJSON:       `{"name": "name", "taskPriority": "high", "projectId": 1, "componentId": 5}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrComponentNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+				mention := mock_services.NewMockMention(c)
+				watcher := mock_services.NewMockWatcher(c)
+
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(uint64(1), nil)
+				mention.EXPECT().SaveMentions(&dto.MentionDto{TaskID: 1, AuthorID: userID, Text: taskData.Description}).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityCreated).Return(nil)
+
+				serv := &services.Service{Task: task, Mention: mention, Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				Description:  "description for @user",
+				TaskPriority: "high",
+				ProjectID:    1,
+				StatusID:     1,
+			},
+			taskDataJSON:       `{"name": "name", "description": "description for @user", "taskPriority": "high", "projectId": 1, "statusId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "1" + "\n",
+		},
+		{
+			name: "OK mentions are not saved",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+				mention := mock_services.NewMockMention(c)
+				watcher := mock_services.NewMockWatcher(c)
+				log := mock_log.NewMockLog(c)
+
+				task.EXPECT().CreateTask(taskData, userID, eventOfType(models.EventTaskCreated)).Return(uint64(1), nil)
+				mention.EXPECT().SaveMentions(&dto.MentionDto{TaskID: 1, AuthorID: userID, Text: taskData.Description}).Return(err)
+				log.EXPECT().Error(err).Return()
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityCreated).Return(nil)
+
+				serv := &services.Service{Task: task, Mention: mention, Watcher: watcher}
+
+				return &Handler{serv, log, nil}
+			},
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				Description:  "description for @user",
+				TaskPriority: "high",
+				ProjectID:    1,
 			},
-			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "projectId": 1, "taskType": "TO DO"}`,
+			taskDataJSON:       `{"name": "name", "description": "description for @user", "taskPriority": "high", "projectId": 1}`,
 			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusOK,
 			expectedReturnBody: "1" + "\n",
@@ -184,7 +302,7 @@ func Test_workOnTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			workOnTaskData:     nil,
 			workOnTaskDataJSON: `{"invalid"}`,
@@ -199,7 +317,7 @@ func Test_workOnTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			workOnTaskData:     nil,
 			workOnTaskDataJSON: `{"taskId": 1}`,
@@ -212,11 +330,11 @@ func Test_workOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().WorkOnTask(workOnTaskData, userID).Return(err)
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskAssigned)).Return(err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			workOnTaskData: &dto.WorkOnTaskDto{
 				TaskID:    1,
@@ -228,15 +346,93 @@ func Test_workOnTask(t *testing.T) {
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
 		{
-			name: "OK",
+			name: "Error project is archived",
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().WorkOnTask(workOnTaskData, userID).Return(nil)
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskAssigned)).Return(repository.ErrProjectArchived)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
+			},
+			workOnTaskData: &dto.WorkOnTaskDto{
+				TaskID:    1,
+				ProjectID: 1,
+			},
+			workOnTaskDataJSON: `{"taskId": 1, "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrProjectArchived.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error task key not found",
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().GetTaskIdByKey("KEY-1").Return(uint64(0), uint64(0), err)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			workOnTaskDataJSON: `{"taskKey": "KEY-1", "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error task key of another project",
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().GetTaskIdByKey("KEY-1").Return(uint64(1), uint64(2), nil)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			workOnTaskDataJSON: `{"taskKey": "KEY-1", "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK by task key",
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+				watcher := mock_services.NewMockWatcher(c)
+
+				task.EXPECT().GetTaskIdByKey("KEY-1").Return(uint64(1), uint64(1), nil)
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskAssigned)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityAssignment).Return(nil)
+
+				serv := &services.Service{Task: task, Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
+			},
+			workOnTaskData: &dto.WorkOnTaskDto{
+				TaskID:    1,
+				TaskKey:   "KEY-1",
+				ProjectID: 1,
+			},
+			workOnTaskDataJSON: `{"taskKey": "KEY-1", "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+				watcher := mock_services.NewMockWatcher(c)
+
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskAssigned)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityAssignment).Return(nil)
+
+				serv := &services.Service{Task: task, Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
 			},
 			workOnTaskData: &dto.WorkOnTaskDto{
 				TaskID:    1,
@@ -312,7 +508,7 @@ func Test_stopWorkOnTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			workOnTaskData:     nil,
 			workOnTaskDataJSON: `{"invalid"}`,
@@ -327,7 +523,7 @@ func Test_stopWorkOnTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			workOnTaskData:     nil,
 			workOnTaskDataJSON: `{"taskId": 1}`,
@@ -340,11 +536,11 @@ func Test_stopWorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().StopWorkOnTask(workOnTaskData, userID).Return(err)
+				task.EXPECT().StopWorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskUnassigned)).Return(err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			workOnTaskData: &dto.WorkOnTaskDto{
 				TaskID:    1,
@@ -359,12 +555,14 @@ func Test_stopWorkOnTask(t *testing.T) {
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
+				watcher := mock_services.NewMockWatcher(c)
 
-				task.EXPECT().StopWorkOnTask(workOnTaskData, userID).Return(nil)
+				task.EXPECT().StopWorkOnTask(workOnTaskData, userID, eventOfType(models.EventTaskUnassigned)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityAssignment).Return(nil)
 
-				serv := &services.Service{Task: task}
+				serv := &services.Service{Task: task, Watcher: watcher}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			workOnTaskData: &dto.WorkOnTaskDto{
 				TaskID:    1,
@@ -440,7 +638,7 @@ func Test_updateTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"invalid"}`,
@@ -455,7 +653,7 @@ func Test_updateTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"name": "N"}`,
@@ -468,11 +666,11 @@ func Test_updateTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().UpdateTask(taskData, userID).Return(uint64(0), err)
+				task.EXPECT().UpdateTask(taskData, userID, eventOfType(models.EventTaskUpdated)).Return(uint64(0), "", err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.UpdateTaskDto{
 				Name:         "name",
@@ -480,23 +678,23 @@ func Test_updateTask(t *testing.T) {
 				TaskPriority: "high",
 				TaskID:       1,
 				ProjectID:    1,
-				TaskType:     "TO DO",
+				StatusID:     1,
 			},
-			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "taskId": 1, "projectId": 1, "taskType": "TO DO"}`,
+			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "taskId": 1, "projectId": 1, "statusId": 1}`,
 			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
 		{
-			name: "OK",
+			name: "Error transition is not allowed",
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().UpdateTask(taskData, userID).Return(uint64(1), nil)
+				task.EXPECT().UpdateTask(taskData, userID, eventOfType(models.EventTaskUpdated)).Return(uint64(0), "", repository.ErrTransitionNotAllowed)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.UpdateTaskDto{
 				Name:         "name",
@@ -504,9 +702,37 @@ func Test_updateTask(t *testing.T) {
 				TaskPriority: "high",
 				TaskID:       1,
 				ProjectID:    1,
-				TaskType:     "TO DO",
+				StatusID:     4,
 			},
-			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "taskId": 1, "projectId": 1, "taskType": "TO DO"}`,
+			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "taskId": 1, "projectId": 1, "statusId": 4}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrTransitionNotAllowed.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+				mention := mock_services.NewMockMention(c)
+				watcher := mock_services.NewMockWatcher(c)
+
+				task.EXPECT().UpdateTask(taskData, userID, eventOfType(models.EventTaskUpdated)).Return(uint64(1), models.ActivityStatus, nil)
+				mention.EXPECT().SaveMentions(&dto.MentionDto{TaskID: 1, AuthorID: userID, Text: taskData.Description}).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityStatus).Return(nil)
+
+				serv := &services.Service{Task: task, Mention: mention, Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData: &dto.UpdateTaskDto{
+				Name:         "name",
+				Description:  "description",
+				TaskPriority: "high",
+				TaskID:       1,
+				ProjectID:    1,
+				StatusID:     1,
+			},
+			taskDataJSON:       `{"name": "name", "description": "description", "taskPriority": "high", "taskId": 1, "projectId": 1, "statusId": 1}`,
 			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusOK,
 			expectedReturnBody: "1" + "\n",
@@ -548,29 +774,249 @@ func Test_updateTask(t *testing.T) {
 	}
 }
 
+func Test_transitionTask(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler
+	err := errors.New("error")
+	taskData := &dto.TransitionTaskDto{TaskID: 1, ProjectID: 1, StatusID: 4, Resolution: "fixed"}
+	taskDataJSON := `{"projectId": 1, "statusId": 4, "resolution": "fixed"}`
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		taskData           *dto.TransitionTaskDto
+		taskDataJSON       string
+		paramId            string
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid task id",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+				task.EXPECT().GetTaskIdByKey("1b").Return(uint64(0), uint64(0), err)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			paramId:            "1b",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid json",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{nil, log, params}
+			},
+			taskDataJSON:       `{"invalid"}`,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidJSON.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid transition data",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				log := mock_log.NewMockLog(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{nil, log, params}
+			},
+			taskDataJSON:       `{"projectId": 1}`,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidTaskData.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error guards failed",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				task.EXPECT().TransitionTask(taskData, userID, eventOfType(models.EventTaskTransitioned)).Return(&repository.GuardError{
+					Failed: []*models.FailedGuard{{
+						Guard:   models.GuardReviewerOnly,
+						Message: "only the reviewer may perform this transition",
+					}},
+				})
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			taskData:           taskData,
+			taskDataJSON:       taskDataJSON,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 2},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedReturnBody: `{"message":"error transition guards failed","guards":[{"guard":"reviewer_only","message":"only the reviewer may perform this transition"}]}` + "\n",
+		},
+		{
+			name: "Error transition is not allowed",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				task.EXPECT().TransitionTask(taskData, userID, eventOfType(models.EventTaskTransitioned)).Return(repository.ErrTransitionNotAllowed)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			taskData:           taskData,
+			taskDataJSON:       taskDataJSON,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusConflict,
+			expectedReturnBody: `{"message":"` + repository.ErrTransitionNotAllowed.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				task.EXPECT().TransitionTask(taskData, userID, eventOfType(models.EventTaskTransitioned)).Return(nil)
+				watcher.EXPECT().NotifyWatchers(uint64(1), userID, models.ActivityStatus).Return(nil)
+
+				serv := &services.Service{Task: task, Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			taskData:           taskData,
+			taskDataJSON:       taskDataJSON,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 3},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodPost, transitionTask, strings.NewReader(test.taskDataJSON))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			handler := test.mockBehaviour(c, test.taskData, userID, echoCtx)
+			e.POST(transitionTask, handler.transitionTask)
+
+			echoCtx.SetPath(transitionTask)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues(test.paramId)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.transitionTask(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
 func Test_getTaskById(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler
 	err := errors.New("error")
-	successReturnBody := `{"id":1,"name":"name","description":"description","priority":"high","projectId":1,"taskType":"TO DO","assignee":{"Int64":1,"Valid":true},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true}}` + "\n"
+	successReturnBody := `{"id":1,"key":"KEY-1","number":1,"name":"name","description":"description","priority":"high","projectId":1,"statusId":1,"status":"TO DO","statusCategory":"todo","kindId":0,"kind":"","kindIcon":"","assignee":{"Int64":1,"Valid":true},"reviewer":{"Int64":0,"Valid":false},"resolution":{"String":"","Valid":false},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true},"reporter":{"Int64":0,"Valid":false},"updatedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"updatedBy":{"Int64":0,"Valid":false},"severity":{"String":"","Valid":false},"environment":{"String":"","Valid":false},"affectedVersion":{"String":"","Valid":false},"stepsToReproduce":{"String":"","Valid":false},"expectedResult":{"String":"","Valid":false},"actualResult":{"String":"","Valid":false},"reproducibility":{"String":"","Valid":false},"componentId":{"Int64":0,"Valid":false},"component":{"String":"","Valid":false},"sprintId":{"Int64":0,"Valid":false},"parentId":{"Int64":0,"Valid":false},"progress":null,"affectsVersions":null,"fixVersions":null,"labels":null,"customFields":null}` + "\n"
+	childrenReturnBody := strings.TrimSuffix(successReturnBody, "}\n") + `,"children":[` + strings.TrimSuffix(successReturnBody, "\n") + "]}\n"
 
 	tests := []struct {
 		name               string
 		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
 		id                 uint64
 		paramId            string
+		query              string
 		expectedStatusCode int
 		expectedReturnBody string
 	}{
 		{
-			name: "Error in params.GetIdParam",
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				return &Handler{}
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(nil, repository.ErrNoRights)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid task id",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
 				params := mock_handler.NewMockParams(c)
 
-				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+				task.EXPECT().GetTaskIdByKey("1b").Return(uint64(0), uint64(0), err)
+
+				serv := &services.Service{Task: task}
 
-				return &Handler{params: params}
+				return &Handler{serv, nil, params}
 			},
 			paramId:            "1b",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
@@ -582,17 +1028,58 @@ func Test_getTaskById(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(nil, err)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(nil, err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusNotFound,
 			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
 		},
+		{
+			name: "OK by task key",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+				task.EXPECT().GetTaskIdByKey("KEY-1").Return(id, uint64(1), nil)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{
+					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
+					Name:        "name",
+					Description: "description",
+					Priority:    "high",
+					ProjectID:   1,
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
+					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
+					CreatedAt: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+					PerformTo: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+				}, nil)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "KEY-1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusFound,
+			expectedReturnBody: successReturnBody,
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
@@ -601,13 +1088,17 @@ func Test_getTaskById(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(&models.Task{
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{
 					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
 					Name:        "name",
 					Description: "description",
 					Priority:    "high",
 					ProjectID:   1,
-					TaskType:    "TO DO",
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
 					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
 					CreatedAt: sql.NullTime{
 						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -623,13 +1114,112 @@ func Test_getTaskById(t *testing.T) {
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusFound,
 			expectedReturnBody: successReturnBody,
 		},
+		{
+			name: "Error invalid children param",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+
+				return &Handler{&services.Service{}, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "children=maybe",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get children",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{ID: 1}, nil)
+				task.EXPECT().GetTaskChildren(id).Return(nil, err)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "children=true",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK with children",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{
+					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
+					Name:        "name",
+					Description: "description",
+					Priority:    "high",
+					ProjectID:   1,
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
+					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
+					CreatedAt: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+					PerformTo: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+				}, nil)
+				task.EXPECT().GetTaskChildren(id).Return([]*models.Task{{
+					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
+					Name:        "name",
+					Description: "description",
+					Priority:    "high",
+					ProjectID:   1,
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
+					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
+					CreatedAt: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+					PerformTo: sql.NullTime{
+						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+						Valid: true,
+					},
+				}}, nil)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			query:              "children=true",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusFound,
+			expectedReturnBody: childrenReturnBody,
+		},
 	}
 
 	for _, test := range tests {
@@ -643,7 +1233,7 @@ func Test_getTaskById(t *testing.T) {
 			validator := validator.New()
 			e.Validator = newValidator(validator)
 
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/?"+test.query, nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 
@@ -655,6 +1245,7 @@ func Test_getTaskById(t *testing.T) {
 			echoCtx.SetPath("/:id")
 			echoCtx.SetParamNames("id")
 			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
 
 			defer rec.Result().Body.Close()
 			req.Close = true
@@ -669,26 +1260,60 @@ func Test_getTaskById(t *testing.T) {
 func Test_getTaskByIdWithAssignee(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler
 	err := errors.New("error")
-	successReturnBody := `{"task":{"id":1,"name":"name","description":"description","priority":"high","projectId":1,"taskType":"TO DO","assignee":{"Int64":1,"Valid":true},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true}},"assignee":{"id":1,"name":"","username":"","email":""}}` + "\n"
+	successReturnBody := `{"task":{"id":1,"key":"KEY-1","number":1,"name":"name","description":"description","priority":"high","projectId":1,"statusId":1,"status":"TO DO","statusCategory":"todo","kindId":0,"kind":"","kindIcon":"","assignee":{"Int64":1,"Valid":true},"reviewer":{"Int64":0,"Valid":false},"resolution":{"String":"","Valid":false},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true},"reporter":{"Int64":0,"Valid":false},"updatedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"updatedBy":{"Int64":0,"Valid":false},"severity":{"String":"","Valid":false},"environment":{"String":"","Valid":false},"affectedVersion":{"String":"","Valid":false},"stepsToReproduce":{"String":"","Valid":false},"expectedResult":{"String":"","Valid":false},"actualResult":{"String":"","Valid":false},"reproducibility":{"String":"","Valid":false},"componentId":{"Int64":0,"Valid":false},"component":{"String":"","Valid":false},"sprintId":{"Int64":0,"Valid":false},"parentId":{"Int64":0,"Valid":false},"progress":null,"affectsVersions":null,"fixVersions":null,"labels":null,"customFields":null},"assignee":{"id":1,"name":"","username":"","email":""},"commentCount":2}` + "\n"
 
 	tests := []struct {
 		name               string
 		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
 		id                 uint64
 		paramId            string
 		expectedStatusCode int
 		expectedReturnBody string
 	}{
 		{
-			name: "Error in params.GetIdParam",
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				return &Handler{}
+			},
+			paramId:            "1",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
 				params := mock_handler.NewMockParams(c)
 
-				params.EXPECT().GetIdParam(ctx).Return(uint64(0), err)
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(nil, repository.ErrNoRights)
+
+				serv := &services.Service{Task: task}
 
-				return &Handler{params: params}
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error invalid task id",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(0), errInvalidParam)
+				task.EXPECT().GetTaskIdByKey("1b").Return(uint64(0), uint64(0), err)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, params}
 			},
 			paramId:            "1b",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
@@ -700,33 +1325,61 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(nil, err)
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(nil, err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusNotFound,
 			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error cannot get comment count",
+			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
+				task := mock_services.NewMockTask(c)
+				comment := mock_services.NewMockComment(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(id, nil)
+
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{ID: 1}, nil)
+				comment.EXPECT().GetCommentCount(id).Return(0, err)
+
+				serv := &services.Service{Task: task, Comment: comment}
+
+				return &Handler{serv, nil, params}
+			},
+			id:                 1,
+			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
 		{
 			name: "Assignee is not valid",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
 				task := mock_services.NewMockTask(c)
+				comment := mock_services.NewMockComment(c)
 				params := mock_handler.NewMockParams(c)
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(
 					&models.Task{
 						ID:          1,
+						Key:         "KEY-1",
+						Number:      1,
 						Name:        "name",
 						Description: "description",
 						Priority:    "high",
 						ProjectID:   1,
-						TaskType:    "TO DO",
+						StatusID:    1,
+						Status:      "TO DO",
+						Category:    "todo",
 						Assignee:    sql.NullInt64{Int64: 0, Valid: false},
 						CreatedAt: sql.NullTime{
 							Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -739,32 +1392,39 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 					},
 					nil,
 				)
+				comment.EXPECT().GetCommentCount(id).Return(2, nil)
 
-				serv := &services.Service{Task: task}
+				serv := &services.Service{Task: task, Comment: comment}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusFound,
-			expectedReturnBody: `{"task":{"id":1,"name":"name","description":"description","priority":"high","projectId":1,"taskType":"TO DO","assignee":{"Int64":0,"Valid":false},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true}},"assignee":null}` + "\n",
+			expectedReturnBody: `{"task":{"id":1,"key":"KEY-1","number":1,"name":"name","description":"description","priority":"high","projectId":1,"statusId":1,"status":"TO DO","statusCategory":"todo","kindId":0,"kind":"","kindIcon":"","assignee":{"Int64":0,"Valid":false},"reviewer":{"Int64":0,"Valid":false},"resolution":{"String":"","Valid":false},"createdAt":{"Time":"1111-11-11T11:11:11Z","Valid":true},"performTo":{"Time":"1111-11-11T11:11:11Z","Valid":true},"reporter":{"Int64":0,"Valid":false},"updatedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"updatedBy":{"Int64":0,"Valid":false},"severity":{"String":"","Valid":false},"environment":{"String":"","Valid":false},"affectedVersion":{"String":"","Valid":false},"stepsToReproduce":{"String":"","Valid":false},"expectedResult":{"String":"","Valid":false},"actualResult":{"String":"","Valid":false},"reproducibility":{"String":"","Valid":false},"componentId":{"Int64":0,"Valid":false},"component":{"String":"","Valid":false},"sprintId":{"Int64":0,"Valid":false},"parentId":{"Int64":0,"Valid":false},"progress":null,"affectsVersions":null,"fixVersions":null,"labels":null,"customFields":null},"assignee":null,"commentCount":2}` + "\n",
 		},
 		{
 			name: "Error cannot get assignee",
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
 				task := mock_services.NewMockTask(c)
 				user := mock_services.NewMockUser(c)
+				comment := mock_services.NewMockComment(c)
 				params := mock_handler.NewMockParams(c)
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(&models.Task{
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{
 					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
 					Name:        "name",
 					Description: "description",
 					Priority:    "high",
 					ProjectID:   1,
-					TaskType:    "TO DO",
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
 					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
 					CreatedAt: sql.NullTime{
 						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -777,14 +1437,16 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 				},
 					nil,
 				)
+				comment.EXPECT().GetCommentCount(id).Return(2, nil)
 				user.EXPECT().GetUserById(uint64(1)).Return(nil, err)
 
-				serv := &services.Service{Task: task, User: user}
+				serv := &services.Service{Task: task, User: user, Comment: comment}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
 		},
@@ -793,17 +1455,22 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64, ctx echo.Context) *Handler {
 				task := mock_services.NewMockTask(c)
 				user := mock_services.NewMockUser(c)
+				comment := mock_services.NewMockComment(c)
 				params := mock_handler.NewMockParams(c)
 
 				params.EXPECT().GetIdParam(ctx).Return(id, nil)
 
-				task.EXPECT().GetTaskById(id).Return(&models.Task{
+				task.EXPECT().GetTaskById(id, uint64(1)).Return(&models.Task{
 					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
 					Name:        "name",
 					Description: "description",
 					Priority:    "high",
 					ProjectID:   1,
-					TaskType:    "TO DO",
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
 					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
 					CreatedAt: sql.NullTime{
 						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -816,14 +1483,16 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 				},
 					nil,
 				)
+				comment.EXPECT().GetCommentCount(id).Return(2, nil)
 				user.EXPECT().GetUserById(uint64(1)).Return(&models.User{ID: 1}, nil)
 
-				serv := &services.Service{Task: task, User: user}
+				serv := &services.Service{Task: task, User: user, Comment: comment}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
+			userData:           &services.TokenData{UserID: 1},
 			expectedStatusCode: http.StatusFound,
 			expectedReturnBody: successReturnBody,
 		},
@@ -852,6 +1521,7 @@ func Test_getTaskByIdWithAssignee(t *testing.T) {
 			echoCtx.SetPath(withAssignee)
 			echoCtx.SetParamNames("id")
 			echoCtx.SetParamValues(test.paramId)
+			echoCtx.Set(userDataCtx, test.userData)
 
 			defer rec.Result().Body.Close()
 			req.Close = true
@@ -891,7 +1561,7 @@ func Test_deleteTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"invalid"}`,
@@ -906,7 +1576,7 @@ func Test_deleteTask(t *testing.T) {
 
 				log.EXPECT().Error(gomock.Any()).Return()
 
-				return &Handler{nil, log, nil, nil}
+				return &Handler{nil, log, nil}
 			},
 			taskData:           nil,
 			taskDataJSON:       `{"taskId": 1}`,
@@ -919,11 +1589,11 @@ func Test_deleteTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().DeleteTask(taskData, userID).Return(err)
+				task.EXPECT().DeleteTask(taskData, userID, eventOfType(models.EventTaskDeleted)).Return(err)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.DeleteTaskDto{
 				TaskID:    1,
@@ -934,16 +1604,56 @@ func Test_deleteTask(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
 		},
+		{
+			name: "Error task is not in the project",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().DeleteTask(taskData, userID, eventOfType(models.EventTaskDeleted)).Return(repository.ErrTaskNotFound)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData: &dto.DeleteTaskDto{
+				TaskID:    1,
+				ProjectID: 1,
+			},
+			taskDataJSON:       `{"taskId": 1, "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + errTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *Handler {
+				task := mock_services.NewMockTask(c)
+
+				task.EXPECT().DeleteTask(taskData, userID, eventOfType(models.EventTaskDeleted)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Task: task}
+
+				return &Handler{serv, nil, nil}
+			},
+			taskData: &dto.DeleteTaskDto{
+				TaskID:    1,
+				ProjectID: 1,
+			},
+			taskDataJSON:       `{"taskId": 1, "projectId": 1}`,
+			userData:           &services.TokenData{UserID: 1},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *Handler {
 				task := mock_services.NewMockTask(c)
 
-				task.EXPECT().DeleteTask(taskData, userID).Return(nil)
+				task.EXPECT().DeleteTask(taskData, userID, eventOfType(models.EventTaskDeleted)).Return(nil)
 
 				serv := &services.Service{Task: task}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			taskData: &dto.DeleteTaskDto{
 				TaskID:    1,
@@ -47,3 +47,17 @@ func (h *Handler) getUserProjects(c echo.Context) error {
 
 	return c.JSON(http.StatusFound, projects)
 }
+
+func (h *Handler) getUserArchivedProjects(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	projects, err := h.service.Project.GetArchivedProjectsByUserId(userData.UserID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusFound, projects)
+}
@@ -54,7 +54,7 @@ func Test_getUserById(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
@@ -80,7 +80,7 @@ func Test_getUserById(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			id:                 1,
 			paramId:            "1",
@@ -160,7 +160,7 @@ func Test_getUserByUsername(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			username:           "username1",
 			paramUsername:      "username1",
@@ -186,7 +186,7 @@ func Test_getUserByUsername(t *testing.T) {
 
 				serv := &services.Service{User: user}
 
-				return &Handler{serv, nil, nil, params}
+				return &Handler{serv, nil, params}
 			},
 			username:           "username1",
 			paramUsername:      "username1",
@@ -257,7 +257,7 @@ func Test_getProjectsByUserId(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &services.TokenData{
 				UserID: 1,
@@ -274,13 +274,13 @@ func Test_getProjectsByUserId(t *testing.T) {
 
 				serv := &services.Service{Project: project}
 
-				return &Handler{serv, nil, nil, nil}
+				return &Handler{serv, nil, nil}
 			},
 			userData: &services.TokenData{
 				UserID: 1,
 			},
 			expectedStatusCode: http.StatusFound,
-			expectedReturnBody: `[{"id":1,"name":"","description":"","admin":0}]` + "\n",
+			expectedReturnBody: `[{"id":1,"name":"","key":"","description":"","admin":0,"visibility":"","defaultPriority":"","requiredBugFields":null,"maxTaskDepth":0,"archivedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]` + "\n",
 		},
 	}
 
@@ -318,3 +318,93 @@ func Test_getProjectsByUserId(t *testing.T) {
 		})
 	}
 }
+
+func Test_getUserArchivedProjects(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, userID uint64) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		userData           *services.TokenData
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid user data",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				return &Handler{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errUserNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error cannot get user archived projects",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().GetArchivedProjectsByUserId(userID).Return(nil, err)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *Handler {
+				project := mock_services.NewMockProject(c)
+
+				project.EXPECT().GetArchivedProjectsByUserId(userID).Return([]*models.Project{{ID: 1}}, nil)
+
+				serv := &services.Service{Project: project}
+
+				return &Handler{serv, nil, nil}
+			},
+			userData: &services.TokenData{
+				UserID: 1,
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedReturnBody: `[{"id":1,"name":"","key":"","description":"","admin":0,"visibility":"","defaultPriority":"","requiredBugFields":null,"maxTaskDepth":0,"archivedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false},"deletedAt":{"Time":"0001-01-01T00:00:00Z","Valid":false}}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			userID := uint64(0)
+			if test.userData != nil {
+				userID = test.userData.UserID
+			}
+
+			handler := test.mockBehaviour(c, userID)
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+			e.GET(archived, handler.getUserArchivedProjects)
+
+			req := httptest.NewRequest(http.MethodGet, archived, nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, test.userData)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getUserArchivedProjects(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
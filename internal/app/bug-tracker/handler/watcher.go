@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+func watcherErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrTaskNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrNoRights):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// notifyWatchers is called once a change of the task is saved, with its
+// events for the watchers, so a failure to add it to the inbox of the
+// watchers does not fail the request and is only logged.
+func (h *Handler) notifyWatchers(taskID, actorID uint64, kind string) {
+	if err := h.service.Watcher.NotifyWatchers(taskID, actorID, kind); err != nil {
+		h.log.Error(err)
+	}
+}
+
+func (h *Handler) watchTask(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := h.service.Watcher.Watch(id, userData.UserID); err != nil {
+		return c.JSON(watcherErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) unwatchTask(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	id, err := h.getTaskIdParam(c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, newErrorMessage(errTaskNotFound))
+	}
+
+	if err := h.service.Watcher.Unwatch(id, userData.UserID); err != nil {
+		return c.JSON(watcherErrorStatus(err), newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, true)
+}
+
+func (h *Handler) getWatchedTasks(c echo.Context) error {
+	userData, err := getUserData(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, newErrorMessage(err))
+	}
+
+	page := new(dto.PageDto)
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	if err := c.Validate(page); err != nil {
+		h.log.Error(err)
+		return c.JSON(http.StatusBadRequest, newErrorMessage(errInvalidParam))
+	}
+
+	tasks, err := h.service.Watcher.GetWatchedTasks(userData.UserID, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, newErrorMessage(err))
+	}
+
+	return c.JSON(http.StatusOK, tasks)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_handler "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/handler/mocks"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services"
+	mock_services "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/services/mocks"
+)
+
+func Test_watchTask(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				watcher.EXPECT().Watch(uint64(1), uint64(1)).Return(repository.ErrNoRights)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedReturnBody: `{"message":"` + repository.ErrNoRights.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				watcher.EXPECT().Watch(uint64(1), uint64(1)).Return(nil)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskWatchers)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.watchTask(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_unwatchTask(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, ctx echo.Context) *Handler
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error task not found",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				watcher.EXPECT().Unwatch(uint64(1), uint64(1)).Return(repository.ErrTaskNotFound)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedReturnBody: `{"message":"` + repository.ErrTaskNotFound.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, ctx echo.Context) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+				params := mock_handler.NewMockParams(c)
+
+				params.EXPECT().GetIdParam(ctx).Return(uint64(1), nil)
+				watcher.EXPECT().Unwatch(uint64(1), uint64(1)).Return(nil)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, params}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: "true" + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			req := httptest.NewRequest(http.MethodDelete, "/", nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+			echoCtx.SetPath(taskWatchers)
+			echoCtx.SetParamNames("id")
+			echoCtx.SetParamValues("1")
+
+			handler := test.mockBehaviour(c, echoCtx)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.unwatchTask(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_getWatchedTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *Handler
+	err := errors.New("error")
+
+	tests := []struct {
+		name               string
+		mockBehaviour      mockBehaviour
+		query              string
+		expectedStatusCode int
+		expectedReturnBody string
+	}{
+		{
+			name: "Error invalid page",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				log := mock_log.NewMockLog(c)
+
+				log.EXPECT().Error(gomock.Any()).Return()
+
+				return &Handler{log: log}
+			},
+			query:              "?limit=500",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedReturnBody: `{"message":"` + errInvalidParam.Error() + `"}` + "\n",
+		},
+		{
+			name: "Error in GetWatchedTasks",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+
+				watcher.EXPECT().GetWatchedTasks(uint64(1), &dto.PageDto{}).Return(nil, err)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedReturnBody: `{"message":"` + err.Error() + `"}` + "\n",
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *Handler {
+				watcher := mock_services.NewMockWatcher(c)
+
+				watcher.EXPECT().GetWatchedTasks(uint64(1), &dto.PageDto{Page: 2, Limit: 10}).Return([]*models.WatchedTask{{
+					Task:      &models.LinkedTask{ID: 1, Key: "BT-1", Name: "task", Status: "Open", Category: "todo"},
+					WatchedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				}}, nil)
+
+				serv := &services.Service{Watcher: watcher}
+
+				return &Handler{serv, nil, nil}
+			},
+			query:              "?page=2&limit=10",
+			expectedStatusCode: http.StatusOK,
+			expectedReturnBody: `[{"task":{"id":1,"key":"BT-1","name":"task","status":"Open","statusCategory":"todo"},"watchedAt":"2023-01-01T00:00:00Z"}]` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			e := echo.New()
+			defer e.Close()
+
+			validator := validator.New()
+			e.Validator = newValidator(validator)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+test.query, nil)
+			rec := httptest.NewRecorder()
+			echoCtx := e.NewContext(req, rec)
+			echoCtx.Set(userDataCtx, &services.TokenData{UserID: 1})
+
+			handler := test.mockBehaviour(c)
+
+			defer rec.Result().Body.Close()
+			req.Close = true
+
+			require.NoError(t, handler.getWatchedTasks(echoCtx))
+			require.Equal(t, test.expectedStatusCode, echoCtx.Response().Status)
+			require.Equal(t, test.expectedReturnBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_notifyWatchers(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	err := errors.New("error")
+	watcher := mock_services.NewMockWatcher(c)
+	log := mock_log.NewMockLog(c)
+
+	watcher.EXPECT().NotifyWatchers(uint64(1), uint64(2), models.ActivityComment).Return(err)
+	log.EXPECT().Error(err).Return()
+
+	handler := &Handler{&services.Service{Watcher: watcher}, log, nil}
+	handler.notifyWatchers(1, 2, models.ActivityComment)
+}
@@ -13,11 +13,27 @@ type KafkaWriter struct {
 	log    log.Log
 }
 
+// Header is a header of a message, kept in the order it was added.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// Message is a message for a writer configured without a topic, so each
+// message names its own. Messages with the same key go to the same partition
+// when the writer balances by hash.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers []Header
+}
+
 //go:generate mockgen -source=kafka.go -destination=mocks/kafka.go
 
 type Kafka interface {
 	Close() error
-	Write(message string) error
+	WriteMessages(ctx context.Context, messages ...Message) error
 }
 
 func NewKafkaWriter(config kafkago.WriterConfig, log log.Log) Kafka {
@@ -31,11 +47,21 @@ func (w *KafkaWriter) Close() error {
 	return w.writer.Close()
 }
 
-func (w *KafkaWriter) Write(message string) error {
-	return w.writer.WriteMessages(
-		context.Background(),
-		kafkago.Message{
-			Value: []byte(message),
-		},
-	)
+func (w *KafkaWriter) WriteMessages(ctx context.Context, messages ...Message) error {
+	kafkaMessages := make([]kafkago.Message, 0, len(messages))
+	for _, message := range messages {
+		headers := make([]kafkago.Header, 0, len(message.Headers))
+		for _, header := range message.Headers {
+			headers = append(headers, kafkago.Header{Key: header.Key, Value: []byte(header.Value)})
+		}
+
+		kafkaMessages = append(kafkaMessages, kafkago.Message{
+			Topic:   message.Topic,
+			Key:     []byte(message.Key),
+			Value:   message.Value,
+			Headers: headers,
+		})
+	}
+
+	return w.writer.WriteMessages(ctx, kafkaMessages...)
 }
@@ -5,9 +5,11 @@
 package mock_kafka
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	kafka "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka"
 )
 
 // MockKafka is a mock of Kafka interface.
@@ -47,16 +49,21 @@ func (mr *MockKafkaMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockKafka)(nil).Close))
 }
 
-// Write mocks base method.
-func (m *MockKafka) Write(message string) error {
+// WriteMessages mocks base method.
+func (m *MockKafka) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Write", message)
+	varargs := []interface{}{ctx}
+	for _, a := range messages {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WriteMessages", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Write indicates an expected call of Write.
-func (mr *MockKafkaMockRecorder) Write(message interface{}) *gomock.Call {
+// WriteMessages indicates an expected call of WriteMessages.
+func (mr *MockKafkaMockRecorder) WriteMessages(ctx interface{}, messages ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockKafka)(nil).Write), message)
+	varargs := append([]interface{}{ctx}, messages...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteMessages", reflect.TypeOf((*MockKafka)(nil).WriteMessages), varargs...)
 }
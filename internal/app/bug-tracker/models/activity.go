@@ -0,0 +1,40 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActivityCreated    = "created"
+	ActivityField      = "field"
+	ActivityAssignment = "assignment"
+	ActivityStatus     = "status"
+	ActivityComment    = "comment"
+	ActivityAttachment = "attachment"
+)
+
+// Activity is one entry of the history of a task. Field changes, assignments
+// and status transitions name the field and keep its old and new value;
+// comments and attachments keep what was added in NewValue and what was
+// removed in OldValue.
+type Activity struct {
+	ID        uint64          `json:"id" db:"id"`
+	TaskID    uint64          `json:"taskId" db:"task_id"`
+	ActorID   sql.NullInt64   `json:"actorId" db:"actor_id"`
+	Kind      string          `json:"kind" db:"kind"`
+	Field     sql.NullString  `json:"field" db:"field"`
+	OldValue  json.RawMessage `json:"oldValue" db:"old_value"`
+	NewValue  json.RawMessage `json:"newValue" db:"new_value"`
+	CreatedAt time.Time       `json:"createdAt" db:"created_at"`
+}
+
+// ActivityPage holds one page of the history of a task, oldest entries
+// first. Count is the number of entries on all pages.
+type ActivityPage struct {
+	Count      int         `json:"count"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	Activities []*Activity `json:"activities"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Attachment is a file uploaded to a task. Its contents are kept in the
+// storage under StorageKey.
+type Attachment struct {
+	ID          uint64        `json:"id" db:"id"`
+	TaskID      uint64        `json:"taskId" db:"task_id"`
+	FileName    string        `json:"fileName" db:"file_name"`
+	ContentType string        `json:"contentType" db:"content_type"`
+	Size        int64         `json:"size" db:"size"`
+	StorageKey  string        `json:"-" db:"storage_key"`
+	UploadedBy  sql.NullInt64 `json:"uploadedBy" db:"uploaded_by"`
+	CreatedAt   time.Time     `json:"createdAt" db:"created_at"`
+}
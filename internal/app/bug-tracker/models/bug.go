@@ -0,0 +1,11 @@
+package models
+
+const (
+	BugFieldSeverity         = "severity"
+	BugFieldEnvironment      = "environment"
+	BugFieldAffectedVersion  = "affectedVersion"
+	BugFieldStepsToReproduce = "stepsToReproduce"
+	BugFieldExpectedResult   = "expectedResult"
+	BugFieldActualResult     = "actualResult"
+	BugFieldReproducibility  = "reproducibility"
+)
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Comment is a Markdown comment on a task. Deleted comments keep their place
+// in the thread with an empty body.
+type Comment struct {
+	ID        uint64        `json:"id" db:"id"`
+	TaskID    uint64        `json:"taskId" db:"task_id"`
+	ParentID  sql.NullInt64 `json:"parentId" db:"parent_id"`
+	AuthorID  sql.NullInt64 `json:"authorId" db:"author_id"`
+	Body      string        `json:"body" db:"body"`
+	CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+	EditedAt  sql.NullTime  `json:"editedAt" db:"edited_at"`
+	DeletedAt sql.NullTime  `json:"deletedAt" db:"deleted_at"`
+
+	Replies []*Comment `json:"replies,omitempty" db:"-"`
+}
+
+type CommentRevision struct {
+	ID        uint64        `json:"id" db:"id"`
+	CommentID uint64        `json:"commentId" db:"comment_id"`
+	Body      string        `json:"body" db:"body"`
+	EditedBy  sql.NullInt64 `json:"editedBy" db:"edited_by"`
+	EditedAt  time.Time     `json:"editedAt" db:"edited_at"`
+}
+
+// CommentPage holds one page of comment threads of a task. Count is the
+// number of comments that are not deleted, Threads the number of threads the
+// pages are cut from.
+type CommentPage struct {
+	Count    int        `json:"count"`
+	Threads  int        `json:"threads"`
+	Page     int        `json:"page"`
+	Limit    int        `json:"limit"`
+	Comments []*Comment `json:"comments"`
+}
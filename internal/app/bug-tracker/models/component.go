@@ -0,0 +1,14 @@
+package models
+
+import (
+	"database/sql"
+)
+
+type Component struct {
+	ID              uint64        `json:"id" db:"id"`
+	ProjectID       uint64        `json:"projectId" db:"project_id"`
+	Name            string        `json:"name" db:"name"`
+	Description     string        `json:"description" db:"description"`
+	Lead            sql.NullInt64 `json:"lead" db:"lead"`
+	DefaultAssignee sql.NullInt64 `json:"defaultAssignee" db:"default_assignee"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "encoding/json"
+
+const (
+	CustomFieldText        = "text"
+	CustomFieldNumber      = "number"
+	CustomFieldDate        = "date"
+	CustomFieldSelect      = "select"
+	CustomFieldMultiSelect = "multiselect"
+	CustomFieldUser        = "user"
+)
+
+type CustomField struct {
+	ID        uint64   `json:"id" db:"id"`
+	ProjectID uint64   `json:"projectId" db:"project_id"`
+	Name      string   `json:"name" db:"name"`
+	Type      string   `json:"type" db:"field_type"`
+	Options   []string `json:"options" db:"options"`
+	Required  bool     `json:"required" db:"required"`
+	Position  int      `json:"position" db:"position"`
+}
+
+// CustomFieldValue is the value a task holds for a custom field. Value is a
+// string for text, date and select fields, a number for number and user
+// fields and a list of strings for multi-select fields.
+type CustomFieldValue struct {
+	FieldID uint64          `json:"fieldId"`
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Value   json.RawMessage `json:"value"`
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventVersion is the version of the Event envelope. It changes only when a
+// field is removed or changes its meaning; new fields and event types keep it.
+const EventVersion = 1
+
+const (
+	HeaderEventType     = "event-type"
+	HeaderEventVersion  = "event-version"
+	HeaderContentType   = "content-type"
+	HeaderCorrelationID = "correlation-id"
+)
+
+const (
+	EventProjectCreated      = "project.created"
+	EventProjectUpdated      = "project.updated"
+	EventProjectDeleted      = "project.deleted"
+	EventProjectRestored     = "project.restored"
+	EventProjectArchived     = "project.archived"
+	EventProjectUnarchived   = "project.unarchived"
+	EventProjectAdminChanged = "project.admin_changed"
+
+	EventMemberAdded   = "member.added"
+	EventMemberRemoved = "member.removed"
+	EventMemberLeft    = "member.left"
+
+	EventTaskCreated      = "task.created"
+	EventTaskUpdated      = "task.updated"
+	EventTaskTransitioned = "task.transitioned"
+	EventTaskAssigned     = "task.assigned"
+	EventTaskUnassigned   = "task.unassigned"
+	EventTaskDeleted      = "task.deleted"
+)
+
+// Event is the envelope every domain event is published in. The layout is
+// described in docs/events.md. CorrelationID ties the event to the request
+// that caused it and travels as a message header.
+type Event struct {
+	Version       int         `json:"version"`
+	ID            string      `json:"id"`
+	Type          string      `json:"type"`
+	OccurredAt    time.Time   `json:"occurredAt"`
+	ActorID       uint64      `json:"actorId"`
+	ProjectID     uint64      `json:"projectId"`
+	TaskID        uint64      `json:"taskId,omitempty"`
+	Payload       interface{} `json:"payload"`
+	CorrelationID string      `json:"-"`
+}
+
+// MemberPayload is the payload of member events.
+type MemberPayload struct {
+	MemberID uint64 `json:"memberId"`
+}
+
+// AdminPayload is the payload of project.admin_changed.
+type AdminPayload struct {
+	AdminID uint64 `json:"adminId"`
+}
+
+// AssigneePayload is the payload of task.assigned.
+type AssigneePayload struct {
+	AssigneeID uint64 `json:"assigneeId"`
+}
+
+// NewEvent builds an event of the given type that occurred now. A nil
+// payload is sent as an empty object.
+func NewEvent(eventType string, actorID, projectID, taskID uint64, payload interface{}) *Event {
+	if payload == nil {
+		payload = struct{}{}
+	}
+
+	return &Event{
+		Version:    EventVersion,
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		ActorID:    actorID,
+		ProjectID:  projectID,
+		TaskID:     taskID,
+		Payload:    payload,
+	}
+}
+
+// Category is the part of the type before the dot: project, member or task.
+func (e *Event) Category() string {
+	category, _, _ := strings.Cut(e.Type, ".")
+
+	return category
+}
+
+// Key keeps the events of a task in order, and the events of a project that
+// are not about a single task.
+func (e *Event) Key() string {
+	if e.TaskID != 0 {
+		return fmt.Sprintf("task-%d", e.TaskID)
+	}
+
+	return fmt.Sprintf("project-%d", e.ProjectID)
+}
+
+// OutboxMessage encodes the event as JSON, with its type, version and
+// correlation id as headers.
+func (e *Event) OutboxMessage() (*OutboxMessage, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{
+		HeaderEventType:    e.Type,
+		HeaderEventVersion: strconv.Itoa(e.Version),
+		HeaderContentType:  "application/json",
+	}
+	if e.CorrelationID != "" {
+		headers[HeaderCorrelationID] = e.CorrelationID
+	}
+
+	return &OutboxMessage{
+		Category: e.Category(),
+		Key:      e.Key(),
+		Value:    value,
+		Headers:  headers,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package models
+
+type IssueKind struct {
+	ID        uint64 `json:"id" db:"id"`
+	ProjectID uint64 `json:"projectId" db:"project_id"`
+	Name      string `json:"name" db:"name"`
+	Icon      string `json:"icon" db:"icon"`
+	Position  int    `json:"position" db:"position"`
+	Default   bool   `json:"default" db:"is_default"`
+	Bug       bool   `json:"bug" db:"is_bug"`
+}
@@ -0,0 +1,9 @@
+package models
+
+type Label struct {
+	ID          uint64 `json:"id" db:"id"`
+	ProjectID   uint64 `json:"projectId" db:"project_id"`
+	Name        string `json:"name" db:"name"`
+	Color       string `json:"color" db:"color"`
+	Description string `json:"description" db:"description"`
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	MailTypeNotification = "notification"
+	MailTypeDigest       = "digest"
+)
+
+// HeaderMailType tells the mail sender the type of a rendered mail. Messages
+// without it are verification mails.
+const HeaderMailType = "mail-type"
+
+// Mail is the value of the messages asking the mail sender to send a mail
+// rendered by the bug tracker.
+type Mail struct {
+	Type    string `json:"type"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// MailItem is a notification as it is rendered in a mail to Email.
+type MailItem struct {
+	ID        uint64
+	Email     string
+	Type      string
+	TaskKey   string
+	TaskName  string
+	Actor     string
+	CreatedAt time.Time
+}
+
+func (i *MailItem) String() string {
+	var change string
+	switch i.Type {
+	case NotificationAssignment:
+		change = "assignee changed"
+	case NotificationComment:
+		change = "comments changed"
+	case NotificationStatus:
+		change = "status changed"
+	case NotificationMention:
+		change = "you were mentioned"
+	default:
+		change = i.Type
+	}
+
+	if i.Actor != "" {
+		change += " by " + i.Actor
+	}
+
+	return fmt.Sprintf("%s %s: %s", i.TaskKey, i.TaskName, change)
+}
+
+func newMailMessage(mail *Mail) (*OutboxMessage, error) {
+	value, err := json.Marshal(mail)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		Category: MailCategory,
+		Key:      mail.To,
+		Value:    value,
+		Headers:  map[string]string{HeaderMailType: mail.Type},
+	}, nil
+}
+
+// NewNotificationMail builds the message asking the mail sender to send a
+// single notification right away.
+func NewNotificationMail(item *MailItem) (*OutboxMessage, error) {
+	return newMailMessage(&Mail{
+		Type:    MailTypeNotification,
+		To:      item.Email,
+		Subject: fmt.Sprintf("[%s] %s", item.TaskKey, item.TaskName),
+		Body:    item.String(),
+	})
+}
+
+// NewDigestMail builds the message asking the mail sender to send the daily
+// digest of the notifications, with their times shown in the location.
+func NewDigestMail(email string, items []*MailItem, location *time.Location) (*OutboxMessage, error) {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("%s %s", item.CreatedAt.In(location).Format("Jan 2 15:04"), item))
+	}
+
+	return newMailMessage(&Mail{
+		Type:    MailTypeDigest,
+		To:      email,
+		Subject: fmt.Sprintf("Daily digest: %d notifications", len(items)),
+		Body:    strings.Join(lines, "\n"),
+	})
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const MentionEventType = "mention"
+
+// MentionCategory is the category of the messages telling users they were
+// mentioned.
+const MentionCategory = "mention"
+
+// Mention is a place a user was mentioned at: the description of the task,
+// or one of its comments when CommentID is set.
+type Mention struct {
+	ID          uint64        `json:"id" db:"id"`
+	Task        *LinkedTask   `json:"task" db:"-"`
+	CommentID   sql.NullInt64 `json:"commentId" db:"comment_id"`
+	MentionedBy sql.NullInt64 `json:"mentionedBy" db:"mentioned_by"`
+	CreatedAt   time.Time     `json:"createdAt" db:"created_at"`
+}
+
+// MentionEvent is the message sent to Kafka for every user newly mentioned
+// in a text. It is saved to the outbox with the mentions.
+type MentionEvent struct {
+	Type        string    `json:"type"`
+	UserID      uint64    `json:"userId"`
+	TaskID      uint64    `json:"taskId"`
+	CommentID   uint64    `json:"commentId,omitempty"`
+	MentionedBy uint64    `json:"mentionedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// OutboxMessage encodes the event as JSON, keyed by the mentioned user so
+// the mentions of a user are sent in order.
+func (e *MentionEvent) OutboxMessage() (*OutboxMessage, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		Category: MentionCategory,
+		Key:      fmt.Sprintf("user-%d", e.UserID),
+		Value:    value,
+	}, nil
+}
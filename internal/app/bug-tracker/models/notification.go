@@ -0,0 +1,119 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Notification types. Watchers are notified with the kind of the activity the
+// change was logged with.
+const (
+	NotificationAssignment = ActivityAssignment
+	NotificationComment    = ActivityComment
+	NotificationStatus     = ActivityStatus
+	NotificationMention    = "mention"
+)
+
+// Notification is one entry of the in-app inbox of a user. CommentID is set
+// for mentions in a comment.
+type Notification struct {
+	ID        uint64        `json:"id" db:"id"`
+	Type      string        `json:"type" db:"type"`
+	Task      *LinkedTask   `json:"task" db:"-"`
+	CommentID sql.NullInt64 `json:"commentId" db:"comment_id"`
+	ActorID   sql.NullInt64 `json:"actorId" db:"actor_id"`
+	CreatedAt time.Time     `json:"createdAt" db:"created_at"`
+	ReadAt    sql.NullTime  `json:"readAt" db:"read_at"`
+}
+
+// IsNotified tells whether watchers are notified about changes of the kind.
+func IsNotified(kind string) bool {
+	switch kind {
+	case NotificationAssignment, NotificationComment, NotificationStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnreadCount is the number of unread notifications in the inbox of a user.
+type UnreadCount struct {
+	Count int `json:"count"`
+}
+
+// Channels a notification type can be delivered through. Email and digest
+// notifications are added to the inbox too.
+const (
+	ChannelEmail  = "email"
+	ChannelDigest = "digest"
+	ChannelInApp  = "in_app"
+	ChannelNone   = "none"
+)
+
+const (
+	DefaultDigestTime = "09:00"
+	DefaultTimeZone   = "UTC"
+)
+
+// NotificationTypes lists the types a user can pick a channel for.
+var NotificationTypes = []string{
+	NotificationAssignment,
+	NotificationComment,
+	NotificationStatus,
+	NotificationMention,
+}
+
+// NotificationPreferences tells through which channel the user gets every
+// notification type, and when the daily digest is sent: at DigestTime,
+// formatted as 15:04, in TimeZone.
+type NotificationPreferences struct {
+	Channels   map[string]string `json:"channels"`
+	DigestTime string            `json:"digestTime"`
+	TimeZone   string            `json:"timeZone"`
+}
+
+// NewNotificationPreferences returns the preferences of a user who has not
+// picked any: everything in-app only, the digest at nine in UTC.
+func NewNotificationPreferences() *NotificationPreferences {
+	channels := make(map[string]string, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		channels[notificationType] = ChannelInApp
+	}
+
+	return &NotificationPreferences{
+		Channels:   channels,
+		DigestTime: DefaultDigestTime,
+		TimeZone:   DefaultTimeZone,
+	}
+}
+
+// NextDigestAt returns the first moment after now a digest sent every day
+// at digestTime, formatted as 15:04, in the time zone is due at, in UTC.
+func NextDigestAt(now time.Time, digestTime, timeZone string) (time.Time, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	at, err := time.Parse("15:04", digestTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := now.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, location)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, at.Hour(), at.Minute(), 0, 0, location)
+	}
+
+	return next.UTC(), nil
+}
+
+// DigestSchedule is the digest of a user that is due.
+type DigestSchedule struct {
+	UserID       uint64
+	Email        string
+	DigestTime   string
+	TimeZone     string
+	NextDigestAt time.Time
+}
@@ -0,0 +1,27 @@
+package models
+
+// MailCategory is the category of the messages for the mail sender. Their
+// value is the address to verify, or a Mail when they have a mail type.
+const MailCategory = "mail"
+
+// OutboxMessage is a Kafka message saved in the same transaction as the
+// change it tells about. The relay sends it to the topic of its category;
+// messages with the same key are sent in the order they were saved.
+type OutboxMessage struct {
+	ID       uint64
+	Category string
+	Key      string
+	Value    []byte
+	Headers  map[string]string
+	Attempts int
+}
+
+// NewMailMessage builds the message asking the mail sender to send a
+// verification code to the address.
+func NewMailMessage(email string) *OutboxMessage {
+	return &OutboxMessage{
+		Category: MailCategory,
+		Key:      email,
+		Value:    []byte(email),
+	}
+}
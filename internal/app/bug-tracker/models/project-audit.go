@@ -0,0 +1,16 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type ProjectAudit struct {
+	ID        uint64         `json:"id" db:"id"`
+	ProjectID uint64         `json:"projectId" db:"project_id"`
+	ActorID   sql.NullInt64  `json:"actorId" db:"actor_id"`
+	Field     string         `json:"field" db:"field"`
+	OldValue  sql.NullString `json:"oldValue" db:"old_value"`
+	NewValue  sql.NullString `json:"newValue" db:"new_value"`
+	ChangedAt time.Time      `json:"changedAt" db:"changed_at"`
+}
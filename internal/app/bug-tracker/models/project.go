@@ -1,8 +1,19 @@
 package models
 
+import (
+	"database/sql"
+)
+
 type Project struct {
-	ID          uint64 `json:"id" db:"id"`
-	Name        string `json:"name" db:"name"`
-	Description string `json:"description" db:"description"`
-	AdminID     uint64 `json:"admin" db:"admin"`
+	ID                uint64       `json:"id" db:"id"`
+	Name              string       `json:"name" db:"name"`
+	Key               string       `json:"key" db:"key"`
+	Description       string       `json:"description" db:"description"`
+	AdminID           uint64       `json:"admin" db:"admin"`
+	Visibility        string       `json:"visibility" db:"visibility"`
+	DefaultPriority   string       `json:"defaultPriority" db:"default_priority"`
+	RequiredBugFields []string     `json:"requiredBugFields" db:"required_bug_fields"`
+	MaxTaskDepth      int          `json:"maxTaskDepth" db:"max_task_depth"`
+	ArchivedAt        sql.NullTime `json:"archivedAt" db:"archived_at"`
+	DeletedAt         sql.NullTime `json:"deletedAt" db:"deleted_at"`
 }
@@ -0,0 +1,22 @@
+package models
+
+// ReleaseNotes is the changelog of a version, made of the done tasks fixed in
+// it and grouped by their issue kind. Template holds the project's own
+// Markdown template, empty when the project uses the default one.
+type ReleaseNotes struct {
+	Version  *Version             `json:"version"`
+	Groups   []*ReleaseNotesGroup `json:"groups"`
+	Template string               `json:"-"`
+}
+
+type ReleaseNotesGroup struct {
+	Kind  string              `json:"kind"`
+	Icon  string              `json:"icon"`
+	Tasks []*ReleaseNotesTask `json:"tasks"`
+}
+
+type ReleaseNotesTask struct {
+	ID   uint64 `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
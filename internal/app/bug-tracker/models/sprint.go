@@ -0,0 +1,22 @@
+package models
+
+import (
+	"database/sql"
+)
+
+const (
+	SprintPlanned = "planned"
+	SprintActive  = "active"
+	SprintClosed  = "closed"
+)
+
+type Sprint struct {
+	ID          uint64       `json:"id" db:"id"`
+	ProjectID   uint64       `json:"projectId" db:"project_id"`
+	Name        string       `json:"name" db:"name"`
+	Goal        string       `json:"goal" db:"goal"`
+	StartDate   sql.NullTime `json:"startDate" db:"start_date"`
+	EndDate     sql.NullTime `json:"endDate" db:"end_date"`
+	State       string       `json:"state" db:"state"`
+	CompletedAt sql.NullTime `json:"completedAt" db:"completed_at"`
+}
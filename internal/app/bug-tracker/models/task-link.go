@@ -0,0 +1,46 @@
+package models
+
+const (
+	LinkBlocks     = "blocks"
+	LinkDuplicates = "duplicates"
+	LinkRelatesTo  = "relates-to"
+	LinkCausedBy   = "caused-by"
+
+	// LinkBlockedBy is accepted when creating a link and stored as a blocks
+	// link read the other way round.
+	LinkBlockedBy = "is-blocked-by"
+
+	LinkOutward = "outward"
+	LinkInward  = "inward"
+)
+
+// TaskLink is a link seen from one of its tasks. Outward links read from that
+// task to the linked one ("blocks"), inward links the other way round
+// ("is blocked by").
+type TaskLink struct {
+	ID        uint64      `json:"id" db:"id"`
+	Type      string      `json:"type" db:"link_type"`
+	Direction string      `json:"direction" db:"-"`
+	Task      *LinkedTask `json:"task" db:"-"`
+}
+
+type LinkedTask struct {
+	ID       uint64 `json:"id" db:"id"`
+	Key      string `json:"key" db:"-"`
+	Name     string `json:"name" db:"name"`
+	Status   string `json:"status" db:"-"`
+	Category string `json:"statusCategory" db:"-"`
+}
+
+// TaskGraph holds every task a task transitively blocks or is blocked by,
+// with an edge going from the blocker to the blocked task.
+type TaskGraph struct {
+	Nodes []*LinkedTask    `json:"nodes"`
+	Edges []*TaskGraphEdge `json:"edges"`
+}
+
+type TaskGraphEdge struct {
+	LinkID uint64 `json:"linkId"`
+	From   uint64 `json:"from"`
+	To     uint64 `json:"to"`
+}
@@ -5,13 +5,57 @@ import (
 )
 
 type Task struct {
-	ID          uint64        `json:"id" db:"id"`
-	Name        string        `json:"name" db:"name"`
-	Description string        `json:"description" db:"description"`
-	Priority    string        `json:"priority" db:"priority"`
-	ProjectID   uint64        `json:"projectId" db:"project_id"`
-	TaskType    string        `json:"taskType" db:"task_type"`
-	Assignee    sql.NullInt64 `json:"assignee" db:"assignee"`
-	CreatedAt   sql.NullTime  `json:"createdAt" db:"created_at"`
-	PerformTo   sql.NullTime  `json:"performTo" db:"perform_to"`
+	ID          uint64         `json:"id" db:"id"`
+	Key         string         `json:"key" db:"-"`
+	Number      uint64         `json:"number" db:"number"`
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	Priority    string         `json:"priority" db:"priority"`
+	ProjectID   uint64         `json:"projectId" db:"project_id"`
+	StatusID    uint64         `json:"statusId" db:"status_id"`
+	Status      string         `json:"status" db:"-"`
+	Category    string         `json:"statusCategory" db:"-"`
+	KindID      uint64         `json:"kindId" db:"kind_id"`
+	Kind        string         `json:"kind" db:"-"`
+	KindIcon    string         `json:"kindIcon" db:"-"`
+	Assignee    sql.NullInt64  `json:"assignee" db:"assignee"`
+	Reviewer    sql.NullInt64  `json:"reviewer" db:"reviewer"`
+	Resolution  sql.NullString `json:"resolution" db:"resolution"`
+	CreatedAt   sql.NullTime   `json:"createdAt" db:"created_at"`
+	PerformTo   sql.NullTime   `json:"performTo" db:"perform_to"`
+	Reporter    sql.NullInt64  `json:"reporter" db:"reporter"`
+	// UpdatedAt and UpdatedBy track every change logged in the task activity:
+	// creation, updates, status changes, taking or dropping it, and labels,
+	// sprints and version releases. Links between tasks do not count.
+	UpdatedAt sql.NullTime  `json:"updatedAt" db:"updated_at"`
+	UpdatedBy sql.NullInt64 `json:"updatedBy" db:"updated_by"`
+
+	Severity         sql.NullString `json:"severity" db:"severity"`
+	Environment      sql.NullString `json:"environment" db:"environment"`
+	AffectedVersion  sql.NullString `json:"affectedVersion" db:"affected_version"`
+	StepsToReproduce sql.NullString `json:"stepsToReproduce" db:"steps_to_reproduce"`
+	ExpectedResult   sql.NullString `json:"expectedResult" db:"expected_result"`
+	ActualResult     sql.NullString `json:"actualResult" db:"actual_result"`
+	Reproducibility  sql.NullString `json:"reproducibility" db:"reproducibility"`
+
+	ComponentID sql.NullInt64  `json:"componentId" db:"component_id"`
+	Component   sql.NullString `json:"component" db:"-"`
+	SprintID    sql.NullInt64  `json:"sprintId" db:"sprint_id"`
+	ParentID    sql.NullInt64  `json:"parentId" db:"parent_id"`
+	Progress    *TaskProgress  `json:"progress" db:"-"`
+
+	AffectsVersions []*TaskVersion `json:"affectsVersions" db:"-"`
+	FixVersions     []*TaskVersion `json:"fixVersions" db:"-"`
+
+	Labels       []*Label            `json:"labels" db:"-"`
+	CustomFields []*CustomFieldValue `json:"customFields" db:"-"`
+
+	Children []*Task `json:"children,omitempty" db:"-"`
+}
+
+// TaskProgress rolls up the status of the direct children of a task.
+type TaskProgress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Percent int `json:"percent"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+)
+
+const (
+	VersionAffects = "affects"
+	VersionFix     = "fix"
+)
+
+type Version struct {
+	ID          uint64       `json:"id" db:"id"`
+	ProjectID   uint64       `json:"projectId" db:"project_id"`
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	ReleaseDate sql.NullTime `json:"releaseDate" db:"release_date"`
+	Released    bool         `json:"released" db:"released"`
+	Archived    bool         `json:"archived" db:"archived"`
+}
+
+// TaskVersion is a version as it is shown on the tasks that affect or are
+// fixed in it.
+type TaskVersion struct {
+	ID       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Released bool   `json:"released"`
+}
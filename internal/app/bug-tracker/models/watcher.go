@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const WatchEventType = "task_change"
+
+// WatchCategory is the category of the messages telling watchers a task
+// changed.
+const WatchCategory = "watch"
+
+// WatchedTask is a task the user watches.
+type WatchedTask struct {
+	Task      *LinkedTask `json:"task" db:"-"`
+	WatchedAt time.Time   `json:"watchedAt" db:"created_at"`
+}
+
+// WatchEvent is the message sent to Kafka for every watcher of a task when
+// someone else changes it. Kind is the kind of the activity the change was
+// logged with. It is saved to the outbox with the change, for every change
+// logged in the activity of the task, labels, sprints and version releases
+// included. Links between tasks and the components and custom fields of the
+// project are not task changes and send none.
+type WatchEvent struct {
+	Type      string    `json:"type"`
+	UserID    uint64    `json:"userId"`
+	TaskID    uint64    `json:"taskId"`
+	Kind      string    `json:"kind"`
+	ChangedBy uint64    `json:"changedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// OutboxMessage encodes the event as JSON, keyed by the watcher so the
+// changes a watcher hears about are sent in order.
+func (e *WatchEvent) OutboxMessage() (*OutboxMessage, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		Category: WatchCategory,
+		Key:      fmt.Sprintf("user-%d", e.UserID),
+		Value:    value,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package models
+
+const (
+	StatusCategoryTodo       = "todo"
+	StatusCategoryInProgress = "in_progress"
+	StatusCategoryDone       = "done"
+)
+
+const (
+	GuardAssigneeRequired   = "assignee_required"
+	GuardResolutionRequired = "resolution_required"
+	GuardAssigneeOnly       = "assignee_only"
+	GuardReviewerOnly       = "reviewer_only"
+	GuardAdminOnly          = "admin_only"
+)
+
+type Status struct {
+	ID        uint64 `json:"id" db:"id"`
+	ProjectID uint64 `json:"projectId" db:"project_id"`
+	Name      string `json:"name" db:"name"`
+	Category  string `json:"category" db:"category"`
+	Position  int    `json:"position" db:"position"`
+	Initial   bool   `json:"initial" db:"is_initial"`
+}
+
+type Transition struct {
+	FromStatusID uint64   `json:"from" db:"from_status_id"`
+	ToStatusID   uint64   `json:"to" db:"to_status_id"`
+	Guards       []string `json:"guards" db:"guards"`
+}
+
+type FailedGuard struct {
+	Guard   string `json:"guard"`
+	Message string `json:"message"`
+}
+
+type Workflow struct {
+	Statuses    []*Status     `json:"statuses"`
+	Transitions []*Transition `json:"transitions"`
+}
@@ -16,6 +16,7 @@ import (
 type Redis interface {
 	Set(ctx context.Context, key, val string, exp time.Duration) error
 	Get(ctx context.Context, key string) (string, error)
+	Delete(ctx context.Context, keys ...string) error
 	SetRefreshToken(ctx context.Context, key, refreshToken string, TTL time.Duration) error
 	GetRefreshToken(ctx context.Context, key string) (string, error)
 	DeleteRefreshToken(ctx context.Context, key string) error
@@ -69,6 +70,17 @@ func (r *RedisRepository) Get(ctx context.Context, key string) (string, error) {
 	return val, nil
 }
 
+func (r *RedisRepository) Delete(ctx context.Context, keys ...string) error {
+	err := r.redis.Del(ctx, keys...).Err()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("[Redis] Delete %s", strings.Join(keys, ", "))
+
+	return nil
+}
+
 func (r *RedisRepository) SetRefreshToken(ctx context.Context, key, refreshToken string, TTL time.Duration) error {
 	userTokens, err := r.getUserRefreshTokens(ctx, fmt.Sprintf("*%s*", strings.Split(key, ":")[0]))
 
@@ -148,6 +148,59 @@ func Test_Get(t *testing.T) {
 	}
 }
 
+func Test_Delete(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, keys []string) *RedisRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		keys          []string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error",
+			keys: []string{"key"},
+			mockBehaviour: func(c *gomock.Controller, keys []string) *RedisRepository {
+				db, mock := redismock.NewClientMock()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectDel(keys...).SetErr(err)
+				log.EXPECT().Error(err)
+
+				return &RedisRepository{redis: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			keys: []string{"key1", "key2"},
+			mockBehaviour: func(c *gomock.Controller, keys []string) *RedisRepository {
+				db, mock := redismock.NewClientMock()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectDel(keys...).SetVal(2)
+				log.EXPECT().Infof("[Redis] Delete %s", "key1, key2")
+
+				return &RedisRepository{redis: db, log: log}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			redis := test.mockBehaviour(c, test.keys)
+
+			err := redis.Delete(context.Background(), test.keys...)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
 func Test_SetRefreshToken(t *testing.T) {
 	type args struct {
 		key          string
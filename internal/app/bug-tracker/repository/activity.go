@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// taskSnapshot builds the fields of task $1 the activity log follows, named
+// the way the API names them. Custom fields are keyed customField.<id>.
+const taskSnapshot = `(SELECT jsonb_build_object(
+			'name', name,
+			'description', description,
+			'priority', task_priority,
+			'statusId', status_id,
+			'resolution', resolution,
+			'kindId', kind_id,
+			'assignee', assignee,
+			'reviewer', reviewer,
+			'performTo', perform_to,
+			'severity', severity,
+			'environment', environment,
+			'affectedVersion', affected_version,
+			'stepsToReproduce', steps_to_reproduce,
+			'expectedResult', expected_result,
+			'actualResult', actual_result,
+			'reproducibility', reproducibility,
+			'componentId', component_id,
+			'sprintId', sprint_id,
+			'parentId', parent_id,
+			'labels', (SELECT COALESCE(jsonb_agg(label_id ORDER BY label_id), '[]')
+				FROM task_labels WHERE task_id = tasks.id),
+			'affectsVersions', (SELECT COALESCE(jsonb_agg(version_id ORDER BY version_id), '[]')
+				FROM task_versions WHERE task_id = tasks.id AND relation = 'affects'),
+			'fixVersions', (SELECT COALESCE(jsonb_agg(version_id ORDER BY version_id), '[]')
+				FROM task_versions WHERE task_id = tasks.id AND relation = 'fix')
+		) || COALESCE((SELECT jsonb_object_agg('customField.' || field_id, COALESCE(to_jsonb(value_options), to_jsonb(value_text)))
+			FROM task_custom_values WHERE task_id = tasks.id), '{}')
+		FROM tasks WHERE id = $1)`
+
+const (
+	taskSnapshotQuery = "SELECT " + taskSnapshot
+	taskChangesQuery  = `INSERT INTO task_activities (task_id, actor_id, kind, field, old_value, new_value, created_at)
+		SELECT $1, $2, CASE key WHEN 'assignee' THEN 'assignment' WHEN 'statusId' THEN 'status' ELSE 'field' END::task_activity_kind,
+		key, old.value, new.value, $4
+		FROM jsonb_each($3::jsonb) AS old FULL JOIN jsonb_each(` + taskSnapshot + `) AS new USING (key)
+		WHERE old.value IS DISTINCT FROM new.value ORDER BY key`
+	touchTasksQuery = "UPDATE tasks SET updated_at = $1, updated_by = $2 WHERE id = ANY($3)"
+	activityQuery   = `INSERT INTO task_activities (task_id, actor_id, kind, field, old_value, new_value, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)`
+)
+
+// takeTaskSnapshot reads the fields of the task as they are before a change,
+// for recordTaskChanges to compare them with afterwards. The snapshot of a
+// missing task is NULL.
+func takeTaskSnapshot(tx *sql.Tx, taskID uint64) (sql.NullString, error) {
+	var snapshot sql.NullString
+	err := tx.QueryRow(taskSnapshotQuery, taskID).Scan(&snapshot)
+
+	return snapshot, err
+}
+
+// recordTaskChanges logs every field of the task that differs from the
+// snapshot taken before the change.
+func recordTaskChanges(tx *sql.Tx, taskID, actorID uint64, snapshot sql.NullString) error {
+	_, err := tx.Exec(taskChangesQuery, taskID, actorID, snapshot, time.Now().UTC())
+
+	return err
+}
+
+// takeTaskSnapshots is takeTaskSnapshot for every task of a bulk change.
+func takeTaskSnapshots(tx *sql.Tx, taskIDs []uint64) ([]sql.NullString, error) {
+	snapshots := make([]sql.NullString, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		snapshot, err := takeTaskSnapshot(tx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// recordTasksChanges is recordTaskChanges for the snapshots taken by
+// takeTaskSnapshots. The tasks that actually changed are marked updated by the
+// actor and returned.
+func recordTasksChanges(tx *sql.Tx, taskIDs []uint64, actorID uint64, snapshots []sql.NullString) ([]uint64, error) {
+	changedAt := time.Now().UTC()
+	changed := make([]uint64, 0, len(taskIDs))
+	for i, taskID := range taskIDs {
+		result, err := tx.Exec(taskChangesQuery, taskID, actorID, snapshots[i], changedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			changed = append(changed, taskID)
+		}
+	}
+
+	if len(changed) > 0 {
+		if _, err := tx.Exec(touchTasksQuery, changedAt, actorID, pq.Array(changed)); err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
+
+// recordActivity logs a single entry for the task. Values are stored as JSON
+// and nil values are left out.
+func recordActivity(tx *sql.Tx, taskID, actorID uint64, kind, field string, oldValue, newValue interface{}) error {
+	oldJSON, err := activityValue(oldValue)
+	if err != nil {
+		return err
+	}
+
+	newJSON, err := activityValue(newValue)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(activityQuery, taskID, actorID, kind, field, oldJSON, newJSON, time.Now().UTC())
+
+	return err
+}
+
+func activityValue(value interface{}) (sql.NullString, error) {
+	if value == nil {
+		return sql.NullString{}, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+type ActivityRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+}
+
+func NewActivityRepo(db *sql.DB, log log.Log, admin admin, member member) Activity {
+	return &ActivityRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+	}
+}
+
+func (r *ActivityRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+// GetActivities returns a page of the history of the task, oldest first.
+func (r *ActivityRepository) GetActivities(taskID, userID uint64, page *dto.PageDto) (*models.ActivityPage, error) {
+	projectID, err := taskProjectID(r.db, taskID)
+	if err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return nil, err
+	}
+
+	if !r.isParticipant(projectID, userID) {
+		return nil, ErrNoRights
+	}
+
+	limit, offset := pageBounds(page)
+	result := &models.ActivityPage{Page: offset/limit + 1, Limit: limit, Activities: make([]*models.Activity, 0)}
+
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM task_activities WHERE task_id = $1", taskID).Scan(&result.Count); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, task_id, actor_id, kind, field, old_value, new_value, created_at FROM task_activities
+		WHERE task_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`,
+		taskID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		activity := new(models.Activity)
+		err := rows.Scan(
+			&activity.ID,
+			&activity.TaskID,
+			&activity.ActorID,
+			&activity.Kind,
+			&activity.Field,
+			(*[]byte)(&activity.OldValue),
+			(*[]byte)(&activity.NewValue),
+			&activity.CreatedAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		result.Activities = append(result.Activities, activity)
+	}
+
+	return result, nil
+}
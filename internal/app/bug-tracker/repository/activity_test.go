@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+// testTaskSnapshot stands in for the snapshot of a task taken before a change.
+const testTaskSnapshot = `{"name": "name", "assignee": null, "statusId": 1}`
+
+// expectTaskSnapshots expects takeTaskSnapshots for the tasks.
+func expectTaskSnapshots(mock sqlmock.Sqlmock, taskIDs ...uint64) {
+	for _, taskID := range taskIDs {
+		mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+			WithArgs(taskID).
+			WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+	}
+}
+
+// expectTaskChanges expects recordTasksChanges to log the changes of a task,
+// if it changed at all.
+func expectTaskChanges(mock sqlmock.Sqlmock, actorID, taskID uint64, changed bool) {
+	var count int64
+	if changed {
+		count = 1
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+		WithArgs(taskID, actorID, testTaskSnapshot, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, count))
+}
+
+// expectTouchTasks expects recordTasksChanges to mark the changed tasks
+// updated by the actor.
+func expectTouchTasks(mock sqlmock.Sqlmock, actorID uint64, taskIDs ...uint64) {
+	ids, _ := pq.Array(taskIDs).Value()
+
+	mock.ExpectExec(regexp.QuoteMeta(touchTasksQuery)).
+		WithArgs(sqlmock.AnyArg(), actorID, ids).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(taskIDs))))
+}
+
+// expectTasksChanges expects recordTasksChanges for tasks that all changed.
+func expectTasksChanges(mock sqlmock.Sqlmock, actorID uint64, taskIDs ...uint64) {
+	for _, taskID := range taskIDs {
+		expectTaskChanges(mock, actorID, taskID, true)
+	}
+
+	if len(taskIDs) > 0 {
+		expectTouchTasks(mock, actorID, taskIDs...)
+	}
+}
+
+func Test_recordTasksChanges(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	expectTaskSnapshots(mock, 1, 2)
+	expectTaskChanges(mock, 3, 1, true)
+	expectTaskChanges(mock, 3, 2, false)
+	expectTouchTasks(mock, 3, 1)
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	snapshots, err := takeTaskSnapshots(tx, []uint64{1, 2})
+	require.NoError(t, err)
+
+	changed, err := recordTasksChanges(tx, []uint64{1, 2}, 3, snapshots)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, changed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_recordActivity(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+		WithArgs(uint64(1), uint64(2), models.ActivityAttachment, "", nil, `{"attachmentId":3,"fileName":"a.txt"}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	err = recordActivity(tx, 1, 2, models.ActivityAttachment, "", nil, attachmentActivity{ID: 3, FileName: "a.txt"})
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetActivities(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *ActivityRepository
+	err := errors.New("error")
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	activityColumns := []string{"id", "task_id", "actor_id", "kind", "field", "old_value", "new_value", "created_at"}
+
+	tests := []struct {
+		name           string
+		page           *dto.PageDto
+		mockBehaviour  mockBehaviour
+		expectedResult *models.ActivityPage
+		expectedError  error
+	}{
+		{
+			name: "Error task not found",
+			page: &dto.PageDto{},
+			mockBehaviour: func(c *gomock.Controller) *ActivityRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnError(sql.ErrNoRows)
+
+				return &ActivityRepository{db: db}
+			},
+			expectedResult: nil,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name: "Error no rights",
+			page: &dto.PageDto{},
+			mockBehaviour: func(c *gomock.Controller) *ActivityRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(2)).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), uint64(2)).Return(err)
+
+				return &ActivityRepository{db: db, admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name: "OK",
+			page: &dto.PageDto{Page: 2, Limit: 1},
+			mockBehaviour: func(c *gomock.Controller) *ActivityRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(2)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM task_activities WHERE task_id = $1")).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(regexp.QuoteMeta(
+					`SELECT id, task_id, actor_id, kind, field, old_value, new_value, created_at FROM task_activities
+					WHERE task_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`,
+				)).WithArgs(uint64(1), 1, 1).WillReturnRows(
+					sqlmock.NewRows(activityColumns).AddRow(uint64(5), uint64(1), int64(2), "status", "statusId", []byte("1"), []byte("2"), createdAt),
+				)
+
+				return &ActivityRepository{db: db, log: log, member: member}
+			},
+			expectedResult: &models.ActivityPage{
+				Count: 2,
+				Page:  2,
+				Limit: 1,
+				Activities: []*models.Activity{{
+					ID:        5,
+					TaskID:    1,
+					ActorID:   sql.NullInt64{Int64: 2, Valid: true},
+					Kind:      models.ActivityStatus,
+					Field:     sql.NullString{String: "statusId", Valid: true},
+					OldValue:  json.RawMessage("1"),
+					NewValue:  json.RawMessage("2"),
+					CreatedAt: createdAt,
+				}},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			res, err := repo.GetActivities(1, 2, test.page)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -22,7 +22,7 @@ func new_adminStrategy(db *sql.DB, log log.Log) admin {
 }
 
 func (s *adminStrategy) IsAdmin(projectID, userID uint64) error {
-	result := s.db.QueryRow("SELECT admin FROM projects WHERE id = $1", projectID)
+	result := s.db.QueryRow("SELECT admin FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID)
 
 	var adminID uint64
 	if err := result.Scan(&adminID); err != nil {
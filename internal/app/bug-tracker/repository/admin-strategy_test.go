@@ -43,7 +43,7 @@ func Test_IsAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1"),
+					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1 AND deleted_at IS NULL"),
 				).WithArgs(projectID).WillReturnError(err)
 				log.EXPECT().Error(err).Return()
 
@@ -61,7 +61,7 @@ func Test_IsAdmin(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"admin"}).AddRow(uint64(2))
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1"),
+					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1 AND deleted_at IS NULL"),
 				).WithArgs(projectID).WillReturnRows(rows)
 
 				return &adminStrategy{db, log}
@@ -78,7 +78,7 @@ func Test_IsAdmin(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"admin"}).AddRow(uint64(1))
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1"),
+					regexp.QuoteMeta("SELECT admin FROM projects WHERE id = $1 AND deleted_at IS NULL"),
 				).WithArgs(projectID).WillReturnRows(rows)
 
 				return &adminStrategy{db, log}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrAttachmentNotFound = errors.New("error attachment is not found")
+	ErrAttachmentQuota    = errors.New("error project attachment quota is exceeded")
+)
+
+const attachmentColumns = "id, task_id, file_name, content_type, size, storage_key, uploaded_by, created_at"
+
+// attachmentActivity is what the activity log keeps of an added or removed
+// attachment.
+type attachmentActivity struct {
+	ID       uint64 `json:"attachmentId"`
+	FileName string `json:"fileName"`
+}
+
+type AttachmentRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewAttachmentRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Attachment {
+	return &AttachmentRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *AttachmentRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+func (r *AttachmentRepository) taskProject(taskID, userID uint64) (uint64, error) {
+	projectID, err := taskProjectID(r.db, taskID)
+	if err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return 0, err
+	}
+
+	if !r.isParticipant(projectID, userID) {
+		return 0, ErrNoRights
+	}
+
+	return projectID, nil
+}
+
+func scanAttachment(row scanner) (*models.Attachment, error) {
+	attachment := new(models.Attachment)
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.UploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// attachmentStorageKeys reads the keys of the attachments the query selects,
+// for their contents to be removed once the rows cascade away.
+func attachmentStorageKeys(tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	storageKeys := make([]string, 0)
+	for rows.Next() {
+		var storageKey string
+		if err := rows.Scan(&storageKey); err != nil {
+			return nil, err
+		}
+
+		storageKeys = append(storageKeys, storageKey)
+	}
+
+	return storageKeys, rows.Err()
+}
+
+// CreateAttachment records an uploaded file as long as the files of the
+// project stay within maxProjectSize bytes.
+func (r *AttachmentRepository) CreateAttachment(attachmentData *dto.CreateAttachmentDto, userID uint64, maxProjectSize int64) (uint64, error) {
+	projectID, err := r.taskProject(attachmentData.TaskID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	// Locking the project serializes uploads, so two concurrent uploads
+	// cannot both fit into the last free space of the quota.
+	if _, err := tx.Exec("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE", projectID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	var used int64
+	err = tx.QueryRow(
+		`SELECT COALESCE(SUM(attachments.size), 0) FROM attachments
+		JOIN tasks ON tasks.id = attachments.task_id WHERE tasks.project_id = $1`,
+		projectID,
+	).Scan(&used)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if used+attachmentData.Size > maxProjectSize {
+		tx.Rollback()
+		return 0, ErrAttachmentQuota
+	}
+
+	var attachmentID uint64
+	err = tx.QueryRow(
+		`INSERT INTO attachments (task_id, file_name, content_type, size, storage_key, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		attachmentData.TaskID,
+		attachmentData.FileName,
+		attachmentData.ContentType,
+		attachmentData.Size,
+		attachmentData.StorageKey,
+		userID,
+		time.Now().UTC(),
+	).Scan(&attachmentID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	activity := attachmentActivity{ID: attachmentID, FileName: attachmentData.FileName}
+	if err := recordActivity(tx, attachmentData.TaskID, userID, models.ActivityAttachment, "", nil, activity); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := addWatchEvents(tx, attachmentData.TaskID, userID, models.ActivityAttachment); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Create attachment: id = %d, task = %d", attachmentID, attachmentData.TaskID)
+
+	return attachmentID, nil
+}
+
+func (r *AttachmentRepository) GetAttachments(taskID, userID uint64) ([]*models.Attachment, error) {
+	if _, err := r.taskProject(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		"SELECT "+attachmentColumns+" FROM attachments WHERE task_id = $1 ORDER BY created_at, id",
+		taskID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]*models.Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetAttachment(taskID, attachmentID, userID uint64) (*models.Attachment, error) {
+	if _, err := r.taskProject(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := scanAttachment(r.db.QueryRow(
+		"SELECT "+attachmentColumns+" FROM attachments WHERE id = $1 AND task_id = $2",
+		attachmentID,
+		taskID,
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAttachmentNotFound
+		}
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// DeleteAttachment removes an attachment the user uploaded, or any attachment
+// when the user is the project admin, and returns the key its contents are
+// stored under.
+func (r *AttachmentRepository) DeleteAttachment(taskID, attachmentID, userID uint64) (string, error) {
+	projectID, err := r.taskProject(taskID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return "", err
+	}
+
+	isAdmin := r.admin.IsAdmin(projectID, userID) == nil
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return "", err
+	}
+
+	var storageKey string
+	activity := attachmentActivity{ID: attachmentID}
+	err = tx.QueryRow(
+		`DELETE FROM attachments WHERE id = $1 AND task_id = $2 AND (uploaded_by = $3 OR $4)
+		RETURNING storage_key, file_name`,
+		attachmentID,
+		taskID,
+		userID,
+		isAdmin,
+	).Scan(&storageKey, &activity.FileName)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return "", ErrAttachmentNotFound
+		}
+		r.log.Error(err)
+		return "", err
+	}
+
+	if err := recordActivity(tx, taskID, userID, models.ActivityAttachment, "", activity, nil); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return "", err
+	}
+
+	if err := addWatchEvents(tx, taskID, userID, models.ActivityAttachment); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return "", err
+	}
+	r.log.Infof("Delete attachment: id = %d", attachmentID)
+
+	return storageKey, nil
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_CreateAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, attachmentData *dto.CreateAttachmentDto, userID uint64) *AttachmentRepository
+	attachmentData := &dto.CreateAttachmentDto{
+		TaskID:      1,
+		FileName:    "screenshot.png",
+		Size:        40,
+		ContentType: "image/png",
+		StorageKey:  "tasks/1/key",
+	}
+	usedQuery := `SELECT COALESCE(SUM(attachments.size), 0) FROM attachments
+		JOIN tasks ON tasks.id = attachments.task_id WHERE tasks.project_id = $1`
+	insertQuery := `INSERT INTO attachments (task_id, file_name, content_type, size, storage_key, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	tests := []struct {
+		name           string
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:   "Error quota is exceeded",
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, attachmentData *dto.CreateAttachmentDto, userID uint64) *AttachmentRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(attachmentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+					WithArgs(uint64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(usedQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(70))
+				mock.ExpectRollback()
+
+				return &AttachmentRepository{db: db, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrAttachmentQuota,
+		},
+		{
+			name:   "OK",
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, attachmentData *dto.CreateAttachmentDto, userID uint64) *AttachmentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(attachmentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+					WithArgs(uint64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(usedQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(60))
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).
+					WithArgs(
+						attachmentData.TaskID,
+						attachmentData.FileName,
+						attachmentData.ContentType,
+						attachmentData.Size,
+						attachmentData.StorageKey,
+						userID,
+						sqlmock.AnyArg(),
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(attachmentData.TaskID, userID, models.ActivityAttachment, "", nil, `{"attachmentId":3,"fileName":"screenshot.png"}`, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, attachmentData.TaskID, userID, 2)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create attachment: id = %d, task = %d", uint64(3), attachmentData.TaskID)
+
+				return &AttachmentRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, attachmentData, test.userID)
+			res, err := repo.CreateAttachment(attachmentData, test.userID, 100)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, userID uint64) *AttachmentRepository
+	err := errors.New("error")
+	query := `DELETE FROM attachments WHERE id = $1 AND task_id = $2 AND (uploaded_by = $3 OR $4)
+		RETURNING storage_key, file_name`
+
+	tests := []struct {
+		name           string
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult string
+		expectedError  error
+	}{
+		{
+			name:   "Error no rights",
+			userID: 2,
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *AttachmentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(err)
+
+				return &AttachmentRepository{db: db, admin: admin, member: member}
+			},
+			expectedResult: "",
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:   "Error attachment of another user",
+			userID: 2,
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *AttachmentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(ErrNoRights)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(3), uint64(1), userID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key", "file_name"}))
+				mock.ExpectRollback()
+
+				return &AttachmentRepository{db: db, admin: admin, member: member, state: state}
+			},
+			expectedResult: "",
+			expectedError:  ErrAttachmentNotFound,
+		},
+		{
+			name:   "OK",
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *AttachmentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(3), uint64(1), userID, true).
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key", "file_name"}).AddRow("tasks/1/key", "screenshot.png"))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(uint64(1), userID, models.ActivityAttachment, "", `{"attachmentId":3,"fileName":"screenshot.png"}`, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, uint64(1), userID)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Delete attachment: id = %d", uint64(3))
+
+				return &AttachmentRepository{db: db, log: log, admin: admin, member: member, state: state}
+			},
+			expectedResult: "tasks/1/key",
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.userID)
+			res, err := repo.DeleteAttachment(1, 3, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
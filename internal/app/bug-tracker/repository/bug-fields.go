@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// RequiredFieldsError is returned when a task is filed without fields its
+// project made mandatory. Fields lists every missing field by its JSON name.
+type RequiredFieldsError struct {
+	Fields []string
+}
+
+func (e *RequiredFieldsError) Error() string {
+	return "error required fields are missing"
+}
+
+func bugFieldValues(fields *dto.BugFieldsDto) map[string]string {
+	return map[string]string{
+		models.BugFieldSeverity:         fields.Severity,
+		models.BugFieldEnvironment:      fields.Environment,
+		models.BugFieldAffectedVersion:  fields.AffectedVersion,
+		models.BugFieldStepsToReproduce: fields.StepsToReproduce,
+		models.BugFieldExpectedResult:   fields.ExpectedResult,
+		models.BugFieldActualResult:     fields.ActualResult,
+		models.BugFieldReproducibility:  fields.Reproducibility,
+	}
+}
+
+// checkBugFields makes sure a task of a bug kind carries every bug field its
+// project requires. Tasks of other kinds are never checked. A zero kindID
+// stands for the project's default kind.
+func checkBugFields(tx *sql.Tx, projectID, kindID uint64, fields *dto.BugFieldsDto) error {
+	var isBug bool
+	var required []string
+
+	err := tx.QueryRow(
+		`SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds
+		JOIN projects ON projects.id = issue_kinds.project_id
+		WHERE issue_kinds.project_id = $1 AND (issue_kinds.id = $2 OR ($2 = 0 AND issue_kinds.is_default))`,
+		projectID,
+		kindID,
+	).Scan(&isBug, pq.Array(&required))
+	if err == sql.ErrNoRows {
+		return ErrIssueKindNotFound
+	}
+	if err != nil || !isBug {
+		return err
+	}
+
+	values := bugFieldValues(fields)
+	missing := make([]string, 0)
+	for _, field := range required {
+		if values[field] == "" {
+			missing = append(missing, field)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &RequiredFieldsError{Fields: missing}
+	}
+
+	return nil
+}
@@ -0,0 +1,452 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrCommentNotFound = errors.New("error comment is not found")
+	ErrCommentDeleted  = errors.New("error comment is deleted")
+)
+
+const commentColumns = "id, task_id, parent_id, author_id, body, created_at, edited_at, deleted_at"
+
+type CommentRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewCommentRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Comment {
+	return &CommentRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *CommentRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+// taskProject returns the project of the task after checking that the user
+// takes part in it.
+func (r *CommentRepository) taskProject(taskID, userID uint64) (uint64, error) {
+	projectID, err := taskProjectID(r.db, taskID)
+	if err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return 0, err
+	}
+
+	if !r.isParticipant(projectID, userID) {
+		return 0, ErrNoRights
+	}
+
+	return projectID, nil
+}
+
+func scanComment(row scanner) (*models.Comment, error) {
+	comment := new(models.Comment)
+	err := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.ParentID,
+		&comment.AuthorID,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.EditedAt,
+		&comment.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (r *CommentRepository) CreateComment(commentData *dto.CreateCommentDto, userID uint64) (uint64, error) {
+	projectID, err := r.taskProject(commentData.TaskID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return 0, err
+	}
+
+	var parentID sql.NullInt64
+	if commentData.ParentID != 0 {
+		var deleted bool
+
+		// A reply to a reply joins the thread of the comment it answers.
+		err := r.db.QueryRow(
+			"SELECT COALESCE(parent_id, id), deleted_at IS NOT NULL FROM comments WHERE id = $1 AND task_id = $2",
+			commentData.ParentID,
+			commentData.TaskID,
+		).Scan(&parentID, &deleted)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrCommentNotFound
+			}
+			r.log.Error(err)
+			return 0, err
+		}
+
+		if deleted {
+			return 0, ErrCommentDeleted
+		}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	var commentID uint64
+	err = tx.QueryRow(
+		`INSERT INTO comments (task_id, parent_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		commentData.TaskID,
+		parentID,
+		userID,
+		commentData.Body,
+		time.Now().UTC(),
+	).Scan(&commentID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	activity := map[string]uint64{"commentId": commentID}
+	if err := recordActivity(tx, commentData.TaskID, userID, models.ActivityComment, "", nil, activity); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := addWatchers(tx, commentData.TaskID, userID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := addWatchEvents(tx, commentData.TaskID, userID, models.ActivityComment); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Create comment: id = %d, task = %d", commentID, commentData.TaskID)
+
+	return commentID, nil
+}
+
+// UpdateComment lets the author change the body of a comment, keeping the
+// previous body in the edit history.
+func (r *CommentRepository) UpdateComment(commentData *dto.UpdateCommentDto, userID uint64) error {
+	projectID, err := r.taskProject(commentData.TaskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	var authorID sql.NullInt64
+	var body string
+	var deleted bool
+	err = tx.QueryRow(
+		"SELECT author_id, body, deleted_at IS NOT NULL FROM comments WHERE id = $1 AND task_id = $2 FOR UPDATE",
+		commentData.CommentID,
+		commentData.TaskID,
+	).Scan(&authorID, &body, &deleted)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return ErrCommentNotFound
+		}
+		r.log.Error(err)
+		return err
+	}
+
+	if deleted {
+		tx.Rollback()
+		return ErrCommentDeleted
+	}
+
+	if !authorID.Valid || uint64(authorID.Int64) != userID {
+		tx.Rollback()
+		return ErrNoRights
+	}
+
+	if body == commentData.Body {
+		return tx.Commit()
+	}
+
+	editedAt := time.Now().UTC()
+	_, err = tx.Exec(
+		"INSERT INTO comment_revisions (comment_id, body, edited_by, edited_at) VALUES ($1, $2, $3, $4)",
+		commentData.CommentID,
+		body,
+		userID,
+		editedAt,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		"UPDATE comments SET body = $1, edited_at = $2 WHERE id = $3",
+		commentData.Body,
+		editedAt,
+		commentData.CommentID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addWatchEvents(tx, commentData.TaskID, userID, models.ActivityComment); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Update comment: id = %d", commentData.CommentID)
+
+	return nil
+}
+
+// DeleteComment blanks a comment of the user, or any comment when the user is
+// the project admin. The comment stays in place so its replies keep their
+// thread, but its edit history goes away with the body.
+func (r *CommentRepository) DeleteComment(taskID, commentID, userID uint64) error {
+	projectID, err := r.taskProject(taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return err
+	}
+
+	isAdmin := r.admin.IsAdmin(projectID, userID) == nil
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	result, err := tx.Exec(
+		`UPDATE comments SET body = '', deleted_at = $1
+		WHERE id = $2 AND task_id = $3 AND deleted_at IS NULL AND (author_id = $4 OR $5)`,
+		time.Now().UTC(),
+		commentID,
+		taskID,
+		userID,
+		isAdmin,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if count == 0 {
+		tx.Rollback()
+		return ErrCommentNotFound
+	}
+
+	if _, err := tx.Exec("DELETE FROM comment_revisions WHERE comment_id = $1", commentID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	activity := map[string]uint64{"commentId": commentID}
+	if err := recordActivity(tx, taskID, userID, models.ActivityComment, "", activity, nil); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addWatchEvents(tx, taskID, userID, models.ActivityComment); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Delete comment: id = %d", commentID)
+
+	return nil
+}
+
+// GetComments returns a page of comment threads of the task, oldest first,
+// with the replies of every thread.
+func (r *CommentRepository) GetComments(taskID, userID uint64, page *dto.PageDto) (*models.CommentPage, error) {
+	if _, err := r.taskProject(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	limit, offset := pageBounds(page)
+	result := &models.CommentPage{Page: offset/limit + 1, Limit: limit, Comments: make([]*models.Comment, 0)}
+
+	err := r.db.QueryRow(
+		`SELECT COUNT(*) FILTER (WHERE deleted_at IS NULL), COUNT(*) FILTER (WHERE parent_id IS NULL)
+		FROM comments WHERE task_id = $1`,
+		taskID,
+	).Scan(&result.Count, &result.Threads)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		"SELECT "+commentColumns+` FROM comments WHERE task_id = $1 AND parent_id IS NULL
+		ORDER BY created_at, id LIMIT $2 OFFSET $3`,
+		taskID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	threads := make(map[uint64]*models.Comment)
+	threadIDs := make([]uint64, 0, limit)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		result.Comments = append(result.Comments, comment)
+		threads[comment.ID] = comment
+		threadIDs = append(threadIDs, comment.ID)
+	}
+
+	if len(threadIDs) == 0 {
+		return result, nil
+	}
+
+	replies, err := r.db.Query(
+		"SELECT "+commentColumns+" FROM comments WHERE parent_id = ANY($1) ORDER BY created_at, id",
+		pq.Array(threadIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer replies.Close()
+
+	for replies.Next() {
+		reply, err := scanComment(replies)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		thread := threads[uint64(reply.ParentID.Int64)]
+		thread.Replies = append(thread.Replies, reply)
+	}
+
+	return result, nil
+}
+
+func (r *CommentRepository) GetCommentCount(taskID uint64) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM comments WHERE task_id = $1 AND deleted_at IS NULL",
+		taskID,
+	).Scan(&count)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *CommentRepository) GetCommentHistory(taskID, commentID, userID uint64) ([]*models.CommentRevision, error) {
+	if _, err := r.taskProject(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT comment_revisions.id, comment_revisions.comment_id, comment_revisions.body,
+		comment_revisions.edited_by, comment_revisions.edited_at
+		FROM comment_revisions JOIN comments ON comments.id = comment_revisions.comment_id
+		WHERE comment_revisions.comment_id = $1 AND comments.task_id = $2
+		ORDER BY comment_revisions.edited_at, comment_revisions.id`,
+		commentID,
+		taskID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]*models.CommentRevision, 0)
+	for rows.Next() {
+		revision := new(models.CommentRevision)
+		err := rows.Scan(&revision.ID, &revision.CommentID, &revision.Body, &revision.EditedBy, &revision.EditedAt)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+const taskProjectQuery = `SELECT tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+		WHERE tasks.id = $1 AND projects.deleted_at IS NULL`
+
+func Test_pageBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		page           *dto.PageDto
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{
+			name:           "Defaults",
+			page:           &dto.PageDto{},
+			expectedLimit:  dto.DefaultPageLimit,
+			expectedOffset: 0,
+		},
+		{
+			name:           "Third page",
+			page:           &dto.PageDto{Page: 3, Limit: 10},
+			expectedLimit:  10,
+			expectedOffset: 20,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit, offset := pageBounds(test.page)
+
+			require.Equal(t, test.expectedLimit, limit)
+			require.Equal(t, test.expectedOffset, offset)
+		})
+	}
+}
+
+func Test_CreateComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentRepository
+	err := errors.New("error")
+	parentQuery := "SELECT COALESCE(parent_id, id), deleted_at IS NOT NULL FROM comments WHERE id = $1 AND task_id = $2"
+	insertQuery := `INSERT INTO comments (task_id, parent_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	tests := []struct {
+		name           string
+		commentData    *dto.CreateCommentDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:        "Error task is not found",
+			commentData: &dto.CreateCommentDto{TaskID: 1, Body: "body"},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnError(sql.ErrNoRows)
+
+				return &CommentRepository{db: db}
+			},
+			expectedResult: 0,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name:        "Error no rights",
+			commentData: &dto.CreateCommentDto{TaskID: 1, Body: "body"},
+			userID:      2,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(err)
+
+				return &CommentRepository{db: db, admin: admin, member: member}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:        "Error parent is deleted",
+			commentData: &dto.CreateCommentDto{TaskID: 1, ParentID: 2, Body: "body"},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta(parentQuery)).
+					WithArgs(commentData.ParentID, commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce", "deleted"}).AddRow(2, true))
+
+				return &CommentRepository{db: db, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrCommentDeleted,
+		},
+		{
+			name:        "OK reply to a reply",
+			commentData: &dto.CreateCommentDto{TaskID: 1, ParentID: 3, Body: "body"},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta(parentQuery)).
+					WithArgs(commentData.ParentID, commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce", "deleted"}).AddRow(2, false))
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(insertQuery)).
+					WithArgs(commentData.TaskID, int64(2), userID, commentData.Body, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(commentData.TaskID, userID, models.ActivityComment, "", nil, `{"commentId":4}`, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(commentData.TaskID, "{1}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, commentData.TaskID, userID, 2, 3)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create comment: id = %d, task = %d", uint64(4), commentData.TaskID)
+
+				return &CommentRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.commentData, test.userID)
+			res, err := repo.CreateComment(test.commentData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, commentData *dto.UpdateCommentDto, userID uint64) *CommentRepository
+	commentData := &dto.UpdateCommentDto{TaskID: 1, CommentID: 2, Body: "new body"}
+	lockQuery := "SELECT author_id, body, deleted_at IS NOT NULL FROM comments WHERE id = $1 AND task_id = $2 FOR UPDATE"
+
+	tests := []struct {
+		name          string
+		commentData   *dto.UpdateCommentDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:        "Error not the author",
+			commentData: commentData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.UpdateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(commentData.CommentID, commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"author_id", "body", "deleted"}).AddRow(5, "body", false))
+				mock.ExpectRollback()
+
+				return &CommentRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:        "OK",
+			commentData: commentData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.UpdateCommentDto, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(commentData.CommentID, commentData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"author_id", "body", "deleted"}).AddRow(1, "body", false))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO comment_revisions (comment_id, body, edited_by, edited_at) VALUES ($1, $2, $3, $4)")).
+					WithArgs(commentData.CommentID, "body", userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE comments SET body = $1, edited_at = $2 WHERE id = $3")).
+					WithArgs(commentData.Body, sqlmock.AnyArg(), commentData.CommentID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, commentData.TaskID, userID)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Update comment: id = %d", commentData.CommentID)
+
+				return &CommentRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.commentData, test.userID)
+			err := repo.UpdateComment(test.commentData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID, commentID, userID uint64) *CommentRepository
+	err := errors.New("error")
+	query := `UPDATE comments SET body = '', deleted_at = $1
+		WHERE id = $2 AND task_id = $3 AND deleted_at IS NULL AND (author_id = $4 OR $5)`
+
+	tests := []struct {
+		name          string
+		taskID        uint64
+		commentID     uint64
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error comment is not found",
+			taskID:    1,
+			commentID: 2,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, taskID, commentID, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(err)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(sqlmock.AnyArg(), commentID, taskID, userID, false).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+
+				return &CommentRepository{db: db, admin: admin, member: member, state: state}
+			},
+			expectedError: ErrCommentNotFound,
+		},
+		{
+			name:      "OK admin",
+			taskID:    1,
+			commentID: 2,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, taskID, commentID, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), userID).Return(nil).Times(2)
+				state.EXPECT().IsWritable(uint64(1)).Return(nil)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(sqlmock.AnyArg(), commentID, taskID, userID, true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM comment_revisions WHERE comment_id = $1")).
+					WithArgs(commentID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(taskID, userID, models.ActivityComment, "", `{"commentId":2}`, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, taskID, userID, 3)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Delete comment: id = %d", commentID)
+
+				return &CommentRepository{db: db, log: log, admin: admin, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskID, test.commentID, test.userID)
+			err := repo.DeleteComment(test.taskID, test.commentID, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetComments(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID, userID uint64) *CommentRepository
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "task_id", "parent_id", "author_id", "body", "created_at", "edited_at", "deleted_at"}
+	countQuery := `SELECT COUNT(*) FILTER (WHERE deleted_at IS NULL), COUNT(*) FILTER (WHERE parent_id IS NULL)
+		FROM comments WHERE task_id = $1`
+	threadsQuery := "SELECT " + commentColumns + ` FROM comments WHERE task_id = $1 AND parent_id IS NULL
+		ORDER BY created_at, id LIMIT $2 OFFSET $3`
+	repliesQuery := "SELECT " + commentColumns + " FROM comments WHERE parent_id = ANY($1) ORDER BY created_at, id"
+
+	tests := []struct {
+		name           string
+		taskID         uint64
+		userID         uint64
+		page           *dto.PageDto
+		mockBehaviour  mockBehaviour
+		expectedResult *models.CommentPage
+		expectedError  error
+	}{
+		{
+			name:   "OK",
+			taskID: 1,
+			userID: 1,
+			page:   &dto.PageDto{Page: 2, Limit: 1},
+			mockBehaviour: func(c *gomock.Controller, taskID, userID uint64) *CommentRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), userID).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "threads"}).AddRow(3, 2))
+				mock.ExpectQuery(regexp.QuoteMeta(threadsQuery)).
+					WithArgs(taskID, 1, 1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 1, nil, 1, "thread", createdAt, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(repliesQuery)).
+					WithArgs("{2}").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, 2, 1, "reply", createdAt, nil, nil))
+
+				return &CommentRepository{db: db, member: member}
+			},
+			expectedResult: &models.CommentPage{
+				Count:   3,
+				Threads: 2,
+				Page:    2,
+				Limit:   1,
+				Comments: []*models.Comment{
+					{
+						ID:        2,
+						TaskID:    1,
+						AuthorID:  sql.NullInt64{Int64: 1, Valid: true},
+						Body:      "thread",
+						CreatedAt: createdAt,
+						Replies: []*models.Comment{
+							{
+								ID:        3,
+								TaskID:    1,
+								ParentID:  sql.NullInt64{Int64: 2, Valid: true},
+								AuthorID:  sql.NullInt64{Int64: 1, Valid: true},
+								Body:      "reply",
+								CreatedAt: createdAt,
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskID, test.userID)
+			res, err := repo.GetComments(test.taskID, test.userID, test.page)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
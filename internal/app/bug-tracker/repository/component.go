@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrComponentNotFound      = errors.New("error component is not found")
+	ErrComponentNameTaken     = errors.New("error component with such a name already exists")
+	ErrComponentUserNotMember = errors.New("error component lead and default assignee have to be members of the project")
+)
+
+const componentsNameConstraint = "components_project_id_name_key"
+
+type ComponentRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewComponentRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Component {
+	return &ComponentRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *ComponentRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+// checkComponentUsers makes sure the lead and the default assignee, when set,
+// belong to the project.
+func (r *ComponentRepository) checkComponentUsers(projectID uint64, userIDs ...uint64) error {
+	for _, userID := range userIDs {
+		if userID != 0 && !r.isParticipant(projectID, userID) {
+			return ErrComponentUserNotMember
+		}
+	}
+
+	return nil
+}
+
+func (r *ComponentRepository) GetComponents(projectID, userID uint64) ([]*models.Component, error) {
+	if !r.isParticipant(projectID, userID) {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, description, lead, default_assignee FROM components
+		WHERE project_id = $1 ORDER BY name`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	components := make([]*models.Component, 0)
+	for rows.Next() {
+		component := new(models.Component)
+		err := rows.Scan(
+			&component.ID,
+			&component.ProjectID,
+			&component.Name,
+			&component.Description,
+			&component.Lead,
+			&component.DefaultAssignee,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		components = append(components, component)
+	}
+
+	return components, nil
+}
+
+func (r *ComponentRepository) CreateComponent(componentData *dto.CreateComponentDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(componentData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(componentData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	err := r.checkComponentUsers(componentData.ProjectID, componentData.LeadID, componentData.DefaultAssigneeID)
+	if err != nil {
+		return 0, err
+	}
+
+	var componentID uint64
+	err = r.db.QueryRow(
+		`INSERT INTO components (project_id, name, description, lead, default_assignee)
+		VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0)) RETURNING id`,
+		componentData.ProjectID,
+		componentData.Name,
+		componentData.Description,
+		componentData.LeadID,
+		componentData.DefaultAssigneeID,
+	).Scan(&componentID)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, componentsNameConstraint) {
+			return 0, ErrComponentNameTaken
+		}
+		return 0, err
+	}
+	r.log.Infof("Create component: id = %d, project = %d", componentID, componentData.ProjectID)
+
+	return componentID, nil
+}
+
+func (r *ComponentRepository) UpdateComponent(componentData *dto.UpdateComponentDto, userID uint64) error {
+	if err := r.admin.IsAdmin(componentData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(componentData.ProjectID); err != nil {
+		return err
+	}
+
+	err := r.checkComponentUsers(componentData.ProjectID, componentData.LeadID, componentData.DefaultAssigneeID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		`UPDATE components SET name = $1, description = $2, lead = NULLIF($3, 0), default_assignee = NULLIF($4, 0)
+		WHERE id = $5 AND project_id = $6`,
+		componentData.Name,
+		componentData.Description,
+		componentData.LeadID,
+		componentData.DefaultAssigneeID,
+		componentData.ComponentID,
+		componentData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, componentsNameConstraint) {
+			return ErrComponentNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrComponentNotFound
+	}
+	r.log.Infof("Update component: id = %d", componentData.ComponentID)
+
+	return nil
+}
+
+// DeleteComponent removes the component, the tasks filed against it are kept
+// without a component.
+func (r *ComponentRepository) DeleteComponent(componentData *dto.DeleteComponentDto, userID uint64) error {
+	if err := r.admin.IsAdmin(componentData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(componentData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		"DELETE FROM components WHERE id = $1 AND project_id = $2",
+		componentData.ComponentID,
+		componentData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrComponentNotFound
+	}
+	r.log.Infof("Delete component: id = %d", componentData.ComponentID)
+
+	return nil
+}
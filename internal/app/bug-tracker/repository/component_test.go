@@ -0,0 +1,441 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetComponents(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ComponentRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Component
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ComponentRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &ComponentRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, description, lead, default_assignee FROM components
+						WHERE project_id = $1 ORDER BY name`,
+					),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &ComponentRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "description", "lead", "default_assignee"}).
+					AddRow(uint64(1), projectID, "API", "", int64(2), int64(3)).
+					AddRow(uint64(2), projectID, "Web", "Frontend", nil, nil)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, description, lead, default_assignee FROM components
+						WHERE project_id = $1 ORDER BY name`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &ComponentRepository{db: db, member: member}
+			},
+			expectedResult: []*models.Component{
+				{
+					ID:              1,
+					ProjectID:       1,
+					Name:            "API",
+					Lead:            sql.NullInt64{Int64: 2, Valid: true},
+					DefaultAssignee: sql.NullInt64{Int64: 3, Valid: true},
+				},
+				{ID: 2, ProjectID: 1, Name: "Web", Description: "Frontend"},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetComponents(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentRepository
+	componentData := &dto.CreateComponentDto{ProjectID: 1, Name: "API", LeadID: 2, DefaultAssigneeID: 3}
+
+	tests := []struct {
+		name           string
+		componentData  *dto.CreateComponentDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:          "Error in admin",
+			componentData: componentData,
+			userID:        2,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(ErrNoRights)
+
+				return &ComponentRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:          "Error default assignee is not a member",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.LeadID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.DefaultAssigneeID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(componentData.ProjectID, componentData.DefaultAssigneeID).Return(ErrNoRights)
+
+				return &ComponentRepository{admin: admin, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrComponentUserNotMember,
+		},
+		{
+			name:          "Error name taken",
+			componentData: &dto.CreateComponentDto{ProjectID: 1, Name: "API"},
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: componentsNameConstraint}
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO components (project_id, name, description, lead, default_assignee)
+						VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0)) RETURNING id`,
+					),
+				).WithArgs(
+					componentData.ProjectID,
+					componentData.Name,
+					componentData.Description,
+					componentData.LeadID,
+					componentData.DefaultAssigneeID,
+				).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &ComponentRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrComponentNameTaken,
+		},
+		{
+			name:          "OK",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.LeadID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.DefaultAssigneeID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO components (project_id, name, description, lead, default_assignee)
+						VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0)) RETURNING id`,
+					),
+				).WithArgs(
+					componentData.ProjectID,
+					componentData.Name,
+					componentData.Description,
+					componentData.LeadID,
+					componentData.DefaultAssigneeID,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+				log.EXPECT().Infof("Create component: id = %d, project = %d", uint64(4), componentData.ProjectID)
+
+				return &ComponentRepository{db: db, log: log, admin: admin, member: member, state: state}
+			},
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.componentData, test.userID)
+			res, err := repo.CreateComponent(test.componentData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, componentData *dto.UpdateComponentDto, userID uint64) *ComponentRepository
+	componentData := &dto.UpdateComponentDto{ProjectID: 1, ComponentID: 4, Name: "Billing", LeadID: 2}
+
+	tests := []struct {
+		name          string
+		componentData *dto.UpdateComponentDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:          "Error lead is not a member",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.UpdateComponentDto, userID uint64) *ComponentRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.LeadID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(componentData.ProjectID, componentData.LeadID).Return(ErrNoRights)
+
+				return &ComponentRepository{admin: admin, member: member, state: state}
+			},
+			expectedError: ErrComponentUserNotMember,
+		},
+		{
+			name:          "Error component not found",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.UpdateComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.LeadID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE components SET name = $1, description = $2, lead = NULLIF($3, 0), default_assignee = NULLIF($4, 0)
+						WHERE id = $5 AND project_id = $6`,
+					),
+				).WithArgs(
+					componentData.Name,
+					componentData.Description,
+					componentData.LeadID,
+					componentData.DefaultAssigneeID,
+					componentData.ComponentID,
+					componentData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &ComponentRepository{db: db, admin: admin, member: member, state: state}
+			},
+			expectedError: ErrComponentNotFound,
+		},
+		{
+			name:          "OK",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.UpdateComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(componentData.ProjectID, componentData.LeadID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE components SET name = $1, description = $2, lead = NULLIF($3, 0), default_assignee = NULLIF($4, 0)
+						WHERE id = $5 AND project_id = $6`,
+					),
+				).WithArgs(
+					componentData.Name,
+					componentData.Description,
+					componentData.LeadID,
+					componentData.DefaultAssigneeID,
+					componentData.ComponentID,
+					componentData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Update component: id = %d", componentData.ComponentID)
+
+				return &ComponentRepository{db: db, log: log, admin: admin, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.componentData, test.userID)
+			err := repo.UpdateComponent(test.componentData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, componentData *dto.DeleteComponentDto, userID uint64) *ComponentRepository
+	componentData := &dto.DeleteComponentDto{ProjectID: 1, ComponentID: 4}
+
+	tests := []struct {
+		name          string
+		componentData *dto.DeleteComponentDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:          "Error in admin",
+			componentData: componentData,
+			userID:        2,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.DeleteComponentDto, userID uint64) *ComponentRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(ErrNoRights)
+
+				return &ComponentRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:          "Error component not found",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.DeleteComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM components WHERE id = $1 AND project_id = $2"),
+				).WithArgs(componentData.ComponentID, componentData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &ComponentRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrComponentNotFound,
+		},
+		{
+			name:          "OK",
+			componentData: componentData,
+			userID:        1,
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.DeleteComponentDto, userID uint64) *ComponentRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(componentData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(componentData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM components WHERE id = $1 AND project_id = $2"),
+				).WithArgs(componentData.ComponentID, componentData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete component: id = %d", componentData.ComponentID)
+
+				return &ComponentRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.componentData, test.userID)
+			err := repo.DeleteComponent(test.componentData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+const (
+	customDateLayout    = "2006-01-02"
+	customTextMaxLength = 10000
+)
+
+// CustomFieldError is returned when a task is given a value that does not fit
+// the type or the options of a custom field.
+type CustomFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *CustomFieldError) Error() string {
+	return fmt.Sprintf("error invalid value for custom field %s: %s", e.Field, e.Reason)
+}
+
+type customFieldDefinition struct {
+	id        uint64
+	name      string
+	fieldType string
+	options   []string
+	required  bool
+}
+
+// customValue is a checked custom field value in the form it is stored in.
+// A cleared value removes whatever the task held for the field.
+type customValue struct {
+	fieldID uint64
+	text    sql.NullString
+	number  sql.NullFloat64
+	options []string
+	cleared bool
+}
+
+func isNullJSON(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// customFieldValues checks values against the custom fields of the project.
+// When a task is created every required field has to be given, later on a
+// required field only cannot be cleared.
+func (r *TaskRepository) customFieldValues(
+	tx *sql.Tx,
+	projectID uint64,
+	values []dto.CustomFieldValueDto,
+	creating bool,
+) ([]*customValue, error) {
+	if len(values) == 0 && !creating {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(
+		`SELECT id, name, field_type, options, required FROM custom_fields
+		WHERE project_id = $1 ORDER BY position, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := make([]*customFieldDefinition, 0)
+	byID := make(map[uint64]*customFieldDefinition)
+	for rows.Next() {
+		definition := new(customFieldDefinition)
+		err := rows.Scan(
+			&definition.id,
+			&definition.name,
+			&definition.fieldType,
+			pq.Array(&definition.options),
+			&definition.required,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		definitions = append(definitions, definition)
+		byID[definition.id] = definition
+	}
+
+	checked := make([]*customValue, 0, len(values))
+	set := make(map[uint64]bool)
+	for _, value := range values {
+		definition, ok := byID[value.FieldID]
+		if !ok {
+			return nil, ErrCustomFieldNotFound
+		}
+
+		result, err := r.parseCustomValue(projectID, definition, value.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		checked = append(checked, result)
+		set[definition.id] = !result.cleared
+	}
+
+	missing := make([]string, 0)
+	for _, definition := range definitions {
+		given, ok := set[definition.id]
+		if definition.required && !given && (creating || ok) {
+			missing = append(missing, definition.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, &RequiredFieldsError{Fields: missing}
+	}
+
+	return checked, nil
+}
+
+func (r *TaskRepository) parseCustomValue(
+	projectID uint64,
+	definition *customFieldDefinition,
+	raw json.RawMessage,
+) (*customValue, error) {
+	value := &customValue{fieldID: definition.id}
+	if isNullJSON(raw) {
+		value.cleared = true
+		return value, nil
+	}
+
+	invalid := func(reason string) error {
+		return &CustomFieldError{Field: definition.name, Reason: reason}
+	}
+
+	switch definition.fieldType {
+	case models.CustomFieldNumber:
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return nil, invalid("expected a number")
+		}
+		value.number = sql.NullFloat64{Float64: number, Valid: true}
+		value.text = sql.NullString{String: strconv.FormatFloat(number, 'f', -1, 64), Valid: true}
+	case models.CustomFieldUser:
+		var userID uint64
+		if err := json.Unmarshal(raw, &userID); err != nil {
+			return nil, invalid("expected a user id")
+		}
+		if !r.isParticipant(projectID, userID) {
+			return nil, invalid("user is not a member of the project")
+		}
+		value.number = sql.NullFloat64{Float64: float64(userID), Valid: true}
+		value.text = sql.NullString{String: strconv.FormatUint(userID, 10), Valid: true}
+	case models.CustomFieldMultiSelect:
+		var options []string
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return nil, invalid("expected a list of options")
+		}
+		for _, option := range options {
+			if !contains(definition.options, option) {
+				return nil, invalid(fmt.Sprintf("unknown option %q", option))
+			}
+		}
+		value.options = options
+		value.cleared = len(options) == 0
+	default:
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, invalid("expected a string")
+		}
+		if err := checkCustomText(definition, text); err != nil {
+			return nil, invalid(err.Error())
+		}
+		value.text = sql.NullString{String: text, Valid: true}
+		value.cleared = text == ""
+	}
+
+	return value, nil
+}
+
+func checkCustomText(definition *customFieldDefinition, text string) error {
+	switch {
+	case text == "":
+		return nil
+	case definition.fieldType == models.CustomFieldDate:
+		if _, err := time.Parse(customDateLayout, text); err != nil {
+			return fmt.Errorf("expected a date in the %s format", customDateLayout)
+		}
+	case definition.fieldType == models.CustomFieldSelect:
+		if !contains(definition.options, text) {
+			return fmt.Errorf("unknown option %q", text)
+		}
+	case len(text) > customTextMaxLength:
+		return fmt.Errorf("text is longer than %d characters", customTextMaxLength)
+	}
+
+	return nil
+}
+
+func saveCustomFieldValues(tx *sql.Tx, taskID uint64, values []*customValue) error {
+	for _, value := range values {
+		var err error
+		if value.cleared {
+			_, err = tx.Exec(
+				"DELETE FROM task_custom_values WHERE task_id = $1 AND field_id = $2",
+				taskID,
+				value.fieldID,
+			)
+		} else {
+			_, err = tx.Exec(
+				`INSERT INTO task_custom_values (task_id, field_id, value_text, value_number, value_options)
+				VALUES ($1, $2, $3, $4, $5) ON CONFLICT (task_id, field_id) DO UPDATE
+				SET value_text = EXCLUDED.value_text, value_number = EXCLUDED.value_number, value_options = EXCLUDED.value_options`,
+				taskID,
+				value.fieldID,
+				value.text,
+				value.number,
+				pq.Array(value.options),
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_customFieldValues(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *TaskRepository
+	projectID := uint64(1)
+
+	noMembers := func(c *gomock.Controller) *TaskRepository {
+		return &TaskRepository{}
+	}
+
+	tests := []struct {
+		name           string
+		values         []dto.CustomFieldValueDto
+		creating       bool
+		mockBehaviour  mockBehaviour
+		expectedResult []*customValue
+		expectedError  error
+	}{
+		{
+			name:           "Error unknown field",
+			values:         []dto.CustomFieldValueDto{{FieldID: 9, Value: json.RawMessage(`"acme"`)}},
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  ErrCustomFieldNotFound,
+		},
+		{
+			name:           "Error required field is missing on create",
+			values:         []dto.CustomFieldValueDto{{FieldID: 2, Value: json.RawMessage(`3`)}},
+			creating:       true,
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  &RequiredFieldsError{Fields: []string{"customer"}},
+		},
+		{
+			name:           "Error required field is cleared",
+			values:         []dto.CustomFieldValueDto{{FieldID: 1, Value: json.RawMessage(`null`)}},
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  &RequiredFieldsError{Fields: []string{"customer"}},
+		},
+		{
+			name:           "Error not a number",
+			values:         []dto.CustomFieldValueDto{{FieldID: 2, Value: json.RawMessage(`"three"`)}},
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  &CustomFieldError{Field: "points", Reason: "expected a number"},
+		},
+		{
+			name:           "Error invalid date",
+			values:         []dto.CustomFieldValueDto{{FieldID: 3, Value: json.RawMessage(`"18.12.2023"`)}},
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  &CustomFieldError{Field: "due", Reason: "expected a date in the 2006-01-02 format"},
+		},
+		{
+			name:           "Error unknown option",
+			values:         []dto.CustomFieldValueDto{{FieldID: 5, Value: json.RawMessage(`["web","android"]`)}},
+			mockBehaviour:  noMembers,
+			expectedResult: nil,
+			expectedError:  &CustomFieldError{Field: "platforms", Reason: `unknown option "android"`},
+		},
+		{
+			name:   "Error user is not a member",
+			values: []dto.CustomFieldValueDto{{FieldID: 6, Value: json.RawMessage(`7`)}},
+			mockBehaviour: func(c *gomock.Controller) *TaskRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, uint64(7)).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(projectID, uint64(7)).Return(ErrNoRights)
+
+				return &TaskRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  &CustomFieldError{Field: "owner", Reason: "user is not a member of the project"},
+		},
+		{
+			name: "OK",
+			values: []dto.CustomFieldValueDto{
+				{FieldID: 1, Value: json.RawMessage(`"acme"`)},
+				{FieldID: 2, Value: json.RawMessage(`2.50`)},
+				{FieldID: 3, Value: json.RawMessage(`"2023-12-18"`)},
+				{FieldID: 4, Value: json.RawMessage(`"high"`)},
+				{FieldID: 5, Value: json.RawMessage(`["web","ios"]`)},
+				{FieldID: 6, Value: json.RawMessage(`7`)},
+			},
+			creating: true,
+			mockBehaviour: func(c *gomock.Controller) *TaskRepository {
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, uint64(7)).Return(nil)
+
+				return &TaskRepository{member: member}
+			},
+			expectedResult: []*customValue{
+				{fieldID: 1, text: sql.NullString{String: "acme", Valid: true}},
+				{
+					fieldID: 2,
+					text:    sql.NullString{String: "2.5", Valid: true},
+					number:  sql.NullFloat64{Float64: 2.5, Valid: true},
+				},
+				{fieldID: 3, text: sql.NullString{String: "2023-12-18", Valid: true}},
+				{fieldID: 4, text: sql.NullString{String: "high", Valid: true}},
+				{fieldID: 5, options: []string{"web", "ios"}},
+				{
+					fieldID: 6,
+					text:    sql.NullString{String: "7", Valid: true},
+					number:  sql.NullFloat64{Float64: 7, Valid: true},
+				},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			db, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			mock.ExpectQuery(
+				regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+			).WithArgs(projectID).WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"}).
+					AddRow(uint64(1), "customer", "text", "{}", true).
+					AddRow(uint64(2), "points", "number", "{}", false).
+					AddRow(uint64(3), "due", "date", "{}", false).
+					AddRow(uint64(4), "priority", "select", "{low,high}", false).
+					AddRow(uint64(5), "platforms", "multiselect", "{web,ios}", false).
+					AddRow(uint64(6), "owner", "user", "{}", false),
+			)
+			tx, _ := db.Begin()
+
+			repo := test.mockBehaviour(c)
+			res, err := repo.customFieldValues(tx, projectID, test.values, test.creating)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrCustomFieldNotFound    = errors.New("error custom field is not found")
+	ErrCustomFieldNameTaken   = errors.New("error custom field with such a name already exists")
+	ErrCustomFieldOptions     = errors.New("error options are required for select fields and not allowed for others")
+	ErrCustomFieldOptionInUse = errors.New("error removed option is used by tasks")
+)
+
+const customFieldsNameConstraint = "custom_fields_project_id_name_key"
+
+type CustomFieldRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewCustomFieldRepo(db *sql.DB, log log.Log, admin admin, member member, state state) CustomField {
+	return &CustomFieldRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func isSelectField(fieldType string) bool {
+	return fieldType == models.CustomFieldSelect || fieldType == models.CustomFieldMultiSelect
+}
+
+func checkCustomFieldOptions(fieldType string, options []string) error {
+	if isSelectField(fieldType) != (len(options) > 0) {
+		return ErrCustomFieldOptions
+	}
+
+	return nil
+}
+
+func (r *CustomFieldRepository) GetCustomFields(projectID, userID uint64) ([]*models.CustomField, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, field_type, options, required, position FROM custom_fields
+		WHERE project_id = $1 ORDER BY position, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := make([]*models.CustomField, 0)
+	for rows.Next() {
+		field := new(models.CustomField)
+		err := rows.Scan(
+			&field.ID,
+			&field.ProjectID,
+			&field.Name,
+			&field.Type,
+			pq.Array(&field.Options),
+			&field.Required,
+			&field.Position,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) CreateCustomField(fieldData *dto.CreateCustomFieldDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(fieldData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(fieldData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	if err := checkCustomFieldOptions(fieldData.Type, fieldData.Options); err != nil {
+		return 0, err
+	}
+
+	options := fieldData.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	var fieldID uint64
+	err := r.db.QueryRow(
+		`INSERT INTO custom_fields (project_id, name, field_type, options, required, position)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		fieldData.ProjectID,
+		fieldData.Name,
+		fieldData.Type,
+		pq.Array(options),
+		fieldData.Required,
+		fieldData.Position,
+	).Scan(&fieldID)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, customFieldsNameConstraint) {
+			return 0, ErrCustomFieldNameTaken
+		}
+		return 0, err
+	}
+	r.log.Infof("Create custom field: id = %d, project = %d", fieldID, fieldData.ProjectID)
+
+	return fieldID, nil
+}
+
+func (r *CustomFieldRepository) UpdateCustomField(fieldData *dto.UpdateCustomFieldDto, userID uint64) error {
+	if err := r.admin.IsAdmin(fieldData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(fieldData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	var fieldType string
+	err = tx.QueryRow(
+		"SELECT field_type FROM custom_fields WHERE id = $1 AND project_id = $2 FOR UPDATE",
+		fieldData.FieldID,
+		fieldData.ProjectID,
+	).Scan(&fieldType)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return ErrCustomFieldNotFound
+		}
+		return err
+	}
+
+	if err := checkCustomFieldOptions(fieldType, fieldData.Options); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	options := fieldData.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	// The type of a field never changes, so the values tasks hold stay valid
+	// as long as no option they use goes away.
+	if isSelectField(fieldType) {
+		var inUse bool
+		err = tx.QueryRow(
+			`SELECT EXISTS (SELECT 1 FROM task_custom_values WHERE field_id = $1
+			AND (value_text <> ALL($2) OR NOT value_options <@ $2))`,
+			fieldData.FieldID,
+			pq.Array(options),
+		).Scan(&inUse)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+
+		if inUse {
+			tx.Rollback()
+			return ErrCustomFieldOptionInUse
+		}
+	}
+
+	_, err = tx.Exec(
+		"UPDATE custom_fields SET name = $1, options = $2, required = $3, position = $4 WHERE id = $5",
+		fieldData.Name,
+		pq.Array(options),
+		fieldData.Required,
+		fieldData.Position,
+		fieldData.FieldID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, customFieldsNameConstraint) {
+			return ErrCustomFieldNameTaken
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Update custom field: id = %d", fieldData.FieldID)
+
+	return nil
+}
+
+// DeleteCustomField removes the field definition together with the values
+// tasks held for it.
+func (r *CustomFieldRepository) DeleteCustomField(fieldData *dto.DeleteCustomFieldDto, userID uint64) error {
+	if err := r.admin.IsAdmin(fieldData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(fieldData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		"DELETE FROM custom_fields WHERE id = $1 AND project_id = $2",
+		fieldData.FieldID,
+		fieldData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrCustomFieldNotFound
+	}
+	r.log.Infof("Delete custom field: id = %d", fieldData.FieldID)
+
+	return nil
+}
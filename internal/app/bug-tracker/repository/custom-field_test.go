@@ -0,0 +1,462 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetCustomFields(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *CustomFieldRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.CustomField
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *CustomFieldRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &CustomFieldRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, field_type, options, required, position FROM custom_fields
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &CustomFieldRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "field_type", "options", "required", "position"}).
+					AddRow(uint64(1), projectID, "customer", "text", "{}", true, 0).
+					AddRow(uint64(2), projectID, "platform", "multiselect", "{web,ios}", false, 1)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, field_type, options, required, position FROM custom_fields
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &CustomFieldRepository{db: db, member: member}
+			},
+			expectedResult: []*models.CustomField{
+				{ID: 1, ProjectID: 1, Name: "customer", Type: "text", Options: []string{}, Required: true, Position: 0},
+				{ID: 2, ProjectID: 1, Name: "platform", Type: "multiselect", Options: []string{"web", "ios"}, Position: 1},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetCustomFields(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository
+	fieldData := &dto.CreateCustomFieldDto{ProjectID: 1, Name: "platform", Type: "select", Options: []string{"web", "ios"}}
+
+	tests := []struct {
+		name           string
+		fieldData      *dto.CreateCustomFieldDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:      "Error in admin",
+			fieldData: fieldData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(ErrNoRights)
+
+				return &CustomFieldRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error select field without options",
+			fieldData: &dto.CreateCustomFieldDto{ProjectID: 1, Name: "platform", Type: "select"},
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				return &CustomFieldRepository{admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrCustomFieldOptions,
+		},
+		{
+			name:      "Error options on a text field",
+			fieldData: &dto.CreateCustomFieldDto{ProjectID: 1, Name: "customer", Type: "text", Options: []string{"acme"}},
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				return &CustomFieldRepository{admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrCustomFieldOptions,
+		},
+		{
+			name:      "Error name taken",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: customFieldsNameConstraint}
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO custom_fields (project_id, name, field_type, options, required, position)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					),
+				).WithArgs(
+					fieldData.ProjectID,
+					fieldData.Name,
+					fieldData.Type,
+					"{\"web\",\"ios\"}",
+					fieldData.Required,
+					fieldData.Position,
+				).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &CustomFieldRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrCustomFieldNameTaken,
+		},
+		{
+			name:      "OK",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("INSERT INTO custom_fields (project_id, name, field_type, options, required, position)"),
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+				log.EXPECT().Infof("Create custom field: id = %d, project = %d", uint64(4), fieldData.ProjectID)
+
+				return &CustomFieldRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.fieldData, test.userID)
+			res, err := repo.CreateCustomField(test.fieldData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, fieldData *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldRepository
+	fieldData := &dto.UpdateCustomFieldDto{ProjectID: 1, FieldID: 2, Name: "platform", Options: []string{"web"}, Position: 1}
+
+	tests := []struct {
+		name          string
+		fieldData     *dto.UpdateCustomFieldDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error custom field not found",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT field_type FROM custom_fields WHERE id = $1 AND project_id = $2 FOR UPDATE"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+				log.EXPECT().Error(sql.ErrNoRows)
+
+				return &CustomFieldRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrCustomFieldNotFound,
+		},
+		{
+			name:      "Error removed option is in use",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT field_type FROM custom_fields WHERE id = $1 AND project_id = $2 FOR UPDATE"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"field_type"}).AddRow("multiselect"))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT EXISTS (SELECT 1 FROM task_custom_values WHERE field_id = $1
+						AND (value_text <> ALL($2) OR NOT value_options <@ $2))`,
+					),
+				).WithArgs(fieldData.FieldID, "{\"web\"}").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+
+				return &CustomFieldRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrCustomFieldOptionInUse,
+		},
+		{
+			name:      "Error options on a number field",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT field_type FROM custom_fields WHERE id = $1 AND project_id = $2 FOR UPDATE"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"field_type"}).AddRow("number"))
+				mock.ExpectRollback()
+
+				return &CustomFieldRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrCustomFieldOptions,
+		},
+		{
+			name:      "OK",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT field_type FROM custom_fields WHERE id = $1 AND project_id = $2 FOR UPDATE"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"field_type"}).AddRow("select"))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM task_custom_values WHERE field_id = $1"),
+				).WithArgs(fieldData.FieldID, "{\"web\"}").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE custom_fields SET name = $1, options = $2, required = $3, position = $4 WHERE id = $5"),
+				).WithArgs(
+					fieldData.Name,
+					"{\"web\"}",
+					fieldData.Required,
+					fieldData.Position,
+					fieldData.FieldID,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Update custom field: id = %d", fieldData.FieldID)
+
+				return &CustomFieldRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.fieldData, test.userID)
+			err := repo.UpdateCustomField(test.fieldData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, fieldData *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldRepository
+	fieldData := &dto.DeleteCustomFieldDto{ProjectID: 1, FieldID: 3}
+
+	tests := []struct {
+		name          string
+		fieldData     *dto.DeleteCustomFieldDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error in admin",
+			fieldData: fieldData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(ErrNoRights)
+
+				return &CustomFieldRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:      "Error custom field not found",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM custom_fields WHERE id = $1 AND project_id = $2"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &CustomFieldRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrCustomFieldNotFound,
+		},
+		{
+			name:      "OK",
+			fieldData: fieldData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(fieldData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(fieldData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM custom_fields WHERE id = $1 AND project_id = $2"),
+				).WithArgs(fieldData.FieldID, fieldData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete custom field: id = %d", fieldData.FieldID)
+
+				return &CustomFieldRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.fieldData, test.userID)
+			err := repo.DeleteCustomField(test.fieldData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrIssueKindNotFound  = errors.New("error issue kind is not found")
+	ErrIssueKindNameTaken = errors.New("error issue kind with such a name already exists")
+	ErrIssueKindInUse     = errors.New("error issue kind is used by tasks")
+	ErrIssueKindDefault   = errors.New("error default issue kind cannot be deleted")
+)
+
+const (
+	issueKindsNameConstraint = "issue_kinds_project_id_name_key"
+	tasksKindConstraint      = "tasks_kind_id_fkey"
+)
+
+type IssueKindRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewIssueKindRepo(db *sql.DB, log log.Log, admin admin, member member, state state) IssueKind {
+	return &IssueKindRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+// createDefaultIssueKinds seeds a new project with the bug, feature and
+// chore kinds, feature being the one new tasks get unless told otherwise.
+func createDefaultIssueKinds(tx *sql.Tx, projectID uint64) error {
+	_, err := tx.Exec(
+		`INSERT INTO issue_kinds (project_id, name, icon, position, is_default, is_bug) VALUES
+		($1, 'bug', 'bug', 0, FALSE, TRUE),
+		($1, 'feature', 'lightbulb', 1, TRUE, FALSE),
+		($1, 'chore', 'wrench', 2, FALSE, FALSE)`,
+		projectID,
+	)
+
+	return err
+}
+
+func (r *IssueKindRepository) GetIssueKinds(projectID, userID uint64) ([]*models.IssueKind, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, icon, position, is_default, is_bug FROM issue_kinds
+		WHERE project_id = $1 ORDER BY position, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	kinds := make([]*models.IssueKind, 0)
+	for rows.Next() {
+		kind := new(models.IssueKind)
+		err := rows.Scan(
+			&kind.ID,
+			&kind.ProjectID,
+			&kind.Name,
+			&kind.Icon,
+			&kind.Position,
+			&kind.Default,
+			&kind.Bug,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		kinds = append(kinds, kind)
+	}
+
+	return kinds, nil
+}
+
+func (r *IssueKindRepository) CreateIssueKind(kindData *dto.CreateIssueKindDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(kindData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(kindData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if kindData.Default {
+		if err := r.resetDefaultIssueKind(tx, kindData.ProjectID); err != nil {
+			return 0, err
+		}
+	}
+
+	var kindID uint64
+	err = tx.QueryRow(
+		`INSERT INTO issue_kinds (project_id, name, icon, position, is_default, is_bug)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		kindData.ProjectID,
+		kindData.Name,
+		kindData.Icon,
+		kindData.Position,
+		kindData.Default,
+		kindData.Bug,
+	).Scan(&kindID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, issueKindsNameConstraint) {
+			return 0, ErrIssueKindNameTaken
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Create issue kind: id = %d, project = %d", kindID, kindData.ProjectID)
+
+	return kindID, nil
+}
+
+func (r *IssueKindRepository) UpdateIssueKind(kindData *dto.UpdateIssueKindDto, userID uint64) error {
+	if err := r.admin.IsAdmin(kindData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(kindData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if kindData.Default {
+		if err := r.resetDefaultIssueKind(tx, kindData.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	// Like the initial status, the default kind is only handed over, never
+	// cleared, so new tasks always have a kind to fall back on.
+	result, err := tx.Exec(
+		`UPDATE issue_kinds SET name = $1, icon = $2, position = $3, is_default = is_default OR $4, is_bug = $5
+		WHERE id = $6 AND project_id = $7`,
+		kindData.Name,
+		kindData.Icon,
+		kindData.Position,
+		kindData.Default,
+		kindData.Bug,
+		kindData.KindID,
+		kindData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, issueKindsNameConstraint) {
+			return ErrIssueKindNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if count == 0 {
+		tx.Rollback()
+		return ErrIssueKindNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Update issue kind: id = %d", kindData.KindID)
+
+	return nil
+}
+
+func (r *IssueKindRepository) resetDefaultIssueKind(tx *sql.Tx, projectID uint64) error {
+	_, err := tx.Exec("UPDATE issue_kinds SET is_default = FALSE WHERE project_id = $1 AND is_default", projectID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+	}
+
+	return err
+}
+
+func (r *IssueKindRepository) DeleteIssueKind(kindData *dto.DeleteIssueKindDto, userID uint64) error {
+	if err := r.admin.IsAdmin(kindData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(kindData.ProjectID); err != nil {
+		return err
+	}
+
+	var isDefault bool
+	err := r.db.QueryRow(
+		"SELECT is_default FROM issue_kinds WHERE id = $1 AND project_id = $2",
+		kindData.KindID,
+		kindData.ProjectID,
+	).Scan(&isDefault)
+	if err != nil {
+		r.log.Error(err)
+		if err == sql.ErrNoRows {
+			return ErrIssueKindNotFound
+		}
+		return err
+	}
+
+	if isDefault {
+		return ErrIssueKindDefault
+	}
+
+	_, err = r.db.Exec("DELETE FROM issue_kinds WHERE id = $1", kindData.KindID)
+	if err != nil {
+		r.log.Error(err)
+		if isForeignKeyViolation(err, tasksKindConstraint) {
+			return ErrIssueKindInUse
+		}
+		return err
+	}
+	r.log.Infof("Delete issue kind: id = %d", kindData.KindID)
+
+	return nil
+}
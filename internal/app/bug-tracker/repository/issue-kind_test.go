@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetIssueKinds(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *IssueKindRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.IssueKind
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *IssueKindRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &IssueKindRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, icon, position, is_default, is_bug FROM issue_kinds
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &IssueKindRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "icon", "position", "is_default", "is_bug"}).
+					AddRow(uint64(1), projectID, "bug", "bug", 0, false, true).
+					AddRow(uint64(2), projectID, "feature", "lightbulb", 1, true, false)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, icon, position, is_default, is_bug FROM issue_kinds
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &IssueKindRepository{db: db, member: member}
+			},
+			expectedResult: []*models.IssueKind{
+				{ID: 1, ProjectID: 1, Name: "bug", Icon: "bug", Position: 0, Default: false, Bug: true},
+				{ID: 2, ProjectID: 1, Name: "feature", Icon: "lightbulb", Position: 1, Default: true},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetIssueKinds(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindRepository
+	kindData := &dto.CreateIssueKindDto{ProjectID: 1, Name: "spike", Icon: "flask", Position: 3, Default: true}
+
+	tests := []struct {
+		name           string
+		kindData       *dto.CreateIssueKindDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:     "Error in admin",
+			kindData: kindData,
+			userID:   2,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(ErrNoRights)
+
+				return &IssueKindRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:     "Error name taken",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: issueKindsNameConstraint}
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE issue_kinds SET is_default = FALSE WHERE project_id = $1 AND is_default"),
+				).WithArgs(kindData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO issue_kinds (project_id, name, icon, position, is_default, is_bug)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					),
+				).WithArgs(
+					kindData.ProjectID,
+					kindData.Name,
+					kindData.Icon,
+					kindData.Position,
+					kindData.Default,
+					kindData.Bug,
+				).WillReturnError(uniqueErr)
+				mock.ExpectRollback()
+				log.EXPECT().Error(uniqueErr)
+
+				return &IssueKindRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrIssueKindNameTaken,
+		},
+		{
+			name:     "OK",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE issue_kinds SET is_default = FALSE WHERE project_id = $1 AND is_default"),
+				).WithArgs(kindData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("INSERT INTO issue_kinds (project_id, name, icon, position, is_default, is_bug)"),
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(4)))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create issue kind: id = %d, project = %d", uint64(4), kindData.ProjectID)
+
+				return &IssueKindRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.kindData, test.userID)
+			res, err := repo.CreateIssueKind(test.kindData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, kindData *dto.UpdateIssueKindDto, userID uint64) *IssueKindRepository
+	kindData := &dto.UpdateIssueKindDto{ProjectID: 1, KindID: 1, Name: "defect", Icon: "bug", Position: 0, Bug: true}
+
+	tests := []struct {
+		name          string
+		kindData      *dto.UpdateIssueKindDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:     "Error issue kind not found",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.UpdateIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE issue_kinds SET name = $1, icon = $2, position = $3, is_default = is_default OR $4, is_bug = $5
+						WHERE id = $6 AND project_id = $7`,
+					),
+				).WithArgs(
+					kindData.Name,
+					kindData.Icon,
+					kindData.Position,
+					kindData.Default,
+					kindData.Bug,
+					kindData.KindID,
+					kindData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+
+				return &IssueKindRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrIssueKindNotFound,
+		},
+		{
+			name:     "OK",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.UpdateIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE issue_kinds SET name = $1"),
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Update issue kind: id = %d", kindData.KindID)
+
+				return &IssueKindRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.kindData, test.userID)
+			err := repo.UpdateIssueKind(test.kindData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, kindData *dto.DeleteIssueKindDto, userID uint64) *IssueKindRepository
+	kindData := &dto.DeleteIssueKindDto{ProjectID: 1, KindID: 3}
+
+	tests := []struct {
+		name          string
+		kindData      *dto.DeleteIssueKindDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:     "Error default issue kind",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.DeleteIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_default FROM issue_kinds WHERE id = $1 AND project_id = $2"),
+				).WithArgs(kindData.KindID, kindData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_default"}).AddRow(true))
+
+				return &IssueKindRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrIssueKindDefault,
+		},
+		{
+			name:     "Error issue kind in use",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.DeleteIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				fkErr := &pq.Error{Code: foreignKeyViolation, Constraint: tasksKindConstraint}
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_default FROM issue_kinds WHERE id = $1 AND project_id = $2"),
+				).WithArgs(kindData.KindID, kindData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_default"}).AddRow(false))
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM issue_kinds WHERE id = $1"),
+				).WithArgs(kindData.KindID).WillReturnError(fkErr)
+				log.EXPECT().Error(fkErr)
+
+				return &IssueKindRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrIssueKindInUse,
+		},
+		{
+			name:     "OK",
+			kindData: kindData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.DeleteIssueKindDto, userID uint64) *IssueKindRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(kindData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(kindData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_default FROM issue_kinds WHERE id = $1 AND project_id = $2"),
+				).WithArgs(kindData.KindID, kindData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_default"}).AddRow(false))
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM issue_kinds WHERE id = $1"),
+				).WithArgs(kindData.KindID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete issue kind: id = %d", kindData.KindID)
+
+				return &IssueKindRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.kindData, test.userID)
+			err := repo.DeleteIssueKind(test.kindData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrLabelNotFound  = errors.New("error label is not found")
+	ErrLabelNameTaken = errors.New("error label with such a name already exists")
+)
+
+const (
+	labelsNameConstraint = "labels_project_id_name_key"
+	defaultLabelColor    = "#808080"
+)
+
+type LabelRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewLabelRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Label {
+	return &LabelRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func labelColor(color string) string {
+	if color == "" {
+		return defaultLabelColor
+	}
+
+	return color
+}
+
+func (r *LabelRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+func (r *LabelRepository) GetLabels(projectID, userID uint64) ([]*models.Label, error) {
+	if !r.isParticipant(projectID, userID) {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		"SELECT id, project_id, name, color, description FROM labels WHERE project_id = $1 ORDER BY name",
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := make([]*models.Label, 0)
+	for rows.Next() {
+		label := new(models.Label)
+		err := rows.Scan(&label.ID, &label.ProjectID, &label.Name, &label.Color, &label.Description)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+func (r *LabelRepository) CreateLabel(labelData *dto.CreateLabelDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(labelData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(labelData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	var labelID uint64
+	err := r.db.QueryRow(
+		"INSERT INTO labels (project_id, name, color, description) VALUES ($1, $2, $3, $4) RETURNING id",
+		labelData.ProjectID,
+		labelData.Name,
+		labelColor(labelData.Color),
+		labelData.Description,
+	).Scan(&labelID)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, labelsNameConstraint) {
+			return 0, ErrLabelNameTaken
+		}
+		return 0, err
+	}
+	r.log.Infof("Create label: id = %d, project = %d", labelID, labelData.ProjectID)
+
+	return labelID, nil
+}
+
+func (r *LabelRepository) UpdateLabel(labelData *dto.UpdateLabelDto, userID uint64) error {
+	if err := r.admin.IsAdmin(labelData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(labelData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		"UPDATE labels SET name = $1, color = $2, description = $3 WHERE id = $4 AND project_id = $5",
+		labelData.Name,
+		labelColor(labelData.Color),
+		labelData.Description,
+		labelData.LabelID,
+		labelData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, labelsNameConstraint) {
+			return ErrLabelNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrLabelNotFound
+	}
+	r.log.Infof("Update label: id = %d", labelData.LabelID)
+
+	return nil
+}
+
+// DeleteLabel removes the label from the project and from every task that
+// carried it. The tasks themselves stay untouched.
+func (r *LabelRepository) DeleteLabel(labelData *dto.DeleteLabelDto, userID uint64) error {
+	if err := r.admin.IsAdmin(labelData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(labelData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		"DELETE FROM labels WHERE id = $1 AND project_id = $2",
+		labelData.LabelID,
+		labelData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrLabelNotFound
+	}
+	r.log.Infof("Delete label: id = %d", labelData.LabelID)
+
+	return nil
+}
+
+// uniqueIDs drops repeated ids so they can be counted against the rows found.
+func uniqueIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// checkTaskLabels makes sure every task and every label belongs to the project.
+func (r *LabelRepository) checkTaskLabels(tx *sql.Tx, projectID uint64, taskIDs, labelIDs []uint64) error {
+	var tasks, labels int
+	err := tx.QueryRow(
+		`SELECT (SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)),
+		(SELECT COUNT(*) FROM labels WHERE project_id = $1 AND id = ANY($3))`,
+		projectID,
+		pq.Array(taskIDs),
+		pq.Array(labelIDs),
+	).Scan(&tasks, &labels)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if tasks != len(taskIDs) {
+		return ErrTaskNotFound
+	}
+
+	if labels != len(labelIDs) {
+		return ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) AddTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	if !r.isParticipant(labelsData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(labelsData.ProjectID); err != nil {
+		return err
+	}
+
+	taskIDs := uniqueIDs(labelsData.TaskIDs)
+	labelIDs := uniqueIDs(labelsData.LabelIDs)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if err := r.checkTaskLabels(tx, labelsData.ProjectID, taskIDs, labelIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO task_labels (task_id, label_id)
+		SELECT task_id, label_id FROM UNNEST($1::BIGINT[]) AS task_id CROSS JOIN UNNEST($2::BIGINT[]) AS label_id
+		ON CONFLICT DO NOTHING`,
+		pq.Array(taskIDs),
+		pq.Array(labelIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Add labels: tasks = %d, labels = %d", len(taskIDs), len(labelIDs))
+
+	return nil
+}
+
+func (r *LabelRepository) RemoveTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	if !r.isParticipant(labelsData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(labelsData.ProjectID); err != nil {
+		return err
+	}
+
+	taskIDs := uniqueIDs(labelsData.TaskIDs)
+	labelIDs := uniqueIDs(labelsData.LabelIDs)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if err := r.checkTaskLabels(tx, labelsData.ProjectID, taskIDs, labelIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM task_labels WHERE task_id = ANY($1) AND label_id = ANY($2)",
+		pq.Array(taskIDs),
+		pq.Array(labelIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Remove labels: tasks = %d, labels = %d", len(taskIDs), len(labelIDs))
+
+	return nil
+}
@@ -0,0 +1,692 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *LabelRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Label
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &LabelRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, project_id, name, color, description FROM labels WHERE project_id = $1 ORDER BY name"),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &LabelRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "color", "description"}).
+					AddRow(uint64(2), projectID, "backend", "#808080", "").
+					AddRow(uint64(1), projectID, "ui", "#ff0000", "User interface")
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, project_id, name, color, description FROM labels WHERE project_id = $1 ORDER BY name"),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &LabelRepository{db: db, member: member}
+			},
+			expectedResult: []*models.Label{
+				{ID: 2, ProjectID: 1, Name: "backend", Color: "#808080"},
+				{ID: 1, ProjectID: 1, Name: "ui", Color: "#ff0000", Description: "User interface"},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetLabels(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelRepository
+	labelData := &dto.CreateLabelDto{ProjectID: 1, Name: "backend"}
+
+	tests := []struct {
+		name           string
+		labelData      *dto.CreateLabelDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:      "Error in admin",
+			labelData: labelData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(ErrNoRights)
+
+				return &LabelRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error project is archived",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(ErrProjectArchived)
+
+				return &LabelRepository{admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrProjectArchived,
+		},
+		{
+			name:      "Error name taken",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: labelsNameConstraint}
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("INSERT INTO labels (project_id, name, color, description) VALUES ($1, $2, $3, $4) RETURNING id"),
+				).WithArgs(labelData.ProjectID, labelData.Name, defaultLabelColor, labelData.Description).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrLabelNameTaken,
+		},
+		{
+			name:      "OK",
+			labelData: &dto.CreateLabelDto{ProjectID: 1, Name: "ui", Color: "#ff0000", Description: "User interface"},
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("INSERT INTO labels (project_id, name, color, description) VALUES ($1, $2, $3, $4) RETURNING id"),
+				).WithArgs(
+					labelData.ProjectID,
+					labelData.Name,
+					labelData.Color,
+					labelData.Description,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+				log.EXPECT().Infof("Create label: id = %d, project = %d", uint64(3), labelData.ProjectID)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.labelData, test.userID)
+			res, err := repo.CreateLabel(test.labelData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelData *dto.UpdateLabelDto, userID uint64) *LabelRepository
+	labelData := &dto.UpdateLabelDto{LabelID: 3, ProjectID: 1, Name: "frontend", Color: "#00ff00"}
+
+	tests := []struct {
+		name          string
+		labelData     *dto.UpdateLabelDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error in admin",
+			labelData: labelData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.UpdateLabelDto, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(ErrNoRights)
+
+				return &LabelRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:      "Error name taken",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.UpdateLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: labelsNameConstraint}
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE labels SET name = $1, color = $2, description = $3 WHERE id = $4 AND project_id = $5"),
+				).WithArgs(
+					labelData.Name,
+					labelData.Color,
+					labelData.Description,
+					labelData.LabelID,
+					labelData.ProjectID,
+				).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrLabelNameTaken,
+		},
+		{
+			name:      "Error label not found",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.UpdateLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE labels SET name = $1, color = $2, description = $3 WHERE id = $4 AND project_id = $5"),
+				).WithArgs(
+					labelData.Name,
+					labelData.Color,
+					labelData.Description,
+					labelData.LabelID,
+					labelData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &LabelRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrLabelNotFound,
+		},
+		{
+			name:      "OK",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.UpdateLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE labels SET name = $1, color = $2, description = $3 WHERE id = $4 AND project_id = $5"),
+				).WithArgs(
+					labelData.Name,
+					labelData.Color,
+					labelData.Description,
+					labelData.LabelID,
+					labelData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Update label: id = %d", labelData.LabelID)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.labelData, test.userID)
+			err := repo.UpdateLabel(test.labelData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelData *dto.DeleteLabelDto, userID uint64) *LabelRepository
+	labelData := &dto.DeleteLabelDto{LabelID: 3, ProjectID: 1}
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		labelData     *dto.DeleteLabelDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error in admin",
+			labelData: labelData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.DeleteLabelDto, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(ErrNoRights)
+
+				return &LabelRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:      "Error",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.DeleteLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM labels WHERE id = $1 AND project_id = $2"),
+				).WithArgs(labelData.LabelID, labelData.ProjectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:      "Error label not found",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.DeleteLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM labels WHERE id = $1 AND project_id = $2"),
+				).WithArgs(labelData.LabelID, labelData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &LabelRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrLabelNotFound,
+		},
+		{
+			name:      "OK",
+			labelData: labelData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.DeleteLabelDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(labelData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM labels WHERE id = $1 AND project_id = $2"),
+				).WithArgs(labelData.LabelID, labelData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete label: id = %d", labelData.LabelID)
+
+				return &LabelRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.labelData, test.userID)
+			err := repo.DeleteLabel(test.labelData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository
+	labelsData := &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2, 2}, LabelIDs: []uint64{3}}
+	checkQuery := `SELECT (SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)),
+		(SELECT COUNT(*) FROM labels WHERE project_id = $1 AND id = ANY($3))`
+
+	tests := []struct {
+		name          string
+		labelsData    *dto.TaskLabelsDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error no rights",
+			labelsData: labelsData,
+			userID:     2,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(labelsData.ProjectID, userID).Return(ErrNoRights)
+
+				return &LabelRepository{admin: admin, member: member}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:       "Error task is not in the project",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(1, 1))
+				mock.ExpectRollback()
+
+				return &LabelRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name:       "Error label is not in the project",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(2, 0))
+				mock.ExpectRollback()
+
+				return &LabelRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrLabelNotFound,
+		},
+		{
+			name:       "OK",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(2, 1))
+				expectTaskSnapshots(mock, 1, 2)
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO task_labels (task_id, label_id)
+						SELECT task_id, label_id FROM UNNEST($1::BIGINT[]) AS task_id CROSS JOIN UNNEST($2::BIGINT[]) AS label_id
+						ON CONFLICT DO NOTHING`,
+					),
+				).WithArgs("{1,2}", "{3}").WillReturnResult(sqlmock.NewResult(0, 2))
+				expectTasksChanges(mock, userID, 1, 2)
+				expectWatchEvents(mock, 1, userID, 4)
+				expectWatchEvents(mock, 2, userID)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Add labels: tasks = %d, labels = %d", 2, 1)
+
+				return &LabelRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.labelsData, test.userID)
+			err := repo.AddTaskLabels(test.labelsData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_RemoveTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository
+	labelsData := &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1, 2}, LabelIDs: []uint64{3}}
+	err := errors.New("error")
+	checkQuery := `SELECT (SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)),
+		(SELECT COUNT(*) FROM labels WHERE project_id = $1 AND id = ANY($3))`
+	removeQuery := "DELETE FROM task_labels WHERE task_id = ANY($1) AND label_id = ANY($2)"
+
+	tests := []struct {
+		name          string
+		labelsData    *dto.TaskLabelsDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error project is archived",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(ErrProjectArchived)
+
+				return &LabelRepository{member: member, state: state}
+			},
+			expectedError: ErrProjectArchived,
+		},
+		{
+			name:       "Error task is not in the project",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(1, 1))
+				mock.ExpectRollback()
+
+				return &LabelRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name:       "Error",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(2, 1))
+				expectTaskSnapshots(mock, 1, 2)
+				mock.ExpectExec(regexp.QuoteMeta(removeQuery)).
+					WithArgs("{1,2}", "{3}").
+					WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &LabelRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:       "OK",
+			labelsData: labelsData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, labelsData *dto.TaskLabelsDto, userID uint64) *LabelRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(labelsData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(labelsData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(checkQuery)).
+					WithArgs(labelsData.ProjectID, "{1,2}", "{3}").
+					WillReturnRows(sqlmock.NewRows([]string{"tasks", "labels"}).AddRow(2, 1))
+				expectTaskSnapshots(mock, 1, 2)
+				mock.ExpectExec(regexp.QuoteMeta(removeQuery)).
+					WithArgs("{1,2}", "{3}").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectTaskChanges(mock, userID, 1, true)
+				expectTaskChanges(mock, userID, 2, false)
+				expectTouchTasks(mock, userID, 1)
+				expectTasksWatchEvents(mock, userID, 1)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Remove labels: tasks = %d, labels = %d", 2, 1)
+
+				return &LabelRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.labelsData, test.userID)
+			err := repo.RemoveTaskLabels(test.labelsData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -23,7 +23,7 @@ func new_memberStrategy(db *sql.DB, log log.Log) member {
 
 func (s *memberStrategy) IsMember(projectID, userID uint64) error {
 	result := s.db.QueryRow(
-		"SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2",
+		"SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2 AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)",
 		projectID,
 		userID,
 	)
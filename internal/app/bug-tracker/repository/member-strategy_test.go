@@ -43,7 +43,9 @@ func Test_IsMember(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2"),
+					regexp.QuoteMeta(
+						"SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2 AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)",
+					),
 				).WithArgs(projectID, userID).WillReturnError(err)
 				log.EXPECT().Error(err).Return()
 
@@ -61,7 +63,9 @@ func Test_IsMember(t *testing.T) {
 
 				rows := sqlmock.NewRows([]string{"member_id"}).AddRow(uint64(1))
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2"),
+					regexp.QuoteMeta(
+						"SELECT member_id FROM projects_members WHERE project_id = $1 AND member_id = $2 AND project_id IN (SELECT id FROM projects WHERE deleted_at IS NULL)",
+					),
 				).WithArgs(projectID, userID).WillReturnRows(rows)
 
 				return &memberStrategy{db, log}
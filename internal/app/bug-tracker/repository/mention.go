@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// mentionPattern matches @username when the @ does not follow a word
+// character, so email addresses are not taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.\-]+)`)
+
+type MentionRepository struct {
+	db  *sql.DB
+	log log.Log
+}
+
+func NewMentionRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Mention {
+	return &MentionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// parseMentions returns the usernames mentioned in the text, each one once.
+// Dots and dashes closing a sentence are not part of the username.
+func parseMentions(text string) []string {
+	usernames := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || seen[username] {
+			continue
+		}
+
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
+
+// SaveMentions brings the mentions stored for a text in line with the text
+// and returns the users that were not mentioned in it before, saving an event
+// for each of them. Only the admin and the members of the project of the task
+// can be mentioned, and authors never mention themselves.
+func (r *MentionRepository) SaveMentions(mentionData *dto.MentionDto) ([]uint64, error) {
+	userIDs := make([]uint64, 0)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	if usernames := parseMentions(mentionData.Text); len(usernames) > 0 {
+		rows, err := tx.Query(
+			`SELECT users.id FROM users
+			JOIN tasks ON tasks.id = $1
+			JOIN projects ON projects.id = tasks.project_id
+			WHERE users.username = ANY($2) AND users.id <> $3 AND (projects.admin = users.id OR EXISTS (
+				SELECT 1 FROM projects_members WHERE project_id = projects.id AND member_id = users.id
+			))`,
+			mentionData.TaskID,
+			pq.Array(usernames),
+			mentionData.AuthorID,
+		)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return nil, err
+		}
+
+		for rows.Next() {
+			var userID uint64
+			if err := rows.Scan(&userID); err != nil {
+				r.log.Error(err)
+				rows.Close()
+				tx.Rollback()
+				return nil, err
+			}
+
+			userIDs = append(userIDs, userID)
+		}
+		rows.Close()
+	}
+
+	_, err = tx.Exec(
+		"DELETE FROM mentions WHERE task_id = $1 AND COALESCE(comment_id, 0) = $2 AND NOT (user_id = ANY($3))",
+		mentionData.TaskID,
+		mentionData.CommentID,
+		pq.Array(userIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	mentioned := make([]uint64, 0)
+	if len(userIDs) > 0 {
+		rows, err := tx.Query(
+			`INSERT INTO mentions (user_id, task_id, comment_id, mentioned_by, created_at)
+			SELECT UNNEST($1::INT[]), $2, NULLIF($3, 0), $4, $5
+			ON CONFLICT DO NOTHING RETURNING user_id`,
+			pq.Array(userIDs),
+			mentionData.TaskID,
+			mentionData.CommentID,
+			mentionData.AuthorID,
+			time.Now().UTC(),
+		)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return nil, err
+		}
+
+		for rows.Next() {
+			var userID uint64
+			if err := rows.Scan(&userID); err != nil {
+				r.log.Error(err)
+				rows.Close()
+				tx.Rollback()
+				return nil, err
+			}
+
+			mentioned = append(mentioned, userID)
+		}
+		rows.Close()
+	}
+
+	if err := addMentionEvents(tx, mentionData, mentioned); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return mentioned, nil
+}
+
+// addMentionEvents saves an event for every user newly mentioned in the text,
+// in the transaction the mentions are saved in.
+func addMentionEvents(tx *sql.Tx, mentionData *dto.MentionDto, userIDs []uint64) error {
+	createdAt := time.Now().UTC()
+	for _, userID := range userIDs {
+		event := &models.MentionEvent{
+			Type:        models.MentionEventType,
+			UserID:      userID,
+			TaskID:      mentionData.TaskID,
+			CommentID:   mentionData.CommentID,
+			MentionedBy: mentionData.AuthorID,
+			CreatedAt:   createdAt,
+		}
+
+		message, err := event.OutboxMessage()
+		if err != nil {
+			return err
+		}
+
+		if err := addToOutbox(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMentions returns a page of the places the user was mentioned at, the
+// latest first. Mentions in deleted comments are left out.
+func (r *MentionRepository) GetMentions(userID uint64, page *dto.PageDto) ([]*models.Mention, error) {
+	limit, offset := pageBounds(page)
+
+	rows, err := r.db.Query(
+		`SELECT mentions.id, mentions.comment_id, mentions.mentioned_by, mentions.created_at,
+		tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM mentions
+		JOIN tasks ON tasks.id = mentions.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		LEFT JOIN comments ON comments.id = mentions.comment_id
+		WHERE mentions.user_id = $1 AND projects.deleted_at IS NULL AND comments.deleted_at IS NULL
+		ORDER BY mentions.created_at DESC, mentions.id DESC LIMIT $2 OFFSET $3`,
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	mentions := make([]*models.Mention, 0)
+	for rows.Next() {
+		mention := new(models.Mention)
+
+		mention.Task, err = scanLinkedTask(rows, &mention.ID, &mention.CommentID, &mention.MentionedBy, &mention.CreatedAt)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		mentions = append(mentions, mention)
+	}
+
+	return mentions, nil
+}
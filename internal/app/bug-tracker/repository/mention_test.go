@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+const (
+	resolveMentionsQuery = `SELECT users.id FROM users
+			JOIN tasks ON tasks.id = $1
+			JOIN projects ON projects.id = tasks.project_id
+			WHERE users.username = ANY($2) AND users.id <> $3 AND (projects.admin = users.id OR EXISTS (
+				SELECT 1 FROM projects_members WHERE project_id = projects.id AND member_id = users.id
+			))`
+	deleteMentionsQuery = "DELETE FROM mentions WHERE task_id = $1 AND COALESCE(comment_id, 0) = $2 AND NOT (user_id = ANY($3))"
+	insertMentionsQuery = `INSERT INTO mentions (user_id, task_id, comment_id, mentioned_by, created_at)
+			SELECT UNNEST($1::INT[]), $2, NULLIF($3, 0), $4, $5
+			ON CONFLICT DO NOTHING RETURNING user_id`
+)
+
+func Test_parseMentions(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		expectedResult []string
+	}{
+		{
+			name:           "No mentions",
+			text:           "write to john@example.com",
+			expectedResult: []string{},
+		},
+		{
+			name:           "Mentions",
+			text:           "@john, please ask @jane.doe. And @john again (@max-1)",
+			expectedResult: []string{"john", "jane.doe", "max-1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedResult, parseMentions(test.text))
+		})
+	}
+}
+
+func Test_SaveMentions(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mentionData    *dto.MentionDto
+		mockBehaviour  mockBehaviour
+		expectedResult []uint64
+		expectedError  error
+	}{
+		{
+			name:        "OK no mentions left",
+			mentionData: &dto.MentionDto{TaskID: 1, AuthorID: 1, Text: "no mentions"},
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(deleteMentionsQuery)).
+					WithArgs(mentionData.TaskID, mentionData.CommentID, "{}").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+
+				return &MentionRepository{db: db}
+			},
+			expectedResult: []uint64{},
+			expectedError:  nil,
+		},
+		{
+			name:        "Error cannot save mention event",
+			mentionData: &dto.MentionDto{TaskID: 1, CommentID: 4, AuthorID: 1, Text: "@jane"},
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(resolveMentionsQuery)).
+					WithArgs(mentionData.TaskID, "{\"jane\"}", mentionData.AuthorID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec(regexp.QuoteMeta(deleteMentionsQuery)).
+					WithArgs(mentionData.TaskID, mentionData.CommentID, "{2}").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(insertMentionsQuery)).
+					WithArgs("{2}", mentionData.TaskID, mentionData.CommentID, mentionData.AuthorID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(2))
+				expectAddEvent(mock, models.MentionCategory, "user-2").WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &MentionRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:        "OK",
+			mentionData: &dto.MentionDto{TaskID: 1, CommentID: 4, AuthorID: 1, Text: "@jane and @john, see @stranger"},
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(resolveMentionsQuery)).
+					WithArgs(mentionData.TaskID, "{\"jane\",\"john\",\"stranger\"}", mentionData.AuthorID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+				mock.ExpectExec(regexp.QuoteMeta(deleteMentionsQuery)).
+					WithArgs(mentionData.TaskID, mentionData.CommentID, "{2,3}").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(insertMentionsQuery)).
+					WithArgs("{2,3}", mentionData.TaskID, mentionData.CommentID, mentionData.AuthorID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+				expectAddEvent(mock, models.MentionCategory, "user-3").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				return &MentionRepository{db: db}
+			},
+			expectedResult: []uint64{3},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.mentionData)
+			res, err := repo.SaveMentions(test.mentionData)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetMentions(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := `SELECT mentions.id, mentions.comment_id, mentions.mentioned_by, mentions.created_at,
+		tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM mentions
+		JOIN tasks ON tasks.id = mentions.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		LEFT JOIN comments ON comments.id = mentions.comment_id
+		WHERE mentions.user_id = $1 AND projects.deleted_at IS NULL AND comments.deleted_at IS NULL
+		ORDER BY mentions.created_at DESC, mentions.id DESC LIMIT $2 OFFSET $3`
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint64(2), dto.DefaultPageLimit, 0).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "comment_id", "mentioned_by", "created_at", "id", "key", "number", "name", "status", "category",
+		}).AddRow(7, 4, 1, createdAt, 1, "KEY", 1, "Login", "To Do", "todo"))
+
+	repo := &MentionRepository{db: db}
+	res, err := repo.GetMentions(2, &dto.PageDto{})
+
+	require.NoError(t, err)
+	require.Equal(t, []*models.Mention{{
+		ID:          7,
+		Task:        &models.LinkedTask{ID: 1, Key: "KEY-1", Name: "Login", Status: "To Do", Category: "todo"},
+		CommentID:   sql.NullInt64{Int64: 4, Valid: true},
+		MentionedBy: sql.NullInt64{Int64: 1, Valid: true},
+		CreatedAt:   createdAt,
+	}}, res)
+}
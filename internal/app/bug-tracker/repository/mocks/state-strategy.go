@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: state-strategy.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstate is a mock of state interface.
+type Mockstate struct {
+	ctrl     *gomock.Controller
+	recorder *MockstateMockRecorder
+}
+
+// MockstateMockRecorder is the mock recorder for Mockstate.
+type MockstateMockRecorder struct {
+	mock *Mockstate
+}
+
+// NewMockstate creates a new mock instance.
+func NewMockstate(ctrl *gomock.Controller) *Mockstate {
+	mock := &Mockstate{ctrl: ctrl}
+	mock.recorder = &MockstateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstate) EXPECT() *MockstateMockRecorder {
+	return m.recorder
+}
+
+// IsWritable mocks base method.
+func (m *Mockstate) IsWritable(projectID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWritable", projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsWritable indicates an expected call of IsWritable.
+func (mr *MockstateMockRecorder) IsWritable(projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWritable", reflect.TypeOf((*Mockstate)(nil).IsWritable), projectID)
+}
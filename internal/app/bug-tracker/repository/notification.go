@@ -0,0 +1,528 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var ErrNotificationNotFound = errors.New("error notification is not found")
+
+// addNotificationsQuery adds a notification for every user but those who
+// turned its type off, with the channel the user gets its type through.
+const addNotificationsQuery = `INSERT INTO notifications (user_id, type, task_id, comment_id, actor_id, created_at, channel)
+	SELECT recipients.user_id, $2, $3, $4, $5, $6, COALESCE(notification_preferences.channel, 'in_app')
+	FROM UNNEST($1::INT[]) AS recipients (user_id)
+	LEFT JOIN notification_preferences
+	ON notification_preferences.user_id = recipients.user_id AND notification_preferences.type = $2
+	WHERE COALESCE(notification_preferences.channel, 'in_app') <> 'none'
+	RETURNING id, channel`
+
+const mailItemsQuery = `SELECT notifications.id, users.email, notifications.type, projects.key, tasks.number, tasks.name,
+	COALESCE(actors.username, ''), notifications.created_at
+	FROM notifications
+	JOIN users ON users.id = notifications.user_id
+	JOIN tasks ON tasks.id = notifications.task_id
+	JOIN projects ON projects.id = tasks.project_id
+	LEFT JOIN users AS actors ON actors.id = notifications.actor_id`
+
+const getNotificationsQuery = `SELECT notifications.id, notifications.type, notifications.comment_id,
+	notifications.actor_id, notifications.created_at, notifications.read_at,
+	tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+	FROM notifications
+	JOIN tasks ON tasks.id = notifications.task_id
+	JOIN projects ON projects.id = tasks.project_id
+	JOIN statuses ON statuses.id = tasks.status_id
+	WHERE notifications.user_id = $1 AND projects.deleted_at IS NULL
+	AND ($2 = FALSE OR notifications.read_at IS NULL)
+	ORDER BY notifications.created_at DESC, notifications.id DESC LIMIT $3 OFFSET $4`
+
+type NotificationRepository struct {
+	db  *sql.DB
+	log log.Log
+}
+
+func NewNotificationRepo(db *sql.DB, log log.Log) Notification {
+	return &NotificationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// AddNotifications adds the notification to the inbox of every user it is
+// sent to but those who turned its type off. Users who get its type by email
+// get a mail right away, saved to the outbox in the same transaction.
+func (r *NotificationRepository) AddNotifications(notificationData *dto.NotificationDto) error {
+	if len(notificationData.UserIDs) == 0 {
+		return nil
+	}
+
+	var commentID sql.NullInt64
+	if notificationData.CommentID != 0 {
+		commentID = sql.NullInt64{Int64: int64(notificationData.CommentID), Valid: true}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	rows, err := tx.Query(
+		addNotificationsQuery,
+		pq.Array(notificationData.UserIDs),
+		notificationData.Type,
+		notificationData.TaskID,
+		commentID,
+		notificationData.ActorID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	emailIDs, err := scanEmailNotifications(rows)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if len(emailIDs) > 0 {
+		if err := addNotificationMails(tx, emailIDs); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof(
+		"Add notifications: type = %s, task = %d, users = %d",
+		notificationData.Type,
+		notificationData.TaskID,
+		len(notificationData.UserIDs),
+	)
+
+	return nil
+}
+
+// scanEmailNotifications returns the ids of the added notifications that are
+// sent by email.
+func scanEmailNotifications(rows *sql.Rows) ([]uint64, error) {
+	defer rows.Close()
+
+	ids := make([]uint64, 0)
+	for rows.Next() {
+		var id uint64
+		var channel string
+		if err := rows.Scan(&id, &channel); err != nil {
+			return nil, err
+		}
+
+		if channel == models.ChannelEmail {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, rows.Err()
+}
+
+func addNotificationMails(tx *sql.Tx, ids []uint64) error {
+	rows, err := tx.Query(mailItemsQuery+" WHERE notifications.id = ANY($1) ORDER BY notifications.id", pq.Array(ids))
+	if err != nil {
+		return err
+	}
+
+	items, err := scanMailItems(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		message, err := models.NewNotificationMail(item)
+		if err != nil {
+			return err
+		}
+
+		if err := addToOutbox(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanMailItems(rows *sql.Rows) ([]*models.MailItem, error) {
+	defer rows.Close()
+
+	items := make([]*models.MailItem, 0)
+	for rows.Next() {
+		item := new(models.MailItem)
+		var projectKey string
+		var number uint64
+		err := rows.Scan(
+			&item.ID,
+			&item.Email,
+			&item.Type,
+			&projectKey,
+			&number,
+			&item.TaskName,
+			&item.Actor,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		item.TaskKey = fmt.Sprintf("%s-%d", projectKey, number)
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetNotifications returns a page of the inbox of the user, the latest
+// notifications first.
+func (r *NotificationRepository) GetNotifications(
+	userID uint64,
+	filter *dto.NotificationFilterDto,
+) ([]*models.Notification, error) {
+	limit, offset := pageBounds(&filter.PageDto)
+
+	rows, err := r.db.Query(getNotificationsQuery, userID, filter.Unread, limit, offset)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*models.Notification, 0)
+	for rows.Next() {
+		notification := new(models.Notification)
+
+		notification.Task, err = scanLinkedTask(
+			rows,
+			&notification.ID,
+			&notification.Type,
+			&notification.CommentID,
+			&notification.ActorID,
+			&notification.CreatedAt,
+			&notification.ReadAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts the unread notifications the inbox of the user lists.
+func (r *NotificationRepository) CountUnread(userID uint64) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		`SELECT COUNT(*) FROM notifications
+		JOIN tasks ON tasks.id = notifications.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		WHERE notifications.user_id = $1 AND notifications.read_at IS NULL AND projects.deleted_at IS NULL`,
+		userID,
+	).Scan(&count)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification of the user as read. A notification that is
+// already read keeps the time it was first read at.
+func (r *NotificationRepository) MarkRead(notificationID, userID uint64) error {
+	result, err := r.db.Exec(
+		"UPDATE notifications SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3",
+		time.Now().UTC(),
+		notificationID,
+		userID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint64) error {
+	_, err := r.db.Exec(
+		"UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL",
+		time.Now().UTC(),
+		userID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Mark all notifications read: user = %d", userID)
+
+	return nil
+}
+
+// GetPreferences returns the notification preferences of the user, with the
+// defaults for what the user has not picked.
+func (r *NotificationRepository) GetPreferences(userID uint64) (*models.NotificationPreferences, error) {
+	preferences := models.NewNotificationPreferences()
+
+	rows, err := r.db.Query("SELECT type, channel FROM notification_preferences WHERE user_id = $1", userID)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var notificationType, channel string
+		if err := rows.Scan(&notificationType, &channel); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		preferences.Channels[notificationType] = channel
+	}
+
+	err = r.db.QueryRow(
+		"SELECT to_char(send_at, 'HH24:MI'), time_zone FROM digest_schedules WHERE user_id = $1",
+		userID,
+	).Scan(&preferences.DigestTime, &preferences.TimeZone)
+	if err != nil && err != sql.ErrNoRows {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// SavePreferences saves the notification preferences of the user and
+// schedules the next digest of the user at nextDigestAt.
+func (r *NotificationRepository) SavePreferences(
+	userID uint64,
+	preferences *models.NotificationPreferences,
+	nextDigestAt time.Time,
+) error {
+	types := make([]string, 0, len(preferences.Channels))
+	for notificationType := range preferences.Channels {
+		types = append(types, notificationType)
+	}
+	sort.Strings(types)
+
+	channels := make([]string, 0, len(types))
+	for _, notificationType := range types {
+		channels = append(channels, preferences.Channels[notificationType])
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO notification_preferences (user_id, type, channel)
+		SELECT $1, UNNEST($2::VARCHAR[]), UNNEST($3::VARCHAR[])
+		ON CONFLICT (user_id, type) DO UPDATE SET channel = EXCLUDED.channel`,
+		userID,
+		pq.Array(types),
+		pq.Array(channels),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO digest_schedules (user_id, time_zone, send_at, next_digest_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET time_zone = EXCLUDED.time_zone, send_at = EXCLUDED.send_at, next_digest_at = EXCLUDED.next_digest_at`,
+		userID,
+		preferences.TimeZone,
+		preferences.DigestTime,
+		nextDigestAt,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Save notification preferences: user = %d", userID)
+
+	return nil
+}
+
+// GetDueDigests returns up to limit digests due at now, the longest due
+// first.
+func (r *NotificationRepository) GetDueDigests(now time.Time, limit int) ([]*models.DigestSchedule, error) {
+	rows, err := r.db.Query(
+		`SELECT digest_schedules.user_id, users.email, to_char(digest_schedules.send_at, 'HH24:MI'),
+		digest_schedules.time_zone, digest_schedules.next_digest_at
+		FROM digest_schedules
+		JOIN users ON users.id = digest_schedules.user_id
+		WHERE digest_schedules.next_digest_at <= $1
+		ORDER BY digest_schedules.next_digest_at LIMIT $2`,
+		now,
+		limit,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]*models.DigestSchedule, 0)
+	for rows.Next() {
+		schedule := new(models.DigestSchedule)
+		err := rows.Scan(
+			&schedule.UserID,
+			&schedule.Email,
+			&schedule.DigestTime,
+			&schedule.TimeZone,
+			&schedule.NextDigestAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// GetDigestItems returns the notifications of the user waiting for the next
+// digest, oldest first.
+func (r *NotificationRepository) GetDigestItems(userID uint64) ([]*models.MailItem, error) {
+	rows, err := r.db.Query(
+		mailItemsQuery+` WHERE notifications.user_id = $1 AND notifications.channel = 'digest'
+		AND notifications.digested_at IS NULL AND projects.deleted_at IS NULL
+		ORDER BY notifications.id`,
+		userID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	items, err := scanMailItems(rows)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CompleteDigest moves the due digest of the user to nextDigestAt. The
+// notifications up to lastItemID are marked digested and the mail, if any,
+// is saved to the outbox with it. It returns false without changing anything
+// when the digest is no longer due at the time it was read at, because
+// another instance completed it or the user changed the schedule.
+func (r *NotificationRepository) CompleteDigest(
+	schedule *models.DigestSchedule,
+	nextDigestAt time.Time,
+	lastItemID uint64,
+	message *models.OutboxMessage,
+) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return false, err
+	}
+
+	result, err := tx.Exec(
+		"UPDATE digest_schedules SET next_digest_at = $1 WHERE user_id = $2 AND next_digest_at = $3",
+		nextDigestAt,
+		schedule.UserID,
+		schedule.NextDigestAt,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return false, err
+	}
+
+	if count == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if message != nil {
+		_, err := tx.Exec(
+			`UPDATE notifications SET digested_at = $1
+			WHERE user_id = $2 AND channel = 'digest' AND digested_at IS NULL AND id <= $3`,
+			time.Now().UTC(),
+			schedule.UserID,
+			lastItemID,
+		)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return false, err
+		}
+
+		if err := addToOutbox(tx, message); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return false, err
+	}
+
+	if message != nil {
+		r.log.Infof("Send digest: user = %d", schedule.UserID)
+	}
+
+	return true, nil
+}
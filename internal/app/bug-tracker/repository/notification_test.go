@@ -0,0 +1,565 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+func Test_AddNotifications(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationRepository
+	err := errors.New("error")
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	mailQuery := mailItemsQuery + " WHERE notifications.id = ANY($1) ORDER BY notifications.id"
+	mailColumns := []string{"id", "email", "type", "key", "number", "name", "username", "created_at"}
+
+	tests := []struct {
+		name             string
+		notificationData *dto.NotificationDto
+		mockBehaviour    mockBehaviour
+		expectedError    error
+	}{
+		{
+			name:             "OK no users",
+			notificationData: &dto.NotificationDto{Type: models.NotificationStatus, TaskID: 1, ActorID: 1},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationRepository {
+				db, _, _ := sqlmock.New()
+
+				return &NotificationRepository{db: db}
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error",
+			notificationData: &dto.NotificationDto{
+				UserIDs: []uint64{2, 3},
+				Type:    models.NotificationStatus,
+				TaskID:  1,
+				ActorID: 1,
+			},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(addNotificationsQuery)).
+					WithArgs("{2,3}", models.NotificationStatus, uint64(1), nil, uint64(1), sqlmock.AnyArg()).
+					WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name: "Error cannot save mail",
+			notificationData: &dto.NotificationDto{
+				UserIDs: []uint64{2},
+				Type:    models.NotificationStatus,
+				TaskID:  1,
+				ActorID: 1,
+			},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(addNotificationsQuery)).
+					WithArgs("{2}", models.NotificationStatus, uint64(1), nil, uint64(1), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "channel"}).AddRow(7, models.ChannelEmail))
+				mock.ExpectQuery(regexp.QuoteMeta(mailQuery)).WithArgs("{7}").
+					WillReturnRows(sqlmock.NewRows(mailColumns).
+						AddRow(7, "jane@gmail.com", models.NotificationStatus, "KEY", 1, "Login", "john", createdAt))
+				expectAddEvent(mock, models.MailCategory, "jane@gmail.com").WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			notificationData: &dto.NotificationDto{
+				UserIDs:   []uint64{2, 3, 4},
+				Type:      models.NotificationMention,
+				TaskID:    1,
+				CommentID: 4,
+				ActorID:   1,
+			},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(addNotificationsQuery)).
+					WithArgs("{2,3,4}", models.NotificationMention, uint64(1), int64(4), uint64(1), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "channel"}).
+						AddRow(7, models.ChannelEmail).
+						AddRow(8, models.ChannelDigest))
+				mock.ExpectQuery(regexp.QuoteMeta(mailQuery)).WithArgs("{7}").
+					WillReturnRows(sqlmock.NewRows(mailColumns).
+						AddRow(7, "jane@gmail.com", models.NotificationMention, "KEY", 1, "Login", "john", createdAt))
+				mock.ExpectExec(regexp.QuoteMeta(addToOutboxQuery)).WithArgs(
+					models.MailCategory,
+					"jane@gmail.com",
+					[]byte(`{"type":"notification","to":"jane@gmail.com","subject":"[KEY-1] Login","body":"KEY-1 Login: you were mentioned by john"}`),
+					[]byte(`{"mail-type":"notification"}`),
+					sqlmock.AnyArg(),
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof(
+					"Add notifications: type = %s, task = %d, users = %d",
+					models.NotificationMention,
+					uint64(1),
+					3,
+				).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.notificationData)
+			err := repo.AddNotifications(test.notificationData)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetNotifications(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	readAt := createdAt.Add(time.Hour)
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(getNotificationsQuery)).
+		WithArgs(uint64(2), true, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "type", "comment_id", "actor_id", "created_at", "read_at",
+			"id", "key", "number", "name", "status", "category",
+		}).
+			AddRow(8, models.NotificationMention, 4, 1, createdAt, nil, 1, "KEY", 1, "Login", "To Do", "todo").
+			AddRow(7, models.NotificationStatus, nil, nil, createdAt, readAt, 1, "KEY", 1, "Login", "To Do", "todo"))
+
+	repo := &NotificationRepository{db: db}
+	res, err := repo.GetNotifications(2, &dto.NotificationFilterDto{
+		PageDto: dto.PageDto{Page: 2, Limit: 10},
+		Unread:  true,
+	})
+
+	task := &models.LinkedTask{ID: 1, Key: "KEY-1", Name: "Login", Status: "To Do", Category: "todo"}
+	require.NoError(t, err)
+	require.Equal(t, []*models.Notification{
+		{
+			ID:        8,
+			Type:      models.NotificationMention,
+			Task:      task,
+			CommentID: sql.NullInt64{Int64: 4, Valid: true},
+			ActorID:   sql.NullInt64{Int64: 1, Valid: true},
+			CreatedAt: createdAt,
+		},
+		{
+			ID:        7,
+			Type:      models.NotificationStatus,
+			Task:      task,
+			CreatedAt: createdAt,
+			ReadAt:    sql.NullTime{Time: readAt, Valid: true},
+		},
+	}, res)
+}
+
+func Test_CountUnread(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT COUNT(*) FROM notifications
+		JOIN tasks ON tasks.id = notifications.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		WHERE notifications.user_id = $1 AND notifications.read_at IS NULL AND projects.deleted_at IS NULL`,
+	)).WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := &NotificationRepository{db: db}
+	count, err := repo.CountUnread(1)
+
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func Test_MarkNotificationRead(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationRepository
+	err := errors.New("error")
+	query := "UPDATE notifications SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3"
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(sqlmock.AnyArg(), uint64(5), uint64(1)).WillReturnError(err)
+				log.EXPECT().Error(err).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name: "Error notification not found",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(sqlmock.AnyArg(), uint64(5), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &NotificationRepository{db: db}
+			},
+			expectedError: ErrNotificationNotFound,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(sqlmock.AnyArg(), uint64(5), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				return &NotificationRepository{db: db}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			err := repo.MarkRead(5, 1)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_MarkAllNotificationsRead(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	db, mock, _ := sqlmock.New()
+	log := mock_log.NewMockLog(c)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), uint64(1)).WillReturnResult(sqlmock.NewResult(0, 4))
+	log.EXPECT().Infof("Mark all notifications read: user = %d", uint64(1)).Return()
+
+	repo := &NotificationRepository{db: db, log: log}
+	require.NoError(t, repo.MarkAllRead(1))
+}
+
+func Test_GetPreferences(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationRepository
+	err := errors.New("error")
+	scheduleQuery := "SELECT to_char(send_at, 'HH24:MI'), time_zone FROM digest_schedules WHERE user_id = $1"
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		expectedResult *models.NotificationPreferences
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT type, channel FROM notification_preferences WHERE user_id = $1")).
+					WithArgs(uint64(1)).WillReturnError(err)
+				log.EXPECT().Error(err).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK defaults",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT type, channel FROM notification_preferences WHERE user_id = $1")).
+					WithArgs(uint64(1)).WillReturnRows(sqlmock.NewRows([]string{"type", "channel"}))
+				mock.ExpectQuery(regexp.QuoteMeta(scheduleQuery)).WithArgs(uint64(1)).WillReturnError(sql.ErrNoRows)
+
+				return &NotificationRepository{db: db}
+			},
+			expectedResult: models.NewNotificationPreferences(),
+			expectedError:  nil,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT type, channel FROM notification_preferences WHERE user_id = $1")).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "channel"}).
+						AddRow(models.NotificationMention, models.ChannelEmail).
+						AddRow(models.NotificationStatus, models.ChannelDigest))
+				mock.ExpectQuery(regexp.QuoteMeta(scheduleQuery)).WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"send_at", "time_zone"}).AddRow("18:30", "Europe/Berlin"))
+
+				return &NotificationRepository{db: db}
+			},
+			expectedResult: &models.NotificationPreferences{
+				Channels: map[string]string{
+					models.NotificationAssignment: models.ChannelInApp,
+					models.NotificationComment:    models.ChannelInApp,
+					models.NotificationStatus:     models.ChannelDigest,
+					models.NotificationMention:    models.ChannelEmail,
+				},
+				DigestTime: "18:30",
+				TimeZone:   "Europe/Berlin",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			res, err := repo.GetPreferences(1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_SavePreferences(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	nextDigestAt := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	preferences := &models.NotificationPreferences{
+		Channels: map[string]string{
+			models.NotificationStatus:  models.ChannelDigest,
+			models.NotificationMention: models.ChannelEmail,
+		},
+		DigestTime: "09:00",
+		TimeZone:   "UTC",
+	}
+
+	db, mock, _ := sqlmock.New()
+	log := mock_log.NewMockLog(c)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(
+		`INSERT INTO notification_preferences (user_id, type, channel)
+		SELECT $1, UNNEST($2::VARCHAR[]), UNNEST($3::VARCHAR[])
+		ON CONFLICT (user_id, type) DO UPDATE SET channel = EXCLUDED.channel`,
+	)).WithArgs(uint64(1), `{"mention","status"}`, `{"email","digest"}`).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(
+		`INSERT INTO digest_schedules (user_id, time_zone, send_at, next_digest_at) VALUES ($1, $2, $3, $4)`,
+	)).WithArgs(uint64(1), "UTC", "09:00", nextDigestAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	log.EXPECT().Infof("Save notification preferences: user = %d", uint64(1)).Return()
+
+	repo := &NotificationRepository{db: db, log: log}
+	require.NoError(t, repo.SavePreferences(1, preferences, nextDigestAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetDueDigests(t *testing.T) {
+	now := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		`SELECT digest_schedules.user_id, users.email, to_char(digest_schedules.send_at, 'HH24:MI'),
+		digest_schedules.time_zone, digest_schedules.next_digest_at
+		FROM digest_schedules
+		JOIN users ON users.id = digest_schedules.user_id
+		WHERE digest_schedules.next_digest_at <= $1
+		ORDER BY digest_schedules.next_digest_at LIMIT $2`,
+	)).WithArgs(now, 10).WillReturnRows(
+		sqlmock.NewRows([]string{"user_id", "email", "send_at", "time_zone", "next_digest_at"}).
+			AddRow(1, "jane@gmail.com", "09:00", "UTC", now),
+	)
+
+	repo := &NotificationRepository{db: db}
+	res, err := repo.GetDueDigests(now, 10)
+
+	require.NoError(t, err)
+	require.Equal(t, []*models.DigestSchedule{{
+		UserID:       1,
+		Email:        "jane@gmail.com",
+		DigestTime:   "09:00",
+		TimeZone:     "UTC",
+		NextDigestAt: now,
+	}}, res)
+}
+
+func Test_GetDigestItems(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(
+		mailItemsQuery + ` WHERE notifications.user_id = $1 AND notifications.channel = 'digest'
+		AND notifications.digested_at IS NULL AND projects.deleted_at IS NULL
+		ORDER BY notifications.id`,
+	)).WithArgs(uint64(1)).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "email", "type", "key", "number", "name", "username", "created_at"}).
+			AddRow(7, "jane@gmail.com", models.NotificationStatus, "KEY", 1, "Login", "", createdAt),
+	)
+
+	repo := &NotificationRepository{db: db}
+	res, err := repo.GetDigestItems(1)
+
+	require.NoError(t, err)
+	require.Equal(t, []*models.MailItem{{
+		ID:        7,
+		Email:     "jane@gmail.com",
+		Type:      models.NotificationStatus,
+		TaskKey:   "KEY-1",
+		TaskName:  "Login",
+		CreatedAt: createdAt,
+	}}, res)
+}
+
+func Test_CompleteDigest(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationRepository
+	err := errors.New("error")
+	dueAt := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	nextDigestAt := dueAt.Add(24 * time.Hour)
+	schedule := &models.DigestSchedule{UserID: 1, Email: "jane@gmail.com", NextDigestAt: dueAt}
+	message := &models.OutboxMessage{Category: models.MailCategory, Key: "jane@gmail.com", Value: []byte("{}")}
+	scheduleQuery := "UPDATE digest_schedules SET next_digest_at = $1 WHERE user_id = $2 AND next_digest_at = $3"
+	digestedQuery := `UPDATE notifications SET digested_at = $1
+			WHERE user_id = $2 AND channel = 'digest' AND digested_at IS NULL AND id <= $3`
+
+	tests := []struct {
+		name           string
+		message        *models.OutboxMessage
+		mockBehaviour  mockBehaviour
+		expectedResult bool
+		expectedError  error
+	}{
+		{
+			name:    "OK completed by another instance",
+			message: message,
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(scheduleQuery)).
+					WithArgs(nextDigestAt, uint64(1), dueAt).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+
+				return &NotificationRepository{db: db}
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name:    "Error cannot save mail",
+			message: message,
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(scheduleQuery)).
+					WithArgs(nextDigestAt, uint64(1), dueAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(digestedQuery)).
+					WithArgs(sqlmock.AnyArg(), uint64(1), uint64(8)).WillReturnResult(sqlmock.NewResult(0, 2))
+				expectAddEvent(mock, models.MailCategory, "jane@gmail.com").WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedResult: false,
+			expectedError:  err,
+		},
+		{
+			name:    "OK nothing to send",
+			message: nil,
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(scheduleQuery)).
+					WithArgs(nextDigestAt, uint64(1), dueAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				return &NotificationRepository{db: db}
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name:    "OK",
+			message: message,
+			mockBehaviour: func(c *gomock.Controller) *NotificationRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(scheduleQuery)).
+					WithArgs(nextDigestAt, uint64(1), dueAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(digestedQuery)).
+					WithArgs(sqlmock.AnyArg(), uint64(1), uint64(8)).WillReturnResult(sqlmock.NewResult(0, 2))
+				expectAddEvent(mock, models.MailCategory, "jane@gmail.com").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Send digest: user = %d", uint64(1)).Return()
+
+				return &NotificationRepository{db: db, log: log}
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			res, err := repo.CompleteDigest(schedule, nextDigestAt, 8, test.message)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// outboxLockID is the advisory lock taken while messages are claimed, so two
+// relays never claim messages with the same key at the same time.
+const outboxLockID = 2300
+
+const addToOutboxQuery = `INSERT INTO outbox (category, message_key, value, headers, created_at, next_attempt_at)
+	VALUES ($1, $2, $3, $4, $5, $5)`
+
+// claimOutboxQuery leases the oldest messages that are not sent yet. Keys with
+// a message that is leased or waits for a retry are left out as a whole, so
+// the messages of a key are sent one batch after another in order.
+const claimOutboxQuery = `UPDATE outbox SET locked_until = $1
+	WHERE id IN (
+		SELECT id FROM outbox
+		WHERE sent_at IS NULL AND message_key NOT IN (
+			SELECT message_key FROM outbox
+			WHERE sent_at IS NULL AND (next_attempt_at > $2 OR locked_until > $2)
+		)
+		ORDER BY id LIMIT $3
+	)
+	RETURNING id, category, message_key, value, headers, attempts`
+
+// execer is a *sql.DB or a *sql.Tx.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// addToOutbox saves the message with the change it tells about.
+func addToOutbox(db execer, message *models.OutboxMessage) error {
+	headers := message.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(addToOutboxQuery, message.Category, message.Key, message.Value, data, time.Now().UTC())
+
+	return err
+}
+
+// addEvent saves the event of a change in the transaction of the change.
+func addEvent(tx *sql.Tx, event *models.Event) error {
+	message, err := event.OutboxMessage()
+	if err != nil {
+		return err
+	}
+
+	return addToOutbox(tx, message)
+}
+
+// execWithEvent runs a statement that makes a change on its own and saves the
+// event of the change with it. No event is saved when no row changed.
+func execWithEvent(db *sql.DB, event *models.Event, query string, args ...interface{}) (sql.Result, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if count > 0 {
+		if err := addEvent(tx, event); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type OutboxRepository struct {
+	db  *sql.DB
+	log log.Log
+}
+
+func NewOutboxRepo(db *sql.DB, log log.Log) Outbox {
+	return &OutboxRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *OutboxRepository) AddMessage(message *models.OutboxMessage) error {
+	if err := addToOutbox(r.db, message); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Add message to outbox: category = %s", message.Category)
+
+	return nil
+}
+
+// ClaimMessages leases up to limit messages to the caller until the lease
+// ends. Messages that are not marked sent or failed by then, because the
+// relay stopped, are claimed again. The messages are returned in order.
+func (r *OutboxRepository) ClaimMessages(limit int, lease time.Duration) ([]*models.OutboxMessage, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", outboxLockID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rows, err := tx.Query(claimOutboxQuery, now.Add(lease), now, limit)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	messages, err := scanOutboxMessages(rows)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return messages, nil
+}
+
+func scanOutboxMessages(rows *sql.Rows) ([]*models.OutboxMessage, error) {
+	defer rows.Close()
+
+	messages := make([]*models.OutboxMessage, 0)
+	for rows.Next() {
+		message := new(models.OutboxMessage)
+		var headers []byte
+		err := rows.Scan(
+			&message.ID,
+			&message.Category,
+			&message.Key,
+			&message.Value,
+			&headers,
+			&message.Attempts,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(headers, &message.Headers); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *OutboxRepository) MarkSent(id uint64) error {
+	_, err := r.db.Exec("UPDATE outbox SET sent_at = $1, locked_until = NULL WHERE id = $2", time.Now().UTC(), id)
+	if err != nil {
+		r.log.Error(err)
+	}
+
+	return err
+}
+
+// MarkFailed keeps the message until the next attempt and records why it
+// could not be sent.
+func (r *OutboxRepository) MarkFailed(id uint64, nextAttemptAt time.Time, reason string) error {
+	_, err := r.db.Exec(
+		`UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2, locked_until = NULL
+		WHERE id = $3`,
+		nextAttemptAt,
+		reason,
+		id,
+	)
+	if err != nil {
+		r.log.Error(err)
+	}
+
+	return err
+}
+
+// ReleaseMessages ends the lease of messages that were claimed but not sent,
+// so they can be claimed again without waiting for the lease to end.
+func (r *OutboxRepository) ReleaseMessages(ids []uint64) error {
+	_, err := r.db.Exec("UPDATE outbox SET locked_until = NULL WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		r.log.Error(err)
+	}
+
+	return err
+}
+
+func (r *OutboxRepository) PurgeSentMessages(sentBefore time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1", sentBefore)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+// expectAddEvent expects the event of a change to be saved to the outbox.
+func expectAddEvent(mock sqlmock.Sqlmock, category, key string) *sqlmock.ExpectedExec {
+	return mock.ExpectExec(regexp.QuoteMeta(addToOutboxQuery)).
+		WithArgs(category, key, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg())
+}
+
+func Test_AddMessage(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, message *models.OutboxMessage) *OutboxRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		message       *models.OutboxMessage
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:    "Error",
+			message: models.NewMailMessage("email@gmail.com"),
+			mockBehaviour: func(c *gomock.Controller, message *models.OutboxMessage) *OutboxRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectExec(regexp.QuoteMeta(addToOutboxQuery)).WithArgs(
+					models.MailCategory,
+					"email@gmail.com",
+					[]byte("email@gmail.com"),
+					[]byte("{}"),
+					sqlmock.AnyArg(),
+				).WillReturnError(err)
+				log.EXPECT().Error(err).Return()
+
+				return &OutboxRepository{db: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			message: &models.OutboxMessage{
+				Category: "task",
+				Key:      "task-1",
+				Value:    []byte("{}"),
+				Headers:  map[string]string{"event-type": "task.created"},
+			},
+			mockBehaviour: func(c *gomock.Controller, message *models.OutboxMessage) *OutboxRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectExec(regexp.QuoteMeta(addToOutboxQuery)).WithArgs(
+					"task",
+					"task-1",
+					[]byte("{}"),
+					[]byte(`{"event-type":"task.created"}`),
+					sqlmock.AnyArg(),
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+				log.EXPECT().Infof("Add message to outbox: category = %s", "task").Return()
+
+				return &OutboxRepository{db: db, log: log}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.message)
+			err := repo.AddMessage(test.message)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_addEvent(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	event := models.NewEvent(models.EventTaskAssigned, 1, 2, 3, &models.AssigneePayload{AssigneeID: 1})
+	event.CorrelationID = "request"
+
+	message, err := event.OutboxMessage()
+	require.NoError(t, err)
+	require.Equal(t, "task", message.Category)
+	require.Equal(t, "task-3", message.Key)
+	require.Equal(t, map[string]string{
+		models.HeaderEventType:     models.EventTaskAssigned,
+		models.HeaderEventVersion:  "1",
+		models.HeaderContentType:   "application/json",
+		models.HeaderCorrelationID: "request",
+	}, message.Headers)
+
+	mock.ExpectBegin()
+	expectAddEvent(mock, "task", "task-3").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	require.NoError(t, addEvent(tx, event))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ClaimMessages(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *OutboxRepository
+	err := errors.New("error")
+	columns := []string{"id", "category", "message_key", "value", "headers", "attempts"}
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.OutboxMessage
+		expectedError  error
+	}{
+		{
+			name: "Error cannot lock",
+			mockBehaviour: func(c *gomock.Controller) *OutboxRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+					WithArgs(outboxLockID).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &OutboxRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "Error cannot claim",
+			mockBehaviour: func(c *gomock.Controller) *OutboxRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+					WithArgs(outboxLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(claimOutboxQuery)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &OutboxRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *OutboxRepository {
+				db, mock, _ := sqlmock.New()
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(3, "task", "task-1", []byte("3"), []byte("{}"), 0).
+					AddRow(1, "task", "task-1", []byte("1"), []byte(`{"event-type":"task.created"}`), 2)
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+					WithArgs(outboxLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(claimOutboxQuery)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10).WillReturnRows(rows)
+				mock.ExpectCommit()
+
+				return &OutboxRepository{db: db}
+			},
+			expectedResult: []*models.OutboxMessage{
+				{
+					ID:       1,
+					Category: "task",
+					Key:      "task-1",
+					Value:    []byte("1"),
+					Headers:  map[string]string{"event-type": "task.created"},
+					Attempts: 2,
+				},
+				{
+					ID:       3,
+					Category: "task",
+					Key:      "task-1",
+					Value:    []byte("3"),
+					Headers:  map[string]string{},
+				},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			res, err := repo.ClaimMessages(10, time.Minute)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_MarkSent(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox SET sent_at = $1, locked_until = NULL WHERE id = $2")).
+		WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := &OutboxRepository{db: db}
+	require.NoError(t, repo.MarkSent(1))
+}
+
+func Test_MarkFailed(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	err := errors.New("error")
+	nextAttemptAt := time.Now().Add(time.Minute)
+	db, mock, _ := sqlmock.New()
+	log := mock_log.NewMockLog(c)
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		`UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $1, last_error = $2, locked_until = NULL
+		WHERE id = $3`,
+	)).WithArgs(nextAttemptAt, "kafka is down", 1).WillReturnError(err)
+	log.EXPECT().Error(err).Return()
+
+	repo := &OutboxRepository{db: db, log: log}
+	require.Equal(t, err, repo.MarkFailed(1, nextAttemptAt, "kafka is down"))
+}
+
+func Test_ReleaseMessages(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox SET locked_until = NULL WHERE id = ANY($1)")).
+		WithArgs("{2,3}").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := &OutboxRepository{db: db}
+	require.NoError(t, repo.ReleaseMessages([]uint64{2, 3}))
+}
+
+func Test_PurgeSentMessages(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	sentBefore := time.Now()
+	db, mock, _ := sqlmock.New()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1")).
+		WithArgs(sentBefore).WillReturnResult(sqlmock.NewResult(0, 5))
+
+	repo := &OutboxRepository{db: db}
+	count, err := repo.PurgeSentMessages(sentBefore)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+
+// pageBounds returns the limit and the offset of the requested page, filling
+// in the defaults for the parts that are not set.
+func pageBounds(page *dto.PageDto) (int, int) {
+	limit := page.Limit
+	if limit == 0 {
+		limit = dto.DefaultPageLimit
+	}
+
+	number := page.Page
+	if number == 0 {
+		number = 1
+	}
+
+	return limit, (number - 1) * limit
+}
@@ -4,11 +4,14 @@ import (
 	"database/sql"
 	"fmt"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 const (
 	driver = "postgres"
+
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
 )
 
 type PostgresConfig struct {
@@ -35,3 +38,15 @@ func OpenPostgres(config *PostgresConfig) (*sql.DB, error) {
 
 	return db, nil
 }
+
+func isUniqueViolation(err error, constraint string) bool {
+	pqErr, ok := err.(*pq.Error)
+
+	return ok && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+func isForeignKeyViolation(err error, constraint string) bool {
+	pqErr, ok := err.(*pq.Error)
+
+	return ok && pqErr.Code == foreignKeyViolation && pqErr.Constraint == constraint
+}
@@ -3,6 +3,12 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
@@ -10,35 +16,197 @@ import (
 )
 
 var (
-	ErrNoRights = errors.New("error no rights to do this operation")
+	ErrNoRights          = errors.New("error no rights to do this operation")
+	ErrProjectNotFound   = errors.New("error project is not found")
+	ErrProjectArchived   = errors.New("error project is archived")
+	ErrProjectNotInTrash = errors.New("error project is not in trash")
+	ErrProjectKeyTaken   = errors.New("error project with such a key already exists")
+	ErrProjectNameTaken  = errors.New("error project with such a name already exists")
 )
 
+const (
+	projectColumns = "id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at"
+
+	projectsKeyConstraint  = "projects_key_key"
+	projectsNameConstraint = "projects_admin_name_key"
+)
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type ProjectRepository struct {
 	db     *sql.DB
 	log    log.Log
 	admin  admin
 	member member
+	state  state
 }
 
-func NewProjectRepo(db *sql.DB, log log.Log, admin admin, member member) Project {
+func NewProjectRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Project {
 	return &ProjectRepository{
 		db:     db,
 		log:    log,
 		admin:  admin,
 		member: member,
+		state:  state,
 	}
 }
 
-func (r *ProjectRepository) CreateProject(projectDto *dto.CreateProjectDto) (uint64, error) {
-	result := r.db.QueryRow(
-		"INSERT INTO projects (name, description, admin) VALUES ($1, $2, $3) RETURNING id",
+func scanProject(row scanner) (*models.Project, error) {
+	project := new(models.Project)
+	err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.Key,
+		&project.Description,
+		&project.AdminID,
+		&project.Visibility,
+		&project.DefaultPriority,
+		pq.Array(&project.RequiredBugFields),
+		&project.MaxTaskDepth,
+		&project.ArchivedAt,
+		&project.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+type projectChange struct {
+	field    string
+	column   string
+	oldValue string
+	newValue string
+	value    interface{}
+}
+
+// projectChanges compares the stored project with the requested update and
+// returns only the fields whose values actually change. Fields left nil in the
+// update are kept as is.
+func projectChanges(project *models.Project, projectData *dto.UpdateProjectDto) []projectChange {
+	fields := []struct {
+		field    string
+		column   string
+		oldValue string
+		newValue *string
+	}{
+		{"name", "name", project.Name, projectData.Name},
+		{"key", "key", project.Key, projectData.Key},
+		{"description", "description", project.Description, projectData.Description},
+		{"visibility", "visibility", project.Visibility, projectData.Visibility},
+		{"defaultPriority", "default_priority", project.DefaultPriority, projectData.DefaultPriority},
+	}
+
+	changes := make([]projectChange, 0, len(fields))
+	for _, f := range fields {
+		if f.newValue == nil || *f.newValue == f.oldValue {
+			continue
+		}
+		changes = append(changes, projectChange{f.field, f.column, f.oldValue, *f.newValue, *f.newValue})
+	}
+
+	if projectData.RequiredBugFields != nil {
+		required := *projectData.RequiredBugFields
+		if required == nil {
+			required = []string{}
+		}
+
+		oldValue := strings.Join(project.RequiredBugFields, ",")
+		newValue := strings.Join(required, ",")
+		if oldValue != newValue {
+			changes = append(changes, projectChange{
+				"requiredBugFields", "required_bug_fields", oldValue, newValue, pq.Array(required),
+			})
+		}
+	}
+
+	if projectData.MaxTaskDepth != nil && *projectData.MaxTaskDepth != project.MaxTaskDepth {
+		changes = append(changes, projectChange{
+			"maxTaskDepth",
+			"max_task_depth",
+			strconv.Itoa(project.MaxTaskDepth),
+			strconv.Itoa(*projectData.MaxTaskDepth),
+			*projectData.MaxTaskDepth,
+		})
+	}
+
+	return changes
+}
+
+func projectConstraintError(err error) error {
+	switch {
+	case isUniqueViolation(err, projectsKeyConstraint):
+		return ErrProjectKeyTaken
+	case isUniqueViolation(err, projectsNameConstraint):
+		return ErrProjectNameTaken
+	default:
+		return err
+	}
+}
+
+func addProjectAudit(tx *sql.Tx, projectID, actorID uint64, change projectChange, changedAt time.Time) error {
+	_, err := tx.Exec(
+		`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		projectID,
+		actorID,
+		change.field,
+		change.oldValue,
+		change.newValue,
+		changedAt,
+	)
+
+	return err
+}
+
+func (r *ProjectRepository) CreateProject(projectDto *dto.CreateProjectDto, event *models.Event) (uint64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	result := tx.QueryRow(
+		`INSERT INTO projects (name, key, description, admin, visibility, default_priority)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
 		projectDto.Name,
+		projectDto.Key,
 		projectDto.Description,
 		projectDto.AdminID,
+		projectDto.Visibility,
+		projectDto.DefaultPriority,
 	)
 
 	var projectID uint64
 	if err := result.Scan(&projectID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, projectConstraintError(err)
+	}
+
+	if err := createDefaultWorkflow(tx, projectID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := createDefaultIssueKinds(tx, projectID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	event.ProjectID = projectID
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		r.log.Error(err)
 		return 0, err
 	}
@@ -48,10 +216,13 @@ func (r *ProjectRepository) CreateProject(projectDto *dto.CreateProjectDto) (uin
 }
 
 func (r *ProjectRepository) GetProjectById(id uint64) (*models.Project, error) {
-	result := r.db.QueryRow("SELECT * FROM projects WHERE id = $1", id)
+	result := r.db.QueryRow(
+		"SELECT "+projectColumns+" FROM projects WHERE id = $1 AND deleted_at IS NULL",
+		id,
+	)
 
-	project := new(models.Project)
-	if err := result.Scan(&project.ID, &project.Name, &project.Description, &project.AdminID); err != nil {
+	project, err := scanProject(result)
+	if err != nil {
 		r.log.Error(err)
 		return nil, err
 	}
@@ -60,44 +231,325 @@ func (r *ProjectRepository) GetProjectById(id uint64) (*models.Project, error) {
 	return project, nil
 }
 
-func (r *ProjectRepository) DeleteProject(projectID, userID uint64) error {
+func (r *ProjectRepository) DeleteProject(projectID, userID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(projectID, userID); err != nil {
 		return err
 	}
 
-	_, err := r.db.Exec("DELETE FROM projects WHERE id = $1", projectID)
+	_, err := execWithEvent(
+		r.db,
+		event,
+		"UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL",
+		time.Now().UTC(),
+		projectID,
+	)
 
 	if err != nil {
 		r.log.Error(err)
+		return err
 	}
+	r.log.Infof("Move project to trash: id = %d", projectID)
 
-	return err
+	return nil
+}
+
+func (r *ProjectRepository) RestoreProject(projectID, userID uint64, event *models.Event) error {
+	result, err := execWithEvent(
+		r.db,
+		event,
+		"UPDATE projects SET deleted_at = NULL WHERE id = $1 AND admin = $2 AND deleted_at IS NOT NULL",
+		projectID,
+		userID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return projectConstraintError(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrProjectNotInTrash
+	}
+	r.log.Infof("Restore project: id = %d", projectID)
+
+	return nil
+}
+
+func (r *ProjectRepository) GetTrash(userID uint64) ([]*models.Project, error) {
+	rows, err := r.db.Query(
+		"SELECT "+projectColumns+" FROM projects WHERE admin = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC",
+		userID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return r.scanProjects(rows)
+}
+
+// PurgeDeletedProjects removes the projects deleted before the given time for
+// good. It returns how many there were and the keys the contents of their
+// attachments are stored under, for the caller to remove.
+func (r *ProjectRepository) PurgeDeletedProjects(deletedBefore time.Time) (int64, []string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, nil, err
+	}
+
+	projectIDs, err := r.lockPurgedProjects(tx, deletedBefore)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	storageKeys, err := attachmentStorageKeys(
+		tx,
+		`SELECT attachments.storage_key FROM attachments JOIN tasks ON tasks.id = attachments.task_id
+		WHERE tasks.project_id = ANY($1)`,
+		pq.Array(projectIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	result, err := tx.Exec("DELETE FROM projects WHERE id = ANY($1)", pq.Array(projectIDs))
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, nil, err
+	}
+
+	return count, storageKeys, nil
 }
 
-func (r *ProjectRepository) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64) error {
+// lockPurgedProjects locks the projects deleted before the given time, which
+// keeps them from being restored while they are purged.
+func (r *ProjectRepository) lockPurgedProjects(tx *sql.Tx, deletedBefore time.Time) ([]uint64, error) {
+	rows, err := tx.Query(
+		"SELECT id FROM projects WHERE deleted_at IS NOT NULL AND deleted_at < $1 FOR UPDATE",
+		deletedBefore,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	projectIDs := make([]uint64, 0)
+	for rows.Next() {
+		var projectID uint64
+		if err := rows.Scan(&projectID); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return projectIDs, nil
+}
+
+func (r *ProjectRepository) ArchiveProject(projectID, userID uint64, event *models.Event) error {
+	if err := r.admin.IsAdmin(projectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(projectID); err != nil {
+		return err
+	}
+
+	_, err := execWithEvent(r.db, event, "UPDATE projects SET archived_at = $1 WHERE id = $2", time.Now().UTC(), projectID)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Archive project: id = %d", projectID)
+
+	return nil
+}
+
+func (r *ProjectRepository) UnarchiveProject(projectID, userID uint64, event *models.Event) error {
+	if err := r.admin.IsAdmin(projectID, userID); err != nil {
+		return err
+	}
+
+	_, err := execWithEvent(r.db, event, "UPDATE projects SET archived_at = NULL WHERE id = $1", projectID)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Unarchive project: id = %d", projectID)
+
+	return nil
+}
+
+func (r *ProjectRepository) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(projectData.ProjectID, userID); err != nil {
 		return err
 	}
 
-	_, err := r.db.Exec(
-		"UPDATE projects SET description = $1 WHERE id = $2",
-		projectData.Description,
+	if err := r.state.IsWritable(projectData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	project, err := scanProject(tx.QueryRow(
+		"SELECT "+projectColumns+" FROM projects WHERE id = $1 FOR UPDATE",
 		projectData.ProjectID,
+	))
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	changes := projectChanges(project, projectData)
+	if len(changes) == 0 {
+		return tx.Commit()
+	}
+
+	sets := make([]string, 0, len(changes))
+	args := make([]interface{}, 0, len(changes)+1)
+	for i, change := range changes {
+		sets = append(sets, fmt.Sprintf("%s = $%d", change.column, i+1))
+		args = append(args, change.value)
+	}
+	args = append(args, projectData.ProjectID)
+
+	_, err = tx.Exec(
+		fmt.Sprintf("UPDATE projects SET %s WHERE id = $%d", strings.Join(sets, ", "), len(args)),
+		args...,
 	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return projectConstraintError(err)
+	}
+
+	changedAt := time.Now().UTC()
+	for _, change := range changes {
+		if change.column != "key" {
+			continue
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO project_key_history (key, project_id, replaced_at) VALUES ($1, $2, $3)
+			ON CONFLICT (key) DO UPDATE SET project_id = EXCLUDED.project_id, replaced_at = EXCLUDED.replaced_at`,
+			change.oldValue,
+			projectData.ProjectID,
+			changedAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if err := addProjectAudit(tx, projectData.ProjectID, userID, change, changedAt); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Update project: id = %d, changed fields = %d", projectData.ProjectID, len(changes))
+
+	return nil
+}
+
+func (r *ProjectRepository) GetProjectAudit(projectID, userID uint64) ([]*models.ProjectAudit, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
 
+	rows, err := r.db.Query(
+		`SELECT id, project_id, actor_id, field, old_value, new_value, changed_at
+		FROM projects_audit WHERE project_id = $1 ORDER BY changed_at DESC, id DESC`,
+		projectID,
+	)
 	if err != nil {
 		r.log.Error(err)
+		return nil, err
 	}
+	defer rows.Close()
 
-	return err
+	entries := make([]*models.ProjectAudit, 0)
+	for rows.Next() {
+		entry := new(models.ProjectAudit)
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ProjectID,
+			&entry.ActorID,
+			&entry.Field,
+			&entry.OldValue,
+			&entry.NewValue,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
 }
 
-func (r *ProjectRepository) AddMember(memberData *dto.AddMemberDto, userID uint64) error {
+func (r *ProjectRepository) AddMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(memberData.ProjectID, userID); err != nil {
 		return err
 	}
 
-	_, err := r.db.Exec(
+	if err := r.state.IsWritable(memberData.ProjectID); err != nil {
+		return err
+	}
+
+	_, err := execWithEvent(
+		r.db,
+		event,
 		"INSERT INTO projects_members (project_id, member_id) VALUES ($1, $2)",
 		memberData.ProjectID,
 		memberData.MemberID,
@@ -110,12 +562,18 @@ func (r *ProjectRepository) AddMember(memberData *dto.AddMemberDto, userID uint6
 	return err
 }
 
-func (r *ProjectRepository) DeleteMember(memberData *dto.AddMemberDto, userID uint64) error {
+func (r *ProjectRepository) DeleteMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(memberData.ProjectID, userID); err != nil {
 		return err
 	}
 
-	_, err := r.db.Exec(
+	if err := r.state.IsWritable(memberData.ProjectID); err != nil {
+		return err
+	}
+
+	_, err := execWithEvent(
+		r.db,
+		event,
 		"DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2",
 		memberData.ProjectID,
 		memberData.MemberID,
@@ -156,12 +614,18 @@ func (r *ProjectRepository) GetMembers(projectID, userID uint64) ([]*models.User
 	return members, nil
 }
 
-func (r *ProjectRepository) LeaveProject(projectID, userID uint64) error {
+func (r *ProjectRepository) LeaveProject(projectID, userID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(projectID, userID); err == nil {
 		return ErrNoRights
 	}
 
-	_, err := r.db.Exec("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2", projectID, userID)
+	_, err := execWithEvent(
+		r.db,
+		event,
+		"DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2",
+		projectID,
+		userID,
+	)
 	if err != nil {
 		r.log.Error(err)
 	}
@@ -169,11 +633,15 @@ func (r *ProjectRepository) LeaveProject(projectID, userID uint64) error {
 	return err
 }
 
-func (r *ProjectRepository) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64) error {
+func (r *ProjectRepository) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64, event *models.Event) error {
 	if err := r.admin.IsAdmin(newAdminData.ProjectID, adminID); err != nil {
 		return err
 	}
 
+	if err := r.state.IsWritable(newAdminData.ProjectID); err != nil {
+		return err
+	}
+
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
@@ -183,10 +651,21 @@ func (r *ProjectRepository) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID u
 	if err != nil {
 		r.log.Error(err)
 		tx.Rollback()
-		return err
+		return projectConstraintError(err)
 	}
 	r.log.Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
 
+	change := projectChange{
+		field:    "admin",
+		oldValue: strconv.FormatUint(adminID, 10),
+		newValue: strconv.FormatUint(newAdminData.NewAdminID, 10),
+	}
+	if err := addProjectAudit(tx, newAdminData.ProjectID, adminID, change, time.Now().UTC()); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
 	_, err = tx.Exec("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2", newAdminData.ProjectID, newAdminData.NewAdminID)
 	if err != nil {
 		r.log.Error(err)
@@ -201,6 +680,12 @@ func (r *ProjectRepository) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID u
 		return err
 	}
 
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		r.log.Error(err)
@@ -211,9 +696,9 @@ func (r *ProjectRepository) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID u
 
 func (r *ProjectRepository) GetProjectsByUserId(id uint64) ([]*models.Project, error) {
 	rows, err := r.db.Query(
-		`SELECT * FROM projects WHERE projects.id IN (
-			SELECT project_id FROM projects_members WHERE member_id = $1
-		) UNION SELECT * FROM projects WHERE admin = $1`,
+		"SELECT "+projectColumns+` FROM projects WHERE (
+			projects.id IN (SELECT project_id FROM projects_members WHERE member_id = $1) OR admin = $1
+		) AND archived_at IS NULL AND deleted_at IS NULL`,
 		id,
 	)
 	if err != nil {
@@ -221,10 +706,31 @@ func (r *ProjectRepository) GetProjectsByUserId(id uint64) ([]*models.Project, e
 		return nil, err
 	}
 
+	return r.scanProjects(rows)
+}
+
+func (r *ProjectRepository) GetArchivedProjectsByUserId(id uint64) ([]*models.Project, error) {
+	rows, err := r.db.Query(
+		"SELECT "+projectColumns+` FROM projects WHERE (
+			projects.id IN (SELECT project_id FROM projects_members WHERE member_id = $1) OR admin = $1
+		) AND archived_at IS NOT NULL AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return r.scanProjects(rows)
+}
+
+func (r *ProjectRepository) scanProjects(rows *sql.Rows) ([]*models.Project, error) {
+	defer rows.Close()
+
 	projects := make([]*models.Project, 0)
 	for rows.Next() {
-		project := new(models.Project)
-		if err := rows.Scan(&project.ID, &project.Name, &project.Description, &project.AdminID); err != nil {
+		project, err := scanProject(rows)
+		if err != nil {
 			r.log.Error(err)
 			return nil, err
 		}
@@ -1,12 +1,15 @@
 package repository
 
 import (
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
@@ -18,6 +21,7 @@ import (
 func Test_CreateProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectCreated, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -29,17 +33,73 @@ func Test_CreateProject(t *testing.T) {
 		{
 			name: "Error",
 			projectData: &dto.CreateProjectDto{
-				Name:        "name",
-				Description: "description",
-				AdminID:     1,
+				Name:            "name",
+				Key:             "KEY",
+				Description:     "description",
+				AdminID:         1,
+				Visibility:      "private",
+				DefaultPriority: "medium",
 			},
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO projects (name, key, description, admin, visibility, default_priority)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					),
+				).WithArgs(
+					projectData.Name,
+					projectData.Key,
+					projectData.Description,
+					projectData.AdminID,
+					projectData.Visibility,
+					projectData.DefaultPriority,
+				).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "Error cannot create default workflow",
+			projectData: &dto.CreateProjectDto{
+				Name:            "name",
+				Key:             "KEY",
+				Description:     "description",
+				AdminID:         1,
+				Visibility:      "private",
+				DefaultPriority: "medium",
+			},
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				projectID := uint64(1)
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(projectID)
+				mock.ExpectBegin()
 				mock.ExpectQuery(
-					regexp.QuoteMeta("INSERT INTO projects (name, description, admin) VALUES ($1, $2, $3) RETURNING id"),
-				).WithArgs(projectData.Name, projectData.Description, projectData.AdminID).WillReturnError(err)
+					regexp.QuoteMeta(
+						`INSERT INTO projects (name, key, description, admin, visibility, default_priority)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					),
+				).WithArgs(
+					projectData.Name,
+					projectData.Key,
+					projectData.Description,
+					projectData.AdminID,
+					projectData.Visibility,
+					projectData.DefaultPriority,
+				).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO statuses (project_id, name, category, position, is_initial) VALUES"),
+				).WithArgs(projectID).WillReturnError(err)
+				mock.ExpectRollback()
 				log.EXPECT().Error(err)
 
 				return &ProjectRepository{db: db, log: log}
@@ -50,9 +110,12 @@ func Test_CreateProject(t *testing.T) {
 		{
 			name: "OK",
 			projectData: &dto.CreateProjectDto{
-				Name:        "name",
-				Description: "description",
-				AdminID:     1,
+				Name:            "name",
+				Key:             "KEY",
+				Description:     "description",
+				AdminID:         1,
+				Visibility:      "private",
+				DefaultPriority: "medium",
 			},
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectRepository {
 				log := mock_log.NewMockLog(c)
@@ -60,9 +123,31 @@ func Test_CreateProject(t *testing.T) {
 
 				projectID := uint64(1)
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(projectID)
+				mock.ExpectBegin()
 				mock.ExpectQuery(
-					regexp.QuoteMeta("INSERT INTO projects (name, description, admin) VALUES ($1, $2, $3) RETURNING id"),
-				).WithArgs(projectData.Name, projectData.Description, projectData.AdminID).WillReturnRows(rows)
+					regexp.QuoteMeta(
+						`INSERT INTO projects (name, key, description, admin, visibility, default_priority)
+						VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+					),
+				).WithArgs(
+					projectData.Name,
+					projectData.Key,
+					projectData.Description,
+					projectData.AdminID,
+					projectData.Visibility,
+					projectData.DefaultPriority,
+				).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO statuses (project_id, name, category, position, is_initial) VALUES"),
+				).WithArgs(projectID).WillReturnResult(sqlmock.NewResult(1, 4))
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO status_transitions (from_status_id, to_status_id)"),
+				).WithArgs(projectID).WillReturnResult(sqlmock.NewResult(1, 12))
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO issue_kinds (project_id, name, icon, position, is_default, is_bug) VALUES"),
+				).WithArgs(projectID).WillReturnResult(sqlmock.NewResult(1, 3))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 				log.EXPECT().Infof("Create project: id = %d", projectID)
 
 				return &ProjectRepository{db: db, log: log}
@@ -78,7 +163,7 @@ func Test_CreateProject(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.projectData)
-			res, err := repo.CreateProject(test.projectData)
+			res, err := repo.CreateProject(test.projectData, event)
 
 			require.Equal(t, test.expectedResult, res)
 			require.Equal(t, test.expectedError, err)
@@ -105,7 +190,9 @@ func Test_GetProjectById(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT * FROM projects WHERE id = $1"),
+					regexp.QuoteMeta(
+						"SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE id = $1 AND deleted_at IS NULL",
+					),
 				).WithArgs(id).WillReturnError(err)
 				log.EXPECT().Error(err)
 
@@ -121,20 +208,27 @@ func Test_GetProjectById(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "admin"}).AddRow(uint64(1), "name", "", uint64(1))
+				rows := sqlmock.NewRows([]string{"id", "name", "key", "description", "admin", "visibility", "default_priority", "required_bug_fields", "max_task_depth", "archived_at", "deleted_at"}).AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, nil)
 
 				mock.ExpectQuery(
-					regexp.QuoteMeta("SELECT * FROM projects WHERE id = $1"),
+					regexp.QuoteMeta(
+						"SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE id = $1 AND deleted_at IS NULL",
+					),
 				).WithArgs(id).WillReturnRows(rows)
 				log.EXPECT().Infof("Get project: id = %d", uint64(1))
 
 				return &ProjectRepository{db: db, log: log}
 			},
 			expectedResult: &models.Project{
-				ID:          1,
-				Name:        "name",
-				Description: "",
-				AdminID:     1,
+				ID:                1,
+				Name:              "name",
+				Key:               "KEY",
+				Description:       "",
+				AdminID:           1,
+				Visibility:        "private",
+				DefaultPriority:   "medium",
+				RequiredBugFields: []string{},
+				MaxTaskDepth:      3,
 			},
 			expectedError: nil,
 		},
@@ -157,6 +251,7 @@ func Test_GetProjectById(t *testing.T) {
 func Test_DeleteProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectDeleted, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -189,9 +284,35 @@ func Test_DeleteProject(t *testing.T) {
 
 				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
-					regexp.QuoteMeta("DELETE FROM projects WHERE id = $1"),
-				).WithArgs(projectID).WillReturnError(err)
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"),
+				).WithArgs(sqlmock.AnyArg(), projectID).WillReturnError(err)
+				mock.ExpectRollback()
+
+				log.EXPECT().Error(err).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin}
+			},
+			expectedError: err,
+		},
+		{
+			name:      "Error cannot save event",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+
+				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"),
+				).WithArgs(sqlmock.AnyArg(), projectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnError(err)
+				mock.ExpectRollback()
 
 				log.EXPECT().Error(err).Return()
 
@@ -199,6 +320,28 @@ func Test_DeleteProject(t *testing.T) {
 			},
 			expectedError: err,
 		},
+		{
+			name:      "OK already in trash",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+
+				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"),
+				).WithArgs(sqlmock.AnyArg(), projectID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Move project to trash: id = %d", projectID)
+
+				return &ProjectRepository{db: db, log: log, admin: admin}
+			},
+			expectedError: nil,
+		},
 		{
 			name:      "OK",
 			projectID: 1,
@@ -206,14 +349,19 @@ func Test_DeleteProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
 
 				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
-					regexp.QuoteMeta("DELETE FROM projects WHERE id = $1"),
-				).WithArgs(projectID).WillReturnResult(sqlmock.NewResult(1, 1))
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"),
+				).WithArgs(sqlmock.AnyArg(), projectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Move project to trash: id = %d", projectID)
 
-				return &ProjectRepository{db: db, log: nil, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin}
 			},
 			expectedError: nil,
 		},
@@ -225,7 +373,7 @@ func Test_DeleteProject(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.projectID, test.userID)
-			err := repo.DeleteProject(test.projectID, test.userID)
+			err := repo.DeleteProject(test.projectID, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -235,6 +383,14 @@ func Test_DeleteProject(t *testing.T) {
 func Test_UpdateProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectUpdated, 1, 1, 0, nil)
+	name := "new name"
+	description := "description"
+	visibility := "private"
+	requiredBugFields := []string{"severity", "stepsToReproduce"}
+	maxTaskDepth := 5
+	key := "API"
+	projectColumnNames := []string{"id", "name", "key", "description", "admin", "visibility", "default_priority", "required_bug_fields", "max_task_depth", "archived_at", "deleted_at"}
 
 	tests := []struct {
 		name          string
@@ -257,120 +413,282 @@ func Test_UpdateProject(t *testing.T) {
 			expectedError: err,
 		},
 		{
-			name:        "Error cannot update project",
-			projectData: &dto.UpdateProjectDto{ProjectID: 1},
+			name:        "Error cannot get project",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Name: &name},
 			userID:      1,
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
-				mock.ExpectExec(
-					regexp.QuoteMeta("UPDATE projects SET description = $1 WHERE id = $2"),
-				).WithArgs(projectData.Description, projectData.ProjectID).WillReturnError(err)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnError(err)
+				mock.ExpectRollback()
 
 				log.EXPECT().Error(err).Return()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
 		{
-			name:        "OK",
-			projectData: &dto.UpdateProjectDto{ProjectID: 1},
+			name:        "OK nothing changed",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Description: &description, Visibility: &visibility},
 			userID:      1,
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
-				mock.ExpectExec(
-					regexp.QuoteMeta("UPDATE projects SET description = $1 WHERE id = $2"),
-				).WithArgs(projectData.Description, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "description", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
+				mock.ExpectCommit()
 
-				return &ProjectRepository{db: db, log: nil, admin: admin}
+				return &ProjectRepository{db: db, admin: admin, state: state}
 			},
 			expectedError: nil,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			c := gomock.NewController(t)
-			defer c.Finish()
+		{
+			name:        "Error project name taken",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Name: &name},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: projectsNameConstraint}
 
-			repo := test.mockBehaviour(c, test.projectData, test.userID)
-			err := repo.UpdateProject(test.projectData, test.userID)
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
-			require.Equal(t, test.expectedError, err)
-		})
-	}
-}
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "description", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET name = $1 WHERE id = $2"),
+				).WithArgs(name, projectData.ProjectID).WillReturnError(uniqueErr)
+				mock.ExpectRollback()
 
-func Test_AddMember(t *testing.T) {
-	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository
-	err := errors.New("error")
+				log.EXPECT().Error(uniqueErr).Return()
 
-	tests := []struct {
-		name          string
-		memberData    *dto.AddMemberDto
-		userID        uint64
-		mockBehaviour mockBehaviour
-		expectedError error
-	}{
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrProjectNameTaken,
+		},
 		{
-			name:       "Error in admin",
-			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
-			userID:     1,
-			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+			name:        "Error cannot write audit",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Name: &name},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
-				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
-				return &ProjectRepository{admin: admin}
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "description", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET name = $1 WHERE id = $2"),
+				).WithArgs(name, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(projectData.ProjectID, userID, "name", "name", name, sqlmock.AnyArg()).WillReturnError(err)
+				mock.ExpectRollback()
+
+				log.EXPECT().Error(err).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
 		{
-			name:       "Error cannot add member to project",
-			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
-			userID:     1,
-			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+			name:        "OK",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Name: &name, Description: &description},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
+
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET name = $1, description = $2 WHERE id = $3"),
+				).WithArgs(name, description, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(projectData.ProjectID, userID, "name", "name", name, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(projectData.ProjectID, userID, "description", "", description, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				log.EXPECT().Infof("Update project: id = %d, changed fields = %d", projectData.ProjectID, 2).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+		{
+			name:        "OK key renamed",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, Key: &key},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
-				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
 				mock.ExpectExec(
-					regexp.QuoteMeta("INSERT INTO projects_members (project_id, member_id) VALUES ($1, $2)"),
-				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnError(err)
+					regexp.QuoteMeta("UPDATE projects SET key = $1 WHERE id = $2"),
+				).WithArgs(key, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO project_key_history (key, project_id, replaced_at) VALUES ($1, $2, $3)
+						ON CONFLICT (key) DO UPDATE SET project_id = EXCLUDED.project_id, replaced_at = EXCLUDED.replaced_at`,
+					),
+				).WithArgs("KEY", projectData.ProjectID, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(projectData.ProjectID, userID, "key", "KEY", key, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 
-				log.EXPECT().Error(err).Return()
+				log.EXPECT().Infof("Update project: id = %d, changed fields = %d", projectData.ProjectID, 1).Return()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
-			expectedError: err,
+			expectedError: nil,
 		},
 		{
-			name:       "OK",
-			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
-			userID:     1,
-			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+			name:        "OK required bug fields",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, RequiredBugFields: &requiredBugFields},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
+
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{severity}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET required_bug_fields = $1 WHERE id = $2"),
+				).WithArgs(`{"severity","stepsToReproduce"}`, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(
+					projectData.ProjectID, userID, "requiredBugFields", "severity", "severity,stepsToReproduce", sqlmock.AnyArg(),
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				log.EXPECT().Infof("Update project: id = %d, changed fields = %d", projectData.ProjectID, 1).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+		{
+			name:        "OK max task depth",
+			projectData: &dto.UpdateProjectDto{ProjectID: 1, MaxTaskDepth: &maxTaskDepth},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
-				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				admin.EXPECT().IsAdmin(projectData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectData.ProjectID).Return(nil)
 
+				rows := sqlmock.NewRows(projectColumnNames).
+					AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, nil)
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT " + projectColumns + " FROM projects WHERE id = $1 FOR UPDATE"),
+				).WithArgs(projectData.ProjectID).WillReturnRows(rows)
 				mock.ExpectExec(
-					regexp.QuoteMeta("INSERT INTO projects_members (project_id, member_id) VALUES ($1, $2)"),
-				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnResult(sqlmock.NewResult(1, 1))
+					regexp.QuoteMeta("UPDATE projects SET max_task_depth = $1 WHERE id = $2"),
+				).WithArgs(maxTaskDepth, projectData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(
+					projectData.ProjectID, userID, "maxTaskDepth", "3", "5", sqlmock.AnyArg(),
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				log.EXPECT().Infof("Update project: id = %d, changed fields = %d", projectData.ProjectID, 1).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: nil,
 		},
@@ -381,23 +699,214 @@ func Test_AddMember(t *testing.T) {
 			c := gomock.NewController(t)
 			defer c.Finish()
 
-			repo := test.mockBehaviour(c, test.memberData, test.userID)
-			err := repo.AddMember(test.memberData, test.userID)
+			repo := test.mockBehaviour(c, test.projectData, test.userID)
+			err := repo.UpdateProject(test.projectData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
 	}
 }
 
-func Test_DeleteMember(t *testing.T) {
-	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository
+func Test_GetProjectAudit(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository
 	err := errors.New("error")
+	changedAt := time.Now()
 
 	tests := []struct {
-		name          string
-		memberData    *dto.AddMemberDto
-		userID        uint64
-		mockBehaviour mockBehaviour
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.ProjectAudit
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &ProjectRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error cannot get audit",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, actor_id, field, old_value, new_value, changed_at
+						FROM projects_audit WHERE project_id = $1 ORDER BY changed_at DESC, id DESC`,
+					),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err).Return()
+
+				return &ProjectRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "actor_id", "field", "old_value", "new_value", "changed_at"}).
+					AddRow(uint64(1), projectID, userID, "name", "old", "new", changedAt)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, actor_id, field, old_value, new_value, changed_at
+						FROM projects_audit WHERE project_id = $1 ORDER BY changed_at DESC, id DESC`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &ProjectRepository{db: db, member: member}
+			},
+			expectedResult: []*models.ProjectAudit{{
+				ID:        1,
+				ProjectID: 1,
+				ActorID:   sql.NullInt64{Int64: 1, Valid: true},
+				Field:     "name",
+				OldValue:  sql.NullString{String: "old", Valid: true},
+				NewValue:  sql.NullString{String: "new", Valid: true},
+				ChangedAt: changedAt,
+			}},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetProjectAudit(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddMember(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository
+	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberAdded, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		memberData    *dto.AddMemberDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error in admin",
+			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(err)
+
+				return &ProjectRepository{admin: admin}
+			},
+			expectedError: err,
+		},
+		{
+			name:       "Error cannot add member to project",
+			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(memberData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO projects_members (project_id, member_id) VALUES ($1, $2)"),
+				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnError(err)
+				mock.ExpectRollback()
+
+				log.EXPECT().Error(err).Return()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:       "OK",
+			memberData: &dto.AddMemberDto{ProjectID: 1, MemberID: 2},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(memberData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("INSERT INTO projects_members (project_id, member_id) VALUES ($1, $2)"),
+				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "member", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.memberData, test.userID)
+			err := repo.AddMember(test.memberData, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteMember(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectRepository
+	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberRemoved, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		memberData    *dto.AddMemberDto
+		userID        uint64
+		mockBehaviour mockBehaviour
 		expectedError error
 	}{
 		{
@@ -421,16 +930,20 @@ func Test_DeleteMember(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(memberData.ProjectID).Return(nil)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnError(err)
+				mock.ExpectRollback()
 
 				log.EXPECT().Error(err).Return()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -442,16 +955,21 @@ func Test_DeleteMember(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(memberData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(memberData.ProjectID).Return(nil)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(memberData.ProjectID, memberData.MemberID).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "member", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 
 				log.EXPECT().Infof("Delete member with id=%d from project with id=%d", memberData.MemberID, memberData.ProjectID)
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: nil,
 		},
@@ -463,7 +981,7 @@ func Test_DeleteMember(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.memberData, test.userID)
-			err := repo.DeleteMember(test.memberData, test.userID)
+			err := repo.DeleteMember(test.memberData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -567,6 +1085,7 @@ func Test_GetMembers(t *testing.T) {
 func Test_LeaveProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberLeft, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -599,9 +1118,11 @@ func Test_LeaveProject(t *testing.T) {
 
 				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(projectID, userID).WillReturnError(err)
+				mock.ExpectRollback()
 
 				log.EXPECT().Error(err).Return()
 
@@ -619,9 +1140,12 @@ func Test_LeaveProject(t *testing.T) {
 
 				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
 
+				mock.ExpectBegin()
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(projectID, userID).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAddEvent(mock, "member", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 
 				return &ProjectRepository{db: db, log: nil, admin: admin}
 			},
@@ -635,7 +1159,7 @@ func Test_LeaveProject(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.projectID, test.userID)
-			err := repo.LeaveProject(test.projectID, test.userID)
+			err := repo.LeaveProject(test.projectID, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -645,6 +1169,7 @@ func Test_LeaveProject(t *testing.T) {
 func Test_SetNewAdmin(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, newAdminData *dto.NewAdminDto, adminID uint64) *ProjectRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectAdminChanged, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -674,12 +1199,14 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin().WillReturnError(err)
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -691,8 +1218,10 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin()
 				mock.ExpectExec(
@@ -705,7 +1234,44 @@ func Test_SetNewAdmin(t *testing.T) {
 				log.EXPECT().Error(err)
 				mock.ExpectRollback()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:         "Error cannot write audit",
+			newAdminData: &dto.NewAdminDto{ProjectID: 1, NewAdminID: 2},
+			userID:       1,
+			mockBehaviour: func(c *gomock.Controller, newAdminData *dto.NewAdminDto, adminID uint64) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET admin = $1 WHERE id = $2"),
+				).WithArgs(
+					newAdminData.NewAdminID,
+					newAdminData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				log.EXPECT().Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(newAdminData.ProjectID, adminID, "admin", "1", "2", sqlmock.AnyArg()).WillReturnError(err)
+
+				log.EXPECT().Error(err)
+				mock.ExpectRollback()
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -717,8 +1283,10 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin()
 				mock.ExpectExec(
@@ -730,6 +1298,13 @@ func Test_SetNewAdmin(t *testing.T) {
 
 				log.EXPECT().Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
 
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(newAdminData.ProjectID, adminID, "admin", "1", "2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(
@@ -740,7 +1315,7 @@ func Test_SetNewAdmin(t *testing.T) {
 				log.EXPECT().Error(err)
 				mock.ExpectRollback()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -752,8 +1327,10 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin()
 				mock.ExpectExec(
@@ -765,6 +1342,13 @@ func Test_SetNewAdmin(t *testing.T) {
 
 				log.EXPECT().Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
 
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(newAdminData.ProjectID, adminID, "admin", "1", "2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(
@@ -782,7 +1366,7 @@ func Test_SetNewAdmin(t *testing.T) {
 				log.EXPECT().Error(err)
 				mock.ExpectRollback()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -794,8 +1378,10 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin()
 				mock.ExpectExec(
@@ -807,6 +1393,13 @@ func Test_SetNewAdmin(t *testing.T) {
 
 				log.EXPECT().Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
 
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(newAdminData.ProjectID, adminID, "admin", "1", "2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(
@@ -821,10 +1414,11 @@ func Test_SetNewAdmin(t *testing.T) {
 					adminID,
 				).WillReturnResult(sqlmock.NewResult(1, 1))
 
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit().WillReturnError(err)
 				log.EXPECT().Error(err)
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -836,8 +1430,10 @@ func Test_SetNewAdmin(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(newAdminData.ProjectID, adminID).Return(nil)
+				state.EXPECT().IsWritable(newAdminData.ProjectID).Return(nil)
 
 				mock.ExpectBegin()
 				mock.ExpectExec(
@@ -849,6 +1445,13 @@ func Test_SetNewAdmin(t *testing.T) {
 
 				log.EXPECT().Infof("Set new admin = %d in project = %d", newAdminData.NewAdminID, newAdminData.ProjectID)
 
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO projects_audit (project_id, actor_id, field, old_value, new_value, changed_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`,
+					),
+				).WithArgs(newAdminData.ProjectID, adminID, "admin", "1", "2", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+
 				mock.ExpectExec(
 					regexp.QuoteMeta("DELETE FROM projects_members WHERE project_id = $1 AND member_id = $2"),
 				).WithArgs(
@@ -863,9 +1466,10 @@ func Test_SetNewAdmin(t *testing.T) {
 					adminID,
 				).WillReturnResult(sqlmock.NewResult(1, 1))
 
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 
-				return &ProjectRepository{db: db, log: log, admin: admin}
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: nil,
 		},
@@ -877,7 +1481,7 @@ func Test_SetNewAdmin(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.newAdminData, test.userID)
-			err := repo.SetNewAdmin(test.newAdminData, test.userID)
+			err := repo.SetNewAdmin(test.newAdminData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -904,9 +1508,9 @@ func Test_getProjectsByUserId(t *testing.T) {
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT * FROM projects WHERE projects.id IN (
-							SELECT project_id FROM projects_members WHERE member_id = $1
-						) UNION SELECT * FROM projects WHERE admin = $1`,
+						`SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE (
+							projects.id IN (SELECT project_id FROM projects_members WHERE member_id = $1) OR admin = $1
+						) AND archived_at IS NULL AND deleted_at IS NULL`,
 					),
 				).WithArgs(id).WillReturnError(err)
 				log.EXPECT().Error(err)
@@ -923,23 +1527,28 @@ func Test_getProjectsByUserId(t *testing.T) {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "admin"}).AddRow(uint64(1), "name", "", uint64(1))
+				rows := sqlmock.NewRows([]string{"id", "name", "key", "description", "admin", "visibility", "default_priority", "required_bug_fields", "max_task_depth", "archived_at", "deleted_at"}).AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, nil)
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT * FROM projects WHERE projects.id IN (
-							SELECT project_id FROM projects_members WHERE member_id = $1
-						) UNION SELECT * FROM projects WHERE admin = $1`,
+						`SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE (
+							projects.id IN (SELECT project_id FROM projects_members WHERE member_id = $1) OR admin = $1
+						) AND archived_at IS NULL AND deleted_at IS NULL`,
 					),
 				).WithArgs(id).WillReturnRows(rows)
 
 				return &ProjectRepository{db: db, log: log}
 			},
 			expectedResult: []*models.Project{{
-				ID:          1,
-				Name:        "name",
-				Description: "",
-				AdminID:     1,
+				ID:                1,
+				Name:              "name",
+				Key:               "KEY",
+				Description:       "",
+				AdminID:           1,
+				Visibility:        "private",
+				DefaultPriority:   "medium",
+				RequiredBugFields: []string{},
+				MaxTaskDepth:      3,
 			}},
 			expectedError: nil,
 		},
@@ -958,3 +1567,344 @@ func Test_getProjectsByUserId(t *testing.T) {
 		})
 	}
 }
+
+func Test_RestoreProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository
+	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectRestored, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		projectID     uint64
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error cannot restore project",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = NULL WHERE id = $1 AND admin = $2 AND deleted_at IS NOT NULL"),
+				).WithArgs(projectID, userID).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err).Return()
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedError: err,
+		},
+		{
+			name:      "Error project name taken",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: projectsNameConstraint}
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = NULL WHERE id = $1 AND admin = $2 AND deleted_at IS NOT NULL"),
+				).WithArgs(projectID, userID).WillReturnError(uniqueErr)
+				mock.ExpectRollback()
+				log.EXPECT().Error(uniqueErr).Return()
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedError: ErrProjectNameTaken,
+		},
+		{
+			name:      "Error project is not in trash",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = NULL WHERE id = $1 AND admin = $2 AND deleted_at IS NOT NULL"),
+				).WithArgs(projectID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+
+				return &ProjectRepository{db: db}
+			},
+			expectedError: ErrProjectNotInTrash,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET deleted_at = NULL WHERE id = $1 AND admin = $2 AND deleted_at IS NOT NULL"),
+				).WithArgs(projectID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Restore project: id = %d", projectID)
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			err := repo.RestoreProject(test.projectID, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTrash(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, userID uint64) *ProjectRepository
+	err := errors.New("error")
+	deletedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Project
+		expectedError  error
+	}{
+		{
+			name:   "Error",
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE admin = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC",
+					),
+				).WithArgs(userID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:   "OK",
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, userID uint64) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "key", "description", "admin", "visibility", "default_priority", "required_bug_fields", "max_task_depth", "archived_at", "deleted_at"}).
+					AddRow(uint64(1), "name", "KEY", "", uint64(1), "private", "medium", "{}", 3, nil, deletedAt)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT id, name, key, description, admin, visibility, default_priority, required_bug_fields, max_task_depth, archived_at, deleted_at FROM projects WHERE admin = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC",
+					),
+				).WithArgs(userID).WillReturnRows(rows)
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Project{{
+				ID:                1,
+				Name:              "name",
+				Key:               "KEY",
+				AdminID:           1,
+				Visibility:        "private",
+				DefaultPriority:   "medium",
+				RequiredBugFields: []string{},
+				MaxTaskDepth:      3,
+				DeletedAt:         sql.NullTime{Time: deletedAt, Valid: true},
+			}},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.userID)
+			res, err := repo.GetTrash(test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_PurgeDeletedProjects(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, deletedBefore time.Time) *ProjectRepository
+	err := errors.New("error")
+	lockQuery := "SELECT id FROM projects WHERE deleted_at IS NOT NULL AND deleted_at < $1 FOR UPDATE"
+	keysQuery := `SELECT attachments.storage_key FROM attachments JOIN tasks ON tasks.id = attachments.task_id
+		WHERE tasks.project_id = ANY($1)`
+
+	tests := []struct {
+		name                string
+		deletedBefore       time.Time
+		mockBehaviour       mockBehaviour
+		expectedResult      int64
+		expectedStorageKeys []string
+		expectedError       error
+	}{
+		{
+			name:          "Error",
+			deletedBefore: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			mockBehaviour: func(c *gomock.Controller, deletedBefore time.Time) *ProjectRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(deletedBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+				mock.ExpectQuery(regexp.QuoteMeta(keysQuery)).
+					WithArgs("{1}").
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key"}))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM projects WHERE id = ANY($1)")).
+					WithArgs("{1}").
+					WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &ProjectRepository{db: db, log: log}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name:          "OK",
+			deletedBefore: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			mockBehaviour: func(c *gomock.Controller, deletedBefore time.Time) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(deletedBefore).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(1)).AddRow(uint64(2)).AddRow(uint64(3)))
+				mock.ExpectQuery(regexp.QuoteMeta(keysQuery)).
+					WithArgs("{1,2,3}").
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("tasks/1/a").AddRow("tasks/4/b"))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM projects WHERE id = ANY($1)")).
+					WithArgs("{1,2,3}").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+
+				return &ProjectRepository{db: db}
+			},
+			expectedResult:      3,
+			expectedStorageKeys: []string{"tasks/1/a", "tasks/4/b"},
+			expectedError:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.deletedBefore)
+			res, storageKeys, err := repo.PurgeDeletedProjects(test.deletedBefore)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedStorageKeys, storageKeys)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_ArchiveProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository
+	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectArchived, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		projectID     uint64
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error in admin",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &ProjectRepository{admin: admin}
+			},
+			expectedError: err,
+		},
+		{
+			name:      "Error project is already archived",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectID).Return(ErrProjectArchived)
+
+				return &ProjectRepository{admin: admin, state: state}
+			},
+			expectedError: ErrProjectArchived,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				log := mock_log.NewMockLog(c)
+
+				admin.EXPECT().IsAdmin(projectID, userID).Return(nil)
+				state.EXPECT().IsWritable(projectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE projects SET archived_at = $1 WHERE id = $2"),
+				).WithArgs(sqlmock.AnyArg(), projectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAddEvent(mock, "project", "project-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Archive project: id = %d", projectID)
+
+				return &ProjectRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			err := repo.ArchiveProject(test.projectID, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+type ReleaseNotesRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewReleaseNotesRepo(db *sql.DB, log log.Log, admin admin, member member, state state) ReleaseNotes {
+	return &ReleaseNotesRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+// GetReleaseNotes collects the done tasks fixed in the version, grouped by
+// issue kind in the order the project lists its kinds.
+func (r *ReleaseNotesRepository) GetReleaseNotes(projectID, versionID, userID uint64) (*models.ReleaseNotes, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
+
+	notes := &models.ReleaseNotes{Version: new(models.Version), Groups: make([]*models.ReleaseNotesGroup, 0)}
+	err := r.db.QueryRow(
+		`SELECT versions.id, versions.project_id, versions.name, versions.description, versions.release_date,
+		versions.released, versions.archived, projects.release_notes_template
+		FROM versions JOIN projects ON projects.id = versions.project_id
+		WHERE versions.id = $1 AND versions.project_id = $2`,
+		versionID,
+		projectID,
+	).Scan(
+		&notes.Version.ID,
+		&notes.Version.ProjectID,
+		&notes.Version.Name,
+		&notes.Version.Description,
+		&notes.Version.ReleaseDate,
+		&notes.Version.Released,
+		&notes.Version.Archived,
+		&notes.Template,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVersionNotFound
+		}
+		r.log.Error(err)
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT tasks.id, projects.key, tasks.number, tasks.name, issue_kinds.name, issue_kinds.icon FROM tasks
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		JOIN issue_kinds ON issue_kinds.id = tasks.kind_id
+		JOIN task_versions ON task_versions.task_id = tasks.id
+		WHERE task_versions.version_id = $1 AND task_versions.relation = 'fix' AND statuses.category = 'done'
+		ORDER BY issue_kinds.position, issue_kinds.id, tasks.number`,
+		versionID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var group *models.ReleaseNotesGroup
+	for rows.Next() {
+		var projectKey, kind, icon string
+		var number uint64
+		task := new(models.ReleaseNotesTask)
+
+		if err := rows.Scan(&task.ID, &projectKey, &number, &task.Name, &kind, &icon); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+		task.Key = fmt.Sprintf("%s-%d", projectKey, number)
+
+		if group == nil || group.Kind != kind {
+			group = &models.ReleaseNotesGroup{Kind: kind, Icon: icon, Tasks: make([]*models.ReleaseNotesTask, 0)}
+			notes.Groups = append(notes.Groups, group)
+		}
+		group.Tasks = append(group.Tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func (r *ReleaseNotesRepository) SetReleaseNotesTemplate(templateData *dto.ReleaseNotesTemplateDto, userID uint64) error {
+	if err := r.admin.IsAdmin(templateData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(templateData.ProjectID); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(
+		"UPDATE projects SET release_notes_template = $1 WHERE id = $2",
+		templateData.Template,
+		templateData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Set release notes template: project = %d", templateData.ProjectID)
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetReleaseNotes(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesRepository
+	err := errors.New("error")
+	versionQuery := `SELECT versions.id, versions.project_id, versions.name, versions.description, versions.release_date,
+		versions.released, versions.archived, projects.release_notes_template
+		FROM versions JOIN projects ON projects.id = versions.project_id
+		WHERE versions.id = $1 AND versions.project_id = $2`
+	tasksQuery := `SELECT tasks.id, projects.key, tasks.number, tasks.name, issue_kinds.name, issue_kinds.icon FROM tasks
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		JOIN issue_kinds ON issue_kinds.id = tasks.kind_id
+		JOIN task_versions ON task_versions.task_id = tasks.id
+		WHERE task_versions.version_id = $1 AND task_versions.relation = 'fix' AND statuses.category = 'done'
+		ORDER BY issue_kinds.position, issue_kinds.id, tasks.number`
+	versionColumns := []string{"id", "project_id", "name", "description", "release_date", "released", "archived", "release_notes_template"}
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		versionID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult *models.ReleaseNotes
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			versionID: 3,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &ReleaseNotesRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error version not found",
+			projectID: 1,
+			versionID: 3,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).
+					WithArgs(versionID, projectID).
+					WillReturnRows(sqlmock.NewRows(versionColumns))
+
+				return &ReleaseNotesRepository{db: db, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrVersionNotFound,
+		},
+		{
+			name:      "Error reading tasks",
+			projectID: 1,
+			versionID: 3,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).
+					WithArgs(versionID, projectID).
+					WillReturnRows(sqlmock.NewRows(versionColumns).AddRow(versionID, projectID, "1.2.0", "", nil, true, false, ""))
+				rows := sqlmock.NewRows([]string{"id", "key", "number", "name", "name", "icon"}).
+					AddRow(uint64(4), "BT", uint64(2), "Crash on login", "bug", "bug").
+					RowError(0, err)
+				mock.ExpectQuery(regexp.QuoteMeta(tasksQuery)).WithArgs(versionID).WillReturnRows(rows)
+				log.EXPECT().Error(err)
+
+				return &ReleaseNotesRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			versionID: 3,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(regexp.QuoteMeta(versionQuery)).
+					WithArgs(versionID, projectID).
+					WillReturnRows(sqlmock.NewRows(versionColumns).AddRow(versionID, projectID, "1.2.0", "", nil, true, false, "{{.Version.Name}}"))
+				rows := sqlmock.NewRows([]string{"id", "key", "number", "name", "name", "icon"}).
+					AddRow(uint64(4), "BT", uint64(2), "Crash on login", "bug", "bug").
+					AddRow(uint64(7), "BT", uint64(5), "Typo in footer", "bug", "bug").
+					AddRow(uint64(6), "BT", uint64(4), "Dark mode", "feature", "lightbulb")
+				mock.ExpectQuery(regexp.QuoteMeta(tasksQuery)).WithArgs(versionID).WillReturnRows(rows)
+
+				return &ReleaseNotesRepository{db: db, member: member}
+			},
+			expectedResult: &models.ReleaseNotes{
+				Version: &models.Version{ID: 3, ProjectID: 1, Name: "1.2.0", Released: true},
+				Groups: []*models.ReleaseNotesGroup{
+					{
+						Kind: "bug",
+						Icon: "bug",
+						Tasks: []*models.ReleaseNotesTask{
+							{ID: 4, Key: "BT-2", Name: "Crash on login"},
+							{ID: 7, Key: "BT-5", Name: "Typo in footer"},
+						},
+					},
+					{
+						Kind:  "feature",
+						Icon:  "lightbulb",
+						Tasks: []*models.ReleaseNotesTask{{ID: 6, Key: "BT-4", Name: "Dark mode"}},
+					},
+				},
+				Template: "{{.Version.Name}}",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.versionID, test.userID)
+			res, err := repo.GetReleaseNotes(test.projectID, test.versionID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_SetReleaseNotesTemplate(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, templateData *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesRepository
+	templateData := &dto.ReleaseNotesTemplateDto{ProjectID: 1, Template: "# {{.Version.Name}}"}
+
+	tests := []struct {
+		name          string
+		templateData  *dto.ReleaseNotesTemplateDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:         "Error in admin",
+			templateData: templateData,
+			userID:       2,
+			mockBehaviour: func(c *gomock.Controller, templateData *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(templateData.ProjectID, userID).Return(ErrNoRights)
+
+				return &ReleaseNotesRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:         "OK",
+			templateData: templateData,
+			userID:       1,
+			mockBehaviour: func(c *gomock.Controller, templateData *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(templateData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(templateData.ProjectID).Return(nil)
+
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE projects SET release_notes_template = $1 WHERE id = $2")).
+					WithArgs(templateData.Template, templateData.ProjectID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Set release notes template: project = %d", templateData.ProjectID)
+
+				return &ReleaseNotesRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.templateData, test.userID)
+			err := repo.SetReleaseNotesTemplate(test.templateData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
@@ -18,41 +19,213 @@ type User interface {
 }
 
 type Project interface {
-	CreateProject(projectData *dto.CreateProjectDto) (uint64, error)
+	CreateProject(projectData *dto.CreateProjectDto, event *models.Event) (uint64, error)
 	GetProjectById(id uint64) (*models.Project, error)
-	DeleteProject(projectID, userID uint64) error
-	UpdateProject(projectData *dto.UpdateProjectDto, userID uint64) error
-	AddMember(memberData *dto.AddMemberDto, userID uint64) error
-	DeleteMember(memberData *dto.AddMemberDto, userID uint64) error
+	DeleteProject(projectID, userID uint64, event *models.Event) error
+	UpdateProject(projectData *dto.UpdateProjectDto, userID uint64, event *models.Event) error
+	GetProjectAudit(projectID, userID uint64) ([]*models.ProjectAudit, error)
+	AddMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error
+	DeleteMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error
 	GetMembers(projectID, userID uint64) ([]*models.User, error)
-	LeaveProject(projectID, userID uint64) error
-	SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64) error
+	LeaveProject(projectID, userID uint64, event *models.Event) error
+	SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64, event *models.Event) error
 	GetProjectsByUserId(id uint64) ([]*models.Project, error)
+	GetArchivedProjectsByUserId(id uint64) ([]*models.Project, error)
+	ArchiveProject(projectID, userID uint64, event *models.Event) error
+	UnarchiveProject(projectID, userID uint64, event *models.Event) error
+	RestoreProject(projectID, userID uint64, event *models.Event) error
+	GetTrash(userID uint64) ([]*models.Project, error)
+	PurgeDeletedProjects(deletedBefore time.Time) (int64, []string, error)
 }
 
 type Task interface {
-	CreateTask(taskData *dto.CreateTaskDto, userID uint64) (uint64, error)
-	WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error
-	StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error
-	UpdateTask(taskData *dto.UpdateTaskDto, userID uint64) (uint64, error)
-	GetTaskById(id uint64) (*models.Task, error)
-	GetTasksByProjectId(id uint64) ([]*models.Task, error)
-	DeleteTask(taskData *dto.DeleteTaskDto, userID uint64) error
+	CreateTask(taskData *dto.CreateTaskDto, userID uint64, event *models.Event) (uint64, error)
+	WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error
+	StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error
+	UpdateTask(taskData *dto.UpdateTaskDto, userID uint64, event *models.Event) (uint64, string, error)
+	TransitionTask(taskData *dto.TransitionTaskDto, userID uint64, event *models.Event) error
+	GetTaskById(id, userID uint64) (*models.Task, error)
+	GetTaskChildren(id uint64) ([]*models.Task, error)
+	GetTaskIdByKey(projectKey string, number uint64) (uint64, uint64, error)
+	GetTasksByProjectId(id uint64, filter *dto.TaskFilterDto) ([]*models.Task, error)
+	DeleteTask(taskData *dto.DeleteTaskDto, userID uint64, event *models.Event) ([]string, error)
+}
+
+type Workflow interface {
+	GetWorkflow(projectID, userID uint64) (*models.Workflow, error)
+	CreateStatus(statusData *dto.CreateStatusDto, userID uint64) (uint64, error)
+	UpdateStatus(statusData *dto.UpdateStatusDto, userID uint64) error
+	DeleteStatus(statusData *dto.DeleteStatusDto, userID uint64) error
+	AddTransition(transitionData *dto.TransitionDto, userID uint64) error
+	DeleteTransition(transitionData *dto.TransitionDto, userID uint64) error
+}
+
+type IssueKind interface {
+	GetIssueKinds(projectID, userID uint64) ([]*models.IssueKind, error)
+	CreateIssueKind(kindData *dto.CreateIssueKindDto, userID uint64) (uint64, error)
+	UpdateIssueKind(kindData *dto.UpdateIssueKindDto, userID uint64) error
+	DeleteIssueKind(kindData *dto.DeleteIssueKindDto, userID uint64) error
+}
+
+type CustomField interface {
+	GetCustomFields(projectID, userID uint64) ([]*models.CustomField, error)
+	CreateCustomField(fieldData *dto.CreateCustomFieldDto, userID uint64) (uint64, error)
+	UpdateCustomField(fieldData *dto.UpdateCustomFieldDto, userID uint64) error
+	DeleteCustomField(fieldData *dto.DeleteCustomFieldDto, userID uint64) error
+}
+
+type Label interface {
+	GetLabels(projectID, userID uint64) ([]*models.Label, error)
+	CreateLabel(labelData *dto.CreateLabelDto, userID uint64) (uint64, error)
+	UpdateLabel(labelData *dto.UpdateLabelDto, userID uint64) error
+	DeleteLabel(labelData *dto.DeleteLabelDto, userID uint64) error
+	AddTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error
+	RemoveTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error
+}
+
+type Component interface {
+	GetComponents(projectID, userID uint64) ([]*models.Component, error)
+	CreateComponent(componentData *dto.CreateComponentDto, userID uint64) (uint64, error)
+	UpdateComponent(componentData *dto.UpdateComponentDto, userID uint64) error
+	DeleteComponent(componentData *dto.DeleteComponentDto, userID uint64) error
+}
+
+type Version interface {
+	GetVersions(projectID, userID uint64) ([]*models.Version, error)
+	CreateVersion(versionData *dto.CreateVersionDto, userID uint64) (uint64, error)
+	UpdateVersion(versionData *dto.UpdateVersionDto, userID uint64) error
+	DeleteVersion(versionData *dto.DeleteVersionDto, userID uint64) error
+	ReleaseVersion(versionData *dto.ReleaseVersionDto, userID uint64) (int64, error)
+}
+
+type ReleaseNotes interface {
+	GetReleaseNotes(projectID, versionID, userID uint64) (*models.ReleaseNotes, error)
+	SetReleaseNotesTemplate(templateData *dto.ReleaseNotesTemplateDto, userID uint64) error
+}
+
+type Sprint interface {
+	GetSprints(projectID, userID uint64) ([]*models.Sprint, error)
+	CreateSprint(sprintData *dto.CreateSprintDto, userID uint64) (uint64, error)
+	UpdateSprint(sprintData *dto.UpdateSprintDto, userID uint64) error
+	DeleteSprint(sprintData *dto.DeleteSprintDto, userID uint64) error
+	StartSprint(sprintData *dto.StartSprintDto, userID uint64) error
+	CompleteSprint(sprintData *dto.CompleteSprintDto, userID uint64) (int64, error)
+	AddSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error
+	RemoveSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error
+}
+
+type TaskLink interface {
+	CreateTaskLink(linkData *dto.CreateTaskLinkDto, userID uint64) (uint64, error)
+	DeleteTaskLink(linkData *dto.DeleteTaskLinkDto, userID uint64) error
+	GetTaskLinks(taskID, userID uint64) ([]*models.TaskLink, error)
+	GetTaskGraph(taskID, userID uint64) (*models.TaskGraph, error)
+}
+
+type Comment interface {
+	CreateComment(commentData *dto.CreateCommentDto, userID uint64) (uint64, error)
+	UpdateComment(commentData *dto.UpdateCommentDto, userID uint64) error
+	DeleteComment(taskID, commentID, userID uint64) error
+	GetComments(taskID, userID uint64, page *dto.PageDto) (*models.CommentPage, error)
+	GetCommentCount(taskID uint64) (int, error)
+	GetCommentHistory(taskID, commentID, userID uint64) ([]*models.CommentRevision, error)
+}
+
+type Mention interface {
+	SaveMentions(mentionData *dto.MentionDto) ([]uint64, error)
+	GetMentions(userID uint64, page *dto.PageDto) ([]*models.Mention, error)
+}
+
+type Attachment interface {
+	CreateAttachment(attachmentData *dto.CreateAttachmentDto, userID uint64, maxProjectSize int64) (uint64, error)
+	GetAttachments(taskID, userID uint64) ([]*models.Attachment, error)
+	GetAttachment(taskID, attachmentID, userID uint64) (*models.Attachment, error)
+	DeleteAttachment(taskID, attachmentID, userID uint64) (string, error)
+}
+
+type Activity interface {
+	GetActivities(taskID, userID uint64, page *dto.PageDto) (*models.ActivityPage, error)
+}
+
+type Watcher interface {
+	Watch(taskID, userID uint64) error
+	Unwatch(taskID, userID uint64) error
+	GetWatchedTasks(userID uint64, page *dto.PageDto) ([]*models.WatchedTask, error)
+	GetWatchers(taskID, actorID uint64) ([]uint64, error)
+}
+
+type Outbox interface {
+	AddMessage(message *models.OutboxMessage) error
+	ClaimMessages(limit int, lease time.Duration) ([]*models.OutboxMessage, error)
+	MarkSent(id uint64) error
+	MarkFailed(id uint64, nextAttemptAt time.Time, reason string) error
+	ReleaseMessages(ids []uint64) error
+	PurgeSentMessages(sentBefore time.Time) (int64, error)
+}
+
+type Notification interface {
+	AddNotifications(notificationData *dto.NotificationDto) error
+	GetNotifications(userID uint64, filter *dto.NotificationFilterDto) ([]*models.Notification, error)
+	CountUnread(userID uint64) (int, error)
+	MarkRead(notificationID, userID uint64) error
+	MarkAllRead(userID uint64) error
+	GetPreferences(userID uint64) (*models.NotificationPreferences, error)
+	SavePreferences(userID uint64, preferences *models.NotificationPreferences, nextDigestAt time.Time) error
+	GetDueDigests(now time.Time, limit int) ([]*models.DigestSchedule, error)
+	GetDigestItems(userID uint64) ([]*models.MailItem, error)
+	CompleteDigest(
+		schedule *models.DigestSchedule,
+		nextDigestAt time.Time,
+		lastItemID uint64,
+		message *models.OutboxMessage,
+	) (bool, error)
 }
 
 type Repository struct {
 	User
 	Project
 	Task
+	Workflow
+	IssueKind
+	CustomField
+	Label
+	Component
+	Version
+	ReleaseNotes
+	Sprint
+	TaskLink
+	Comment
+	Mention
+	Attachment
+	Activity
+	Watcher
+	Outbox
+	Notification
 }
 
 func NewRepository(db *sql.DB, log log.Log) *Repository {
 	admin := new_adminStrategy(db, log)
 	member := new_memberStrategy(db, log)
+	state := new_stateStrategy(db, log)
 
 	return &Repository{
-		User:    NewUserRepo(db, log),
-		Project: NewProjectRepo(db, log, admin, member),
-		Task:    NewTaskRepo(db, log, admin, member),
+		User:         NewUserRepo(db, log),
+		Project:      NewProjectRepo(db, log, admin, member, state),
+		Task:         NewTaskRepo(db, log, admin, member, state),
+		Workflow:     NewWorkflowRepo(db, log, admin, member, state),
+		IssueKind:    NewIssueKindRepo(db, log, admin, member, state),
+		CustomField:  NewCustomFieldRepo(db, log, admin, member, state),
+		Label:        NewLabelRepo(db, log, admin, member, state),
+		Component:    NewComponentRepo(db, log, admin, member, state),
+		Version:      NewVersionRepo(db, log, admin, member, state),
+		ReleaseNotes: NewReleaseNotesRepo(db, log, admin, member, state),
+		Sprint:       NewSprintRepo(db, log, admin, member, state),
+		TaskLink:     NewTaskLinkRepo(db, log, admin, member, state),
+		Comment:      NewCommentRepo(db, log, admin, member, state),
+		Mention:      NewMentionRepo(db, log, admin, member, state),
+		Attachment:   NewAttachmentRepo(db, log, admin, member, state),
+		Activity:     NewActivityRepo(db, log, admin, member),
+		Watcher:      NewWatcherRepo(db, log, admin, member),
+		Outbox:       NewOutboxRepo(db, log),
+		Notification: NewNotificationRepo(db, log),
 	}
 }
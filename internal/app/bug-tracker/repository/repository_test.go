@@ -18,10 +18,27 @@ func Test_NewRepository(t *testing.T) {
 	log := mock_log.NewMockLog(c)
 	admin := new_adminStrategy(db, log)
 	member := new_memberStrategy(db, log)
+	state := new_stateStrategy(db, log)
 	expectedRepo := &Repository{
-		User:    NewUserRepo(db, log),
-		Project: NewProjectRepo(db, log, admin, member),
-		Task:    NewTaskRepo(db, log, admin, member),
+		User:         NewUserRepo(db, log),
+		Project:      NewProjectRepo(db, log, admin, member, state),
+		Task:         NewTaskRepo(db, log, admin, member, state),
+		Workflow:     NewWorkflowRepo(db, log, admin, member, state),
+		IssueKind:    NewIssueKindRepo(db, log, admin, member, state),
+		CustomField:  NewCustomFieldRepo(db, log, admin, member, state),
+		Label:        NewLabelRepo(db, log, admin, member, state),
+		Component:    NewComponentRepo(db, log, admin, member, state),
+		Version:      NewVersionRepo(db, log, admin, member, state),
+		ReleaseNotes: NewReleaseNotesRepo(db, log, admin, member, state),
+		Sprint:       NewSprintRepo(db, log, admin, member, state),
+		TaskLink:     NewTaskLinkRepo(db, log, admin, member, state),
+		Comment:      NewCommentRepo(db, log, admin, member, state),
+		Mention:      NewMentionRepo(db, log, admin, member, state),
+		Attachment:   NewAttachmentRepo(db, log, admin, member, state),
+		Activity:     NewActivityRepo(db, log, admin, member),
+		Watcher:      NewWatcherRepo(db, log, admin, member),
+		Outbox:       NewOutboxRepo(db, log),
+		Notification: NewNotificationRepo(db, log),
 	}
 	repo := NewRepository(db, log)
 
@@ -0,0 +1,583 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrSprintNotFound      = errors.New("error sprint is not found")
+	ErrSprintNameTaken     = errors.New("error sprint with such a name already exists")
+	ErrSprintDates         = errors.New("error sprint cannot end before it starts")
+	ErrSprintAlreadyActive = errors.New("error project already has an active sprint")
+	ErrSprintNotPlanned    = errors.New("error only a planned sprint can be started")
+	ErrSprintNotActive     = errors.New("error only the active sprint can be completed")
+	ErrSprintActive        = errors.New("error active sprint cannot be deleted")
+	ErrSprintClosed        = errors.New("error sprint is closed")
+	ErrSprintMoveToSelf    = errors.New("error unfinished tasks cannot move to the sprint being completed")
+)
+
+const (
+	sprintsNameConstraint   = "sprints_project_id_name_key"
+	sprintsActiveConstraint = "sprints_project_id_active_key"
+)
+
+type SprintRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewSprintRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Sprint {
+	return &SprintRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *SprintRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+// checkSprintDates compares the dates as they come, which works for the
+// 2006-01-02 layout the dto enforces.
+func checkSprintDates(startDate, endDate string) error {
+	if startDate != "" && endDate != "" && endDate < startDate {
+		return ErrSprintDates
+	}
+
+	return nil
+}
+
+func (r *SprintRepository) GetSprints(projectID, userID uint64) ([]*models.Sprint, error) {
+	if !r.isParticipant(projectID, userID) {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, goal, start_date, end_date, state, completed_at FROM sprints
+		WHERE project_id = $1 ORDER BY start_date NULLS LAST, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	sprints := make([]*models.Sprint, 0)
+	for rows.Next() {
+		sprint := new(models.Sprint)
+		err := rows.Scan(
+			&sprint.ID,
+			&sprint.ProjectID,
+			&sprint.Name,
+			&sprint.Goal,
+			&sprint.StartDate,
+			&sprint.EndDate,
+			&sprint.State,
+			&sprint.CompletedAt,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		sprints = append(sprints, sprint)
+	}
+
+	return sprints, nil
+}
+
+func (r *SprintRepository) CreateSprint(sprintData *dto.CreateSprintDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(sprintData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(sprintData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	if err := checkSprintDates(sprintData.StartDate, sprintData.EndDate); err != nil {
+		return 0, err
+	}
+
+	var sprintID uint64
+	err := r.db.QueryRow(
+		`INSERT INTO sprints (project_id, name, goal, start_date, end_date)
+		VALUES ($1, $2, $3, NULLIF($4, '')::DATE, NULLIF($5, '')::DATE) RETURNING id`,
+		sprintData.ProjectID,
+		sprintData.Name,
+		sprintData.Goal,
+		sprintData.StartDate,
+		sprintData.EndDate,
+	).Scan(&sprintID)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, sprintsNameConstraint) {
+			return 0, ErrSprintNameTaken
+		}
+		return 0, err
+	}
+	r.log.Infof("Create sprint: id = %d, project = %d", sprintID, sprintData.ProjectID)
+
+	return sprintID, nil
+}
+
+func (r *SprintRepository) UpdateSprint(sprintData *dto.UpdateSprintDto, userID uint64) error {
+	if err := r.admin.IsAdmin(sprintData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(sprintData.ProjectID); err != nil {
+		return err
+	}
+
+	if err := checkSprintDates(sprintData.StartDate, sprintData.EndDate); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		`UPDATE sprints SET name = $1, goal = $2, start_date = NULLIF($3, '')::DATE, end_date = NULLIF($4, '')::DATE
+		WHERE id = $5 AND project_id = $6`,
+		sprintData.Name,
+		sprintData.Goal,
+		sprintData.StartDate,
+		sprintData.EndDate,
+		sprintData.SprintID,
+		sprintData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, sprintsNameConstraint) {
+			return ErrSprintNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrSprintNotFound
+	}
+	r.log.Infof("Update sprint: id = %d", sprintData.SprintID)
+
+	return nil
+}
+
+// DeleteSprint removes a planned or closed sprint, its tasks go back to the
+// backlog.
+func (r *SprintRepository) DeleteSprint(sprintData *dto.DeleteSprintDto, userID uint64) error {
+	if err := r.admin.IsAdmin(sprintData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(sprintData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	state, err := r.lockSprint(tx, sprintData.SprintID, sprintData.ProjectID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if state == models.SprintActive {
+		tx.Rollback()
+		return ErrSprintActive
+	}
+
+	_, err = tx.Exec("DELETE FROM sprints WHERE id = $1", sprintData.SprintID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Delete sprint: id = %d", sprintData.SprintID)
+
+	return nil
+}
+
+// StartSprint makes a planned sprint the active one. A sprint without dates
+// starts today and lasts two weeks.
+func (r *SprintRepository) StartSprint(sprintData *dto.StartSprintDto, userID uint64) error {
+	if err := r.admin.IsAdmin(sprintData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(sprintData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	state, err := r.lockSprint(tx, sprintData.SprintID, sprintData.ProjectID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if state != models.SprintPlanned {
+		tx.Rollback()
+		return ErrSprintNotPlanned
+	}
+
+	_, err = tx.Exec(
+		`UPDATE sprints SET state = 'active', start_date = COALESCE(start_date, CURRENT_DATE),
+		end_date = COALESCE(end_date, COALESCE(start_date, CURRENT_DATE) + 14) WHERE id = $1`,
+		sprintData.SprintID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, sprintsActiveConstraint) {
+			return ErrSprintAlreadyActive
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Start sprint: id = %d", sprintData.SprintID)
+
+	return nil
+}
+
+// CompleteSprint closes the active sprint and returns how many of its tasks
+// that were not done moved on.
+func (r *SprintRepository) CompleteSprint(sprintData *dto.CompleteSprintDto, userID uint64) (int64, error) {
+	if sprintData.MoveToSprintID == sprintData.SprintID {
+		return 0, ErrSprintMoveToSelf
+	}
+
+	if err := r.admin.IsAdmin(sprintData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(sprintData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	state, err := r.lockSprint(tx, sprintData.SprintID, sprintData.ProjectID)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if state != models.SprintActive {
+		tx.Rollback()
+		return 0, ErrSprintNotActive
+	}
+
+	if sprintData.MoveToSprintID != 0 {
+		state, err := r.lockSprint(tx, sprintData.MoveToSprintID, sprintData.ProjectID)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		if state == models.SprintClosed {
+			tx.Rollback()
+			return 0, ErrSprintClosed
+		}
+	}
+
+	taskIDs, err := r.unfinishedTasks(tx, sprintData.SprintID)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	_, err = tx.Exec(
+		"UPDATE tasks SET sprint_id = NULLIF($2, 0) WHERE id = ANY($1)",
+		pq.Array(taskIDs),
+		sprintData.MoveToSprintID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+	moved := int64(len(taskIDs))
+
+	_, err = tx.Exec(
+		"UPDATE sprints SET state = 'closed', completed_at = $1 WHERE id = $2",
+		time.Now().UTC(),
+		sprintData.SprintID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Complete sprint: id = %d, moved tasks = %d", sprintData.SprintID, moved)
+
+	return moved, nil
+}
+
+func (r *SprintRepository) AddSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	if !r.isParticipant(tasksData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(tasksData.ProjectID); err != nil {
+		return err
+	}
+
+	taskIDs := uniqueIDs(tasksData.TaskIDs)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if err := r.lockOpenSprint(tx, tasksData.SprintID, tasksData.ProjectID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	result, err := tx.Exec(
+		"UPDATE tasks SET sprint_id = $1 WHERE project_id = $2 AND id = ANY($3)",
+		tasksData.SprintID,
+		tasksData.ProjectID,
+		pq.Array(taskIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if count != int64(len(taskIDs)) {
+		tx.Rollback()
+		return ErrTaskNotFound
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Add sprint tasks: sprint = %d, tasks = %d", tasksData.SprintID, len(taskIDs))
+
+	return nil
+}
+
+// RemoveSprintTasks moves the tasks of the sprint back to the backlog, tasks
+// that are not in the sprint are left as they are.
+func (r *SprintRepository) RemoveSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	if !r.isParticipant(tasksData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(tasksData.ProjectID); err != nil {
+		return err
+	}
+
+	taskIDs := uniqueIDs(tasksData.TaskIDs)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if err := r.lockOpenSprint(tx, tasksData.SprintID, tasksData.ProjectID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		"UPDATE tasks SET sprint_id = NULL WHERE sprint_id = $1 AND id = ANY($2)",
+		tasksData.SprintID,
+		pq.Array(taskIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Remove sprint tasks: sprint = %d, tasks = %d", tasksData.SprintID, len(taskIDs))
+
+	return nil
+}
+
+// unfinishedTasks locks the tasks of the sprint whose status is not done.
+func (r *SprintRepository) unfinishedTasks(tx *sql.Tx, sprintID uint64) ([]uint64, error) {
+	rows, err := tx.Query(
+		`SELECT tasks.id FROM tasks JOIN statuses ON statuses.id = tasks.status_id
+		WHERE tasks.sprint_id = $1 AND statuses.category <> 'done' ORDER BY tasks.id FOR UPDATE OF tasks`,
+		sprintID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	taskIDs := make([]uint64, 0)
+	for rows.Next() {
+		var taskID uint64
+		if err := rows.Scan(&taskID); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return taskIDs, nil
+}
+
+func (r *SprintRepository) lockSprint(tx *sql.Tx, sprintID, projectID uint64) (string, error) {
+	var state string
+	err := tx.QueryRow(
+		"SELECT state FROM sprints WHERE id = $1 AND project_id = $2 FOR UPDATE",
+		sprintID,
+		projectID,
+	).Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrSprintNotFound
+		}
+		r.log.Error(err)
+		return "", err
+	}
+
+	return state, nil
+}
+
+// lockOpenSprint locks a sprint whose tasks may still change, closed sprints
+// keep the tasks they were completed with.
+func (r *SprintRepository) lockOpenSprint(tx *sql.Tx, sprintID, projectID uint64) error {
+	state, err := r.lockSprint(tx, sprintID, projectID)
+	if err != nil {
+		return err
+	}
+
+	if state == models.SprintClosed {
+		return ErrSprintClosed
+	}
+
+	return nil
+}
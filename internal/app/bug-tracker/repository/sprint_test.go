@@ -0,0 +1,839 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+const lockSprintQuery = "SELECT state FROM sprints WHERE id = $1 AND project_id = $2 FOR UPDATE"
+
+func Test_GetSprints(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *SprintRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Sprint
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *SprintRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &SprintRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "goal", "start_date", "end_date", "state", "completed_at"}).
+					AddRow(uint64(1), projectID, "Sprint 1", "Login", time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), "active", nil).
+					AddRow(uint64(2), projectID, "Sprint 2", "", nil, nil, "planned", nil)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, goal, start_date, end_date, state, completed_at FROM sprints
+						WHERE project_id = $1 ORDER BY start_date NULLS LAST, id`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &SprintRepository{db: db, member: member}
+			},
+			expectedResult: []*models.Sprint{
+				{
+					ID:        1,
+					ProjectID: 1,
+					Name:      "Sprint 1",
+					Goal:      "Login",
+					StartDate: sql.NullTime{Time: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					EndDate:   sql.NullTime{Time: time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), Valid: true},
+					State:     models.SprintActive,
+				},
+				{ID: 2, ProjectID: 1, Name: "Sprint 2", State: models.SprintPlanned},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetSprints(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintRepository
+	sprintData := &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1", StartDate: "2023-03-01", EndDate: "2023-03-15"}
+	query := `INSERT INTO sprints (project_id, name, goal, start_date, end_date)
+		VALUES ($1, $2, $3, NULLIF($4, '')::DATE, NULLIF($5, '')::DATE) RETURNING id`
+
+	tests := []struct {
+		name           string
+		sprintData     *dto.CreateSprintDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:       "Error in admin",
+			sprintData: sprintData,
+			userID:     2,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(ErrNoRights)
+
+				return &SprintRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:       "Error sprint ends before it starts",
+			sprintData: &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1", StartDate: "2023-03-15", EndDate: "2023-03-01"},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				return &SprintRepository{admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrSprintDates,
+		},
+		{
+			name:       "Error name taken",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: sprintsNameConstraint}
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+					sprintData.ProjectID,
+					sprintData.Name,
+					sprintData.Goal,
+					sprintData.StartDate,
+					sprintData.EndDate,
+				).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrSprintNameTaken,
+		},
+		{
+			name:       "OK",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(
+					sprintData.ProjectID,
+					sprintData.Name,
+					sprintData.Goal,
+					sprintData.StartDate,
+					sprintData.EndDate,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+				log.EXPECT().Infof("Create sprint: id = %d, project = %d", uint64(3), sprintData.ProjectID)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.sprintData, test.userID)
+			res, err := repo.CreateSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.UpdateSprintDto, userID uint64) *SprintRepository
+	sprintData := &dto.UpdateSprintDto{ProjectID: 1, SprintID: 3, Name: "Sprint 3", Goal: "Billing"}
+	query := `UPDATE sprints SET name = $1, goal = $2, start_date = NULLIF($3, '')::DATE, end_date = NULLIF($4, '')::DATE
+		WHERE id = $5 AND project_id = $6`
+
+	tests := []struct {
+		name          string
+		sprintData    *dto.UpdateSprintDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error sprint not found",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.UpdateSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(
+					sprintData.Name,
+					sprintData.Goal,
+					sprintData.StartDate,
+					sprintData.EndDate,
+					sprintData.SprintID,
+					sprintData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrSprintNotFound,
+		},
+		{
+			name:       "OK",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.UpdateSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(
+					sprintData.Name,
+					sprintData.Goal,
+					sprintData.StartDate,
+					sprintData.EndDate,
+					sprintData.SprintID,
+					sprintData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Update sprint: id = %d", sprintData.SprintID)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.sprintData, test.userID)
+			err := repo.UpdateSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.DeleteSprintDto, userID uint64) *SprintRepository
+	sprintData := &dto.DeleteSprintDto{ProjectID: 1, SprintID: 3}
+
+	tests := []struct {
+		name          string
+		sprintData    *dto.DeleteSprintDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error sprint not found",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.DeleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrSprintNotFound,
+		},
+		{
+			name:       "Error sprint is active",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.DeleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("active"))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrSprintActive,
+		},
+		{
+			name:       "OK",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.DeleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sprints WHERE id = $1")).
+					WithArgs(sprintData.SprintID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Delete sprint: id = %d", sprintData.SprintID)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.sprintData, test.userID)
+			err := repo.DeleteSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_StartSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.StartSprintDto, userID uint64) *SprintRepository
+	sprintData := &dto.StartSprintDto{ProjectID: 1, SprintID: 3}
+	query := `UPDATE sprints SET state = 'active', start_date = COALESCE(start_date, CURRENT_DATE),
+		end_date = COALESCE(end_date, COALESCE(start_date, CURRENT_DATE) + 14) WHERE id = $1`
+
+	tests := []struct {
+		name          string
+		sprintData    *dto.StartSprintDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error sprint is not planned",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.StartSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("closed"))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrSprintNotPlanned,
+		},
+		{
+			name:       "Error another sprint is active",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.StartSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: sprintsActiveConstraint}
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sprintData.SprintID).WillReturnError(uniqueErr)
+				mock.ExpectRollback()
+				log.EXPECT().Error(uniqueErr)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrSprintAlreadyActive,
+		},
+		{
+			name:       "OK",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.StartSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sprintData.SprintID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Start sprint: id = %d", sprintData.SprintID)
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.sprintData, test.userID)
+			err := repo.StartSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CompleteSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository
+	sprintData := &dto.CompleteSprintDto{ProjectID: 1, SprintID: 3, MoveToSprintID: 4}
+	unfinishedQuery := `SELECT tasks.id FROM tasks JOIN statuses ON statuses.id = tasks.status_id
+		WHERE tasks.sprint_id = $1 AND statuses.category <> 'done' ORDER BY tasks.id FOR UPDATE OF tasks`
+	moveQuery := "UPDATE tasks SET sprint_id = NULLIF($2, 0) WHERE id = ANY($1)"
+	closeQuery := "UPDATE sprints SET state = 'closed', completed_at = $1 WHERE id = $2"
+
+	tests := []struct {
+		name           string
+		sprintData     *dto.CompleteSprintDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name:       "Error tasks move to the same sprint",
+			sprintData: &dto.CompleteSprintDto{ProjectID: 1, SprintID: 3, MoveToSprintID: 3},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository {
+				return &SprintRepository{}
+			},
+			expectedResult: 0,
+			expectedError:  ErrSprintMoveToSelf,
+		},
+		{
+			name:       "Error sprint is not active",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrSprintNotActive,
+		},
+		{
+			name:       "Error next sprint is closed",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("active"))
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.MoveToSprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("closed"))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrSprintClosed,
+		},
+		{
+			name:       "OK to the backlog",
+			sprintData: &dto.CompleteSprintDto{ProjectID: 1, SprintID: 3},
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("active"))
+				mock.ExpectQuery(regexp.QuoteMeta(unfinishedQuery)).
+					WithArgs(sprintData.SprintID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(5)).AddRow(uint64(6)).AddRow(uint64(7)))
+				expectTaskSnapshots(mock, 5, 6, 7)
+				mock.ExpectExec(regexp.QuoteMeta(moveQuery)).
+					WithArgs("{5,6,7}", uint64(0)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				expectTasksChanges(mock, userID, 5, 6, 7)
+				expectTasksWatchEvents(mock, userID, 5, 6, 7)
+				mock.ExpectExec(regexp.QuoteMeta(closeQuery)).
+					WithArgs(sqlmock.AnyArg(), sprintData.SprintID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Complete sprint: id = %d, moved tasks = %d", sprintData.SprintID, int64(3))
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+		{
+			name:       "OK to the next sprint",
+			sprintData: sprintData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CompleteSprintDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(sprintData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(sprintData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.SprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("active"))
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(sprintData.MoveToSprintID, sprintData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				mock.ExpectQuery(regexp.QuoteMeta(unfinishedQuery)).
+					WithArgs(sprintData.SprintID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(5)).AddRow(uint64(6)))
+				expectTaskSnapshots(mock, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(moveQuery)).
+					WithArgs("{5,6}", sprintData.MoveToSprintID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectTasksChanges(mock, userID, 5, 6)
+				expectTasksWatchEvents(mock, userID, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(closeQuery)).
+					WithArgs(sqlmock.AnyArg(), sprintData.SprintID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Complete sprint: id = %d, moved tasks = %d", sprintData.SprintID, int64(2))
+
+				return &SprintRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 2,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.sprintData, test.userID)
+			res, err := repo.CompleteSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddSprintTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository
+	tasksData := &dto.SprintTasksDto{ProjectID: 1, SprintID: 3, TaskIDs: []uint64{5, 6, 5}}
+	query := "UPDATE tasks SET sprint_id = $1 WHERE project_id = $2 AND id = ANY($3)"
+
+	tests := []struct {
+		name          string
+		tasksData     *dto.SprintTasksDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error sprint is closed",
+			tasksData: tasksData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(tasksData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(tasksData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("closed"))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrSprintClosed,
+		},
+		{
+			name:      "Error task is not in the project",
+			tasksData: tasksData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(tasksData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(tasksData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				expectTaskSnapshots(mock, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID, "{5,6}").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectRollback()
+
+				return &SprintRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name:      "OK",
+			tasksData: tasksData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(tasksData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(tasksData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("active"))
+				expectTaskSnapshots(mock, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID, "{5,6}").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectTasksChanges(mock, userID, 5, 6)
+				expectTasksWatchEvents(mock, userID, 5, 6)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Add sprint tasks: sprint = %d, tasks = %d", tasksData.SprintID, 2)
+
+				return &SprintRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.tasksData, test.userID)
+			err := repo.AddSprintTasks(test.tasksData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_RemoveSprintTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository
+	tasksData := &dto.SprintTasksDto{ProjectID: 1, SprintID: 3, TaskIDs: []uint64{5}}
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		tasksData     *dto.SprintTasksDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error no rights",
+			tasksData: tasksData,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(tasksData.ProjectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(tasksData.ProjectID, userID).Return(err)
+
+				return &SprintRepository{admin: admin, member: member}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:      "OK",
+			tasksData: tasksData,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, tasksData *dto.SprintTasksDto, userID uint64) *SprintRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(tasksData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(tasksData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockSprintQuery)).
+					WithArgs(tasksData.SprintID, tasksData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("planned"))
+				expectTaskSnapshots(mock, 5)
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET sprint_id = NULL WHERE sprint_id = $1 AND id = ANY($2)")).
+					WithArgs(tasksData.SprintID, "{5}").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectTasksChanges(mock, userID, 5)
+				expectTasksWatchEvents(mock, userID, 5)
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Remove sprint tasks: sprint = %d, tasks = %d", tasksData.SprintID, 1)
+
+				return &SprintRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.tasksData, test.userID)
+			err := repo.RemoveSprintTasks(test.tasksData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
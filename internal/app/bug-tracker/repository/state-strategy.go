@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+)
+
+//go:generate mockgen -source=state-strategy.go -destination=mocks/state-strategy.go
+
+type state interface {
+	IsWritable(projectID uint64) error
+}
+
+type stateStrategy struct {
+	db  *sql.DB
+	log log.Log
+}
+
+func new_stateStrategy(db *sql.DB, log log.Log) state {
+	return &stateStrategy{db, log}
+}
+
+func (s *stateStrategy) IsWritable(projectID uint64) error {
+	result := s.db.QueryRow("SELECT archived_at FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID)
+
+	var archivedAt sql.NullTime
+	if err := result.Scan(&archivedAt); err != nil {
+		s.log.Error(err)
+		if err == sql.ErrNoRows {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+
+	if archivedAt.Valid {
+		return ErrProjectArchived
+	}
+
+	return nil
+}
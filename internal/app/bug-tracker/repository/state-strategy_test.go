@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_new_stateStrategy(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	log := mock_log.NewMockLog(c)
+	db, _, _ := sqlmock.New()
+
+	expected := &stateStrategy{db, log}
+
+	require.Equal(t, expected, new_stateStrategy(db, log))
+}
+
+func Test_IsWritable(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID uint64) *stateStrategy
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		projectID     uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:      "Error cannot get project",
+			projectID: 1,
+			mockBehaviour: func(c *gomock.Controller, projectID uint64) *stateStrategy {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id = $1 AND deleted_at IS NULL"),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err).Return()
+
+				return &stateStrategy{db, log}
+			},
+			expectedError: err,
+		},
+		{
+			name:      "Error project not found",
+			projectID: 1,
+			mockBehaviour: func(c *gomock.Controller, projectID uint64) *stateStrategy {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id = $1 AND deleted_at IS NULL"),
+				).WithArgs(projectID).WillReturnError(sql.ErrNoRows)
+				log.EXPECT().Error(sql.ErrNoRows).Return()
+
+				return &stateStrategy{db, log}
+			},
+			expectedError: ErrProjectNotFound,
+		},
+		{
+			name:      "Error project is archived",
+			projectID: 1,
+			mockBehaviour: func(c *gomock.Controller, projectID uint64) *stateStrategy {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				rows := sqlmock.NewRows([]string{"archived_at"}).AddRow(time.Now())
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id = $1 AND deleted_at IS NULL"),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &stateStrategy{db, log}
+			},
+			expectedError: ErrProjectArchived,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			mockBehaviour: func(c *gomock.Controller, projectID uint64) *stateStrategy {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				rows := sqlmock.NewRows([]string{"archived_at"}).AddRow(nil)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id = $1 AND deleted_at IS NULL"),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &stateStrategy{db, log}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			state := test.mockBehaviour(c, test.projectID)
+
+			err := state.IsWritable(test.projectID)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrParentNotFound     = errors.New("error parent task is not found")
+	ErrParentOtherProject = errors.New("error parent task belongs to another project")
+	ErrTaskHierarchyCycle = errors.New("error task cannot be a parent of its ancestor")
+	ErrTaskDepthExceeded  = errors.New("error task hierarchy is too deep")
+)
+
+// checkTaskParent makes sure the task can be placed under the parent: the
+// parent has to belong to the same project, must not be the task itself or
+// one of its descendants, and the resulting hierarchy has to fit into the
+// depth limit of the project. taskID is zero for a task being created.
+func (r *TaskRepository) checkTaskParent(tx *sql.Tx, projectID, taskID, parentID uint64) error {
+	if parentID == 0 {
+		return nil
+	}
+
+	if taskID != 0 {
+		var currentParentID uint64
+		err := tx.QueryRow("SELECT COALESCE(parent_id, 0) FROM tasks WHERE id = $1", taskID).Scan(&currentParentID)
+		if err != nil {
+			r.log.Error(err)
+			return err
+		}
+
+		// Keeping the parent never fails, even if the project has lowered
+		// its depth limit since the task was placed.
+		if currentParentID == parentID {
+			return nil
+		}
+	}
+
+	// Locking the project serializes hierarchy changes, so two concurrent
+	// moves cannot build a cycle together.
+	var maxDepth int
+	err := tx.QueryRow(
+		"SELECT max_task_depth FROM projects WHERE id = $1 FOR NO KEY UPDATE",
+		projectID,
+	).Scan(&maxDepth)
+	if err != nil {
+		r.log.Error(err)
+		if err == sql.ErrNoRows {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+
+	var parentProjectID uint64
+	err = tx.QueryRow("SELECT project_id FROM tasks WHERE id = $1", parentID).Scan(&parentProjectID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrParentNotFound
+		}
+		r.log.Error(err)
+		return err
+	}
+
+	if parentProjectID != projectID {
+		return ErrParentOtherProject
+	}
+
+	var level int
+	var cycle bool
+	err = tx.QueryRow(
+		`WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 1 AS level FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT tasks.id, tasks.parent_id, ancestors.level + 1
+			FROM tasks JOIN ancestors ON tasks.id = ancestors.parent_id
+			WHERE ancestors.level <= $3
+		)
+		SELECT MAX(level), COALESCE(bool_or(id = $2), false) FROM ancestors`,
+		parentID,
+		taskID,
+		maxDepth,
+	).Scan(&level, &cycle)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if cycle {
+		return ErrTaskHierarchyCycle
+	}
+
+	height := 1
+	if taskID != 0 {
+		err = tx.QueryRow(
+			`WITH RECURSIVE subtree AS (
+				SELECT id, 1 AS level FROM tasks WHERE id = $1
+				UNION ALL
+				SELECT tasks.id, subtree.level + 1
+				FROM tasks JOIN subtree ON tasks.parent_id = subtree.id
+				WHERE subtree.level <= $2
+			)
+			SELECT MAX(level) FROM subtree`,
+			taskID,
+			maxDepth,
+		).Scan(&height)
+		if err != nil {
+			r.log.Error(err)
+			return err
+		}
+	}
+
+	if level+height > maxDepth {
+		return ErrTaskDepthExceeded
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) GetTaskChildren(id uint64) ([]*models.Task, error) {
+	rows, err := r.db.Query(
+		`SELECT `+taskColumns+`
+		FROM `+taskTables+`
+		WHERE tasks.parent_id = $1 AND projects.deleted_at IS NULL
+		ORDER BY tasks.number`,
+		id,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := make([]*models.Task, 0)
+	for rows.Next() {
+		child, err := scanTask(rows)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		children = append(children, child)
+	}
+
+	return children, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+const (
+	taskAncestorsQuery = `WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 1 AS level FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT tasks.id, tasks.parent_id, ancestors.level + 1
+			FROM tasks JOIN ancestors ON tasks.id = ancestors.parent_id
+			WHERE ancestors.level <= $3
+		)
+		SELECT MAX(level), COALESCE(bool_or(id = $2), false) FROM ancestors`
+	taskSubtreeQuery = `WITH RECURSIVE subtree AS (
+				SELECT id, 1 AS level FROM tasks WHERE id = $1
+				UNION ALL
+				SELECT tasks.id, subtree.level + 1
+				FROM tasks JOIN subtree ON tasks.parent_id = subtree.id
+				WHERE subtree.level <= $2
+			)
+			SELECT MAX(level) FROM subtree`
+)
+
+func Test_checkTaskParent(t *testing.T) {
+	type mockBehaviour func(mock sqlmock.Sqlmock)
+
+	expectProject := func(mock sqlmock.Sqlmock, maxDepth int, parentProjectID uint64) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT max_task_depth FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+			WithArgs(uint64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"max_task_depth"}).AddRow(maxDepth))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT project_id FROM tasks WHERE id = $1")).
+			WithArgs(uint64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(parentProjectID))
+	}
+
+	tests := []struct {
+		name          string
+		taskID        uint64
+		parentID      uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:          "OK no parent",
+			taskID:        2,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {},
+			expectedError: nil,
+		},
+		{
+			name:     "OK parent is kept",
+			taskID:   2,
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(parent_id, 0) FROM tasks WHERE id = $1")).
+					WithArgs(uint64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}).AddRow(uint64(3)))
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Error parent is not found",
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT max_task_depth FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"max_task_depth"}).AddRow(3))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT project_id FROM tasks WHERE id = $1")).
+					WithArgs(uint64(3)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}))
+			},
+			expectedError: ErrParentNotFound,
+		},
+		{
+			name:     "Error parent in another project",
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				expectProject(mock, 3, 2)
+			},
+			expectedError: ErrParentOtherProject,
+		},
+		{
+			name:     "Error cycle",
+			taskID:   2,
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(parent_id, 0) FROM tasks WHERE id = $1")).
+					WithArgs(uint64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}).AddRow(uint64(0)))
+				expectProject(mock, 3, 1)
+				mock.ExpectQuery(regexp.QuoteMeta(taskAncestorsQuery)).
+					WithArgs(uint64(3), uint64(2), 3).
+					WillReturnRows(sqlmock.NewRows([]string{"max", "bool_or"}).AddRow(2, true))
+			},
+			expectedError: ErrTaskHierarchyCycle,
+		},
+		{
+			name:     "Error depth exceeded",
+			taskID:   2,
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(parent_id, 0) FROM tasks WHERE id = $1")).
+					WithArgs(uint64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"parent_id"}).AddRow(uint64(0)))
+				expectProject(mock, 3, 1)
+				mock.ExpectQuery(regexp.QuoteMeta(taskAncestorsQuery)).
+					WithArgs(uint64(3), uint64(2), 3).
+					WillReturnRows(sqlmock.NewRows([]string{"max", "bool_or"}).AddRow(1, false))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSubtreeQuery)).
+					WithArgs(uint64(2), 3).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(3))
+			},
+			expectedError: ErrTaskDepthExceeded,
+		},
+		{
+			name:     "OK new subtask",
+			parentID: 3,
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				expectProject(mock, 3, 1)
+				mock.ExpectQuery(regexp.QuoteMeta(taskAncestorsQuery)).
+					WithArgs(uint64(3), uint64(0), 3).
+					WillReturnRows(sqlmock.NewRows([]string{"max", "bool_or"}).AddRow(2, false))
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			test.mockBehaviour(mock)
+
+			tx, _ := db.Begin()
+			repo := &TaskRepository{db: db}
+			err := repo.checkTaskParent(tx, 1, test.taskID, test.parentID)
+
+			require.Equal(t, test.expectedError, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_GetTaskChildren(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, id uint64) *TaskRepository
+	err := errors.New("error")
+
+	query := "SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.parent_id = $1 AND projects.deleted_at IS NULL ORDER BY tasks.number"
+
+	tests := []struct {
+		name           string
+		id             uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Task
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			id:   1,
+			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK no children",
+			id:   1,
+			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.id)
+			res, err := repo.GetTaskChildren(test.id)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
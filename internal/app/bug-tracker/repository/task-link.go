@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrTaskLinkNotFound = errors.New("error task link is not found")
+	ErrTaskLinkExists   = errors.New("error such a task link already exists")
+	ErrTaskLinkCycle    = errors.New("error blocking link would create a cycle")
+	ErrTaskBlocked      = errors.New("error task is blocked by unresolved tasks")
+)
+
+const taskLinksUniqueConstraint = "task_links_source_id_target_id_link_type_key"
+
+type TaskLinkRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewTaskLinkRepo(db *sql.DB, log log.Log, admin admin, member member, state state) TaskLink {
+	return &TaskLinkRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *TaskLinkRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+func scanLinkedTask(row scanner, dest ...interface{}) (*models.LinkedTask, error) {
+	task := new(models.LinkedTask)
+	var projectKey string
+	var number uint64
+
+	err := row.Scan(append(dest, &task.ID, &projectKey, &number, &task.Name, &task.Status, &task.Category)...)
+	if err != nil {
+		return nil, err
+	}
+	task.Key = fmt.Sprintf("%s-%d", projectKey, number)
+
+	return task, nil
+}
+
+// linkEnds returns the source and the target a link is stored with. Blocked
+// by links are stored as blocks links and relates to links, which read the
+// same both ways, always start at the lower id so they are stored only once.
+func linkEnds(linkData *dto.CreateTaskLinkDto) (uint64, uint64, string) {
+	source, target := linkData.TaskID, linkData.TargetID
+
+	switch linkData.Type {
+	case models.LinkBlockedBy:
+		return target, source, models.LinkBlocks
+	case models.LinkRelatesTo:
+		if source > target {
+			source, target = target, source
+		}
+	}
+
+	return source, target, linkData.Type
+}
+
+func (r *TaskLinkRepository) CreateTaskLink(linkData *dto.CreateTaskLinkDto, userID uint64) (uint64, error) {
+	if !r.isParticipant(linkData.ProjectID, userID) {
+		return 0, ErrNoRights
+	}
+
+	if err := r.state.IsWritable(linkData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	source, target, linkType := linkEnds(linkData)
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if linkType == models.LinkBlocks {
+		// Locking the project serializes blocking links, so two concurrent
+		// links cannot build a cycle together.
+		_, err := tx.Exec("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE", linkData.ProjectID)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	var count int
+	err = tx.QueryRow(
+		"SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)",
+		linkData.ProjectID,
+		pq.Array([]uint64{source, target}),
+	).Scan(&count)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if count != 2 {
+		tx.Rollback()
+		return 0, ErrTaskNotFound
+	}
+
+	if linkType == models.LinkBlocks {
+		var cycle bool
+		err := tx.QueryRow(
+			`WITH RECURSIVE blocked AS (
+				SELECT target_id FROM task_links WHERE source_id = $1 AND link_type = 'blocks'
+				UNION
+				SELECT task_links.target_id FROM task_links JOIN blocked ON task_links.source_id = blocked.target_id
+				WHERE task_links.link_type = 'blocks'
+			)
+			SELECT EXISTS (SELECT 1 FROM blocked WHERE target_id = $2)`,
+			target,
+			source,
+		).Scan(&cycle)
+		if err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return 0, err
+		}
+
+		if cycle {
+			tx.Rollback()
+			return 0, ErrTaskLinkCycle
+		}
+	}
+
+	var linkID uint64
+	err = tx.QueryRow(
+		`INSERT INTO task_links (source_id, target_id, link_type, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		source,
+		target,
+		linkType,
+		userID,
+		time.Now().UTC(),
+	).Scan(&linkID)
+	if err != nil {
+		tx.Rollback()
+		if isUniqueViolation(err, taskLinksUniqueConstraint) {
+			return 0, ErrTaskLinkExists
+		}
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Create task link: id = %d, type = %s", linkID, linkType)
+
+	return linkID, nil
+}
+
+func (r *TaskLinkRepository) DeleteTaskLink(linkData *dto.DeleteTaskLinkDto, userID uint64) error {
+	if !r.isParticipant(linkData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(linkData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		`DELETE FROM task_links USING tasks
+		WHERE task_links.id = $1 AND $2 IN (task_links.source_id, task_links.target_id)
+		AND tasks.id = $2 AND tasks.project_id = $3`,
+		linkData.LinkID,
+		linkData.TaskID,
+		linkData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrTaskLinkNotFound
+	}
+	r.log.Infof("Delete task link: id = %d", linkData.LinkID)
+
+	return nil
+}
+
+// taskParticipant checks that the user can see the task through its project.
+func (r *TaskLinkRepository) taskParticipant(taskID, userID uint64) error {
+	projectID, err := taskProjectID(r.db, taskID)
+	if err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return err
+	}
+
+	if !r.isParticipant(projectID, userID) {
+		return ErrNoRights
+	}
+
+	return nil
+}
+
+func (r *TaskLinkRepository) GetTaskLinks(taskID, userID uint64) ([]*models.TaskLink, error) {
+	if err := r.taskParticipant(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT task_links.id, task_links.link_type, task_links.source_id = $1,
+		tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM task_links
+		JOIN tasks ON tasks.id = CASE WHEN task_links.source_id = $1 THEN task_links.target_id ELSE task_links.source_id END
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE (task_links.source_id = $1 OR task_links.target_id = $1) AND projects.deleted_at IS NULL
+		ORDER BY task_links.link_type, task_links.id`,
+		taskID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*models.TaskLink, 0)
+	for rows.Next() {
+		link := new(models.TaskLink)
+		var outward bool
+
+		link.Task, err = scanLinkedTask(rows, &link.ID, &link.Type, &outward)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		link.Direction = models.LinkInward
+		if outward {
+			link.Direction = models.LinkOutward
+		}
+
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
+func (r *TaskLinkRepository) GetTaskGraph(taskID, userID uint64) (*models.TaskGraph, error) {
+	if err := r.taskParticipant(taskID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`WITH RECURSIVE downstream AS (
+			SELECT id, source_id, target_id FROM task_links WHERE source_id = $1 AND link_type = 'blocks'
+			UNION
+			SELECT task_links.id, task_links.source_id, task_links.target_id
+			FROM task_links JOIN downstream ON task_links.source_id = downstream.target_id
+			WHERE task_links.link_type = 'blocks'
+		), upstream AS (
+			SELECT id, source_id, target_id FROM task_links WHERE target_id = $1 AND link_type = 'blocks'
+			UNION
+			SELECT task_links.id, task_links.source_id, task_links.target_id
+			FROM task_links JOIN upstream ON task_links.target_id = upstream.source_id
+			WHERE task_links.link_type = 'blocks'
+		)
+		SELECT id, source_id, target_id FROM downstream
+		UNION
+		SELECT id, source_id, target_id FROM upstream
+		ORDER BY id`,
+		taskID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	graph := &models.TaskGraph{Nodes: make([]*models.LinkedTask, 0), Edges: make([]*models.TaskGraphEdge, 0)}
+	taskIDs := []uint64{taskID}
+	for rows.Next() {
+		edge := new(models.TaskGraphEdge)
+		if err := rows.Scan(&edge.LinkID, &edge.From, &edge.To); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		graph.Edges = append(graph.Edges, edge)
+		taskIDs = append(taskIDs, edge.From, edge.To)
+	}
+
+	nodes, err := r.db.Query(
+		`SELECT tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM tasks
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE tasks.id = ANY($1) AND projects.deleted_at IS NULL
+		ORDER BY tasks.id`,
+		pq.Array(uniqueIDs(taskIDs)),
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer nodes.Close()
+
+	for nodes.Next() {
+		node, err := scanLinkedTask(nodes)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	if len(graph.Nodes) == 0 {
+		return nil, ErrTaskNotFound
+	}
+
+	return graph, nil
+}
+
+// checkBlockers refuses to resolve a task while any task blocking it is not
+// done yet.
+func checkBlockers(tx *sql.Tx, taskID uint64) error {
+	var blockers int
+	err := tx.QueryRow(
+		`SELECT COUNT(*) FROM task_links
+		JOIN tasks ON tasks.id = task_links.source_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE task_links.target_id = $1 AND task_links.link_type = 'blocks' AND statuses.category <> 'done'`,
+		taskID,
+	).Scan(&blockers)
+	if err != nil {
+		return err
+	}
+
+	if blockers > 0 {
+		return ErrTaskBlocked
+	}
+
+	return nil
+}
@@ -0,0 +1,545 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+const (
+	blockingCycleQuery = `WITH RECURSIVE blocked AS (
+				SELECT target_id FROM task_links WHERE source_id = $1 AND link_type = 'blocks'
+				UNION
+				SELECT task_links.target_id FROM task_links JOIN blocked ON task_links.source_id = blocked.target_id
+				WHERE task_links.link_type = 'blocks'
+			)
+			SELECT EXISTS (SELECT 1 FROM blocked WHERE target_id = $2)`
+	insertTaskLinkQuery = `INSERT INTO task_links (source_id, target_id, link_type, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+)
+
+func Test_linkEnds(t *testing.T) {
+	tests := []struct {
+		name           string
+		linkData       *dto.CreateTaskLinkDto
+		expectedSource uint64
+		expectedTarget uint64
+		expectedType   string
+	}{
+		{
+			name:           "Blocks",
+			linkData:       &dto.CreateTaskLinkDto{TaskID: 2, TargetID: 1, Type: models.LinkBlocks},
+			expectedSource: 2,
+			expectedTarget: 1,
+			expectedType:   models.LinkBlocks,
+		},
+		{
+			name:           "Is blocked by",
+			linkData:       &dto.CreateTaskLinkDto{TaskID: 1, TargetID: 2, Type: models.LinkBlockedBy},
+			expectedSource: 2,
+			expectedTarget: 1,
+			expectedType:   models.LinkBlocks,
+		},
+		{
+			name:           "Relates to",
+			linkData:       &dto.CreateTaskLinkDto{TaskID: 2, TargetID: 1, Type: models.LinkRelatesTo},
+			expectedSource: 1,
+			expectedTarget: 2,
+			expectedType:   models.LinkRelatesTo,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source, target, linkType := linkEnds(test.linkData)
+
+			require.Equal(t, test.expectedSource, source)
+			require.Equal(t, test.expectedTarget, target)
+			require.Equal(t, test.expectedType, linkType)
+		})
+	}
+}
+
+func Test_CreateTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		linkData       *dto.CreateTaskLinkDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:     "Error no rights",
+			linkData: &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlocks},
+			userID:   2,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(linkData.ProjectID, userID).Return(err)
+
+				return &TaskLinkRepository{admin: admin, member: member}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:     "Error task is not found",
+			linkData: &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkDuplicates},
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(linkData.ProjectID, "{1,2}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+
+				return &TaskLinkRepository{db: db, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name:     "Error cycle",
+			linkData: &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlocks},
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+					WithArgs(linkData.ProjectID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(linkData.ProjectID, "{1,2}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(regexp.QuoteMeta(blockingCycleQuery)).
+					WithArgs(uint64(2), uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+
+				return &TaskLinkRepository{db: db, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrTaskLinkCycle,
+		},
+		{
+			name:     "Error link exists",
+			linkData: &dto.CreateTaskLinkDto{TaskID: 2, ProjectID: 1, TargetID: 1, Type: models.LinkRelatesTo},
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(linkData.ProjectID, "{1,2}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(regexp.QuoteMeta(insertTaskLinkQuery)).
+					WithArgs(uint64(1), uint64(2), models.LinkRelatesTo, userID, sqlmock.AnyArg()).
+					WillReturnError(&pq.Error{Code: uniqueViolation, Constraint: taskLinksUniqueConstraint})
+				mock.ExpectRollback()
+
+				return &TaskLinkRepository{db: db, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrTaskLinkExists,
+		},
+		{
+			name:     "OK is blocked by",
+			linkData: &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlockedBy},
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM projects WHERE id = $1 FOR NO KEY UPDATE")).
+					WithArgs(linkData.ProjectID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(linkData.ProjectID, "{2,1}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery(regexp.QuoteMeta(blockingCycleQuery)).
+					WithArgs(uint64(1), uint64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(insertTaskLinkQuery)).
+					WithArgs(uint64(2), uint64(1), models.LinkBlocks, userID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create task link: id = %d, type = %s", uint64(5), models.LinkBlocks)
+
+				return &TaskLinkRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.linkData, test.userID)
+			res, err := repo.CreateTaskLink(test.linkData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkRepository
+	linkData := &dto.DeleteTaskLinkDto{TaskID: 1, ProjectID: 1, LinkID: 5}
+	query := `DELETE FROM task_links USING tasks
+		WHERE task_links.id = $1 AND $2 IN (task_links.source_id, task_links.target_id)
+		AND tasks.id = $2 AND tasks.project_id = $3`
+
+	tests := []struct {
+		name          string
+		linkData      *dto.DeleteTaskLinkDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:     "Error link is not found",
+			linkData: linkData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(linkData.LinkID, linkData.TaskID, linkData.ProjectID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &TaskLinkRepository{db: db, member: member, state: state}
+			},
+			expectedError: ErrTaskLinkNotFound,
+		},
+		{
+			name:     "OK",
+			linkData: linkData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(linkData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(linkData.ProjectID).Return(nil)
+
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(linkData.LinkID, linkData.TaskID, linkData.ProjectID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete task link: id = %d", linkData.LinkID)
+
+				return &TaskLinkRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.linkData, test.userID)
+			err := repo.DeleteTaskLink(test.linkData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskLinks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID uint64) *TaskLinkRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		taskID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.TaskLink
+		expectedError  error
+	}{
+		{
+			name:   "Error task not found",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnError(sql.ErrNoRows)
+
+				return &TaskLinkRepository{db: db}
+			},
+			expectedResult: nil,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name:   "Error no rights",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), uint64(1)).Return(err)
+
+				return &TaskLinkRepository{db: db, admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:   "Error",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT task_links.id, task_links.link_type")).WithArgs(taskID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &TaskLinkRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:   "OK",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "link_type", "outward", "id", "key", "number", "name", "name", "category"}).
+					AddRow(uint64(5), models.LinkBlocks, false, uint64(2), "KEY", uint64(2), "Login", "Done", "done").
+					AddRow(uint64(6), models.LinkDuplicates, true, uint64(3), "KEY", uint64(3), "Crash", "To Do", "todo")
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT task_links.id, task_links.link_type, task_links.source_id = $1,
+						tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+						FROM task_links
+						JOIN tasks ON tasks.id = CASE WHEN task_links.source_id = $1 THEN task_links.target_id ELSE task_links.source_id END
+						JOIN projects ON projects.id = tasks.project_id
+						JOIN statuses ON statuses.id = tasks.status_id
+						WHERE (task_links.source_id = $1 OR task_links.target_id = $1) AND projects.deleted_at IS NULL
+						ORDER BY task_links.link_type, task_links.id`,
+					),
+				).WithArgs(taskID).WillReturnRows(rows)
+
+				return &TaskLinkRepository{db: db, member: member}
+			},
+			expectedResult: []*models.TaskLink{
+				{
+					ID:        5,
+					Type:      models.LinkBlocks,
+					Direction: models.LinkInward,
+					Task:      &models.LinkedTask{ID: 2, Key: "KEY-2", Name: "Login", Status: "Done", Category: "done"},
+				},
+				{
+					ID:        6,
+					Type:      models.LinkDuplicates,
+					Direction: models.LinkOutward,
+					Task:      &models.LinkedTask{ID: 3, Key: "KEY-3", Name: "Crash", Status: "To Do", Category: "todo"},
+				},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskID)
+			res, err := repo.GetTaskLinks(test.taskID, 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskGraph(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID uint64) *TaskLinkRepository
+	err := errors.New("error")
+	nodesQuery := `SELECT tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM tasks
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE tasks.id = ANY($1) AND projects.deleted_at IS NULL
+		ORDER BY tasks.id`
+	nodeColumns := []string{"id", "key", "number", "name", "name", "category"}
+
+	tests := []struct {
+		name           string
+		taskID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult *models.TaskGraph
+		expectedError  error
+	}{
+		{
+			name:   "Error no rights",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), uint64(1)).Return(err)
+
+				return &TaskLinkRepository{db: db, admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:   "Error task is not found",
+			taskID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta("WITH RECURSIVE downstream AS")).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "source_id", "target_id"}))
+				mock.ExpectQuery(regexp.QuoteMeta(nodesQuery)).
+					WithArgs("{1}").
+					WillReturnRows(sqlmock.NewRows(nodeColumns))
+
+				return &TaskLinkRepository{db: db, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name:   "OK",
+			taskID: 2,
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(1)).Return(nil)
+				mock.ExpectQuery(regexp.QuoteMeta("WITH RECURSIVE downstream AS")).
+					WithArgs(taskID).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "source_id", "target_id"}).
+							AddRow(uint64(5), uint64(1), uint64(2)).
+							AddRow(uint64(6), uint64(2), uint64(3)),
+					)
+				mock.ExpectQuery(regexp.QuoteMeta(nodesQuery)).
+					WithArgs("{2,1,3}").
+					WillReturnRows(
+						sqlmock.NewRows(nodeColumns).
+							AddRow(uint64(1), "KEY", uint64(1), "API", "Done", "done").
+							AddRow(uint64(2), "KEY", uint64(2), "Login", "To Do", "todo").
+							AddRow(uint64(3), "KEY", uint64(3), "Release", "To Do", "todo"),
+					)
+
+				return &TaskLinkRepository{db: db, member: member}
+			},
+			expectedResult: &models.TaskGraph{
+				Nodes: []*models.LinkedTask{
+					{ID: 1, Key: "KEY-1", Name: "API", Status: "Done", Category: "done"},
+					{ID: 2, Key: "KEY-2", Name: "Login", Status: "To Do", Category: "todo"},
+					{ID: 3, Key: "KEY-3", Name: "Release", Status: "To Do", Category: "todo"},
+				},
+				Edges: []*models.TaskGraphEdge{
+					{LinkID: 5, From: 1, To: 2},
+					{LinkID: 6, From: 2, To: 3},
+				},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskID)
+			res, err := repo.GetTaskGraph(test.taskID, 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
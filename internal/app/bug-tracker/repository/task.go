@@ -2,47 +2,269 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 )
 
+const (
+	taskColumns = `tasks.id,
+			tasks.number,
+			projects.key,
+			tasks.name,
+			tasks.description,
+			tasks.task_priority,
+			tasks.project_id,
+			tasks.status_id,
+			statuses.name,
+			statuses.category,
+			tasks.kind_id,
+			issue_kinds.name,
+			issue_kinds.icon,
+			tasks.assignee,
+			tasks.reviewer,
+			tasks.resolution,
+			tasks.created_at,
+			tasks.perform_to,
+			tasks.reporter,
+			tasks.updated_at,
+			tasks.updated_by,
+			tasks.severity,
+			tasks.environment,
+			tasks.affected_version,
+			tasks.steps_to_reproduce,
+			tasks.expected_result,
+			tasks.actual_result,
+			tasks.reproducibility,
+			tasks.component_id,
+			components.name,
+			tasks.sprint_id,
+			tasks.parent_id,
+			(SELECT json_build_object('total', COUNT(*), 'done', COUNT(*) FILTER (WHERE child_statuses.category = 'done'))
+			FROM tasks AS children JOIN statuses AS child_statuses ON child_statuses.id = children.status_id
+			WHERE children.parent_id = tasks.id),
+			(SELECT json_agg(json_build_object('id', versions.id, 'name', versions.name, 'released', versions.released)
+				ORDER BY versions.release_date NULLS LAST, versions.id)
+			FROM task_versions JOIN versions ON versions.id = task_versions.version_id
+			WHERE task_versions.task_id = tasks.id AND task_versions.relation = 'affects'),
+			(SELECT json_agg(json_build_object('id', versions.id, 'name', versions.name, 'released', versions.released)
+				ORDER BY versions.release_date NULLS LAST, versions.id)
+			FROM task_versions JOIN versions ON versions.id = task_versions.version_id
+			WHERE task_versions.task_id = tasks.id AND task_versions.relation = 'fix'),
+			(SELECT json_agg(json_build_object(
+				'id', labels.id,
+				'projectId', labels.project_id,
+				'name', labels.name,
+				'color', labels.color,
+				'description', labels.description
+			) ORDER BY labels.name)
+			FROM task_labels JOIN labels ON labels.id = task_labels.label_id
+			WHERE task_labels.task_id = tasks.id),
+			(SELECT json_agg(json_build_object(
+				'fieldId', custom_fields.id,
+				'name', custom_fields.name,
+				'type', custom_fields.field_type,
+				'value', CASE custom_fields.field_type
+					WHEN 'number' THEN to_json(task_custom_values.value_number)
+					WHEN 'user' THEN to_json(task_custom_values.value_number::BIGINT)
+					WHEN 'multiselect' THEN to_json(task_custom_values.value_options)
+					ELSE to_json(task_custom_values.value_text)
+				END
+			) ORDER BY custom_fields.position, custom_fields.id)
+			FROM task_custom_values JOIN custom_fields ON custom_fields.id = task_custom_values.field_id
+			WHERE task_custom_values.task_id = tasks.id)`
+	taskTables = `tasks
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		JOIN issue_kinds ON issue_kinds.id = tasks.kind_id
+		LEFT JOIN components ON components.id = tasks.component_id`
+)
+
+var (
+	ErrTaskNotFound      = errors.New("error task is not found")
+	ErrReviewerNotMember = errors.New("error reviewer is not a member of the project")
+	ErrAssigneeNotMember = errors.New("error assignee is not a member of the project")
+	ErrInvalidTaskFilter = errors.New("error invalid task filter")
+)
+
+var taskSortColumns = map[string]string{
+	"id":        "tasks.id",
+	"number":    "tasks.number",
+	"priority":  "tasks.task_priority",
+	"createdAt": "tasks.created_at",
+	"performTo": "tasks.perform_to",
+	"reporter":  "tasks.reporter",
+	"updatedAt": "tasks.updated_at",
+	"updatedBy": "tasks.updated_by",
+}
+
 type TaskRepository struct {
 	db     *sql.DB
 	log    log.Log
 	admin  admin
 	member member
+	state  state
 }
 
-func NewTaskRepo(db *sql.DB, log log.Log, admin admin, member member) Task {
+func NewTaskRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Task {
 	return &TaskRepository{
 		db:     db,
 		log:    log,
 		admin:  admin,
 		member: member,
+		state:  state,
 	}
 }
 
-func (r *TaskRepository) CreateTask(taskData *dto.CreateTaskDto, userID uint64) (uint64, error) {
+func (r *TaskRepository) CreateTask(taskData *dto.CreateTaskDto, userID uint64, event *models.Event) (uint64, error) {
 	if err := r.admin.IsAdmin(taskData.ProjectID, userID); err != nil {
 		return 0, err
 	}
 
-	result := r.db.QueryRow(
-		"INSERT INTO tasks (name, description, task_priority, project_id, task_type, created_at, perform_to) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+	if err := r.state.IsWritable(taskData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	if taskData.AssigneeID != 0 && !r.isParticipant(taskData.ProjectID, taskData.AssigneeID) {
+		return 0, ErrAssigneeNotMember
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if err := checkBugFields(tx, taskData.ProjectID, taskData.KindID, &taskData.BugFieldsDto); err != nil {
+		if _, ok := err.(*RequiredFieldsError); !ok {
+			r.log.Error(err)
+		}
+		tx.Rollback()
+		return 0, err
+	}
+
+	customValues, err := r.customFieldValues(tx, taskData.ProjectID, taskData.CustomFields, true)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	assignee, err := r.componentAssignee(tx, taskData.ProjectID, taskData.ComponentID)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := r.checkTaskParent(tx, taskData.ProjectID, 0, taskData.ParentID); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if taskData.AssigneeID != 0 {
+		assignee = sql.NullInt64{Int64: int64(taskData.AssigneeID), Valid: true}
+	}
+
+	var number uint64
+	err = tx.QueryRow(
+		"UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter",
+		taskData.ProjectID,
+	).Scan(&number)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	result := tx.QueryRow(
+		`INSERT INTO tasks (name, description, task_priority, project_id, status_id, created_at, perform_to, number, kind_id,
+		severity, environment, affected_version, steps_to_reproduce, expected_result, actual_result, reproducibility,
+		component_id, assignee, parent_id, reporter, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (SELECT id FROM statuses WHERE project_id = $4 AND is_initial)), $6, $7, $8,
+		COALESCE(NULLIF($9, 0), (SELECT id FROM issue_kinds WHERE project_id = $4 AND is_default)),
+		NULLIF($10, '')::bug_severity, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''),
+		NULLIF($16, '')::bug_reproducibility, NULLIF($17, 0), $18, NULLIF($19, 0), $20, $6, $20)
+		RETURNING id`,
 		taskData.Name,
 		taskData.Description,
 		taskData.TaskPriority,
 		taskData.ProjectID,
-		taskData.TaskType,
-		time.Now(),
+		taskData.StatusID,
+		time.Now().UTC(),
 		taskData.PerformTo,
+		number,
+		taskData.KindID,
+		taskData.Severity,
+		taskData.Environment,
+		taskData.AffectedVersion,
+		taskData.StepsToReproduce,
+		taskData.ExpectedResult,
+		taskData.ActualResult,
+		taskData.Reproducibility,
+		taskData.ComponentID,
+		assignee,
+		taskData.ParentID,
+		userID,
 	)
 
 	var taskID uint64
 	if err := result.Scan(&taskID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, taskConstraintError(err)
+	}
+
+	if err := saveCustomFieldValues(tx, taskID, customValues); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = r.saveTaskVersions(tx, taskData.ProjectID, taskID, taskData.AffectsVersions, taskData.FixVersions)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := recordActivity(tx, taskID, userID, models.ActivityCreated, "", nil, nil); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	watchers := []uint64{userID}
+	if assignee.Valid {
+		watchers = append(watchers, uint64(assignee.Int64))
+	}
+
+	if err := addWatchers(tx, taskID, watchers...); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := addWatchEvents(tx, taskID, userID, models.ActivityCreated); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	event.TaskID = taskID
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		r.log.Error(err)
 		return 0, err
 	}
@@ -51,130 +273,798 @@ func (r *TaskRepository) CreateTask(taskData *dto.CreateTaskDto, userID uint64)
 	return taskID, nil
 }
 
-func (r *TaskRepository) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
+func (r *TaskRepository) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
 	if r.member.IsMember(workOnTaskData.ProjectID, userID) != nil && r.admin.IsAdmin(workOnTaskData.ProjectID, userID) != nil {
 		return ErrNoRights
 	}
 
-	_, err := r.db.Exec("UPDATE tasks SET assignee = $1 WHERE id = $2 AND assignee IS NULL", userID, workOnTaskData.TaskID)
-	if err != nil {
-		r.log.Error(err)
+	if err := r.state.IsWritable(workOnTaskData.ProjectID); err != nil {
 		return err
 	}
 
-	return nil
+	return r.changeAssignee(
+		workOnTaskData.TaskID,
+		workOnTaskData.ProjectID,
+		userID,
+		event,
+		"UPDATE tasks SET assignee = $1, updated_at = $2, updated_by = $1 WHERE id = $3 AND assignee IS NULL RETURNING assignee",
+		userID,
+		time.Now().UTC(),
+		workOnTaskData.TaskID,
+	)
 }
 
-func (r *TaskRepository) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
+func (r *TaskRepository) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
 	if r.member.IsMember(workOnTaskData.ProjectID, userID) != nil && r.admin.IsAdmin(workOnTaskData.ProjectID, userID) != nil {
 		return ErrNoRights
 	}
 
-	_, err := r.db.Exec("UPDATE tasks SET assignee = NULL WHERE id = $1 AND assignee IS NOT NULL", workOnTaskData.TaskID)
+	if err := r.state.IsWritable(workOnTaskData.ProjectID); err != nil {
+		return err
+	}
+
+	return r.changeAssignee(
+		workOnTaskData.TaskID,
+		workOnTaskData.ProjectID,
+		userID,
+		event,
+		"UPDATE tasks SET assignee = NULL, updated_at = $1, updated_by = $2 WHERE id = $3 AND assignee IS NOT NULL RETURNING assignee",
+		time.Now().UTC(),
+		userID,
+		workOnTaskData.TaskID,
+	)
+}
+
+// changeAssignee runs the update of the assignee of the task, which returns
+// the new assignee, and logs the assignment when the update changed it. The
+// task has to be in the project the rights were checked against. A new
+// assignee starts watching the task. The event and the events for the
+// watchers are saved only when the update changed the task.
+func (r *TaskRepository) changeAssignee(
+	taskID, projectID, userID uint64,
+	event *models.Event,
+	query string,
+	args ...interface{},
+) error {
+	tx, err := r.db.Begin()
 	if err != nil {
 		r.log.Error(err)
 		return err
 	}
 
+	if _, _, err := r.lockTask(tx, taskID, projectID); err != nil {
+		return err
+	}
+
+	snapshot, err := takeTaskSnapshot(tx, taskID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	var assignee sql.NullInt64
+	err = tx.QueryRow(query, args...).Scan(&assignee)
+	if err != nil && err != sql.ErrNoRows {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+	changed := err == nil
+
+	if err := recordTaskChanges(tx, taskID, userID, snapshot); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if assignee.Valid {
+		if err := addWatchers(tx, taskID, uint64(assignee.Int64)); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if changed {
+		if err := addWatchEvents(tx, taskID, userID, models.ActivityAssignment); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+
+		if err := addEvent(tx, event); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+
 	return nil
 }
 
-func (r *TaskRepository) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64) (uint64, error) {
+// UpdateTask returns the id of the task and the kind of change its watchers
+// are told about, a status change when the status changed.
+func (r *TaskRepository) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64, event *models.Event) (uint64, string, error) {
 	if err := r.admin.IsAdmin(taskData.ProjectID, userID); err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	result := r.db.QueryRow(
-		"UPDATE tasks SET name = $1, description = $2, task_priority = $3, project_id = $4, task_type = $5, perform_to = $6 WHERE id = $7 RETURNING id",
+	if err := r.state.IsWritable(taskData.ProjectID); err != nil {
+		return 0, "", err
+	}
+
+	if taskData.ReviewerID != 0 && !r.isParticipant(taskData.ProjectID, taskData.ReviewerID) {
+		return 0, "", ErrReviewerNotMember
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, "", err
+	}
+
+	statusID, transition, err := r.lockTask(tx, taskData.TaskID, taskData.ProjectID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	resolution := transition.resolution
+	kind := models.ActivityField
+	if taskData.StatusID != 0 && taskData.StatusID != statusID {
+		transition.actorID = userID
+		transition.isAdmin = func() bool { return true }
+
+		if resolution, err = r.checkTransition(tx, statusID, taskData.StatusID, transition, ""); err != nil {
+			return 0, "", err
+		}
+		statusID = taskData.StatusID
+		kind = models.ActivityStatus
+	}
+
+	customValues, err := r.customFieldValues(tx, taskData.ProjectID, taskData.CustomFields, false)
+	if err != nil {
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	assignee, err := r.componentAssignee(tx, taskData.ProjectID, taskData.ComponentID)
+	if err != nil {
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	if err := r.checkTaskParent(tx, taskData.ProjectID, taskData.TaskID, taskData.ParentID); err != nil {
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	snapshot, err := takeTaskSnapshot(tx, taskData.TaskID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	result := tx.QueryRow(
+		`UPDATE tasks SET name = $1, description = $2, task_priority = $3, status_id = $4, perform_to = $5,
+		reviewer = NULLIF($6, 0), resolution = $7, kind_id = COALESCE(NULLIF($8, 0), kind_id),
+		severity = NULLIF($9, '')::bug_severity, environment = NULLIF($10, ''), affected_version = NULLIF($11, ''),
+		steps_to_reproduce = NULLIF($12, ''), expected_result = NULLIF($13, ''), actual_result = NULLIF($14, ''),
+		reproducibility = NULLIF($15, '')::bug_reproducibility, component_id = NULLIF($16, 0),
+		assignee = CASE WHEN component_id IS DISTINCT FROM NULLIF($16, 0) THEN COALESCE(assignee, $17) ELSE assignee END,
+		parent_id = NULLIF($18, 0), updated_at = $20, updated_by = $21
+		WHERE id = $19 RETURNING id, assignee`,
 		taskData.Name,
 		taskData.Description,
 		taskData.TaskPriority,
-		taskData.ProjectID,
-		taskData.TaskType,
+		statusID,
 		taskData.PerformTo,
+		taskData.ReviewerID,
+		resolution,
+		taskData.KindID,
+		taskData.Severity,
+		taskData.Environment,
+		taskData.AffectedVersion,
+		taskData.StepsToReproduce,
+		taskData.ExpectedResult,
+		taskData.ActualResult,
+		taskData.Reproducibility,
+		taskData.ComponentID,
+		assignee,
+		taskData.ParentID,
 		taskData.TaskID,
+		time.Now().UTC(),
+		userID,
 	)
 
 	var taskID uint64
-	if err := result.Scan(&taskID); err != nil {
+	if err := result.Scan(&taskID, &assignee); err != nil {
 		r.log.Error(err)
-		return 0, err
+		tx.Rollback()
+		return 0, "", taskConstraintError(err)
 	}
-	r.log.Infof("Create task: id = %d", taskID)
 
-	return taskID, nil
+	if err := saveCustomFieldValues(tx, taskID, customValues); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	err = r.saveTaskVersions(tx, taskData.ProjectID, taskID, taskData.AffectsVersions, taskData.FixVersions)
+	if err != nil {
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	if err := recordTaskChanges(tx, taskID, userID, snapshot); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	// The component may have given the task its default assignee.
+	if assignee.Valid && assignee != transition.assignee {
+		if err := addWatchers(tx, taskID, uint64(assignee.Int64)); err != nil {
+			r.log.Error(err)
+			tx.Rollback()
+			return 0, "", err
+		}
+	}
+
+	if err := addWatchEvents(tx, taskID, userID, kind); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, "", err
+	}
+	r.log.Infof("Update task: id = %d", taskID)
+
+	return taskID, kind, nil
 }
 
-func (r *TaskRepository) GetTaskById(id uint64) (*models.Task, error) {
-	result := r.db.QueryRow(
-		`SELECT 
-			id, 
-			name, 
-			description, 
-			task_priority, 
-			project_id, 
-			task_type, 
-			assignee, 
-			created_at, 
-			perform_to 
-		FROM tasks WHERE id = $1`,
-		id,
+func (r *TaskRepository) TransitionTask(taskData *dto.TransitionTaskDto, userID uint64, event *models.Event) error {
+	if !r.isParticipant(taskData.ProjectID, userID) {
+		return ErrNoRights
+	}
+
+	if err := r.state.IsWritable(taskData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	statusID, transition, err := r.lockTask(tx, taskData.TaskID, taskData.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if statusID == taskData.StatusID {
+		return tx.Commit()
+	}
+
+	transition.actorID = userID
+	transition.isAdmin = func() bool { return r.admin.IsAdmin(taskData.ProjectID, userID) == nil }
+
+	resolution, err := r.checkTransition(tx, statusID, taskData.StatusID, transition, taskData.Resolution)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := takeTaskSnapshot(tx, taskData.TaskID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	_, err = tx.Exec(
+		"UPDATE tasks SET status_id = $1, resolution = $2, updated_at = $3, updated_by = $4 WHERE id = $5",
+		taskData.StatusID,
+		resolution,
+		time.Now().UTC(),
+		userID,
+		taskData.TaskID,
 	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordTaskChanges(tx, taskData.TaskID, userID, snapshot); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addWatchEvents(tx, taskData.TaskID, userID, models.ActivityStatus); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Transition task: id = %d, status = %d", taskData.TaskID, taskData.StatusID)
+
+	return nil
+}
+
+func taskConstraintError(err error) error {
+	switch {
+	case isForeignKeyViolation(err, tasksStatusConstraint):
+		return ErrStatusNotFound
+	case isForeignKeyViolation(err, tasksKindConstraint):
+		return ErrIssueKindNotFound
+	default:
+		return err
+	}
+}
+
+func (r *TaskRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
 
+// componentAssignee checks that the component belongs to the project and
+// returns the user its tasks go to, which is nobody once the default assignee
+// has left the project.
+func (r *TaskRepository) componentAssignee(tx *sql.Tx, projectID, componentID uint64) (sql.NullInt64, error) {
+	var assignee sql.NullInt64
+	if componentID == 0 {
+		return assignee, nil
+	}
+
+	err := tx.QueryRow(
+		"SELECT default_assignee FROM components WHERE id = $1 AND project_id = $2",
+		componentID,
+		projectID,
+	).Scan(&assignee)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return assignee, ErrComponentNotFound
+		}
+		r.log.Error(err)
+		return assignee, err
+	}
+
+	if assignee.Valid && !r.isParticipant(projectID, uint64(assignee.Int64)) {
+		return sql.NullInt64{}, nil
+	}
+
+	return assignee, nil
+}
+
+// lockTask locks the task row for the rest of the transaction and returns its
+// current status together with the fields transition guards look at.
+func (r *TaskRepository) lockTask(tx *sql.Tx, taskID, projectID uint64) (uint64, *taskTransition, error) {
+	var statusID uint64
+	transition := &taskTransition{taskID: taskID}
+
+	err := tx.QueryRow(
+		"SELECT status_id, assignee, reviewer, resolution FROM tasks WHERE id = $1 AND project_id = $2 FOR UPDATE",
+		taskID,
+		projectID,
+	).Scan(&statusID, &transition.assignee, &transition.reviewer, &transition.resolution)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, nil, ErrTaskNotFound
+		}
+		return 0, nil, err
+	}
+
+	return statusID, transition, nil
+}
+
+// checkTransition validates a status change against the workflow and its
+// guards, returning the resolution the task should have afterwards.
+func (r *TaskRepository) checkTransition(
+	tx *sql.Tx,
+	fromStatusID, toStatusID uint64,
+	transition *taskTransition,
+	resolution string,
+) (sql.NullString, error) {
+	guards, category, err := transitionRule(tx, fromStatusID, toStatusID)
+	if err != nil {
+		if err != ErrTransitionNotAllowed {
+			r.log.Error(err)
+		}
+		tx.Rollback()
+		return sql.NullString{}, err
+	}
+
+	if resolution != "" {
+		transition.resolution = sql.NullString{String: resolution, Valid: true}
+	}
+
+	if err := evaluateGuards(guards, transition); err != nil {
+		tx.Rollback()
+		return sql.NullString{}, err
+	}
+
+	if category == models.StatusCategoryDone {
+		if err := checkBlockers(tx, transition.taskID); err != nil {
+			if err != ErrTaskBlocked {
+				r.log.Error(err)
+			}
+			tx.Rollback()
+			return sql.NullString{}, err
+		}
+	}
+
+	return resolutionFor(category, resolution, transition.resolution), nil
+}
+
+func scanTask(row scanner) (*models.Task, error) {
 	task := new(models.Task)
-	if err := result.Scan(
+	var projectKey string
+	var progress []byte
+	var affectsVersions, fixVersions []byte
+	var labels []byte
+	var customFields []byte
+
+	err := row.Scan(
 		&task.ID,
+		&task.Number,
+		&projectKey,
 		&task.Name,
 		&task.Description,
 		&task.Priority,
 		&task.ProjectID,
-		&task.TaskType,
+		&task.StatusID,
+		&task.Status,
+		&task.Category,
+		&task.KindID,
+		&task.Kind,
+		&task.KindIcon,
 		&task.Assignee,
+		&task.Reviewer,
+		&task.Resolution,
 		&task.CreatedAt,
 		&task.PerformTo,
-	); err != nil {
+		&task.Reporter,
+		&task.UpdatedAt,
+		&task.UpdatedBy,
+		&task.Severity,
+		&task.Environment,
+		&task.AffectedVersion,
+		&task.StepsToReproduce,
+		&task.ExpectedResult,
+		&task.ActualResult,
+		&task.Reproducibility,
+		&task.ComponentID,
+		&task.Component,
+		&task.SprintID,
+		&task.ParentID,
+		&progress,
+		&affectsVersions,
+		&fixVersions,
+		&labels,
+		&customFields,
+	)
+	if err != nil {
+		return nil, err
+	}
+	task.Key = fmt.Sprintf("%s-%d", projectKey, task.Number)
+
+	if len(progress) > 0 {
+		task.Progress = new(models.TaskProgress)
+		if err := json.Unmarshal(progress, task.Progress); err != nil {
+			return nil, err
+		}
+
+		if task.Progress.Total == 0 {
+			task.Progress = nil
+		} else {
+			task.Progress.Percent = task.Progress.Done * 100 / task.Progress.Total
+		}
+	}
+
+	task.AffectsVersions = make([]*models.TaskVersion, 0)
+	if len(affectsVersions) > 0 {
+		if err := json.Unmarshal(affectsVersions, &task.AffectsVersions); err != nil {
+			return nil, err
+		}
+	}
+
+	task.FixVersions = make([]*models.TaskVersion, 0)
+	if len(fixVersions) > 0 {
+		if err := json.Unmarshal(fixVersions, &task.FixVersions); err != nil {
+			return nil, err
+		}
+	}
+
+	task.Labels = make([]*models.Label, 0)
+	if len(labels) > 0 {
+		if err := json.Unmarshal(labels, &task.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	task.CustomFields = make([]*models.CustomFieldValue, 0)
+	if len(customFields) > 0 {
+		if err := json.Unmarshal(customFields, &task.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// GetTaskById returns the task when the user can see it through its project.
+func (r *TaskRepository) GetTaskById(id, userID uint64) (*models.Task, error) {
+	result := r.db.QueryRow(
+		`SELECT `+taskColumns+`
+		FROM `+taskTables+`
+		WHERE tasks.id = $1 AND projects.deleted_at IS NULL`,
+		id,
+	)
+
+	task, err := scanTask(result)
+	if err != nil {
 		r.log.Error(err)
 		return nil, err
 	}
+
+	if !r.isParticipant(task.ProjectID, userID) {
+		return nil, ErrNoRights
+	}
 	r.log.Infof("Get task: id = %d", id)
 
 	return task, nil
 }
 
-func (r *TaskRepository) GetTasksByProjectId(id uint64) ([]*models.Task, error) {
+// GetTaskIdByKey resolves a task key such as API-42 to the task and its
+// project. Keys a project had before a rename still resolve, though the
+// current key of a project wins.
+func (r *TaskRepository) GetTaskIdByKey(projectKey string, number uint64) (uint64, uint64, error) {
+	result := r.db.QueryRow(
+		`SELECT tasks.id, tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+		WHERE tasks.number = $2 AND projects.deleted_at IS NULL AND (
+			projects.key = $1 OR projects.id IN (SELECT project_id FROM project_key_history WHERE key = $1)
+		) ORDER BY projects.key = $1 DESC LIMIT 1`,
+		projectKey,
+		number,
+	)
+
+	var taskID, projectID uint64
+	if err := result.Scan(&taskID, &projectID); err != nil {
+		r.log.Error(err)
+		if err == sql.ErrNoRows {
+			return 0, 0, ErrTaskNotFound
+		}
+		return 0, 0, err
+	}
+
+	return taskID, projectID, nil
+}
+
+// taskFilterQuery builds the WHERE and ORDER BY clauses for listing the tasks
+// of a project, adding a condition for every filter that is set.
+func taskFilterQuery(projectID uint64, filter *dto.TaskFilterDto) (string, string, []interface{}, error) {
+	conditions := []string{"tasks.project_id = $1", "projects.deleted_at IS NULL"}
+	args := []interface{}{projectID}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter != nil {
+		if filter.Kind != "" {
+			addCondition("issue_kinds.name = $%d", filter.Kind)
+		}
+		if filter.Status != "" {
+			addCondition("statuses.name = $%d", filter.Status)
+		}
+		if filter.StatusCategory != "" {
+			addCondition("statuses.category = $%d", filter.StatusCategory)
+		}
+		if filter.Severity != "" {
+			addCondition("tasks.severity = $%d", filter.Severity)
+		}
+		if filter.Reproducibility != "" {
+			addCondition("tasks.reproducibility = $%d", filter.Reproducibility)
+		}
+		if filter.AffectedVersion != "" {
+			addCondition("tasks.affected_version = $%d", filter.AffectedVersion)
+		}
+		if filter.Component != 0 {
+			addCondition("tasks.component_id = $%d", filter.Component)
+		}
+		if filter.Reporter != 0 {
+			addCondition("tasks.reporter = $%d", filter.Reporter)
+		}
+		if filter.UpdatedBy != 0 {
+			addCondition("tasks.updated_by = $%d", filter.UpdatedBy)
+		}
+		if filter.UpdatedAfter != "" {
+			updatedAfter, err := parseTaskFilterTime(filter.UpdatedAfter)
+			if err != nil {
+				return "", "", nil, err
+			}
+			addCondition("tasks.updated_at >= $%d", updatedAfter)
+		}
+		if filter.UpdatedBefore != "" {
+			updatedBefore, err := parseTaskFilterTime(filter.UpdatedBefore)
+			if err != nil {
+				return "", "", nil, err
+			}
+			addCondition("tasks.updated_at < $%d", updatedBefore)
+		}
+		if filter.Sprint != 0 {
+			addCondition("tasks.sprint_id = $%d", filter.Sprint)
+		}
+		if filter.Backlog {
+			conditions = append(conditions, "tasks.sprint_id IS NULL")
+		}
+		if filter.AffectsVersion != 0 {
+			addCondition(
+				"EXISTS (SELECT 1 FROM task_versions WHERE task_versions.task_id = tasks.id AND task_versions.version_id = $%d AND task_versions.relation = 'affects')",
+				filter.AffectsVersion,
+			)
+		}
+		if filter.FixVersion != 0 {
+			addCondition(
+				"EXISTS (SELECT 1 FROM task_versions WHERE task_versions.task_id = tasks.id AND task_versions.version_id = $%d AND task_versions.relation = 'fix')",
+				filter.FixVersion,
+			)
+		}
+		if len(filter.Labels) > 0 {
+			if filter.LabelMatch == "all" {
+				addCondition(
+					"$%d::BIGINT[] <@ ARRAY(SELECT label_id FROM task_labels WHERE task_labels.task_id = tasks.id)",
+					pq.Array(filter.Labels),
+				)
+			} else {
+				addCondition(
+					"EXISTS (SELECT 1 FROM task_labels WHERE task_labels.task_id = tasks.id AND task_labels.label_id = ANY($%d))",
+					pq.Array(filter.Labels),
+				)
+			}
+		}
+		for _, customField := range filter.CustomFields {
+			fieldID, value, err := parseCustomFieldFilter(customField)
+			if err != nil {
+				return "", "", nil, err
+			}
+
+			args = append(args, fieldID, value)
+			conditions = append(conditions, fmt.Sprintf(
+				`EXISTS (SELECT 1 FROM task_custom_values WHERE task_custom_values.task_id = tasks.id
+				AND task_custom_values.field_id = $%d
+				AND (task_custom_values.value_text = $%[2]d OR $%[2]d = ANY(task_custom_values.value_options)))`,
+				len(args)-1,
+				len(args),
+			))
+		}
+	}
+
+	orderBy := "tasks.id"
+	if filter != nil && filter.Sort != "" {
+		order, err := taskSortQuery(filter.Sort, &args)
+		if err != nil {
+			return "", "", nil, err
+		}
+		orderBy = order + ", tasks.id"
+	}
+
+	return strings.Join(conditions, " AND "), orderBy, args, nil
+}
+
+// parseTaskFilterTime reads an RFC 3339 timestamp and normalises it to UTC.
+func parseTaskFilterTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidTaskFilter
+	}
+
+	return t.UTC(), nil
+}
+
+func parseCustomFieldFilter(filter string) (uint64, string, error) {
+	id, value, ok := strings.Cut(filter, ":")
+	if !ok {
+		return 0, "", ErrInvalidTaskFilter
+	}
+
+	fieldID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidTaskFilter
+	}
+
+	return fieldID, value, nil
+}
+
+// taskSortQuery turns a sort key into an ORDER BY expression. Tasks without a
+// value for the custom field they are sorted by always come last.
+func taskSortQuery(sort string, args *[]interface{}) (string, error) {
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = sort[1:]
+	}
+
+	if column, ok := taskSortColumns[sort]; ok {
+		return column + " " + direction, nil
+	}
+
+	if !strings.HasPrefix(sort, "cf:") {
+		return "", ErrInvalidTaskFilter
+	}
+
+	fieldID, err := strconv.ParseUint(strings.TrimPrefix(sort, "cf:"), 10, 64)
+	if err != nil {
+		return "", ErrInvalidTaskFilter
+	}
+
+	*args = append(*args, fieldID)
+	value := func(column string) string {
+		return fmt.Sprintf(
+			`(SELECT task_custom_values.%s FROM task_custom_values
+			WHERE task_custom_values.task_id = tasks.id AND task_custom_values.field_id = $%d)`,
+			column,
+			len(*args),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s %s NULLS LAST, %s %s NULLS LAST",
+		value("value_number"), direction, value("value_text"), direction,
+	), nil
+}
+
+func (r *TaskRepository) GetTasksByProjectId(id uint64, filter *dto.TaskFilterDto) ([]*models.Task, error) {
+	where, orderBy, args, err := taskFilterQuery(id, filter)
+	if err != nil {
+		return nil, err
+	}
+
 	rows, err := r.db.Query(
-		`SELECT 
-			id, 
-			name, 
-			description, 
-			task_priority, 
-			project_id, 
-			task_type, 
-			assignee, 
-			created_at, 
-			perform_to 
-		FROM tasks WHERE project_id = $1`,
-		id,
+		`SELECT `+taskColumns+`
+		FROM `+taskTables+`
+		WHERE `+where+`
+		ORDER BY `+orderBy,
+		args...,
 	)
 	if err != nil {
 		r.log.Error(err)
 		return nil, err
 	}
+	defer rows.Close()
 
 	tasks := make([]*models.Task, 0)
 	for rows.Next() {
-		task := new(models.Task)
-		err := rows.Scan(
-			&task.ID,
-			&task.Name,
-			&task.Description,
-			&task.Priority,
-			&task.ProjectID,
-			&task.TaskType,
-			&task.Assignee,
-			&task.CreatedAt,
-			&task.PerformTo,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			r.log.Error(err)
 			return nil, err
@@ -186,16 +1076,66 @@ func (r *TaskRepository) GetTasksByProjectId(id uint64) ([]*models.Task, error)
 	return tasks, nil
 }
 
-func (r *TaskRepository) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64) error {
+// DeleteTask returns the keys the contents of the attachments of the task are
+// stored under, for the caller to remove once the task is gone.
+func (r *TaskRepository) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64, event *models.Event) ([]string, error) {
 	if err := r.admin.IsAdmin(taskData.ProjectID, userID); err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err := r.db.Exec("DELETE FROM tasks WHERE id = $1", taskData.TaskID)
+	if err := r.state.IsWritable(taskData.ProjectID); err != nil {
+		return nil, err
+	}
 
+	tx, err := r.db.Begin()
 	if err != nil {
 		r.log.Error(err)
+		return nil, err
+	}
+
+	// Locking the task keeps attachments from being added to it meanwhile.
+	if _, _, err := r.lockTask(tx, taskData.TaskID, taskData.ProjectID); err != nil {
+		return nil, err
+	}
+
+	storageKeys, err := attachmentStorageKeys(tx, "SELECT storage_key FROM attachments WHERE task_id = $1", taskData.TaskID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM tasks WHERE id = $1", taskData.TaskID); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := addEvent(tx, event); err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return storageKeys, nil
+}
+
+// taskProjectID returns the project of a task that is not in the trash.
+func taskProjectID(db *sql.DB, taskID uint64) (uint64, error) {
+	var projectID uint64
+	err := db.QueryRow(
+		`SELECT tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+		WHERE tasks.id = $1 AND projects.deleted_at IS NULL`,
+		taskID,
+	).Scan(&projectID)
+	if err == sql.ErrNoRows {
+		return 0, ErrTaskNotFound
 	}
 
-	return err
+	return projectID, err
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"regexp"
 	"testing"
@@ -20,7 +21,15 @@ import (
 func Test_CreateTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskCreated, 1, 1, 1, nil)
 	performTo := "2023-12-18 10:53:00"
+	taskData := &dto.CreateTaskDto{
+		Name:         "name",
+		Description:  "description",
+		TaskPriority: "high",
+		ProjectID:    1,
+		PerformTo:    performTo,
+	}
 
 	tests := []struct {
 		name           string
@@ -31,16 +40,9 @@ func Test_CreateTask(t *testing.T) {
 		expectedError  error
 	}{
 		{
-			name: "Error in admin",
-			taskData: &dto.CreateTaskDto{
-				Name:         "name",
-				Description:  "description",
-				TaskPriority: "high",
-				ProjectID:    1,
-				TaskType:     "IN PROGRESS",
-				PerformTo:    performTo,
-			},
-			userID: 1,
+			name:     "Error in admin",
+			taskData: taskData,
+			userID:   1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
 				admin := mock_repository.NewMockadmin(c)
 
@@ -51,82 +53,440 @@ func Test_CreateTask(t *testing.T) {
 			expectedError: err,
 		},
 		{
-			name: "Error",
-			taskData: &dto.CreateTaskDto{
-				Name:         "name",
-				Description:  "description",
-				TaskPriority: "high",
-				ProjectID:    1,
-				TaskType:     "IN PROGRESS",
-				PerformTo:    performTo,
+			name:     "Error cannot allocate task number",
+			taskData: taskData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"})
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter"),
+				).WithArgs(taskData.ProjectID).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
-			userID: 1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name:     "Error required bug fields are missing",
+			taskData: &dto.CreateTaskDto{Name: "name", TaskPriority: "high", ProjectID: 1, KindID: 1, PerformTo: performTo},
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(
+					sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(true, "{severity,stepsToReproduce}"),
+				)
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  &RequiredFieldsError{Fields: []string{"severity", "stepsToReproduce"}},
+		},
+		{
+			name:     "Error",
+			taskData: taskData,
+			userID:   1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
 
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"})
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"task_counter"}).AddRow(uint64(42)))
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"INSERT INTO tasks (name, description, task_priority, project_id, task_type, created_at, perform_to) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+						`INSERT INTO tasks (name, description, task_priority, project_id, status_id, created_at, perform_to, number, kind_id,
+						severity, environment, affected_version, steps_to_reproduce, expected_result, actual_result, reproducibility,
+						component_id, assignee, parent_id, reporter, updated_at, updated_by)
+						VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (SELECT id FROM statuses WHERE project_id = $4 AND is_initial)), $6, $7, $8,
+						COALESCE(NULLIF($9, 0), (SELECT id FROM issue_kinds WHERE project_id = $4 AND is_default)),
+						NULLIF($10, '')::bug_severity, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''),
+						NULLIF($16, '')::bug_reproducibility, NULLIF($17, 0), $18, NULLIF($19, 0), $20, $6, $20)
+						RETURNING id`,
 					),
 				).WithArgs(
 					taskData.Name,
 					taskData.Description,
 					taskData.TaskPriority,
 					taskData.ProjectID,
-					taskData.TaskType,
+					taskData.StatusID,
 					sqlmock.AnyArg(),
 					performTo,
+					uint64(42),
+					taskData.KindID,
+					taskData.Severity,
+					taskData.Environment,
+					taskData.AffectedVersion,
+					taskData.StepsToReproduce,
+					taskData.ExpectedResult,
+					taskData.ActualResult,
+					taskData.Reproducibility,
+					taskData.ComponentID,
+					sql.NullInt64{},
+					taskData.ParentID,
+					userID,
 				).WillReturnError(err)
+				mock.ExpectRollback()
 				log.EXPECT().Error(err)
 
-				return &TaskRepository{db: db, log: log, admin: admin}
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedResult: 0,
 			expectedError:  err,
 		},
 		{
-			name: "OK",
+			name:     "OK",
+			taskData: taskData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"})
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"task_counter"}).AddRow(uint64(42)))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO tasks (name, description, task_priority, project_id, status_id, created_at, perform_to, number, kind_id,
+						severity, environment, affected_version, steps_to_reproduce, expected_result, actual_result, reproducibility,
+						component_id, assignee, parent_id, reporter, updated_at, updated_by)
+						VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (SELECT id FROM statuses WHERE project_id = $4 AND is_initial)), $6, $7, $8,
+						COALESCE(NULLIF($9, 0), (SELECT id FROM issue_kinds WHERE project_id = $4 AND is_default)),
+						NULLIF($10, '')::bug_severity, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''),
+						NULLIF($16, '')::bug_reproducibility, NULLIF($17, 0), $18, NULLIF($19, 0), $20, $6, $20)
+						RETURNING id`,
+					),
+				).WithArgs(
+					taskData.Name,
+					taskData.Description,
+					taskData.TaskPriority,
+					taskData.ProjectID,
+					taskData.StatusID,
+					sqlmock.AnyArg(),
+					performTo,
+					uint64(42),
+					taskData.KindID,
+					taskData.Severity,
+					taskData.Environment,
+					taskData.AffectedVersion,
+					taskData.StepsToReproduce,
+					taskData.ExpectedResult,
+					taskData.ActualResult,
+					taskData.Reproducibility,
+					taskData.ComponentID,
+					sql.NullInt64{},
+					taskData.ParentID,
+					userID,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(uint64(1), userID, models.ActivityCreated, "", nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(uint64(1), "{1}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create task: id = %d", uint64(1))
+
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 1,
+			expectedError:  nil,
+		},
+		{
+			name: "OK with custom fields",
 			taskData: &dto.CreateTaskDto{
 				Name:         "name",
-				Description:  "description",
 				TaskPriority: "high",
 				ProjectID:    1,
-				TaskType:     "IN PROGRESS",
 				PerformTo:    performTo,
+				CustomFields: []dto.CustomFieldValueDto{{FieldID: 3, Value: json.RawMessage(`"acme"`)}},
 			},
 			userID: 1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
 
-				rows := sqlmock.NewRows([]string{"id"}).AddRow(uint64(1))
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"}).
+					AddRow(uint64(3), "customer", "text", "{}", true)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"task_counter"}).AddRow(uint64(42)))
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"INSERT INTO tasks (name, description, task_priority, project_id, task_type, created_at, perform_to) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+						`INSERT INTO tasks (name, description, task_priority, project_id, status_id, created_at, perform_to, number, kind_id,
+						severity, environment, affected_version, steps_to_reproduce, expected_result, actual_result, reproducibility,
+						component_id, assignee, parent_id, reporter, updated_at, updated_by)
+						VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (SELECT id FROM statuses WHERE project_id = $4 AND is_initial)), $6, $7, $8,
+						COALESCE(NULLIF($9, 0), (SELECT id FROM issue_kinds WHERE project_id = $4 AND is_default)),
+						NULLIF($10, '')::bug_severity, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''),
+						NULLIF($16, '')::bug_reproducibility, NULLIF($17, 0), $18, NULLIF($19, 0), $20, $6, $20)
+						RETURNING id`,
 					),
 				).WithArgs(
 					taskData.Name,
 					taskData.Description,
 					taskData.TaskPriority,
 					taskData.ProjectID,
-					taskData.TaskType,
+					taskData.StatusID,
 					sqlmock.AnyArg(),
 					performTo,
-				).WillReturnRows(rows)
+					uint64(42),
+					taskData.KindID,
+					taskData.Severity,
+					taskData.Environment,
+					taskData.AffectedVersion,
+					taskData.StepsToReproduce,
+					taskData.ExpectedResult,
+					taskData.ActualResult,
+					taskData.Reproducibility,
+					taskData.ComponentID,
+					sql.NullInt64{},
+					taskData.ParentID,
+					userID,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(1)))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO task_custom_values (task_id, field_id, value_text, value_number, value_options)
+						VALUES ($1, $2, $3, $4, $5) ON CONFLICT (task_id, field_id) DO UPDATE`,
+					),
+				).WithArgs(uint64(1), uint64(3), "acme", nil, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(uint64(1), userID, models.ActivityCreated, "", nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(uint64(1), "{1}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 				log.EXPECT().Infof("Create task: id = %d", uint64(1))
 
-				return &TaskRepository{db: db, log: log, admin: admin}
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedResult: 1,
 			expectedError:  nil,
 		},
+		{
+			name: "Error assignee is not a member",
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				TaskPriority: "high",
+				ProjectID:    1,
+				AssigneeID:   7,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(taskData.ProjectID, taskData.AssigneeID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(taskData.ProjectID, taskData.AssigneeID).Return(ErrNoRights)
+
+				return &TaskRepository{admin: admin, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrAssigneeNotMember,
+		},
+		{
+			name: "Error component not found",
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				TaskPriority: "high",
+				ProjectID:    1,
+				ComponentID:  5,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"})
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT default_assignee FROM components WHERE id = $1 AND project_id = $2"),
+				).WithArgs(taskData.ComponentID, taskData.ProjectID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrComponentNotFound,
+		},
+		{
+			name: "OK assigned to the component default assignee",
+			taskData: &dto.CreateTaskDto{
+				Name:         "name",
+				TaskPriority: "high",
+				ProjectID:    1,
+				ComponentID:  5,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(taskData.ProjectID, uint64(3)).Return(nil)
+
+				bugKindRows := sqlmock.NewRows([]string{"is_bug", "required_bug_fields"}).AddRow(false, "{}")
+				customFieldRows := sqlmock.NewRows([]string{"id", "name", "field_type", "options", "required"})
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT issue_kinds.is_bug, projects.required_bug_fields FROM issue_kinds"),
+				).WithArgs(taskData.ProjectID, taskData.KindID).WillReturnRows(bugKindRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT id, name, field_type, options, required FROM custom_fields"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(customFieldRows)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT default_assignee FROM components WHERE id = $1 AND project_id = $2"),
+				).WithArgs(taskData.ComponentID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"default_assignee"}).AddRow(int64(3)))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("UPDATE projects SET task_counter = task_counter + 1 WHERE id = $1 RETURNING task_counter"),
+				).WithArgs(taskData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"task_counter"}).AddRow(uint64(43)))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO tasks (name, description, task_priority, project_id, status_id, created_at, perform_to, number, kind_id,
+						severity, environment, affected_version, steps_to_reproduce, expected_result, actual_result, reproducibility,
+						component_id, assignee, parent_id, reporter, updated_at, updated_by)
+						VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, 0), (SELECT id FROM statuses WHERE project_id = $4 AND is_initial)), $6, $7, $8,
+						COALESCE(NULLIF($9, 0), (SELECT id FROM issue_kinds WHERE project_id = $4 AND is_default)),
+						NULLIF($10, '')::bug_severity, NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''),
+						NULLIF($16, '')::bug_reproducibility, NULLIF($17, 0), $18, NULLIF($19, 0), $20, $6, $20)
+						RETURNING id`,
+					),
+				).WithArgs(
+					taskData.Name,
+					taskData.Description,
+					taskData.TaskPriority,
+					taskData.ProjectID,
+					taskData.StatusID,
+					sqlmock.AnyArg(),
+					taskData.PerformTo,
+					uint64(43),
+					taskData.KindID,
+					taskData.Severity,
+					taskData.Environment,
+					taskData.AffectedVersion,
+					taskData.StepsToReproduce,
+					taskData.ExpectedResult,
+					taskData.ActualResult,
+					taskData.Reproducibility,
+					taskData.ComponentID,
+					sql.NullInt64{Int64: 3, Valid: true},
+					taskData.ParentID,
+					userID,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(2)))
+				mock.ExpectExec(regexp.QuoteMeta(activityQuery)).
+					WithArgs(uint64(2), userID, models.ActivityCreated, "", nil, nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(uint64(2), "{1,3}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 2, userID, 3)
+				expectAddEvent(mock, "task", "task-2").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create task: id = %d", uint64(2))
+
+				return &TaskRepository{db: db, log: log, admin: admin, member: member, state: state}
+			},
+			expectedResult: 2,
+			expectedError:  nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -135,7 +495,7 @@ func Test_CreateTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.taskData, test.userID)
-			res, err := repo.CreateTask(test.taskData, test.userID)
+			res, err := repo.CreateTask(test.taskData, test.userID, event)
 
 			require.Equal(t, test.expectedResult, res)
 			require.Equal(t, test.expectedError, err)
@@ -143,9 +503,12 @@ func Test_CreateTask(t *testing.T) {
 	}
 }
 
+const lockTaskQuery = "SELECT status_id, assignee, reviewer, resolution FROM tasks WHERE id = $1 AND project_id = $2 FOR UPDATE"
+
 func Test_WorkOnTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskAssigned, 1, 1, 1, nil)
 
 	tests := []struct {
 		name           string
@@ -173,7 +536,7 @@ func Test_WorkOnTask(t *testing.T) {
 			expectedError: ErrNoRights,
 		},
 		{
-			name: "Error cannot work on task",
+			name: "Error task is in another project",
 			workOnTaskData: &dto.WorkOnTaskDto{
 				TaskID:    1,
 				ProjectID: 1,
@@ -183,20 +546,58 @@ func Test_WorkOnTask(t *testing.T) {
 				member := mock_repository.NewMockmember(c)
 				db, mock, _ := sqlmock.New()
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
 				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
 
-				mock.ExpectExec(
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+				log.EXPECT().Error(sql.ErrNoRows)
+
+				return &TaskRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name: "Error cannot work on task",
+			workOnTaskData: &dto.WorkOnTaskDto{
+				TaskID:    1,
+				ProjectID: 1,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskRepository {
+				member := mock_repository.NewMockmember(c)
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "assignee", "reviewer", "resolution"}).AddRow(1, nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"UPDATE tasks SET assignee = $1 WHERE id = $2 AND assignee IS NULL",
+						"UPDATE tasks SET assignee = $1, updated_at = $2, updated_by = $1 WHERE id = $3 AND assignee IS NULL RETURNING assignee",
 					),
 				).WithArgs(
 					userID,
+					sqlmock.AnyArg(),
 					workOnTaskData.TaskID,
 				).WillReturnError(err)
+				mock.ExpectRollback()
 				log.EXPECT().Error(err)
 
-				return &TaskRepository{db: db, log: log, member: member}
+				return &TaskRepository{db: db, log: log, member: member, state: state}
 			},
 			expectedError: err,
 		},
@@ -211,19 +612,78 @@ func Test_WorkOnTask(t *testing.T) {
 				member := mock_repository.NewMockmember(c)
 				db, mock, _ := sqlmock.New()
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
 				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "assignee", "reviewer", "resolution"}).AddRow(1, nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"UPDATE tasks SET assignee = $1, updated_at = $2, updated_by = $1 WHERE id = $3 AND assignee IS NULL RETURNING assignee",
+					),
+				).WithArgs(
+					userID,
+					sqlmock.AnyArg(),
+					workOnTaskData.TaskID,
+				).WillReturnRows(sqlmock.NewRows([]string{"assignee"}).AddRow(userID))
+				mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+					WithArgs(uint64(1), userID, testTaskSnapshot, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(uint64(1), "{1}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID, 2)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				return &TaskRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+		{
+			name: "OK task is already taken",
+			workOnTaskData: &dto.WorkOnTaskDto{
+				TaskID:    1,
+				ProjectID: 1,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskRepository {
+				member := mock_repository.NewMockmember(c)
+				db, mock, _ := sqlmock.New()
+				state := mock_repository.NewMockstate(c)
 
-				mock.ExpectExec(
+				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "assignee", "reviewer", "resolution"}).AddRow(1, nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"UPDATE tasks SET assignee = $1 WHERE id = $2 AND assignee IS NULL",
+						"UPDATE tasks SET assignee = $1, updated_at = $2, updated_by = $1 WHERE id = $3 AND assignee IS NULL RETURNING assignee",
 					),
 				).WithArgs(
 					userID,
+					sqlmock.AnyArg(),
 					workOnTaskData.TaskID,
-				).WillReturnResult(sqlmock.NewResult(1, 1))
+				).WillReturnRows(sqlmock.NewRows([]string{"assignee"}))
+				mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+					WithArgs(uint64(1), userID, testTaskSnapshot, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
 
-				return &TaskRepository{db: db, log: log, member: member}
+				return &TaskRepository{db: db, member: member, state: state}
 			},
 			expectedError: nil,
 		},
@@ -235,7 +695,7 @@ func Test_WorkOnTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.workOnTaskData, test.userID)
-			err := repo.WorkOnTask(test.workOnTaskData, test.userID)
+			err := repo.WorkOnTask(test.workOnTaskData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -245,6 +705,7 @@ func Test_WorkOnTask(t *testing.T) {
 func Test_StopWorkOnTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskUnassigned, 1, 1, 1, nil)
 
 	tests := []struct {
 		name           string
@@ -282,19 +743,31 @@ func Test_StopWorkOnTask(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 				log := mock_log.NewMockLog(c)
 				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
 
 				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
-
-				mock.ExpectExec(
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "assignee", "reviewer", "resolution"}).AddRow(1, nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"UPDATE tasks SET assignee = NULL WHERE id = $1 AND assignee IS NOT NULL",
+						"UPDATE tasks SET assignee = NULL, updated_at = $1, updated_by = $2 WHERE id = $3 AND assignee IS NOT NULL RETURNING assignee",
 					),
 				).WithArgs(
+					sqlmock.AnyArg(),
+					userID,
 					workOnTaskData.TaskID,
 				).WillReturnError(err)
+				mock.ExpectRollback()
 				log.EXPECT().Error(err)
 
-				return &TaskRepository{db: db, log: log, member: member}
+				return &TaskRepository{db: db, log: log, member: member, state: state}
 			},
 			expectedError: err,
 		},
@@ -308,20 +781,37 @@ func Test_StopWorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskRepository {
 				db, mock, _ := sqlmock.New()
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
 				member := mock_repository.NewMockmember(c)
 
 				member.EXPECT().IsMember(workOnTaskData.ProjectID, userID).Return(nil)
-
-				mock.ExpectExec(
+				state.EXPECT().IsWritable(workOnTaskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(workOnTaskData.TaskID, workOnTaskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status_id", "assignee", "reviewer", "resolution"}).AddRow(1, nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"UPDATE tasks SET assignee = NULL WHERE id = $1 AND assignee IS NOT NULL",
+						"UPDATE tasks SET assignee = NULL, updated_at = $1, updated_by = $2 WHERE id = $3 AND assignee IS NOT NULL RETURNING assignee",
 					),
 				).WithArgs(
+					sqlmock.AnyArg(),
+					userID,
 					workOnTaskData.TaskID,
-				).WillReturnResult(sqlmock.NewResult(1, 1))
-
-				return &TaskRepository{db: db, log: log, member: member}
+				).WillReturnRows(sqlmock.NewRows([]string{"assignee"}).AddRow(nil))
+				mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+					WithArgs(uint64(1), userID, testTaskSnapshot, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				return &TaskRepository{db: db, log: log, member: member, state: state}
 			},
 			expectedError: nil,
 		},
@@ -333,7 +823,7 @@ func Test_StopWorkOnTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.workOnTaskData, test.userID)
-			err := repo.StopWorkOnTask(test.workOnTaskData, test.userID)
+			err := repo.StopWorkOnTask(test.workOnTaskData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -343,7 +833,18 @@ func Test_StopWorkOnTask(t *testing.T) {
 func Test_UpdateTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskUpdated, 1, 1, 1, nil)
 	performTo := "2023-12-18 10:53:00"
+	taskData := &dto.UpdateTaskDto{
+		Name:         "name",
+		Description:  "description",
+		TaskPriority: "high",
+		ProjectID:    1,
+		TaskID:       1,
+		StatusID:     2,
+		PerformTo:    performTo,
+	}
+	lockColumns := []string{"status_id", "assignee", "reviewer", "resolution"}
 
 	tests := []struct {
 		name           string
@@ -351,20 +852,13 @@ func Test_UpdateTask(t *testing.T) {
 		userID         uint64
 		mockBehaviour  mockBehaviour
 		expectedResult uint64
+		expectedKind   string
 		expectedError  error
 	}{
 		{
-			name: "Error in admin",
-			taskData: &dto.UpdateTaskDto{
-				Name:         "name",
-				Description:  "description",
-				TaskPriority: "high",
-				ProjectID:    1,
-				TaskID:       1,
-				TaskType:     "IN PROGRESS",
-				PerformTo:    performTo,
-			},
-			userID: 1,
+			name:     "Error in admin",
+			taskData: taskData,
+			userID:   1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
 				admin := mock_repository.NewMockadmin(c)
 
@@ -375,82 +869,227 @@ func Test_UpdateTask(t *testing.T) {
 			expectedError: err,
 		},
 		{
-			name: "Error",
+			name: "Error reviewer is not a member",
 			taskData: &dto.UpdateTaskDto{
 				Name:         "name",
 				Description:  "description",
 				TaskPriority: "high",
 				ProjectID:    1,
 				TaskID:       1,
-				TaskType:     "IN PROGRESS",
-				PerformTo:    performTo,
+				ReviewerID:   3,
 			},
 			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+				member.EXPECT().IsMember(taskData.ProjectID, taskData.ReviewerID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(taskData.ProjectID, taskData.ReviewerID).Return(ErrNoRights)
+
+				return &TaskRepository{admin: admin, member: member, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrReviewerNotMember,
+		},
+		{
+			name:     "Error task not found",
+			taskData: taskData,
+			userID:   1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
 
+				mock.ExpectBegin()
 				mock.ExpectQuery(
-					regexp.QuoteMeta(
-						"UPDATE tasks SET name = $1, description = $2, task_priority = $3, project_id = $4, task_type = $5, perform_to = $6 WHERE id = $7 RETURNING id",
-					),
-				).WithArgs(
-					taskData.Name,
-					taskData.Description,
-					taskData.TaskPriority,
-					taskData.ProjectID,
-					taskData.TaskType,
-					taskData.PerformTo,
-					taskData.TaskID,
-				).WillReturnError(err)
-				log.EXPECT().Error(err)
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+				log.EXPECT().Error(sql.ErrNoRows)
 
-				return &TaskRepository{db: db, log: log, admin: admin}
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedResult: 0,
-			expectedError:  err,
+			expectedError:  ErrTaskNotFound,
 		},
 		{
-			name: "OK",
-			taskData: &dto.UpdateTaskDto{
-				Name:         "name",
-				Description:  "description",
-				TaskPriority: "high",
-				ProjectID:    1,
-				TaskID:       1,
-				TaskType:     "IN PROGRESS",
-				PerformTo:    performTo,
+			name:     "Error transition is not allowed",
+			taskData: taskData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(1), nil, nil, nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(1), taskData.StatusID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, admin: admin, state: state}
 			},
-			userID: 1,
+			expectedResult: 0,
+			expectedError:  ErrTransitionNotAllowed,
+		},
+		{
+			name:     "Error guard failed",
+			taskData: taskData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(1), nil, nil, nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(1), taskData.StatusID).WillReturnRows(
+					sqlmock.NewRows([]string{"guards", "category"}).AddRow("{assignee_required}", "in_progress"),
+				)
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError: &GuardError{Failed: []*models.FailedGuard{{
+				Guard:   models.GuardAssigneeRequired,
+				Message: guardMessages[models.GuardAssigneeRequired],
+			}}},
+		},
+		{
+			name:     "Error",
+			taskData: taskData,
+			userID:   1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(2), nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`UPDATE tasks SET name = $1, description = $2, task_priority = $3, status_id = $4, perform_to = $5,
+						reviewer = NULLIF($6, 0), resolution = $7, kind_id = COALESCE(NULLIF($8, 0), kind_id),
+						severity = NULLIF($9, '')::bug_severity, environment = NULLIF($10, ''), affected_version = NULLIF($11, ''),
+						steps_to_reproduce = NULLIF($12, ''), expected_result = NULLIF($13, ''), actual_result = NULLIF($14, ''),
+						reproducibility = NULLIF($15, '')::bug_reproducibility, component_id = NULLIF($16, 0),
+						assignee = CASE WHEN component_id IS DISTINCT FROM NULLIF($16, 0) THEN COALESCE(assignee, $17) ELSE assignee END,
+						parent_id = NULLIF($18, 0), updated_at = $20, updated_by = $21
+						WHERE id = $19 RETURNING id, assignee`,
+					),
+				).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name:     "OK",
+			taskData: taskData,
+			userID:   1,
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
 
-				rows := sqlmock.NewRows([]string{"id"}).AddRow(uint64(1))
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(1), nil, nil, nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(1), taskData.StatusID).WillReturnRows(
+					sqlmock.NewRows([]string{"guards", "category"}).AddRow("{}", "in_progress"),
+				)
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						"UPDATE tasks SET name = $1, description = $2, task_priority = $3, project_id = $4, task_type = $5, perform_to = $6 WHERE id = $7 RETURNING id",
+						`UPDATE tasks SET name = $1, description = $2, task_priority = $3, status_id = $4, perform_to = $5,
+						reviewer = NULLIF($6, 0), resolution = $7, kind_id = COALESCE(NULLIF($8, 0), kind_id),
+						severity = NULLIF($9, '')::bug_severity, environment = NULLIF($10, ''), affected_version = NULLIF($11, ''),
+						steps_to_reproduce = NULLIF($12, ''), expected_result = NULLIF($13, ''), actual_result = NULLIF($14, ''),
+						reproducibility = NULLIF($15, '')::bug_reproducibility, component_id = NULLIF($16, 0),
+						assignee = CASE WHEN component_id IS DISTINCT FROM NULLIF($16, 0) THEN COALESCE(assignee, $17) ELSE assignee END,
+						parent_id = NULLIF($18, 0), updated_at = $20, updated_by = $21
+						WHERE id = $19 RETURNING id, assignee`,
 					),
 				).WithArgs(
 					taskData.Name,
 					taskData.Description,
 					taskData.TaskPriority,
-					taskData.ProjectID,
-					taskData.TaskType,
+					taskData.StatusID,
 					taskData.PerformTo,
+					taskData.ReviewerID,
+					nil,
+					taskData.KindID,
+					taskData.Severity,
+					taskData.Environment,
+					taskData.AffectedVersion,
+					taskData.StepsToReproduce,
+					taskData.ExpectedResult,
+					taskData.ActualResult,
+					taskData.Reproducibility,
+					taskData.ComponentID,
+					sql.NullInt64{},
+					taskData.ParentID,
 					taskData.TaskID,
-				).WillReturnRows(rows)
-				log.EXPECT().Infof("Create task: id = %d", uint64(1))
-
-				return &TaskRepository{db: db, log: log, admin: admin}
+					sqlmock.AnyArg(),
+					userID,
+				).WillReturnRows(sqlmock.NewRows([]string{"id", "assignee"}).AddRow(uint64(1), uint64(3)))
+				mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+					WithArgs(uint64(1), userID, testTaskSnapshot, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(addWatchersQuery)).
+					WithArgs(uint64(1), "{3}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID, 3)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Update task: id = %d", uint64(1))
+
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedResult: 1,
+			expectedKind:   models.ActivityStatus,
 			expectedError:  nil,
 		},
 	}
@@ -461,9 +1100,157 @@ func Test_UpdateTask(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.taskData, test.userID)
-			res, err := repo.UpdateTask(test.taskData, test.userID)
+			res, kind, err := repo.UpdateTask(test.taskData, test.userID, event)
 
 			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedKind, kind)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_TransitionTask(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskRepository
+	event := models.NewEvent(models.EventTaskTransitioned, 1, 1, 1, nil)
+	taskData := &dto.TransitionTaskDto{TaskID: 1, ProjectID: 1, StatusID: 4, Resolution: "fixed"}
+	lockColumns := []string{"status_id", "assignee", "reviewer", "resolution"}
+
+	tests := []struct {
+		name          string
+		taskData      *dto.TransitionTaskDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:     "Error no rights",
+			taskData: taskData,
+			userID:   5,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(taskData.ProjectID, userID).Return(ErrNoRights)
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(ErrNoRights)
+
+				return &TaskRepository{admin: admin, member: member}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:     "Error only the reviewer may close",
+			taskData: taskData,
+			userID:   2,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(3), int64(2), int64(3), nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(3), taskData.StatusID).WillReturnRows(
+					sqlmock.NewRows([]string{"guards", "category"}).AddRow("{reviewer_only,resolution_required}", "done"),
+				)
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, member: member, state: state}
+			},
+			expectedError: &GuardError{Failed: []*models.FailedGuard{{
+				Guard:   models.GuardReviewerOnly,
+				Message: guardMessages[models.GuardReviewerOnly],
+			}}},
+		},
+		{
+			name:     "Error task is blocked",
+			taskData: taskData,
+			userID:   3,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(3), int64(2), int64(3), nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(3), taskData.StatusID).WillReturnRows(
+					sqlmock.NewRows([]string{"guards", "category"}).AddRow("{reviewer_only,resolution_required}", "done"),
+				)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT COUNT(*) FROM task_links"),
+				).WithArgs(taskData.TaskID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+
+				return &TaskRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: ErrTaskBlocked,
+		},
+		{
+			name:     "OK",
+			taskData: taskData,
+			userID:   3,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+				state := mock_repository.NewMockstate(c)
+
+				member.EXPECT().IsMember(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(lockTaskQuery),
+				).WithArgs(taskData.TaskID, taskData.ProjectID).WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(3), int64(2), int64(3), nil))
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT status_transitions.guards, statuses.category FROM status_transitions"),
+				).WithArgs(uint64(3), taskData.StatusID).WillReturnRows(
+					sqlmock.NewRows([]string{"guards", "category"}).AddRow("{reviewer_only,resolution_required}", "done"),
+				)
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT COUNT(*) FROM task_links"),
+				).WithArgs(taskData.TaskID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(regexp.QuoteMeta(taskSnapshotQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot"}).AddRow(testTaskSnapshot))
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE tasks SET status_id = $1, resolution = $2, updated_at = $3, updated_by = $4 WHERE id = $5"),
+				).WithArgs(taskData.StatusID, taskData.Resolution, sqlmock.AnyArg(), userID, taskData.TaskID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(taskChangesQuery)).
+					WithArgs(uint64(1), userID, testTaskSnapshot, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectWatchEvents(mock, 1, userID, 2, 3)
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Transition task: id = %d, status = %d", taskData.TaskID, taskData.StatusID)
+
+				return &TaskRepository{db: db, log: log, member: member, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskData, test.userID)
+			err := repo.TransitionTask(test.taskData, test.userID, event)
+
 			require.Equal(t, test.expectedError, err)
 		})
 	}
@@ -489,17 +1276,7 @@ func Test_GetTaskById(t *testing.T) {
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT 
-							id, 
-							name, 
-							description, 
-							task_priority, 
-							project_id, 
-							task_type, 
-							assignee, 
-							created_at, 
-							perform_to 
-						FROM tasks WHERE id = $1`,
+						"SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.id = $1 AND projects.deleted_at IS NULL",
 					),
 				).WithArgs(id).WillReturnError(err)
 				log.EXPECT().Error(err)
@@ -515,55 +1292,110 @@ func Test_GetTaskById(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
 
 				rows := sqlmock.NewRows([]string{
 					"id",
+					"number",
+					"key",
 					"name",
 					"description",
 					"task_priority",
 					"project_id",
-					"task_type",
+					"status_id",
+					"name",
+					"category",
+					"kind_id",
+					"name",
+					"icon",
 					"assignee",
+					"reviewer",
+					"resolution",
 					"created_at",
 					"perform_to",
+					"reporter",
+					"updated_at",
+					"updated_by",
+					"severity",
+					"environment",
+					"affected_version",
+					"steps_to_reproduce",
+					"expected_result",
+					"actual_result",
+					"reproducibility",
+					"component_id",
+					"name",
+					"sprint_id",
+					"parent_id",
+					"progress",
+					"affects_versions",
+					"fix_versions",
+					"labels",
+					"custom_fields",
 				}).AddRow(
 					uint64(1),
+					uint64(1),
+					"KEY",
 					"name",
 					"description",
 					"high",
 					uint64(1),
+					uint64(1),
 					"TO DO",
+					"todo",
+					uint64(2),
+					"feature",
+					"lightbulb",
 					uint64(1),
+					nil,
+					nil,
 					time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
 					time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+					int64(2),
+					time.Date(2222, 2, 2, 2, 2, 2, 0, time.UTC),
+					int64(1),
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					int64(5),
+					"API",
+					int64(7),
+					int64(3),
+					[]byte(`{"total":4,"done":1}`),
+					nil,
+					[]byte(`[{"id":6,"name":"1.2.0","released":false}]`),
+					[]byte(`[{"id":4,"projectId":1,"name":"backend","color":"#808080","description":""}]`),
+					[]byte(`[{"fieldId":3,"name":"customer","type":"text","value":"acme"}]`),
 				)
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT 
-							id, 
-							name, 
-							description, 
-							task_priority, 
-							project_id, 
-							task_type, 
-							assignee, 
-							created_at, 
-							perform_to 
-						FROM tasks WHERE id = $1`,
+						"SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.id = $1 AND projects.deleted_at IS NULL",
 					),
 				).WithArgs(id).WillReturnRows(rows)
+				member.EXPECT().IsMember(uint64(1), uint64(2)).Return(nil)
 				log.EXPECT().Infof("Get task: id = %d", uint64(1))
 
-				return &TaskRepository{db: db, log: log}
+				return &TaskRepository{db: db, log: log, member: member}
 			},
 			expectedResult: &models.Task{
 				ID:          1,
+				Key:         "KEY-1",
+				Number:      1,
 				Name:        "name",
 				Description: "description",
 				Priority:    "high",
 				ProjectID:   1,
-				TaskType:    "TO DO",
+				StatusID:    1,
+				Status:      "TO DO",
+				Category:    "todo",
+				KindID:      2,
+				Kind:        "feature",
+				KindIcon:    "lightbulb",
 				Assignee:    sql.NullInt64{Int64: 1, Valid: true},
 				CreatedAt: sql.NullTime{
 					Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -573,6 +1405,27 @@ func Test_GetTaskById(t *testing.T) {
 					Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
 					Valid: true,
 				},
+				Reporter: sql.NullInt64{Int64: 2, Valid: true},
+				UpdatedAt: sql.NullTime{
+					Time:  time.Date(2222, 2, 2, 2, 2, 2, 0, time.UTC),
+					Valid: true,
+				},
+				UpdatedBy:       sql.NullInt64{Int64: 1, Valid: true},
+				ComponentID:     sql.NullInt64{Int64: 5, Valid: true},
+				Component:       sql.NullString{String: "API", Valid: true},
+				SprintID:        sql.NullInt64{Int64: 7, Valid: true},
+				ParentID:        sql.NullInt64{Int64: 3, Valid: true},
+				Progress:        &models.TaskProgress{Total: 4, Done: 1, Percent: 25},
+				AffectsVersions: []*models.TaskVersion{},
+				FixVersions: []*models.TaskVersion{
+					{ID: 6, Name: "1.2.0"},
+				},
+				Labels: []*models.Label{
+					{ID: 4, ProjectID: 1, Name: "backend", Color: "#808080"},
+				},
+				CustomFields: []*models.CustomFieldValue{
+					{FieldID: 3, Name: "customer", Type: "text", Value: json.RawMessage(`"acme"`)},
+				},
 			},
 			expectedError: nil,
 		},
@@ -584,7 +1437,7 @@ func Test_GetTaskById(t *testing.T) {
 			defer c.Finish()
 
 			repo := test.mockBehaviour(c, test.id)
-			res, err := repo.GetTaskById(test.id)
+			res, err := repo.GetTaskById(test.id, 2)
 
 			require.Equal(t, test.expectedResult, res)
 			require.Equal(t, test.expectedError, err)
@@ -593,12 +1446,13 @@ func Test_GetTaskById(t *testing.T) {
 }
 
 func Test_GetTasksByProjectId(t *testing.T) {
-	type mockBehaviour func(c *gomock.Controller, id uint64) *TaskRepository
+	type mockBehaviour func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository
 	err := errors.New("error")
 
 	tests := []struct {
 		name           string
 		id             uint64
+		filter         *dto.TaskFilterDto
 		mockBehaviour  mockBehaviour
 		expectedResult []*models.Task
 		expectedError  error
@@ -606,23 +1460,13 @@ func Test_GetTasksByProjectId(t *testing.T) {
 		{
 			name: "Error",
 			id:   1,
-			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskRepository {
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT 
-							id, 
-							name, 
-							description, 
-							task_priority, 
-							project_id, 
-							task_type, 
-							assignee, 
-							created_at, 
-							perform_to 
-						FROM tasks WHERE project_id = $1`,
+						"SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL ORDER BY tasks.id",
 					),
 				).WithArgs(id).WillReturnError(err)
 				log.EXPECT().Error(err)
@@ -635,45 +1479,91 @@ func Test_GetTasksByProjectId(t *testing.T) {
 		{
 			name: "OK",
 			id:   1,
-			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskRepository {
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
 				log := mock_log.NewMockLog(c)
 				db, mock, _ := sqlmock.New()
 
 				rows := sqlmock.NewRows([]string{
 					"id",
+					"number",
+					"key",
 					"name",
 					"description",
 					"task_priority",
 					"project_id",
-					"task_type",
+					"status_id",
+					"name",
+					"category",
+					"kind_id",
+					"name",
+					"icon",
 					"assignee",
+					"reviewer",
+					"resolution",
 					"created_at",
 					"perform_to",
+					"reporter",
+					"updated_at",
+					"updated_by",
+					"severity",
+					"environment",
+					"affected_version",
+					"steps_to_reproduce",
+					"expected_result",
+					"actual_result",
+					"reproducibility",
+					"component_id",
+					"name",
+					"sprint_id",
+					"parent_id",
+					"progress",
+					"affects_versions",
+					"fix_versions",
+					"labels",
+					"custom_fields",
 				}).AddRow(
 					uint64(1),
+					uint64(1),
+					"KEY",
 					"name",
 					"description",
 					"high",
 					uint64(1),
+					uint64(1),
 					"TO DO",
+					"todo",
+					uint64(2),
+					"feature",
+					"lightbulb",
 					uint64(1),
+					nil,
+					nil,
 					time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
 					time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
+					int64(2),
+					time.Date(2222, 2, 2, 2, 2, 2, 0, time.UTC),
+					int64(1),
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
+					nil,
 				)
 
 				mock.ExpectQuery(
 					regexp.QuoteMeta(
-						`SELECT 
-							id, 
-							name, 
-							description, 
-							task_priority, 
-							project_id, 
-							task_type, 
-							assignee, 
-							created_at, 
-							perform_to 
-						FROM tasks WHERE project_id = $1`,
+						"SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL ORDER BY tasks.id",
 					),
 				).WithArgs(id).WillReturnRows(rows)
 
@@ -682,11 +1572,18 @@ func Test_GetTasksByProjectId(t *testing.T) {
 			expectedResult: []*models.Task{
 				{
 					ID:          1,
+					Key:         "KEY-1",
+					Number:      1,
 					Name:        "name",
 					Description: "description",
 					Priority:    "high",
 					ProjectID:   1,
-					TaskType:    "TO DO",
+					StatusID:    1,
+					Status:      "TO DO",
+					Category:    "todo",
+					KindID:      2,
+					Kind:        "feature",
+					KindIcon:    "lightbulb",
 					Assignee:    sql.NullInt64{Int64: 1, Valid: true},
 					CreatedAt: sql.NullTime{
 						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
@@ -696,10 +1593,263 @@ func Test_GetTasksByProjectId(t *testing.T) {
 						Time:  time.Date(1111, 11, 11, 11, 11, 11, 0, time.UTC),
 						Valid: true,
 					},
+					Reporter: sql.NullInt64{Int64: 2, Valid: true},
+					UpdatedAt: sql.NullTime{
+						Time:  time.Date(2222, 2, 2, 2, 2, 2, 0, time.UTC),
+						Valid: true,
+					},
+					UpdatedBy:       sql.NullInt64{Int64: 1, Valid: true},
+					AffectsVersions: []*models.TaskVersion{},
+					FixVersions:     []*models.TaskVersion{},
+					Labels:          []*models.Label{},
+					CustomFields:    []*models.CustomFieldValue{},
 				},
 			},
 			expectedError: nil,
 		},
+		{
+			name:   "OK with filters",
+			id:     1,
+			filter: &dto.TaskFilterDto{Kind: "bug", Status: "IN REVIEW", StatusCategory: "in_progress"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND issue_kinds.name = $2 AND statuses.name = $3 AND statuses.category = $4 ORDER BY tasks.id`,
+					),
+				).WithArgs(id, filter.Kind, filter.Status, filter.StatusCategory).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with bug filters",
+			id:     1,
+			filter: &dto.TaskFilterDto{Severity: "critical", Reproducibility: "always", AffectedVersion: "1.2.0"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND tasks.severity = $2 AND tasks.reproducibility = $3 AND tasks.affected_version = $4 ORDER BY tasks.id`,
+					),
+				).WithArgs(id, filter.Severity, filter.Reproducibility, filter.AffectedVersion).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name: "OK with authorship filter and sort",
+			id:   1,
+			filter: &dto.TaskFilterDto{
+				Reporter:     2,
+				UpdatedBy:    3,
+				UpdatedAfter: "2023-01-01T03:00:00+03:00",
+				Sort:         "-updatedAt",
+			},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND tasks.reporter = $2 AND tasks.updated_by = $3 AND tasks.updated_at >= $4
+						ORDER BY tasks.updated_at DESC, tasks.id`,
+					),
+				).WithArgs(id, filter.Reporter, filter.UpdatedBy, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with custom field filter and sort",
+			id:     1,
+			filter: &dto.TaskFilterDto{CustomFields: []string{"3:acme"}, Sort: "-cf:4"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND EXISTS (SELECT 1 FROM task_custom_values WHERE task_custom_values.task_id = tasks.id
+						AND task_custom_values.field_id = $2
+						AND (task_custom_values.value_text = $3 OR $3 = ANY(task_custom_values.value_options)))
+						ORDER BY (SELECT task_custom_values.value_number FROM task_custom_values
+						WHERE task_custom_values.task_id = tasks.id AND task_custom_values.field_id = $4) DESC NULLS LAST,
+						(SELECT task_custom_values.value_text FROM task_custom_values
+						WHERE task_custom_values.task_id = tasks.id AND task_custom_values.field_id = $4) DESC NULLS LAST, tasks.id`,
+					),
+				).WithArgs(id, uint64(3), "acme", uint64(4)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with component filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{Component: 5},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND tasks.component_id = $2 ORDER BY tasks.id`,
+					),
+				).WithArgs(id, filter.Component).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with fix version filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{FixVersion: 6},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND EXISTS (SELECT 1 FROM task_versions WHERE task_versions.task_id = tasks.id AND task_versions.version_id = $2 AND task_versions.relation = 'fix') ORDER BY tasks.id`,
+					),
+				).WithArgs(id, filter.FixVersion).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with backlog filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{Backlog: true},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT " + taskColumns + " FROM " + taskTables + ` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND tasks.sprint_id IS NULL ORDER BY tasks.id`,
+					),
+				).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with any label filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{Labels: []uint64{4, 5}},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND EXISTS (SELECT 1 FROM task_labels WHERE task_labels.task_id = tasks.id AND task_labels.label_id = ANY($2))
+						ORDER BY tasks.id`,
+					),
+				).WithArgs(id, "{4,5}").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK with all labels filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{Labels: []uint64{4, 5}, LabelMatch: "all"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT "+taskColumns+" FROM "+taskTables+` WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL
+						AND $2::BIGINT[] <@ ARRAY(SELECT label_id FROM task_labels WHERE task_labels.task_id = tasks.id)
+						ORDER BY tasks.id`,
+					),
+				).WithArgs(id, "{4,5}").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "OK sorted by a task column",
+			id:     1,
+			filter: &dto.TaskFilterDto{Sort: "performTo"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						"SELECT " + taskColumns + " FROM " + taskTables + " WHERE tasks.project_id = $1 AND projects.deleted_at IS NULL ORDER BY tasks.perform_to ASC, tasks.id",
+					),
+				).WithArgs(id).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: []*models.Task{},
+			expectedError:  nil,
+		},
+		{
+			name:   "Error invalid sort",
+			id:     1,
+			filter: &dto.TaskFilterDto{Sort: "cf:abc"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				return &TaskRepository{}
+			},
+			expectedResult: nil,
+			expectedError:  ErrInvalidTaskFilter,
+		},
+		{
+			name:   "Error invalid updated time",
+			id:     1,
+			filter: &dto.TaskFilterDto{UpdatedBefore: "2023-01-01 00:00:00"},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				return &TaskRepository{}
+			},
+			expectedResult: nil,
+			expectedError:  ErrInvalidTaskFilter,
+		},
+		{
+			name:   "Error invalid custom field filter",
+			id:     1,
+			filter: &dto.TaskFilterDto{CustomFields: []string{"acme"}},
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskRepository {
+				return &TaskRepository{}
+			},
+			expectedResult: nil,
+			expectedError:  ErrInvalidTaskFilter,
+		},
 	}
 
 	for _, test := range tests {
@@ -707,8 +1857,8 @@ func Test_GetTasksByProjectId(t *testing.T) {
 			c := gomock.NewController(t)
 			defer c.Finish()
 
-			repo := test.mockBehaviour(c, test.id)
-			res, err := repo.GetTasksByProjectId(test.id)
+			repo := test.mockBehaviour(c, test.id, test.filter)
+			res, err := repo.GetTasksByProjectId(test.id, test.filter)
 
 			require.Equal(t, test.expectedResult, res)
 			require.Equal(t, test.expectedError, err)
@@ -719,13 +1869,18 @@ func Test_GetTasksByProjectId(t *testing.T) {
 func Test_DeleteTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskRepository
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskDeleted, 1, 1, 1, nil)
+
+	keysQuery := "SELECT storage_key FROM attachments WHERE task_id = $1"
+	lockColumns := []string{"status_id", "assignee", "reviewer", "resolution"}
 
 	tests := []struct {
-		name          string
-		taskData      *dto.DeleteTaskDto
-		userID        uint64
-		mockBehaviour mockBehaviour
-		expectedError error
+		name                string
+		taskData            *dto.DeleteTaskDto
+		userID              uint64
+		mockBehaviour       mockBehaviour
+		expectedStorageKeys []string
+		expectedError       error
 	}{
 		{
 			name: "Error in admin",
@@ -743,6 +1898,33 @@ func Test_DeleteTask(t *testing.T) {
 			},
 			expectedError: err,
 		},
+		{
+			name: "Error task is in another project",
+			taskData: &dto.DeleteTaskDto{
+				ProjectID: 1,
+				TaskID:    1,
+			},
+			userID: 1,
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(taskData.TaskID, taskData.ProjectID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+				log.EXPECT().Error(sql.ErrNoRows)
+
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrTaskNotFound,
+		},
 		{
 			name: "Error cannot delete task",
 			taskData: &dto.DeleteTaskDto{
@@ -754,16 +1936,26 @@ func Test_DeleteTask(t *testing.T) {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
 				log := mock_log.NewMockLog(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
-
-				mock.ExpectExec(
-					regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1"),
-				).WithArgs(taskData.TaskID).WillReturnError(err)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(taskData.TaskID, taskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(1), nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(keysQuery)).
+					WithArgs(taskData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key"}))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1")).
+					WithArgs(taskData.TaskID).
+					WillReturnError(err)
+				mock.ExpectRollback()
 
 				log.EXPECT().Error(err).Return()
 
-				return &TaskRepository{db: db, log: log, admin: admin}
+				return &TaskRepository{db: db, log: log, admin: admin, state: state}
 			},
 			expectedError: err,
 		},
@@ -777,16 +1969,125 @@ func Test_DeleteTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskRepository {
 				db, mock, _ := sqlmock.New()
 				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
 
 				admin.EXPECT().IsAdmin(taskData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(taskData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockTaskQuery)).
+					WithArgs(taskData.TaskID, taskData.ProjectID).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow(uint64(1), nil, nil, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(keysQuery)).
+					WithArgs(taskData.TaskID).
+					WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("tasks/1/a"))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1")).
+					WithArgs(taskData.TaskID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAddEvent(mock, "task", "task-1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+
+				return &TaskRepository{db: db, admin: admin, state: state}
+			},
+			expectedStorageKeys: []string{"tasks/1/a"},
+			expectedError:       nil,
+		},
+	}
 
-				mock.ExpectExec(
-					regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1"),
-				).WithArgs(taskData.TaskID).WillReturnResult(sqlmock.NewResult(1, 1))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.taskData, test.userID)
+			storageKeys, err := repo.DeleteTask(test.taskData, test.userID, event)
 
-				return &TaskRepository{db: db, log: nil, admin: admin}
+			require.Equal(t, test.expectedStorageKeys, storageKeys)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskIdByKey(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectKey string, number uint64) *TaskRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name            string
+		projectKey      string
+		number          uint64
+		mockBehaviour   mockBehaviour
+		expectedResult  uint64
+		expectedProject uint64
+		expectedError   error
+	}{
+		{
+			name:       "Error task not found",
+			projectKey: "API",
+			number:     42,
+			mockBehaviour: func(c *gomock.Controller, projectKey string, number uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT tasks.id, tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+						WHERE tasks.number = $2 AND projects.deleted_at IS NULL AND (
+							projects.key = $1 OR projects.id IN (SELECT project_id FROM project_key_history WHERE key = $1)
+						) ORDER BY projects.key = $1 DESC LIMIT 1`,
+					),
+				).WithArgs(projectKey, number).WillReturnError(sql.ErrNoRows)
+				log.EXPECT().Error(sql.ErrNoRows)
+
+				return &TaskRepository{db: db, log: log}
 			},
-			expectedError: nil,
+			expectedResult: 0,
+			expectedError:  ErrTaskNotFound,
+		},
+		{
+			name:       "Error",
+			projectKey: "API",
+			number:     42,
+			mockBehaviour: func(c *gomock.Controller, projectKey string, number uint64) *TaskRepository {
+				log := mock_log.NewMockLog(c)
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT tasks.id, tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+						WHERE tasks.number = $2 AND projects.deleted_at IS NULL AND (
+							projects.key = $1 OR projects.id IN (SELECT project_id FROM project_key_history WHERE key = $1)
+						) ORDER BY projects.key = $1 DESC LIMIT 1`,
+					),
+				).WithArgs(projectKey, number).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &TaskRepository{db: db, log: log}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name:       "OK",
+			projectKey: "API",
+			number:     42,
+			mockBehaviour: func(c *gomock.Controller, projectKey string, number uint64) *TaskRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT tasks.id, tasks.project_id FROM tasks JOIN projects ON projects.id = tasks.project_id
+						WHERE tasks.number = $2 AND projects.deleted_at IS NULL AND (
+							projects.key = $1 OR projects.id IN (SELECT project_id FROM project_key_history WHERE key = $1)
+						) ORDER BY projects.key = $1 DESC LIMIT 1`,
+					),
+				).WithArgs(projectKey, number).WillReturnRows(sqlmock.NewRows([]string{"id", "project_id"}).AddRow(uint64(7), uint64(3)))
+
+				return &TaskRepository{db: db}
+			},
+			expectedResult:  7,
+			expectedProject: 3,
+			expectedError:   nil,
 		},
 	}
 
@@ -795,9 +2096,11 @@ func Test_DeleteTask(t *testing.T) {
 			c := gomock.NewController(t)
 			defer c.Finish()
 
-			repo := test.mockBehaviour(c, test.taskData, test.userID)
-			err := repo.DeleteTask(test.taskData, test.userID)
+			repo := test.mockBehaviour(c, test.projectKey, test.number)
+			res, projectID, err := repo.GetTaskIdByKey(test.projectKey, test.number)
 
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedProject, projectID)
 			require.Equal(t, test.expectedError, err)
 		})
 	}
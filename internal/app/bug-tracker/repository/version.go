@@ -0,0 +1,402 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrVersionNotFound  = errors.New("error version is not found")
+	ErrVersionNameTaken = errors.New("error version with such a name already exists")
+	ErrVersionReleased  = errors.New("error version is already released")
+)
+
+const versionsNameConstraint = "versions_project_id_name_key"
+
+type VersionRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewVersionRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Version {
+	return &VersionRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+func (r *VersionRepository) GetVersions(projectID, userID uint64) ([]*models.Version, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, description, release_date, released, archived FROM versions
+		WHERE project_id = $1 ORDER BY release_date NULLS LAST, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]*models.Version, 0)
+	for rows.Next() {
+		version := new(models.Version)
+		err := rows.Scan(
+			&version.ID,
+			&version.ProjectID,
+			&version.Name,
+			&version.Description,
+			&version.ReleaseDate,
+			&version.Released,
+			&version.Archived,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (r *VersionRepository) CreateVersion(versionData *dto.CreateVersionDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(versionData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(versionData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	var versionID uint64
+	err := r.db.QueryRow(
+		`INSERT INTO versions (project_id, name, description, release_date)
+		VALUES ($1, $2, $3, NULLIF($4, '')::DATE) RETURNING id`,
+		versionData.ProjectID,
+		versionData.Name,
+		versionData.Description,
+		versionData.ReleaseDate,
+	).Scan(&versionID)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, versionsNameConstraint) {
+			return 0, ErrVersionNameTaken
+		}
+		return 0, err
+	}
+	r.log.Infof("Create version: id = %d, project = %d", versionID, versionData.ProjectID)
+
+	return versionID, nil
+}
+
+func (r *VersionRepository) UpdateVersion(versionData *dto.UpdateVersionDto, userID uint64) error {
+	if err := r.admin.IsAdmin(versionData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(versionData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		`UPDATE versions SET name = $1, description = $2, release_date = NULLIF($3, '')::DATE, archived = $4
+		WHERE id = $5 AND project_id = $6`,
+		versionData.Name,
+		versionData.Description,
+		versionData.ReleaseDate,
+		versionData.Archived,
+		versionData.VersionID,
+		versionData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		if isUniqueViolation(err, versionsNameConstraint) {
+			return ErrVersionNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrVersionNotFound
+	}
+	r.log.Infof("Update version: id = %d", versionData.VersionID)
+
+	return nil
+}
+
+// DeleteVersion removes the version from the project and from the tasks that
+// affected or were fixed in it.
+func (r *VersionRepository) DeleteVersion(versionData *dto.DeleteVersionDto, userID uint64) error {
+	if err := r.admin.IsAdmin(versionData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(versionData.ProjectID); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(
+		"DELETE FROM versions WHERE id = $1 AND project_id = $2",
+		versionData.VersionID,
+		versionData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrVersionNotFound
+	}
+	r.log.Infof("Delete version: id = %d", versionData.VersionID)
+
+	return nil
+}
+
+// ReleaseVersion marks the version released and returns how many unfinished
+// tasks were moved on to the next version.
+func (r *VersionRepository) ReleaseVersion(versionData *dto.ReleaseVersionDto, userID uint64) (int64, error) {
+	if err := r.admin.IsAdmin(versionData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(versionData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if err := r.lockUnreleasedVersion(tx, versionData.VersionID, versionData.ProjectID); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var moved int64
+	if versionData.MoveToVersionID != 0 {
+		if err := r.lockUnreleasedVersion(tx, versionData.MoveToVersionID, versionData.ProjectID); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		moved, err = r.moveUnfinishedTasks(tx, versionData.VersionID, versionData.MoveToVersionID, userID)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	_, err = tx.Exec(
+		"UPDATE versions SET released = TRUE, release_date = COALESCE(release_date, CURRENT_DATE) WHERE id = $1",
+		versionData.VersionID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Release version: id = %d, moved tasks = %d", versionData.VersionID, moved)
+
+	return moved, nil
+}
+
+func (r *VersionRepository) lockUnreleasedVersion(tx *sql.Tx, versionID, projectID uint64) error {
+	var released bool
+	err := tx.QueryRow(
+		"SELECT released FROM versions WHERE id = $1 AND project_id = $2 FOR UPDATE",
+		versionID,
+		projectID,
+	).Scan(&released)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrVersionNotFound
+		}
+		r.log.Error(err)
+		return err
+	}
+
+	if released {
+		return ErrVersionReleased
+	}
+
+	return nil
+}
+
+// moveUnfinishedTasks hands the tasks fixed in a version whose status is not
+// done over to another version.
+func (r *VersionRepository) moveUnfinishedTasks(tx *sql.Tx, fromVersionID, toVersionID, userID uint64) (int64, error) {
+	taskIDs, err := r.unfinishedTasks(tx, fromVersionID)
+	if err != nil {
+		return 0, err
+	}
+
+	snapshots, err := takeTaskSnapshots(tx, taskIDs)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	_, err = tx.Exec(
+		`WITH moved AS (
+			DELETE FROM task_versions
+			WHERE version_id = $1 AND relation = 'fix' AND task_id = ANY($3)
+			RETURNING task_id
+		)
+		INSERT INTO task_versions (task_id, version_id, relation)
+		SELECT task_id, $2, 'fix' FROM moved ON CONFLICT DO NOTHING`,
+		fromVersionID,
+		toVersionID,
+		pq.Array(taskIDs),
+	)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	changed, err := recordTasksChanges(tx, taskIDs, userID, snapshots)
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if err := addTasksWatchEvents(tx, changed, userID, models.ActivityField); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	return int64(len(taskIDs)), nil
+}
+
+func (r *VersionRepository) unfinishedTasks(tx *sql.Tx, versionID uint64) ([]uint64, error) {
+	rows, err := tx.Query(
+		`SELECT tasks.id FROM task_versions
+		JOIN tasks ON tasks.id = task_versions.task_id JOIN statuses ON statuses.id = tasks.status_id
+		WHERE task_versions.version_id = $1 AND task_versions.relation = 'fix' AND statuses.category <> 'done'
+		ORDER BY tasks.id FOR UPDATE OF tasks`,
+		versionID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	taskIDs := make([]uint64, 0)
+	for rows.Next() {
+		var taskID uint64
+		if err := rows.Scan(&taskID); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return taskIDs, nil
+}
+
+// saveTaskVersions replaces the affected and fix versions of a task. A nil
+// list leaves the versions of that kind as they are. Callers log the change
+// through the snapshot of the task.
+func (r *TaskRepository) saveTaskVersions(tx *sql.Tx, projectID, taskID uint64, affects, fixes []uint64) error {
+	relations := []struct {
+		relation string
+		ids      []uint64
+	}{
+		{models.VersionAffects, affects},
+		{models.VersionFix, fixes},
+	}
+
+	for _, relation := range relations {
+		if relation.ids == nil {
+			continue
+		}
+
+		ids := uniqueIDs(relation.ids)
+		if len(ids) > 0 {
+			var count int
+			err := tx.QueryRow(
+				"SELECT COUNT(*) FROM versions WHERE project_id = $1 AND id = ANY($2)",
+				projectID,
+				pq.Array(ids),
+			).Scan(&count)
+			if err != nil {
+				r.log.Error(err)
+				return err
+			}
+
+			if count != len(ids) {
+				return ErrVersionNotFound
+			}
+		}
+
+		_, err := tx.Exec(
+			"DELETE FROM task_versions WHERE task_id = $1 AND relation = $2",
+			taskID,
+			relation.relation,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return err
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO task_versions (task_id, version_id, relation)
+			SELECT $1, version_id, $2 FROM UNNEST($3::BIGINT[]) AS version_id`,
+			taskID,
+			relation.relation,
+			pq.Array(ids),
+		)
+		if err != nil {
+			r.log.Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,596 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetVersions(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *VersionRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult []*models.Version
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *VersionRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &VersionRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "name", "description", "release_date", "released", "archived"}).
+					AddRow(uint64(1), projectID, "1.0.0", "", time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC), true, false).
+					AddRow(uint64(2), projectID, "1.1.0", "Next", nil, false, false)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, description, release_date, released, archived FROM versions
+						WHERE project_id = $1 ORDER BY release_date NULLS LAST, id`,
+					),
+				).WithArgs(projectID).WillReturnRows(rows)
+
+				return &VersionRepository{db: db, member: member}
+			},
+			expectedResult: []*models.Version{
+				{
+					ID:          1,
+					ProjectID:   1,
+					Name:        "1.0.0",
+					ReleaseDate: sql.NullTime{Time: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC), Valid: true},
+					Released:    true,
+				},
+				{ID: 2, ProjectID: 1, Name: "1.1.0", Description: "Next"},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetVersions(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionRepository
+	versionData := &dto.CreateVersionDto{ProjectID: 1, Name: "1.2.0", ReleaseDate: "2023-03-01"}
+
+	tests := []struct {
+		name           string
+		versionData    *dto.CreateVersionDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:        "Error in admin",
+			versionData: versionData,
+			userID:      2,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(ErrNoRights)
+
+				return &VersionRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:        "Error name taken",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: versionsNameConstraint}
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO versions (project_id, name, description, release_date)
+						VALUES ($1, $2, $3, NULLIF($4, '')::DATE) RETURNING id`,
+					),
+				).WithArgs(
+					versionData.ProjectID,
+					versionData.Name,
+					versionData.Description,
+					versionData.ReleaseDate,
+				).WillReturnError(uniqueErr)
+				log.EXPECT().Error(uniqueErr)
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrVersionNameTaken,
+		},
+		{
+			name:        "OK",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO versions (project_id, name, description, release_date)
+						VALUES ($1, $2, $3, NULLIF($4, '')::DATE) RETURNING id`,
+					),
+				).WithArgs(
+					versionData.ProjectID,
+					versionData.Name,
+					versionData.Description,
+					versionData.ReleaseDate,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(3)))
+				log.EXPECT().Infof("Create version: id = %d, project = %d", uint64(3), versionData.ProjectID)
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.versionData, test.userID)
+			res, err := repo.CreateVersion(test.versionData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, versionData *dto.UpdateVersionDto, userID uint64) *VersionRepository
+	versionData := &dto.UpdateVersionDto{ProjectID: 1, VersionID: 3, Name: "1.2.1", Archived: true}
+
+	tests := []struct {
+		name          string
+		versionData   *dto.UpdateVersionDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:        "Error version not found",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.UpdateVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE versions SET name = $1, description = $2, release_date = NULLIF($3, '')::DATE, archived = $4
+						WHERE id = $5 AND project_id = $6`,
+					),
+				).WithArgs(
+					versionData.Name,
+					versionData.Description,
+					versionData.ReleaseDate,
+					versionData.Archived,
+					versionData.VersionID,
+					versionData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &VersionRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrVersionNotFound,
+		},
+		{
+			name:        "OK",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.UpdateVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE versions SET name = $1, description = $2, release_date = NULLIF($3, '')::DATE, archived = $4
+						WHERE id = $5 AND project_id = $6`,
+					),
+				).WithArgs(
+					versionData.Name,
+					versionData.Description,
+					versionData.ReleaseDate,
+					versionData.Archived,
+					versionData.VersionID,
+					versionData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Update version: id = %d", versionData.VersionID)
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.versionData, test.userID)
+			err := repo.UpdateVersion(test.versionData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, versionData *dto.DeleteVersionDto, userID uint64) *VersionRepository
+	versionData := &dto.DeleteVersionDto{ProjectID: 1, VersionID: 3}
+
+	tests := []struct {
+		name          string
+		versionData   *dto.DeleteVersionDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:        "Error version not found",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.DeleteVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM versions WHERE id = $1 AND project_id = $2"),
+				).WithArgs(versionData.VersionID, versionData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				return &VersionRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrVersionNotFound,
+		},
+		{
+			name:        "OK",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.DeleteVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM versions WHERE id = $1 AND project_id = $2"),
+				).WithArgs(versionData.VersionID, versionData.ProjectID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete version: id = %d", versionData.VersionID)
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.versionData, test.userID)
+			err := repo.DeleteVersion(test.versionData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_ReleaseVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository
+	versionData := &dto.ReleaseVersionDto{ProjectID: 1, VersionID: 3, MoveToVersionID: 4}
+	lockQuery := "SELECT released FROM versions WHERE id = $1 AND project_id = $2 FOR UPDATE"
+	unfinishedQuery := `SELECT tasks.id FROM task_versions
+		JOIN tasks ON tasks.id = task_versions.task_id JOIN statuses ON statuses.id = tasks.status_id
+		WHERE task_versions.version_id = $1 AND task_versions.relation = 'fix' AND statuses.category <> 'done'
+		ORDER BY tasks.id FOR UPDATE OF tasks`
+	moveQuery := `WITH moved AS (
+			DELETE FROM task_versions
+			WHERE version_id = $1 AND relation = 'fix' AND task_id = ANY($3)
+			RETURNING task_id
+		)
+		INSERT INTO task_versions (task_id, version_id, relation)
+		SELECT task_id, $2, 'fix' FROM moved ON CONFLICT DO NOTHING`
+	releaseQuery := "UPDATE versions SET released = TRUE, release_date = COALESCE(release_date, CURRENT_DATE) WHERE id = $1"
+
+	tests := []struct {
+		name           string
+		versionData    *dto.ReleaseVersionDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name:        "Error in admin",
+			versionData: versionData,
+			userID:      2,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(ErrNoRights)
+
+				return &VersionRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:        "Error version already released",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.VersionID, versionData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(true))
+				mock.ExpectRollback()
+
+				return &VersionRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrVersionReleased,
+		},
+		{
+			name:        "Error next version not found",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.VersionID, versionData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.MoveToVersionID, versionData.ProjectID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+
+				return &VersionRepository{db: db, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrVersionNotFound,
+		},
+		{
+			name:        "OK without moving tasks",
+			versionData: &dto.ReleaseVersionDto{ProjectID: 1, VersionID: 3},
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.VersionID, versionData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(false))
+				mock.ExpectExec(regexp.QuoteMeta(releaseQuery)).
+					WithArgs(versionData.VersionID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Release version: id = %d, moved tasks = %d", versionData.VersionID, int64(0))
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  nil,
+		},
+		{
+			name:        "OK moving unfinished tasks",
+			versionData: versionData,
+			userID:      1,
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.ReleaseVersionDto, userID uint64) *VersionRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(versionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(versionData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.VersionID, versionData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+					WithArgs(versionData.MoveToVersionID, versionData.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"released"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(unfinishedQuery)).
+					WithArgs(versionData.VersionID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(5)).AddRow(uint64(6)))
+				expectTaskSnapshots(mock, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(moveQuery)).
+					WithArgs(versionData.VersionID, versionData.MoveToVersionID, "{5,6}").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				expectTasksChanges(mock, userID, 5, 6)
+				expectTasksWatchEvents(mock, userID, 5, 6)
+				mock.ExpectExec(regexp.QuoteMeta(releaseQuery)).
+					WithArgs(versionData.VersionID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Release version: id = %d, moved tasks = %d", versionData.VersionID, int64(2))
+
+				return &VersionRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 2,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.versionData, test.userID)
+			res, err := repo.ReleaseVersion(test.versionData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_saveTaskVersions(t *testing.T) {
+	type mockBehaviour func(mock sqlmock.Sqlmock)
+
+	tests := []struct {
+		name          string
+		affects       []uint64
+		fixes         []uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:          "OK nothing given",
+			mockBehaviour: func(mock sqlmock.Sqlmock) {},
+			expectedError: nil,
+		},
+		{
+			name:    "Error version is not in the project",
+			affects: []uint64{6, 7},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM versions WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(uint64(1), "{6,7}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expectedError: ErrVersionNotFound,
+		},
+		{
+			name:    "OK",
+			affects: []uint64{},
+			fixes:   []uint64{6, 6},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_versions WHERE task_id = $1 AND relation = $2")).
+					WithArgs(uint64(2), models.VersionAffects).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM versions WHERE project_id = $1 AND id = ANY($2)")).
+					WithArgs(uint64(1), "{6}").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_versions WHERE task_id = $1 AND relation = $2")).
+					WithArgs(uint64(2), models.VersionFix).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO task_versions (task_id, version_id, relation)
+						SELECT $1, version_id, $2 FROM UNNEST($3::BIGINT[]) AS version_id`,
+					),
+				).WithArgs(uint64(2), models.VersionFix, "{6}").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			mock.ExpectBegin()
+			test.mockBehaviour(mock)
+
+			tx, _ := db.Begin()
+			repo := &TaskRepository{db: db}
+			err := repo.saveTaskVersions(tx, 1, 2, test.affects, test.fixes)
+
+			require.Equal(t, test.expectedError, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+const addWatchersQuery = `INSERT INTO task_watchers (task_id, user_id, created_at)
+	SELECT $1, UNNEST($2::INT[]), $3 ON CONFLICT DO NOTHING`
+
+// watchersQuery selects the watchers of a task to tell about a change made
+// by an actor: everyone but the actor who still takes part in the project.
+const watchersQuery = `SELECT task_watchers.user_id FROM task_watchers
+	JOIN tasks ON tasks.id = task_watchers.task_id
+	JOIN projects ON projects.id = tasks.project_id
+	WHERE task_watchers.task_id = $1 AND task_watchers.user_id <> $2 AND projects.deleted_at IS NULL
+	AND (projects.admin = task_watchers.user_id OR EXISTS (
+		SELECT 1 FROM projects_members WHERE project_id = projects.id AND member_id = task_watchers.user_id
+	))
+	ORDER BY task_watchers.user_id`
+
+// addWatchers makes the users watch the task as part of a change that gets
+// them involved in it. Users who already watch it are left as they are.
+func addWatchers(tx *sql.Tx, taskID uint64, userIDs ...uint64) error {
+	_, err := tx.Exec(addWatchersQuery, taskID, pq.Array(userIDs), time.Now().UTC())
+
+	return err
+}
+
+type WatcherRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+}
+
+func NewWatcherRepo(db *sql.DB, log log.Log, admin admin, member member) Watcher {
+	return &WatcherRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+	}
+}
+
+func (r *WatcherRepository) isParticipant(projectID, userID uint64) bool {
+	return r.member.IsMember(projectID, userID) == nil || r.admin.IsAdmin(projectID, userID) == nil
+}
+
+func (r *WatcherRepository) Watch(taskID, userID uint64) error {
+	projectID, err := taskProjectID(r.db, taskID)
+	if err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return err
+	}
+
+	if !r.isParticipant(projectID, userID) {
+		return ErrNoRights
+	}
+
+	_, err = r.db.Exec(
+		"INSERT INTO task_watchers (task_id, user_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		taskID,
+		userID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Watch task: id = %d, user = %d", taskID, userID)
+
+	return nil
+}
+
+// Unwatch stops the user watching the task. It is allowed to users who are
+// no longer in the project too.
+func (r *WatcherRepository) Unwatch(taskID, userID uint64) error {
+	if _, err := taskProjectID(r.db, taskID); err != nil {
+		if err != ErrTaskNotFound {
+			r.log.Error(err)
+		}
+		return err
+	}
+
+	_, err := r.db.Exec("DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2", taskID, userID)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Unwatch task: id = %d, user = %d", taskID, userID)
+
+	return nil
+}
+
+// GetWatchedTasks returns a page of the tasks the user watches, the latest
+// watched first.
+func (r *WatcherRepository) GetWatchedTasks(userID uint64, page *dto.PageDto) ([]*models.WatchedTask, error) {
+	limit, offset := pageBounds(page)
+
+	rows, err := r.db.Query(
+		`SELECT task_watchers.created_at, tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM task_watchers
+		JOIN tasks ON tasks.id = task_watchers.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE task_watchers.user_id = $1 AND projects.deleted_at IS NULL
+		ORDER BY task_watchers.created_at DESC, tasks.id DESC LIMIT $2 OFFSET $3`,
+		userID,
+		limit,
+		offset,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]*models.WatchedTask, 0)
+	for rows.Next() {
+		task := new(models.WatchedTask)
+
+		task.Task, err = scanLinkedTask(rows, &task.WatchedAt)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// addWatchEvents saves an event for every watcher of the task to tell about
+// a change made by the actor, in the transaction of the change. Kind is the
+// kind of the activity the change is logged with.
+func addWatchEvents(tx *sql.Tx, taskID, actorID uint64, kind string) error {
+	rows, err := tx.Query(watchersQuery, taskID, actorID)
+	if err != nil {
+		return err
+	}
+
+	userIDs, err := scanUserIDs(rows)
+	if err != nil {
+		return err
+	}
+
+	createdAt := time.Now().UTC()
+	for _, userID := range userIDs {
+		event := &models.WatchEvent{
+			Type:      models.WatchEventType,
+			UserID:    userID,
+			TaskID:    taskID,
+			Kind:      kind,
+			ChangedBy: actorID,
+			CreatedAt: createdAt,
+		}
+
+		message, err := event.OutboxMessage()
+		if err != nil {
+			return err
+		}
+
+		if err := addToOutbox(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTasksWatchEvents is addWatchEvents for every task of a bulk change.
+func addTasksWatchEvents(tx *sql.Tx, taskIDs []uint64, actorID uint64, kind string) error {
+	for _, taskID := range taskIDs {
+		if err := addWatchEvents(tx, taskID, actorID, kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanUserIDs(rows *sql.Rows) ([]uint64, error) {
+	defer rows.Close()
+
+	userIDs := make([]uint64, 0)
+	for rows.Next() {
+		var userID uint64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// GetWatchers returns the watchers of the task to notify about a change made
+// by the actor.
+func (r *WatcherRepository) GetWatchers(taskID, actorID uint64) ([]uint64, error) {
+	rows, err := r.db.Query(watchersQuery, taskID, actorID)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	userIDs, err := scanUserIDs(rows)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+// expectWatchEvents expects addWatchEvents for the task, returning the
+// watchers to save an event for.
+func expectWatchEvents(mock sqlmock.Sqlmock, taskID, actorID uint64, watchers ...uint64) {
+	rows := sqlmock.NewRows([]string{"user_id"})
+	for _, watcher := range watchers {
+		rows.AddRow(watcher)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(watchersQuery)).WithArgs(taskID, actorID).WillReturnRows(rows)
+	for _, watcher := range watchers {
+		expectAddEvent(mock, models.WatchCategory, fmt.Sprintf("user-%d", watcher)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+}
+
+// expectTasksWatchEvents expects addTasksWatchEvents for tasks nobody else
+// watches.
+func expectTasksWatchEvents(mock sqlmock.Sqlmock, actorID uint64, taskIDs ...uint64) {
+	for _, taskID := range taskIDs {
+		expectWatchEvents(mock, taskID, actorID)
+	}
+}
+
+func Test_Watch(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *WatcherRepository
+	err := errors.New("error")
+	insertQuery := "INSERT INTO task_watchers (task_id, user_id, created_at) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING"
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error task not found",
+			mockBehaviour: func(c *gomock.Controller) *WatcherRepository {
+				db, mock, _ := sqlmock.New()
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnError(sql.ErrNoRows)
+
+				return &WatcherRepository{db: db}
+			},
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name: "Error no rights",
+			mockBehaviour: func(c *gomock.Controller) *WatcherRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(2)).Return(err)
+				admin.EXPECT().IsAdmin(uint64(1), uint64(2)).Return(err)
+
+				return &WatcherRepository{db: db, admin: admin, member: member}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *WatcherRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+				member.EXPECT().IsMember(uint64(1), uint64(2)).Return(nil)
+				mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+					WithArgs(uint64(1), uint64(2), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Watch task: id = %d, user = %d", uint64(1), uint64(2))
+
+				return &WatcherRepository{db: db, log: log, member: member}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c)
+			err := repo.Watch(1, 2)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_Unwatch(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	log := mock_log.NewMockLog(c)
+
+	mock.ExpectQuery(regexp.QuoteMeta(taskProjectQuery)).
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uint64(1)))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2")).
+		WithArgs(uint64(1), uint64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	log.EXPECT().Infof("Unwatch task: id = %d, user = %d", uint64(1), uint64(2))
+
+	repo := &WatcherRepository{db: db, log: log}
+
+	require.NoError(t, repo.Unwatch(1, 2))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetWatchedTasks(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := `SELECT task_watchers.created_at, tasks.id, projects.key, tasks.number, tasks.name, statuses.name, statuses.category
+		FROM task_watchers
+		JOIN tasks ON tasks.id = task_watchers.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		JOIN statuses ON statuses.id = tasks.status_id
+		WHERE task_watchers.user_id = $1 AND projects.deleted_at IS NULL
+		ORDER BY task_watchers.created_at DESC, tasks.id DESC LIMIT $2 OFFSET $3`
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint64(2), dto.DefaultPageLimit, 0).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"created_at", "id", "key", "number", "name", "status", "category",
+		}).AddRow(createdAt, 1, "KEY", 1, "Login", "To Do", "todo"))
+
+	repo := &WatcherRepository{db: db}
+	res, err := repo.GetWatchedTasks(2, &dto.PageDto{})
+
+	require.NoError(t, err)
+	require.Equal(t, []*models.WatchedTask{{
+		Task:      &models.LinkedTask{ID: 1, Key: "KEY-1", Name: "Login", Status: "To Do", Category: "todo"},
+		WatchedAt: createdAt,
+	}}, res)
+}
+
+func Test_GetWatchers(t *testing.T) {
+	query := `SELECT task_watchers.user_id FROM task_watchers
+		JOIN tasks ON tasks.id = task_watchers.task_id
+		JOIN projects ON projects.id = tasks.project_id
+		WHERE task_watchers.task_id = $1 AND task_watchers.user_id <> $2 AND projects.deleted_at IS NULL`
+
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint64(1), uint64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3).AddRow(4))
+
+	repo := &WatcherRepository{db: db}
+	res, err := repo.GetWatchers(1, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4}, res)
+}
+
+// watchEventValue matches the value of the event saved for a watcher of task
+// 1 changed by user 2.
+type watchEventValue struct {
+	userID uint64
+	kind   string
+}
+
+func (v watchEventValue) Match(value sqldriver.Value) bool {
+	data, ok := value.([]byte)
+	if !ok {
+		return false
+	}
+
+	event := new(models.WatchEvent)
+	if err := json.Unmarshal(data, event); err != nil {
+		return false
+	}
+
+	return event.Type == models.WatchEventType && event.UserID == v.userID && event.TaskID == 1 &&
+		event.Kind == v.kind && event.ChangedBy == 2
+}
+
+func Test_addWatchEvents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(watchersQuery)).
+		WithArgs(uint64(1), uint64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3).AddRow(4))
+	for _, userID := range []uint64{3, 4} {
+		mock.ExpectExec(regexp.QuoteMeta(addToOutboxQuery)).
+			WithArgs(
+				models.WatchCategory,
+				fmt.Sprintf("user-%d", userID),
+				watchEventValue{userID: userID, kind: models.ActivityStatus},
+				sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	err = addWatchEvents(tx, 1, 2, models.ActivityStatus)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,463 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+)
+
+var (
+	ErrStatusNotFound       = errors.New("error status is not found")
+	ErrStatusNameTaken      = errors.New("error status with such a name already exists")
+	ErrStatusInUse          = errors.New("error status is used by tasks")
+	ErrStatusInitial        = errors.New("error initial status cannot be deleted")
+	ErrTransitionNotAllowed = errors.New("error transition is not allowed by the project workflow")
+)
+
+const (
+	statusesNameConstraint = "statuses_project_id_name_key"
+	tasksStatusConstraint  = "tasks_status_id_fkey"
+)
+
+type WorkflowRepository struct {
+	db     *sql.DB
+	log    log.Log
+	admin  admin
+	member member
+	state  state
+}
+
+func NewWorkflowRepo(db *sql.DB, log log.Log, admin admin, member member, state state) Workflow {
+	return &WorkflowRepository{
+		db:     db,
+		log:    log,
+		admin:  admin,
+		member: member,
+		state:  state,
+	}
+}
+
+// createDefaultWorkflow seeds a new project with the statuses the tracker
+// used before workflows became configurable, allowing every transition.
+func createDefaultWorkflow(tx *sql.Tx, projectID uint64) error {
+	_, err := tx.Exec(
+		`INSERT INTO statuses (project_id, name, category, position, is_initial) VALUES
+		($1, 'TO DO', 'todo', 0, TRUE),
+		($1, 'IN PROGRESS', 'in_progress', 1, FALSE),
+		($1, 'IN REVIEW', 'in_progress', 2, FALSE),
+		($1, 'DONE', 'done', 3, FALSE)`,
+		projectID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO status_transitions (from_status_id, to_status_id)
+		SELECT source.id, target.id FROM statuses AS source
+		JOIN statuses AS target ON target.project_id = source.project_id AND target.id <> source.id
+		WHERE source.project_id = $1`,
+		projectID,
+	)
+
+	return err
+}
+
+// GuardError is returned when a transition exists in the workflow but one or
+// more of its guards reject it. Failed lists every guard that did not pass.
+type GuardError struct {
+	Failed []*models.FailedGuard
+}
+
+func (e *GuardError) Error() string {
+	return "error transition guards failed"
+}
+
+var guardMessages = map[string]string{
+	models.GuardAssigneeRequired:   "task must be assigned before this transition",
+	models.GuardResolutionRequired: "resolution must be set for this transition",
+	models.GuardAssigneeOnly:       "only the assignee may perform this transition",
+	models.GuardReviewerOnly:       "only the reviewer may perform this transition",
+	models.GuardAdminOnly:          "only the project admin may perform this transition",
+}
+
+// taskTransition holds what guards need to know about a task and the user
+// moving it.
+type taskTransition struct {
+	taskID     uint64
+	actorID    uint64
+	isAdmin    func() bool
+	assignee   sql.NullInt64
+	reviewer   sql.NullInt64
+	resolution sql.NullString
+}
+
+func (t *taskTransition) passes(guard string) bool {
+	switch guard {
+	case models.GuardAssigneeRequired:
+		return t.assignee.Valid
+	case models.GuardResolutionRequired:
+		return t.resolution.Valid && t.resolution.String != ""
+	case models.GuardAssigneeOnly:
+		return t.assignee.Valid && uint64(t.assignee.Int64) == t.actorID
+	case models.GuardReviewerOnly:
+		return t.reviewer.Valid && uint64(t.reviewer.Int64) == t.actorID
+	case models.GuardAdminOnly:
+		return t.isAdmin()
+	default:
+		return false
+	}
+}
+
+func evaluateGuards(guards []string, transition *taskTransition) error {
+	failed := make([]*models.FailedGuard, 0)
+	for _, guard := range guards {
+		if !transition.passes(guard) {
+			failed = append(failed, &models.FailedGuard{Guard: guard, Message: guardMessages[guard]})
+		}
+	}
+
+	if len(failed) > 0 {
+		return &GuardError{Failed: failed}
+	}
+
+	return nil
+}
+
+// transitionRule returns the guards of the transition between two statuses
+// and the category of the target status. ErrTransitionNotAllowed is returned
+// when the workflow has no such transition.
+func transitionRule(tx *sql.Tx, fromStatusID, toStatusID uint64) ([]string, string, error) {
+	var guards []string
+	var category string
+
+	err := tx.QueryRow(
+		`SELECT status_transitions.guards, statuses.category FROM status_transitions
+		JOIN statuses ON statuses.id = status_transitions.to_status_id
+		WHERE status_transitions.from_status_id = $1 AND status_transitions.to_status_id = $2`,
+		fromStatusID,
+		toStatusID,
+	).Scan(pq.Array(&guards), &category)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrTransitionNotAllowed
+	}
+
+	return guards, category, err
+}
+
+// resolutionFor keeps the resolution only while a task is in a done status.
+func resolutionFor(category string, requested string, current sql.NullString) sql.NullString {
+	if category != models.StatusCategoryDone {
+		return sql.NullString{}
+	}
+
+	if requested != "" {
+		return sql.NullString{String: requested, Valid: true}
+	}
+
+	return current
+}
+
+func (r *WorkflowRepository) GetWorkflow(projectID, userID uint64) (*models.Workflow, error) {
+	if r.member.IsMember(projectID, userID) != nil && r.admin.IsAdmin(projectID, userID) != nil {
+		return nil, ErrNoRights
+	}
+
+	statuses, err := r.getStatuses(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(
+		`SELECT from_status_id, to_status_id, guards FROM status_transitions
+		WHERE from_status_id IN (SELECT id FROM statuses WHERE project_id = $1)
+		ORDER BY from_status_id, to_status_id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	transitions := make([]*models.Transition, 0)
+	for rows.Next() {
+		transition := new(models.Transition)
+		if err := rows.Scan(&transition.FromStatusID, &transition.ToStatusID, pq.Array(&transition.Guards)); err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return &models.Workflow{Statuses: statuses, Transitions: transitions}, nil
+}
+
+func (r *WorkflowRepository) getStatuses(projectID uint64) ([]*models.Status, error) {
+	rows, err := r.db.Query(
+		`SELECT id, project_id, name, category, position, is_initial FROM statuses
+		WHERE project_id = $1 ORDER BY position, id`,
+		projectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	statuses := make([]*models.Status, 0)
+	for rows.Next() {
+		status := new(models.Status)
+		err := rows.Scan(
+			&status.ID,
+			&status.ProjectID,
+			&status.Name,
+			&status.Category,
+			&status.Position,
+			&status.Initial,
+		)
+		if err != nil {
+			r.log.Error(err)
+			return nil, err
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (r *WorkflowRepository) CreateStatus(statusData *dto.CreateStatusDto, userID uint64) (uint64, error) {
+	if err := r.admin.IsAdmin(statusData.ProjectID, userID); err != nil {
+		return 0, err
+	}
+
+	if err := r.state.IsWritable(statusData.ProjectID); err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+
+	if statusData.Initial {
+		if err := r.resetInitialStatus(tx, statusData.ProjectID); err != nil {
+			return 0, err
+		}
+	}
+
+	var statusID uint64
+	err = tx.QueryRow(
+		`INSERT INTO statuses (project_id, name, category, position, is_initial)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		statusData.ProjectID,
+		statusData.Name,
+		statusData.Category,
+		statusData.Position,
+		statusData.Initial,
+	).Scan(&statusID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, statusesNameConstraint) {
+			return 0, ErrStatusNameTaken
+		}
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return 0, err
+	}
+	r.log.Infof("Create status: id = %d, project = %d", statusID, statusData.ProjectID)
+
+	return statusID, nil
+}
+
+func (r *WorkflowRepository) UpdateStatus(statusData *dto.UpdateStatusDto, userID uint64) error {
+	if err := r.admin.IsAdmin(statusData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(statusData.ProjectID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if statusData.Initial {
+		if err := r.resetInitialStatus(tx, statusData.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	// A status stays initial until another one takes its place, so the
+	// project never ends up without a status for new tasks.
+	result, err := tx.Exec(
+		`UPDATE statuses SET name = $1, category = $2, position = $3, is_initial = is_initial OR $4
+		WHERE id = $5 AND project_id = $6`,
+		statusData.Name,
+		statusData.Category,
+		statusData.Position,
+		statusData.Initial,
+		statusData.StatusID,
+		statusData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		if isUniqueViolation(err, statusesNameConstraint) {
+			return ErrStatusNameTaken
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+		return err
+	}
+
+	if count == 0 {
+		tx.Rollback()
+		return ErrStatusNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.log.Error(err)
+		return err
+	}
+	r.log.Infof("Update status: id = %d", statusData.StatusID)
+
+	return nil
+}
+
+func (r *WorkflowRepository) resetInitialStatus(tx *sql.Tx, projectID uint64) error {
+	_, err := tx.Exec("UPDATE statuses SET is_initial = FALSE WHERE project_id = $1 AND is_initial", projectID)
+	if err != nil {
+		r.log.Error(err)
+		tx.Rollback()
+	}
+
+	return err
+}
+
+func (r *WorkflowRepository) DeleteStatus(statusData *dto.DeleteStatusDto, userID uint64) error {
+	if err := r.admin.IsAdmin(statusData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(statusData.ProjectID); err != nil {
+		return err
+	}
+
+	var initial bool
+	err := r.db.QueryRow(
+		"SELECT is_initial FROM statuses WHERE id = $1 AND project_id = $2",
+		statusData.StatusID,
+		statusData.ProjectID,
+	).Scan(&initial)
+	if err != nil {
+		r.log.Error(err)
+		if err == sql.ErrNoRows {
+			return ErrStatusNotFound
+		}
+		return err
+	}
+
+	if initial {
+		return ErrStatusInitial
+	}
+
+	_, err = r.db.Exec("DELETE FROM statuses WHERE id = $1", statusData.StatusID)
+	if err != nil {
+		r.log.Error(err)
+		if isForeignKeyViolation(err, tasksStatusConstraint) {
+			return ErrStatusInUse
+		}
+		return err
+	}
+	r.log.Infof("Delete status: id = %d", statusData.StatusID)
+
+	return nil
+}
+
+func (r *WorkflowRepository) AddTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	if err := r.admin.IsAdmin(transitionData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(transitionData.ProjectID); err != nil {
+		return err
+	}
+
+	var count int
+	err := r.db.QueryRow(
+		"SELECT COUNT(*) FROM statuses WHERE project_id = $1 AND id IN ($2, $3)",
+		transitionData.ProjectID,
+		transitionData.FromStatusID,
+		transitionData.ToStatusID,
+	).Scan(&count)
+	if err != nil {
+		r.log.Error(err)
+		return err
+	}
+
+	if count != 2 {
+		return ErrStatusNotFound
+	}
+
+	guards := transitionData.Guards
+	if guards == nil {
+		guards = []string{}
+	}
+
+	_, err = r.db.Exec(
+		`INSERT INTO status_transitions (from_status_id, to_status_id, guards) VALUES ($1, $2, $3)
+		ON CONFLICT (from_status_id, to_status_id) DO UPDATE SET guards = EXCLUDED.guards`,
+		transitionData.FromStatusID,
+		transitionData.ToStatusID,
+		pq.Array(guards),
+	)
+	if err != nil {
+		r.log.Error(err)
+	}
+
+	return err
+}
+
+func (r *WorkflowRepository) DeleteTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	if err := r.admin.IsAdmin(transitionData.ProjectID, userID); err != nil {
+		return err
+	}
+
+	if err := r.state.IsWritable(transitionData.ProjectID); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(
+		`DELETE FROM status_transitions WHERE from_status_id = $1 AND to_status_id = $2
+		AND from_status_id IN (SELECT id FROM statuses WHERE project_id = $3)`,
+		transitionData.FromStatusID,
+		transitionData.ToStatusID,
+		transitionData.ProjectID,
+	)
+	if err != nil {
+		r.log.Error(err)
+	}
+
+	return err
+}
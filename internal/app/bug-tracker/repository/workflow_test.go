@@ -0,0 +1,666 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	mock_log "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/log/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetWorkflow(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *WorkflowRepository
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		projectID      uint64
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult *models.Workflow
+		expectedError  error
+	}{
+		{
+			name:      "Error no rights",
+			projectID: 1,
+			userID:    2,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *WorkflowRepository {
+				admin := mock_repository.NewMockadmin(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(err)
+				admin.EXPECT().IsAdmin(projectID, userID).Return(err)
+
+				return &WorkflowRepository{admin: admin, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  ErrNoRights,
+		},
+		{
+			name:      "Error cannot get statuses",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, category, position, is_initial FROM statuses
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &WorkflowRepository{db: db, log: log, member: member}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:      "OK",
+			projectID: 1,
+			userID:    1,
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				member := mock_repository.NewMockmember(c)
+
+				member.EXPECT().IsMember(projectID, userID).Return(nil)
+
+				statuses := sqlmock.NewRows([]string{"id", "project_id", "name", "category", "position", "is_initial"}).
+					AddRow(uint64(1), projectID, "TO DO", "todo", 0, true).
+					AddRow(uint64(2), projectID, "DONE", "done", 1, false)
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT id, project_id, name, category, position, is_initial FROM statuses
+						WHERE project_id = $1 ORDER BY position, id`,
+					),
+				).WithArgs(projectID).WillReturnRows(statuses)
+
+				transitions := sqlmock.NewRows([]string{"from_status_id", "to_status_id", "guards"}).
+					AddRow(uint64(1), uint64(2), "{reviewer_only,resolution_required}")
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`SELECT from_status_id, to_status_id, guards FROM status_transitions
+						WHERE from_status_id IN (SELECT id FROM statuses WHERE project_id = $1)
+						ORDER BY from_status_id, to_status_id`,
+					),
+				).WithArgs(projectID).WillReturnRows(transitions)
+
+				return &WorkflowRepository{db: db, member: member}
+			},
+			expectedResult: &models.Workflow{
+				Statuses: []*models.Status{
+					{ID: 1, ProjectID: 1, Name: "TO DO", Category: "todo", Position: 0, Initial: true},
+					{ID: 2, ProjectID: 1, Name: "DONE", Category: "done", Position: 1, Initial: false},
+				},
+				Transitions: []*models.Transition{{
+					FromStatusID: 1,
+					ToStatusID:   2,
+					Guards:       []string{models.GuardReviewerOnly, models.GuardResolutionRequired},
+				}},
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := repo.GetWorkflow(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowRepository
+	err := errors.New("error")
+	statusData := &dto.CreateStatusDto{ProjectID: 1, Name: "Needs Info", Category: "todo", Position: 1}
+	initialStatusData := &dto.CreateStatusDto{ProjectID: 1, Name: "Triage", Category: "todo", Initial: true}
+
+	tests := []struct {
+		name           string
+		statusData     *dto.CreateStatusDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name:       "Error in admin",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(err)
+
+				return &WorkflowRepository{admin: admin}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name:       "Error status name taken",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				uniqueErr := &pq.Error{Code: uniqueViolation, Constraint: statusesNameConstraint}
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO statuses (project_id, name, category, position, is_initial)
+						VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					),
+				).WithArgs(
+					statusData.ProjectID,
+					statusData.Name,
+					statusData.Category,
+					statusData.Position,
+					statusData.Initial,
+				).WillReturnError(uniqueErr)
+				mock.ExpectRollback()
+				log.EXPECT().Error(uniqueErr)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 0,
+			expectedError:  ErrStatusNameTaken,
+		},
+		{
+			name:       "OK initial status",
+			statusData: initialStatusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE statuses SET is_initial = FALSE WHERE project_id = $1 AND is_initial"),
+				).WithArgs(statusData.ProjectID).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(
+					regexp.QuoteMeta(
+						`INSERT INTO statuses (project_id, name, category, position, is_initial)
+						VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+					),
+				).WithArgs(
+					statusData.ProjectID,
+					statusData.Name,
+					statusData.Category,
+					statusData.Position,
+					statusData.Initial,
+				).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint64(5)))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Create status: id = %d, project = %d", uint64(5), statusData.ProjectID)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.statusData, test.userID)
+			res, err := repo.CreateStatus(test.statusData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, statusData *dto.UpdateStatusDto, userID uint64) *WorkflowRepository
+	err := errors.New("error")
+	statusData := &dto.UpdateStatusDto{ProjectID: 1, StatusID: 2, Name: "Verified", Category: "done", Position: 4}
+
+	tests := []struct {
+		name          string
+		statusData    *dto.UpdateStatusDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error in state",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.UpdateStatusDto, userID uint64) *WorkflowRepository {
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(ErrProjectArchived)
+
+				return &WorkflowRepository{admin: admin, state: state}
+			},
+			expectedError: ErrProjectArchived,
+		},
+		{
+			name:       "Error status not found",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.UpdateStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`UPDATE statuses SET name = $1, category = $2, position = $3, is_initial = is_initial OR $4
+						WHERE id = $5 AND project_id = $6`,
+					),
+				).WithArgs(
+					statusData.Name,
+					statusData.Category,
+					statusData.Position,
+					statusData.Initial,
+					statusData.StatusID,
+					statusData.ProjectID,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+
+				return &WorkflowRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrStatusNotFound,
+		},
+		{
+			name:       "Error",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.UpdateStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE statuses SET name = $1"),
+				).WillReturnError(err)
+				mock.ExpectRollback()
+				log.EXPECT().Error(err)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:       "OK",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.UpdateStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectBegin()
+				mock.ExpectExec(
+					regexp.QuoteMeta("UPDATE statuses SET name = $1"),
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				log.EXPECT().Infof("Update status: id = %d", statusData.StatusID)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.statusData, test.userID)
+			err := repo.UpdateStatus(test.statusData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, statusData *dto.DeleteStatusDto, userID uint64) *WorkflowRepository
+	statusData := &dto.DeleteStatusDto{ProjectID: 1, StatusID: 2}
+
+	tests := []struct {
+		name          string
+		statusData    *dto.DeleteStatusDto
+		userID        uint64
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name:       "Error status not found",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.DeleteStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_initial FROM statuses WHERE id = $1 AND project_id = $2"),
+				).WithArgs(statusData.StatusID, statusData.ProjectID).WillReturnError(sql.ErrNoRows)
+				log.EXPECT().Error(sql.ErrNoRows)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrStatusNotFound,
+		},
+		{
+			name:       "Error initial status",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.DeleteStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_initial FROM statuses WHERE id = $1 AND project_id = $2"),
+				).WithArgs(statusData.StatusID, statusData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_initial"}).AddRow(true))
+
+				return &WorkflowRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrStatusInitial,
+		},
+		{
+			name:       "Error status in use",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.DeleteStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+				fkErr := &pq.Error{Code: foreignKeyViolation, Constraint: tasksStatusConstraint}
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_initial FROM statuses WHERE id = $1 AND project_id = $2"),
+				).WithArgs(statusData.StatusID, statusData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_initial"}).AddRow(false))
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM statuses WHERE id = $1"),
+				).WithArgs(statusData.StatusID).WillReturnError(fkErr)
+				log.EXPECT().Error(fkErr)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: ErrStatusInUse,
+		},
+		{
+			name:       "OK",
+			statusData: statusData,
+			userID:     1,
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.DeleteStatusDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(statusData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(statusData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT is_initial FROM statuses WHERE id = $1 AND project_id = $2"),
+				).WithArgs(statusData.StatusID, statusData.ProjectID).WillReturnRows(sqlmock.NewRows([]string{"is_initial"}).AddRow(false))
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM statuses WHERE id = $1"),
+				).WithArgs(statusData.StatusID).WillReturnResult(sqlmock.NewResult(0, 1))
+				log.EXPECT().Infof("Delete status: id = %d", statusData.StatusID)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.statusData, test.userID)
+			err := repo.DeleteStatus(test.statusData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddTransition(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository
+	transitionData := &dto.TransitionDto{
+		ProjectID:    1,
+		FromStatusID: 1,
+		ToStatusID:   2,
+		Guards:       []string{models.GuardAssigneeRequired},
+	}
+
+	tests := []struct {
+		name           string
+		transitionData *dto.TransitionDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedError  error
+	}{
+		{
+			name:           "Error status from another project",
+			transitionData: transitionData,
+			userID:         1,
+			mockBehaviour: func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(transitionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(transitionData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT COUNT(*) FROM statuses WHERE project_id = $1 AND id IN ($2, $3)"),
+				).WithArgs(
+					transitionData.ProjectID,
+					transitionData.FromStatusID,
+					transitionData.ToStatusID,
+				).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				return &WorkflowRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: ErrStatusNotFound,
+		},
+		{
+			name:           "OK",
+			transitionData: transitionData,
+			userID:         1,
+			mockBehaviour: func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(transitionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(transitionData.ProjectID).Return(nil)
+
+				mock.ExpectQuery(
+					regexp.QuoteMeta("SELECT COUNT(*) FROM statuses WHERE project_id = $1 AND id IN ($2, $3)"),
+				).WithArgs(
+					transitionData.ProjectID,
+					transitionData.FromStatusID,
+					transitionData.ToStatusID,
+				).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`INSERT INTO status_transitions (from_status_id, to_status_id, guards) VALUES ($1, $2, $3)
+						ON CONFLICT (from_status_id, to_status_id) DO UPDATE SET guards = EXCLUDED.guards`,
+					),
+				).WithArgs(
+					transitionData.FromStatusID,
+					transitionData.ToStatusID,
+					pq.Array(transitionData.Guards),
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				return &WorkflowRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.transitionData, test.userID)
+			err := repo.AddTransition(test.transitionData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteTransition(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository
+	err := errors.New("error")
+	transitionData := &dto.TransitionDto{ProjectID: 1, FromStatusID: 1, ToStatusID: 2}
+
+	tests := []struct {
+		name           string
+		transitionData *dto.TransitionDto
+		userID         uint64
+		mockBehaviour  mockBehaviour
+		expectedError  error
+	}{
+		{
+			name:           "Error in admin",
+			transitionData: transitionData,
+			userID:         2,
+			mockBehaviour: func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository {
+				admin := mock_repository.NewMockadmin(c)
+
+				admin.EXPECT().IsAdmin(transitionData.ProjectID, userID).Return(ErrNoRights)
+
+				return &WorkflowRepository{admin: admin}
+			},
+			expectedError: ErrNoRights,
+		},
+		{
+			name:           "Error",
+			transitionData: transitionData,
+			userID:         1,
+			mockBehaviour: func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				log := mock_log.NewMockLog(c)
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(transitionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(transitionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta(
+						`DELETE FROM status_transitions WHERE from_status_id = $1 AND to_status_id = $2
+						AND from_status_id IN (SELECT id FROM statuses WHERE project_id = $3)`,
+					),
+				).WithArgs(
+					transitionData.FromStatusID,
+					transitionData.ToStatusID,
+					transitionData.ProjectID,
+				).WillReturnError(err)
+				log.EXPECT().Error(err)
+
+				return &WorkflowRepository{db: db, log: log, admin: admin, state: state}
+			},
+			expectedError: err,
+		},
+		{
+			name:           "OK",
+			transitionData: transitionData,
+			userID:         1,
+			mockBehaviour: func(c *gomock.Controller, transitionData *dto.TransitionDto, userID uint64) *WorkflowRepository {
+				db, mock, _ := sqlmock.New()
+				admin := mock_repository.NewMockadmin(c)
+				state := mock_repository.NewMockstate(c)
+
+				admin.EXPECT().IsAdmin(transitionData.ProjectID, userID).Return(nil)
+				state.EXPECT().IsWritable(transitionData.ProjectID).Return(nil)
+
+				mock.ExpectExec(
+					regexp.QuoteMeta("DELETE FROM status_transitions WHERE from_status_id = $1 AND to_status_id = $2"),
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				return &WorkflowRepository{db: db, admin: admin, state: state}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			repo := test.mockBehaviour(c, test.transitionData, test.userID)
+			err := repo.DeleteTransition(test.transitionData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type ActivityService struct {
+	repo repository.Activity
+}
+
+func NewActivity(repo repository.Activity) Activity {
+	return &ActivityService{repo: repo}
+}
+
+func (s *ActivityService) GetActivities(taskID, userID uint64, page *dto.PageDto) (*models.ActivityPage, error) {
+	return s.repo.GetActivities(taskID, userID, page)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetActivities(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID, userID uint64, page *dto.PageDto) *ActivityService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		taskID         uint64
+		userID         uint64
+		page           *dto.PageDto
+		expectedResult *models.ActivityPage
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, taskID, userID uint64, page *dto.PageDto) *ActivityService {
+				activity := mock_repository.NewMockActivity(c)
+
+				activity.EXPECT().GetActivities(taskID, userID, page).Return(nil, err)
+
+				return &ActivityService{repo: activity}
+			},
+			taskID:         1,
+			userID:         1,
+			page:           &dto.PageDto{},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskID, userID uint64, page *dto.PageDto) *ActivityService {
+				activity := mock_repository.NewMockActivity(c)
+
+				activity.EXPECT().GetActivities(taskID, userID, page).Return(&models.ActivityPage{Count: 1, Page: 1, Limit: 20}, nil)
+
+				return &ActivityService{repo: activity}
+			},
+			taskID:         1,
+			userID:         1,
+			page:           &dto.PageDto{},
+			expectedResult: &models.ActivityPage{Count: 1, Page: 1, Limit: 20},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.taskID, test.userID, test.page)
+			res, err := service.GetActivities(test.taskID, test.userID, test.page)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+var ErrAttachmentTooLarge = errors.New("error file exceeds the attachment size limit")
+
+type AttachmentService struct {
+	repo    repository.Attachment
+	storage storage.Storage
+	quota   storage.Quota
+}
+
+func NewAttachment(repo repository.Attachment, storage storage.Storage, quota storage.Quota) Attachment {
+	return &AttachmentService{repo: repo, storage: storage, quota: quota}
+}
+
+// sniffContentType detects the type of the file from its first bytes rather
+// than trusting the type the client sent, and returns a reader that still
+// yields the whole file.
+func sniffContentType(body io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), body), nil
+}
+
+// deleteObjects removes the stored contents of attachments whose records are
+// already gone. It goes on past failures and returns the first one.
+func deleteObjects(ctx context.Context, store storage.Storage, storageKeys []string) error {
+	var firstErr error
+	for _, storageKey := range storageKeys {
+		if err := store.Delete(ctx, storageKey); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("error deleting stored object %s: %w", storageKey, err)
+		}
+	}
+
+	return firstErr
+}
+
+// CreateAttachment records the file first, which reserves its place in the
+// project quota, and then stores its contents. The record is dropped again
+// when the contents cannot be stored, and the error says so when even that
+// fails.
+func (s *AttachmentService) CreateAttachment(ctx context.Context, attachmentData *dto.CreateAttachmentDto, body io.Reader, userID uint64) (uint64, error) {
+	if attachmentData.Size > s.quota.MaxFileSize {
+		return 0, ErrAttachmentTooLarge
+	}
+
+	contentType, body, err := sniffContentType(body)
+	if err != nil {
+		return 0, err
+	}
+	attachmentData.ContentType = contentType
+	attachmentData.StorageKey = fmt.Sprintf("tasks/%d/%s", attachmentData.TaskID, uuid.NewString())
+
+	attachmentID, err := s.repo.CreateAttachment(attachmentData, userID, s.quota.MaxProjectSize)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.storage.Put(ctx, attachmentData.StorageKey, io.LimitReader(body, attachmentData.Size), attachmentData.Size, contentType)
+	if err != nil {
+		if _, deleteErr := s.repo.DeleteAttachment(attachmentData.TaskID, attachmentID, userID); deleteErr != nil {
+			return 0, fmt.Errorf("%w (error dropping attachment %d: %v)", err, attachmentID, deleteErr)
+		}
+		return 0, err
+	}
+
+	return attachmentID, nil
+}
+
+func (s *AttachmentService) GetAttachments(taskID, userID uint64) ([]*models.Attachment, error) {
+	return s.repo.GetAttachments(taskID, userID)
+}
+
+func (s *AttachmentService) GetAttachment(ctx context.Context, taskID, attachmentID, userID uint64) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetAttachment(taskID, attachmentID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, attachmentID, userID uint64) error {
+	storageKey, err := s.repo.DeleteAttachment(taskID, attachmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.Delete(ctx, storageKey)
+}
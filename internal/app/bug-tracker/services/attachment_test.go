@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+	mock_storage "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage/mocks"
+)
+
+func Test_CreateAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *AttachmentService
+	err := errors.New("error")
+	quota := storage.Quota{MaxFileSize: 10, MaxProjectSize: 100}
+	content := "plain text"
+
+	tests := []struct {
+		name           string
+		size           int64
+		mockBehaviour  mockBehaviour
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error file is too large",
+			size: 11,
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				return &AttachmentService{quota: quota}
+			},
+			expectedResult: 0,
+			expectedError:  ErrAttachmentTooLarge,
+		},
+		{
+			name: "Error in repo.CreateAttachment",
+			size: int64(len(content)),
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+
+				attachment.EXPECT().CreateAttachment(gomock.Any(), uint64(1), quota.MaxProjectSize).Return(uint64(0), err)
+
+				return &AttachmentService{repo: attachment, quota: quota}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "Error in storage.Put",
+			size: int64(len(content)),
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+				store := mock_storage.NewMockStorage(c)
+
+				attachment.EXPECT().CreateAttachment(gomock.Any(), uint64(1), quota.MaxProjectSize).Return(uint64(3), nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(content)), gomock.Any()).Return(err)
+				attachment.EXPECT().DeleteAttachment(uint64(1), uint64(3), uint64(1)).Return("", nil)
+
+				return &AttachmentService{repo: attachment, storage: store, quota: quota}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "Error dropping the record after storage.Put",
+			size: int64(len(content)),
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+				store := mock_storage.NewMockStorage(c)
+
+				attachment.EXPECT().CreateAttachment(gomock.Any(), uint64(1), quota.MaxProjectSize).Return(uint64(3), nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(content)), gomock.Any()).Return(err)
+				attachment.EXPECT().DeleteAttachment(uint64(1), uint64(3), uint64(1)).Return("", repository.ErrProjectArchived)
+
+				return &AttachmentService{repo: attachment, storage: store, quota: quota}
+			},
+			expectedResult: 0,
+			expectedError:  fmt.Errorf("%w (error dropping attachment %d: %v)", err, uint64(3), repository.ErrProjectArchived),
+		},
+		{
+			name: "OK",
+			size: int64(len(content)),
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+				store := mock_storage.NewMockStorage(c)
+
+				attachment.EXPECT().CreateAttachment(gomock.Any(), uint64(1), quota.MaxProjectSize).
+					DoAndReturn(func(attachmentData *dto.CreateAttachmentDto, userID uint64, maxProjectSize int64) (uint64, error) {
+						require.Equal(t, "text/plain; charset=utf-8", attachmentData.ContentType)
+						require.True(t, strings.HasPrefix(attachmentData.StorageKey, "tasks/1/"))
+
+						return 3, nil
+					})
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(len(content)), "text/plain; charset=utf-8").
+					DoAndReturn(func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+						stored, err := io.ReadAll(body)
+						require.NoError(t, err)
+						require.Equal(t, content, string(stored))
+
+						return nil
+					})
+
+				return &AttachmentService{repo: attachment, storage: store, quota: quota}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachmentData := &dto.CreateAttachmentDto{TaskID: 1, FileName: "notes.txt", Size: test.size}
+			service := test.mockBehaviour(c)
+			res, err := service.CreateAttachment(context.Background(), attachmentData, strings.NewReader(content), 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteAttachment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *AttachmentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error in repo.DeleteAttachment",
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+
+				attachment.EXPECT().DeleteAttachment(uint64(1), uint64(3), uint64(1)).Return("", err)
+
+				return &AttachmentService{repo: attachment}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *AttachmentService {
+				attachment := mock_repository.NewMockAttachment(c)
+				store := mock_storage.NewMockStorage(c)
+
+				attachment.EXPECT().DeleteAttachment(uint64(1), uint64(3), uint64(1)).Return("tasks/1/key", nil)
+				store.EXPECT().Delete(gomock.Any(), "tasks/1/key").Return(nil)
+
+				return &AttachmentService{repo: attachment, storage: store}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			err := service.DeleteAttachment(context.Background(), 1, 3, 1)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
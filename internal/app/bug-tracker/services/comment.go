@@ -0,0 +1,39 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type CommentService struct {
+	repo repository.Comment
+}
+
+func NewComment(repo repository.Comment) Comment {
+	return &CommentService{repo: repo}
+}
+
+func (s *CommentService) CreateComment(commentData *dto.CreateCommentDto, userID uint64) (uint64, error) {
+	return s.repo.CreateComment(commentData, userID)
+}
+
+func (s *CommentService) UpdateComment(commentData *dto.UpdateCommentDto, userID uint64) error {
+	return s.repo.UpdateComment(commentData, userID)
+}
+
+func (s *CommentService) DeleteComment(taskID, commentID, userID uint64) error {
+	return s.repo.DeleteComment(taskID, commentID, userID)
+}
+
+func (s *CommentService) GetComments(taskID, userID uint64, page *dto.PageDto) (*models.CommentPage, error) {
+	return s.repo.GetComments(taskID, userID, page)
+}
+
+func (s *CommentService) GetCommentCount(taskID uint64) (int, error) {
+	return s.repo.GetCommentCount(taskID)
+}
+
+func (s *CommentService) GetCommentHistory(taskID, commentID, userID uint64) ([]*models.CommentRevision, error) {
+	return s.repo.GetCommentHistory(taskID, commentID, userID)
+}
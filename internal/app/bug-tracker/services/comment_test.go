@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_CreateComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		commentData    *dto.CreateCommentDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentService {
+				comment := mock_repository.NewMockComment(c)
+
+				comment.EXPECT().CreateComment(commentData, userID).Return(uint64(0), err)
+
+				return &CommentService{repo: comment}
+			},
+			commentData:    &dto.CreateCommentDto{TaskID: 1, Body: "body"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.CreateCommentDto, userID uint64) *CommentService {
+				comment := mock_repository.NewMockComment(c)
+
+				comment.EXPECT().CreateComment(commentData, userID).Return(uint64(2), nil)
+
+				return &CommentService{repo: comment}
+			},
+			commentData:    &dto.CreateCommentDto{TaskID: 1, Body: "body"},
+			userID:         1,
+			expectedResult: 2,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.commentData, test.userID)
+			res, err := service.CreateComment(test.commentData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateComment(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, commentData *dto.UpdateCommentDto, userID uint64) *CommentService
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		commentData   *dto.UpdateCommentDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, commentData *dto.UpdateCommentDto, userID uint64) *CommentService {
+				comment := mock_repository.NewMockComment(c)
+
+				comment.EXPECT().UpdateComment(commentData, userID).Return(nil)
+
+				return &CommentService{repo: comment}
+			},
+			commentData:   &dto.UpdateCommentDto{TaskID: 1, CommentID: 2, Body: "body"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.commentData, test.userID)
+			err := service.UpdateComment(test.commentData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetComments(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID, userID uint64, page *dto.PageDto) *CommentService
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		taskID         uint64
+		userID         uint64
+		page           *dto.PageDto
+		expectedResult *models.CommentPage
+		expectedError  error
+	}{
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskID, userID uint64, page *dto.PageDto) *CommentService {
+				comment := mock_repository.NewMockComment(c)
+
+				comment.EXPECT().GetComments(taskID, userID, page).Return(&models.CommentPage{Count: 1, Threads: 1, Page: 1, Limit: 20}, nil)
+
+				return &CommentService{repo: comment}
+			},
+			taskID:         1,
+			userID:         1,
+			page:           &dto.PageDto{},
+			expectedResult: &models.CommentPage{Count: 1, Threads: 1, Page: 1, Limit: 20},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.taskID, test.userID, test.page)
+			res, err := service.GetComments(test.taskID, test.userID, test.page)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type ComponentService struct {
+	repo repository.Component
+}
+
+func NewComponent(repo repository.Component) Component {
+	return &ComponentService{repo: repo}
+}
+
+func (s *ComponentService) GetComponents(projectID, userID uint64) ([]*models.Component, error) {
+	return s.repo.GetComponents(projectID, userID)
+}
+
+func (s *ComponentService) CreateComponent(componentData *dto.CreateComponentDto, userID uint64) (uint64, error) {
+	return s.repo.CreateComponent(componentData, userID)
+}
+
+func (s *ComponentService) UpdateComponent(componentData *dto.UpdateComponentDto, userID uint64) error {
+	return s.repo.UpdateComponent(componentData, userID)
+}
+
+func (s *ComponentService) DeleteComponent(componentData *dto.DeleteComponentDto, userID uint64) error {
+	return s.repo.DeleteComponent(componentData, userID)
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetComponents(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ComponentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.Component
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().GetComponents(projectID, userID).Return(nil, err)
+
+				return &ComponentService{repo: component}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().GetComponents(projectID, userID).Return([]*models.Component{{ID: 1}}, nil)
+
+				return &ComponentService{repo: component}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.Component{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetComponents(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		componentData  *dto.CreateComponentDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().CreateComponent(componentData, userID).Return(uint64(0), err)
+
+				return &ComponentService{repo: component}
+			},
+			componentData:  &dto.CreateComponentDto{ProjectID: 1, Name: "API"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, componentData *dto.CreateComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().CreateComponent(componentData, userID).Return(uint64(5), nil)
+
+				return &ComponentService{repo: component}
+			},
+			componentData:  &dto.CreateComponentDto{ProjectID: 1, Name: "API"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.componentData, test.userID)
+			res, err := service.CreateComponent(test.componentData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *ComponentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateComponentDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().UpdateComponent(data, userID).Return(err)
+
+				return &ComponentService{repo: component}
+			},
+			data:          &dto.UpdateComponentDto{ProjectID: 1, ComponentID: 2, Name: "Web"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().UpdateComponent(data, userID).Return(nil)
+
+				return &ComponentService{repo: component}
+			},
+			data:          &dto.UpdateComponentDto{ProjectID: 1, ComponentID: 2, Name: "Web"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateComponent(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteComponent(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *ComponentService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteComponentDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().DeleteComponent(data, userID).Return(err)
+
+				return &ComponentService{repo: component}
+			},
+			data:          &dto.DeleteComponentDto{ProjectID: 1, ComponentID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteComponentDto, userID uint64) *ComponentService {
+				component := mock_repository.NewMockComponent(c)
+
+				component.EXPECT().DeleteComponent(data, userID).Return(nil)
+
+				return &ComponentService{repo: component}
+			},
+			data:          &dto.DeleteComponentDto{ProjectID: 1, ComponentID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteComponent(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type CustomFieldService struct {
+	repo repository.CustomField
+}
+
+func NewCustomField(repo repository.CustomField) CustomField {
+	return &CustomFieldService{repo: repo}
+}
+
+func (s *CustomFieldService) GetCustomFields(projectID, userID uint64) ([]*models.CustomField, error) {
+	return s.repo.GetCustomFields(projectID, userID)
+}
+
+func (s *CustomFieldService) CreateCustomField(fieldData *dto.CreateCustomFieldDto, userID uint64) (uint64, error) {
+	return s.repo.CreateCustomField(fieldData, userID)
+}
+
+func (s *CustomFieldService) UpdateCustomField(fieldData *dto.UpdateCustomFieldDto, userID uint64) error {
+	return s.repo.UpdateCustomField(fieldData, userID)
+}
+
+func (s *CustomFieldService) DeleteCustomField(fieldData *dto.DeleteCustomFieldDto, userID uint64) error {
+	return s.repo.DeleteCustomField(fieldData, userID)
+}
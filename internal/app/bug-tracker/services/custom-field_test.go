@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetCustomFields(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *CustomFieldService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.CustomField
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().GetCustomFields(projectID, userID).Return(nil, err)
+
+				return &CustomFieldService{repo: field}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().GetCustomFields(projectID, userID).Return([]*models.CustomField{{ID: 1}}, nil)
+
+				return &CustomFieldService{repo: field}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.CustomField{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetCustomFields(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		fieldData      *dto.CreateCustomFieldDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().CreateCustomField(fieldData, userID).Return(uint64(0), err)
+
+				return &CustomFieldService{repo: field}
+			},
+			fieldData:      &dto.CreateCustomFieldDto{ProjectID: 1, Name: "customer", Type: "text"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, fieldData *dto.CreateCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().CreateCustomField(fieldData, userID).Return(uint64(5), nil)
+
+				return &CustomFieldService{repo: field}
+			},
+			fieldData:      &dto.CreateCustomFieldDto{ProjectID: 1, Name: "customer", Type: "text"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.fieldData, test.userID)
+			res, err := service.CreateCustomField(test.fieldData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateCustomFieldDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().UpdateCustomField(data, userID).Return(err)
+
+				return &CustomFieldService{repo: field}
+			},
+			data:          &dto.UpdateCustomFieldDto{ProjectID: 1, FieldID: 2, Name: "client"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().UpdateCustomField(data, userID).Return(nil)
+
+				return &CustomFieldService{repo: field}
+			},
+			data:          &dto.UpdateCustomFieldDto{ProjectID: 1, FieldID: 2, Name: "client"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateCustomField(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteCustomField(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteCustomFieldDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().DeleteCustomField(data, userID).Return(err)
+
+				return &CustomFieldService{repo: field}
+			},
+			data:          &dto.DeleteCustomFieldDto{ProjectID: 1, FieldID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteCustomFieldDto, userID uint64) *CustomFieldService {
+				field := mock_repository.NewMockCustomField(c)
+
+				field.EXPECT().DeleteCustomField(data, userID).Return(nil)
+
+				return &CustomFieldService{repo: field}
+			},
+			data:          &dto.DeleteCustomFieldDto{ProjectID: 1, FieldID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteCustomField(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
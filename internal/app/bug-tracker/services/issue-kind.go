@@ -0,0 +1,31 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type IssueKindService struct {
+	repo repository.IssueKind
+}
+
+func NewIssueKind(repo repository.IssueKind) IssueKind {
+	return &IssueKindService{repo: repo}
+}
+
+func (s *IssueKindService) GetIssueKinds(projectID, userID uint64) ([]*models.IssueKind, error) {
+	return s.repo.GetIssueKinds(projectID, userID)
+}
+
+func (s *IssueKindService) CreateIssueKind(kindData *dto.CreateIssueKindDto, userID uint64) (uint64, error) {
+	return s.repo.CreateIssueKind(kindData, userID)
+}
+
+func (s *IssueKindService) UpdateIssueKind(kindData *dto.UpdateIssueKindDto, userID uint64) error {
+	return s.repo.UpdateIssueKind(kindData, userID)
+}
+
+func (s *IssueKindService) DeleteIssueKind(kindData *dto.DeleteIssueKindDto, userID uint64) error {
+	return s.repo.DeleteIssueKind(kindData, userID)
+}
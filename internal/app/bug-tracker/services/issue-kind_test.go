@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetIssueKinds(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *IssueKindService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.IssueKind
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().GetIssueKinds(projectID, userID).Return(nil, err)
+
+				return &IssueKindService{repo: kind}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().GetIssueKinds(projectID, userID).Return([]*models.IssueKind{{ID: 1}}, nil)
+
+				return &IssueKindService{repo: kind}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.IssueKind{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetIssueKinds(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		kindData       *dto.CreateIssueKindDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().CreateIssueKind(kindData, userID).Return(uint64(0), err)
+
+				return &IssueKindService{repo: kind}
+			},
+			kindData:       &dto.CreateIssueKindDto{ProjectID: 1, Name: "spike", Icon: "flask"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, kindData *dto.CreateIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().CreateIssueKind(kindData, userID).Return(uint64(5), nil)
+
+				return &IssueKindService{repo: kind}
+			},
+			kindData:       &dto.CreateIssueKindDto{ProjectID: 1, Name: "spike", Icon: "flask"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.kindData, test.userID)
+			res, err := service.CreateIssueKind(test.kindData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *IssueKindService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateIssueKindDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().UpdateIssueKind(data, userID).Return(err)
+
+				return &IssueKindService{repo: kind}
+			},
+			data:          &dto.UpdateIssueKindDto{ProjectID: 1, KindID: 2, Name: "defect", Icon: "bug"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().UpdateIssueKind(data, userID).Return(nil)
+
+				return &IssueKindService{repo: kind}
+			},
+			data:          &dto.UpdateIssueKindDto{ProjectID: 1, KindID: 2, Name: "defect", Icon: "bug"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateIssueKind(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteIssueKind(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *IssueKindService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteIssueKindDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().DeleteIssueKind(data, userID).Return(err)
+
+				return &IssueKindService{repo: kind}
+			},
+			data:          &dto.DeleteIssueKindDto{ProjectID: 1, KindID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteIssueKindDto, userID uint64) *IssueKindService {
+				kind := mock_repository.NewMockIssueKind(c)
+
+				kind.EXPECT().DeleteIssueKind(data, userID).Return(nil)
+
+				return &IssueKindService{repo: kind}
+			},
+			data:          &dto.DeleteIssueKindDto{ProjectID: 1, KindID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteIssueKind(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type LabelService struct {
+	repo repository.Label
+}
+
+func NewLabel(repo repository.Label) Label {
+	return &LabelService{repo: repo}
+}
+
+func (s *LabelService) GetLabels(projectID, userID uint64) ([]*models.Label, error) {
+	return s.repo.GetLabels(projectID, userID)
+}
+
+func (s *LabelService) CreateLabel(labelData *dto.CreateLabelDto, userID uint64) (uint64, error) {
+	return s.repo.CreateLabel(labelData, userID)
+}
+
+func (s *LabelService) UpdateLabel(labelData *dto.UpdateLabelDto, userID uint64) error {
+	return s.repo.UpdateLabel(labelData, userID)
+}
+
+func (s *LabelService) DeleteLabel(labelData *dto.DeleteLabelDto, userID uint64) error {
+	return s.repo.DeleteLabel(labelData, userID)
+}
+
+func (s *LabelService) AddTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	return s.repo.AddTaskLabels(labelsData, userID)
+}
+
+func (s *LabelService) RemoveTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	return s.repo.RemoveTaskLabels(labelsData, userID)
+}
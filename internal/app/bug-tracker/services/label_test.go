@@ -0,0 +1,333 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.Label
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().GetLabels(projectID, userID).Return(nil, err)
+
+				return &LabelService{repo: label}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().GetLabels(projectID, userID).Return([]*models.Label{{ID: 1}}, nil)
+
+				return &LabelService{repo: label}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.Label{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetLabels(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		labelData      *dto.CreateLabelDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().CreateLabel(labelData, userID).Return(uint64(0), err)
+
+				return &LabelService{repo: label}
+			},
+			labelData:      &dto.CreateLabelDto{ProjectID: 1, Name: "backend"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, labelData *dto.CreateLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().CreateLabel(labelData, userID).Return(uint64(5), nil)
+
+				return &LabelService{repo: label}
+			},
+			labelData:      &dto.CreateLabelDto{ProjectID: 1, Name: "backend"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.labelData, test.userID)
+			res, err := service.CreateLabel(test.labelData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateLabelDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().UpdateLabel(data, userID).Return(err)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.UpdateLabelDto{ProjectID: 1, LabelID: 2, Name: "frontend"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().UpdateLabel(data, userID).Return(nil)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.UpdateLabelDto{ProjectID: 1, LabelID: 2, Name: "frontend"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateLabel(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteLabel(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteLabelDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().DeleteLabel(data, userID).Return(err)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.DeleteLabelDto{ProjectID: 1, LabelID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteLabelDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().DeleteLabel(data, userID).Return(nil)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.DeleteLabelDto{ProjectID: 1, LabelID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteLabel(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.TaskLabelsDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().AddTaskLabels(data, userID).Return(err)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1}, LabelIDs: []uint64{2}},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().AddTaskLabels(data, userID).Return(nil)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1}, LabelIDs: []uint64{2}},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.AddTaskLabels(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_RemoveTaskLabels(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.TaskLabelsDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().RemoveTaskLabels(data, userID).Return(err)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1}, LabelIDs: []uint64{2}},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TaskLabelsDto, userID uint64) *LabelService {
+				label := mock_repository.NewMockLabel(c)
+
+				label.EXPECT().RemoveTaskLabels(data, userID).Return(nil)
+
+				return &LabelService{repo: label}
+			},
+			data:          &dto.TaskLabelsDto{ProjectID: 1, TaskIDs: []uint64{1}, LabelIDs: []uint64{2}},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.RemoveTaskLabels(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type MentionService struct {
+	repo         repository.Mention
+	notification Notification
+}
+
+func NewMention(repo repository.Mention, notification Notification) Mention {
+	return &MentionService{repo: repo, notification: notification}
+}
+
+// SaveMentions stores the mentions of a text and adds the mention to the
+// inbox of every user mentioned in it for the first time. The repository
+// saves their events with the mentions.
+func (s *MentionService) SaveMentions(mentionData *dto.MentionDto) error {
+	userIDs, err := s.repo.SaveMentions(mentionData)
+	if err != nil {
+		return err
+	}
+
+	return s.notification.Notify(context.Background(), &dto.NotificationDto{
+		UserIDs:   userIDs,
+		Type:      models.NotificationMention,
+		TaskID:    mentionData.TaskID,
+		CommentID: mentionData.CommentID,
+		ActorID:   mentionData.AuthorID,
+	})
+}
+
+func (s *MentionService) GetMentions(userID uint64, page *dto.PageDto) ([]*models.Mention, error) {
+	return s.repo.GetMentions(userID, page)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_redis "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis/mocks"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_SaveMentions(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionService
+	err := errors.New("error")
+	mentionData := &dto.MentionDto{TaskID: 1, CommentID: 4, AuthorID: 1, Text: "@jane"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error in repo.SaveMentions",
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionService {
+				mention := mock_repository.NewMockMention(c)
+
+				mention.EXPECT().SaveMentions(mentionData).Return(nil, err)
+
+				return &MentionService{repo: mention}
+			},
+			expectedError: err,
+		},
+		{
+			name: "Error in notification.Notify",
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionService {
+				mention := mock_repository.NewMockMention(c)
+				notification := mock_repository.NewMockNotification(c)
+
+				mention.EXPECT().SaveMentions(mentionData).Return([]uint64{2}, nil)
+				notification.EXPECT().AddNotifications(gomock.Any()).Return(err)
+
+				return &MentionService{repo: mention, notification: &NotificationService{repo: notification}}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, mentionData *dto.MentionDto) *MentionService {
+				mention := mock_repository.NewMockMention(c)
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				mention.EXPECT().SaveMentions(mentionData).Return([]uint64{2, 3}, nil)
+				notification.EXPECT().AddNotifications(&dto.NotificationDto{
+					UserIDs:   []uint64{2, 3},
+					Type:      models.NotificationMention,
+					TaskID:    mentionData.TaskID,
+					CommentID: mentionData.CommentID,
+					ActorID:   mentionData.AuthorID,
+				}).Return(nil)
+				redis.EXPECT().Delete(gomock.Any(), "notifications:unread:2", "notifications:unread:3").Return(nil)
+
+				return &MentionService{
+					repo:         mention,
+					notification: &NotificationService{repo: notification, redis: redis},
+				}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, mentionData)
+			err := service.SaveMentions(mentionData)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package mock_services
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -343,60 +344,89 @@ func (m *MockProject) EXPECT() *MockProjectMockRecorder {
 }
 
 // AddMember mocks base method.
-func (m *MockProject) AddMember(memberData *dto.AddMemberDto, userID uint64) error {
+func (m *MockProject) AddMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMember", memberData, userID)
+	ret := m.ctrl.Call(m, "AddMember", memberData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddMember indicates an expected call of AddMember.
-func (mr *MockProjectMockRecorder) AddMember(memberData, userID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) AddMember(memberData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockProject)(nil).AddMember), memberData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockProject)(nil).AddMember), memberData, userID, event)
+}
+
+// ArchiveProject mocks base method.
+func (m *MockProject) ArchiveProject(projectID, userID uint64, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProject", projectID, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveProject indicates an expected call of ArchiveProject.
+func (mr *MockProjectMockRecorder) ArchiveProject(projectID, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProject", reflect.TypeOf((*MockProject)(nil).ArchiveProject), projectID, userID, event)
 }
 
 // CreateProject mocks base method.
-func (m *MockProject) CreateProject(projectData *dto.CreateProjectDto) (uint64, error) {
+func (m *MockProject) CreateProject(projectData *dto.CreateProjectDto, event *models.Event) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", projectData)
+	ret := m.ctrl.Call(m, "CreateProject", projectData, event)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProject indicates an expected call of CreateProject.
-func (mr *MockProjectMockRecorder) CreateProject(projectData interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) CreateProject(projectData, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), projectData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProject)(nil).CreateProject), projectData, event)
 }
 
 // DeleteMember mocks base method.
-func (m *MockProject) DeleteMember(memberData *dto.AddMemberDto, userID uint64) error {
+func (m *MockProject) DeleteMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMember", memberData, userID)
+	ret := m.ctrl.Call(m, "DeleteMember", memberData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteMember indicates an expected call of DeleteMember.
-func (mr *MockProjectMockRecorder) DeleteMember(memberData, userID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) DeleteMember(memberData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockProject)(nil).DeleteMember), memberData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockProject)(nil).DeleteMember), memberData, userID, event)
 }
 
 // DeleteProject mocks base method.
-func (m *MockProject) DeleteProject(projectID, userID uint64) error {
+func (m *MockProject) DeleteProject(projectID, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProject", projectID, userID)
+	ret := m.ctrl.Call(m, "DeleteProject", projectID, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProject indicates an expected call of DeleteProject.
-func (mr *MockProjectMockRecorder) DeleteProject(projectID, userID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) DeleteProject(projectID, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), projectID, userID, event)
+}
+
+// GetArchivedProjectsByUserId mocks base method.
+func (m *MockProject) GetArchivedProjectsByUserId(id uint64) ([]*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedProjectsByUserId", id)
+	ret0, _ := ret[0].([]*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedProjectsByUserId indicates an expected call of GetArchivedProjectsByUserId.
+func (mr *MockProjectMockRecorder) GetArchivedProjectsByUserId(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProject)(nil).DeleteProject), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedProjectsByUserId", reflect.TypeOf((*MockProject)(nil).GetArchivedProjectsByUserId), id)
 }
 
 // GetMembers mocks base method.
@@ -414,6 +444,21 @@ func (mr *MockProjectMockRecorder) GetMembers(projectID, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockProject)(nil).GetMembers), projectID, userID)
 }
 
+// GetProjectAudit mocks base method.
+func (m *MockProject) GetProjectAudit(projectID, userID uint64) ([]*models.ProjectAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectAudit", projectID, userID)
+	ret0, _ := ret[0].([]*models.ProjectAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectAudit indicates an expected call of GetProjectAudit.
+func (mr *MockProjectMockRecorder) GetProjectAudit(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectAudit", reflect.TypeOf((*MockProject)(nil).GetProjectAudit), projectID, userID)
+}
+
 // GetProjectById mocks base method.
 func (m *MockProject) GetProjectById(id uint64) (*models.Project, error) {
 	m.ctrl.T.Helper()
@@ -444,46 +489,104 @@ func (mr *MockProjectMockRecorder) GetProjectsByUserId(id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectsByUserId", reflect.TypeOf((*MockProject)(nil).GetProjectsByUserId), id)
 }
 
+// GetTrash mocks base method.
+func (m *MockProject) GetTrash(userID uint64) ([]*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", userID)
+	ret0, _ := ret[0].([]*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockProjectMockRecorder) GetTrash(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockProject)(nil).GetTrash), userID)
+}
+
 // LeaveProject mocks base method.
-func (m *MockProject) LeaveProject(projectID, userID uint64) error {
+func (m *MockProject) LeaveProject(projectID, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LeaveProject", projectID, userID)
+	ret := m.ctrl.Call(m, "LeaveProject", projectID, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // LeaveProject indicates an expected call of LeaveProject.
-func (mr *MockProjectMockRecorder) LeaveProject(projectID, userID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) LeaveProject(projectID, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveProject", reflect.TypeOf((*MockProject)(nil).LeaveProject), projectID, userID, event)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockProject) PurgeTrash(retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockProjectMockRecorder) PurgeTrash(retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockProject)(nil).PurgeTrash), retention)
+}
+
+// RestoreProject mocks base method.
+func (m *MockProject) RestoreProject(projectID, userID uint64, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProject", projectID, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreProject indicates an expected call of RestoreProject.
+func (mr *MockProjectMockRecorder) RestoreProject(projectID, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveProject", reflect.TypeOf((*MockProject)(nil).LeaveProject), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProject", reflect.TypeOf((*MockProject)(nil).RestoreProject), projectID, userID, event)
 }
 
 // SetNewAdmin mocks base method.
-func (m *MockProject) SetNewAdmin(newAdmintData *dto.NewAdminDto, adminID uint64) error {
+func (m *MockProject) SetNewAdmin(newAdmintData *dto.NewAdminDto, adminID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetNewAdmin", newAdmintData, adminID)
+	ret := m.ctrl.Call(m, "SetNewAdmin", newAdmintData, adminID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetNewAdmin indicates an expected call of SetNewAdmin.
-func (mr *MockProjectMockRecorder) SetNewAdmin(newAdmintData, adminID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) SetNewAdmin(newAdmintData, adminID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNewAdmin", reflect.TypeOf((*MockProject)(nil).SetNewAdmin), newAdmintData, adminID, event)
+}
+
+// UnarchiveProject mocks base method.
+func (m *MockProject) UnarchiveProject(projectID, userID uint64, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveProject", projectID, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnarchiveProject indicates an expected call of UnarchiveProject.
+func (mr *MockProjectMockRecorder) UnarchiveProject(projectID, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNewAdmin", reflect.TypeOf((*MockProject)(nil).SetNewAdmin), newAdmintData, adminID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveProject", reflect.TypeOf((*MockProject)(nil).UnarchiveProject), projectID, userID, event)
 }
 
 // UpdateProject mocks base method.
-func (m *MockProject) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64) error {
+func (m *MockProject) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProject", projectData, userID)
+	ret := m.ctrl.Call(m, "UpdateProject", projectData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProject indicates an expected call of UpdateProject.
-func (mr *MockProjectMockRecorder) UpdateProject(projectData, userID interface{}) *gomock.Call {
+func (mr *MockProjectMockRecorder) UpdateProject(projectData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), projectData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProject)(nil).UpdateProject), projectData, userID, event)
 }
 
 // MockTask is a mock of Task interface.
@@ -510,103 +613,1564 @@ func (m *MockTask) EXPECT() *MockTaskMockRecorder {
 }
 
 // CreateTask mocks base method.
-func (m *MockTask) CreateTask(taskData *dto.CreateTaskDto, userID uint64) (uint64, error) {
+func (m *MockTask) CreateTask(taskData *dto.CreateTaskDto, userID uint64, event *models.Event) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", taskData, userID)
+	ret := m.ctrl.Call(m, "CreateTask", taskData, userID, event)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskMockRecorder) CreateTask(taskData, userID interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) CreateTask(taskData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTask)(nil).CreateTask), taskData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTask)(nil).CreateTask), taskData, userID, event)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTask) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64) error {
+func (m *MockTask) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", taskData, userID)
+	ret := m.ctrl.Call(m, "DeleteTask", taskData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskMockRecorder) DeleteTask(taskData, userID interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) DeleteTask(taskData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), taskData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTask)(nil).DeleteTask), taskData, userID, event)
 }
 
 // GetTaskById mocks base method.
-func (m *MockTask) GetTaskById(id uint64) (*models.Task, error) {
+func (m *MockTask) GetTaskById(id, userID uint64) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskById", id)
+	ret := m.ctrl.Call(m, "GetTaskById", id, userID)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskById indicates an expected call of GetTaskById.
-func (mr *MockTaskMockRecorder) GetTaskById(id interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetTaskById(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTask)(nil).GetTaskById), id, userID)
+}
+
+// GetTaskChildren mocks base method.
+func (m *MockTask) GetTaskChildren(id uint64) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskChildren", id)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskChildren indicates an expected call of GetTaskChildren.
+func (mr *MockTaskMockRecorder) GetTaskChildren(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskChildren", reflect.TypeOf((*MockTask)(nil).GetTaskChildren), id)
+}
+
+// GetTaskIdByKey mocks base method.
+func (m *MockTask) GetTaskIdByKey(taskKey string) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIdByKey", taskKey)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskIdByKey indicates an expected call of GetTaskIdByKey.
+func (mr *MockTaskMockRecorder) GetTaskIdByKey(taskKey interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskById", reflect.TypeOf((*MockTask)(nil).GetTaskById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIdByKey", reflect.TypeOf((*MockTask)(nil).GetTaskIdByKey), taskKey)
 }
 
 // GetTasksByProjectId mocks base method.
-func (m *MockTask) GetTasksByProjectId(id uint64) ([]*models.Task, error) {
+func (m *MockTask) GetTasksByProjectId(id uint64, filter *dto.TaskFilterDto) ([]*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasksByProjectId", id)
+	ret := m.ctrl.Call(m, "GetTasksByProjectId", id, filter)
 	ret0, _ := ret[0].([]*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasksByProjectId indicates an expected call of GetTasksByProjectId.
-func (mr *MockTaskMockRecorder) GetTasksByProjectId(id interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) GetTasksByProjectId(id, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByProjectId", reflect.TypeOf((*MockTask)(nil).GetTasksByProjectId), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByProjectId", reflect.TypeOf((*MockTask)(nil).GetTasksByProjectId), id, filter)
 }
 
 // StopWorkOnTask mocks base method.
-func (m *MockTask) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
+func (m *MockTask) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StopWorkOnTask", workOnTaskData, userID)
+	ret := m.ctrl.Call(m, "StopWorkOnTask", workOnTaskData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // StopWorkOnTask indicates an expected call of StopWorkOnTask.
-func (mr *MockTaskMockRecorder) StopWorkOnTask(workOnTaskData, userID interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) StopWorkOnTask(workOnTaskData, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopWorkOnTask", reflect.TypeOf((*MockTask)(nil).StopWorkOnTask), workOnTaskData, userID, event)
+}
+
+// TransitionTask mocks base method.
+func (m *MockTask) TransitionTask(taskData *dto.TransitionTaskDto, userID uint64, event *models.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionTask", taskData, userID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransitionTask indicates an expected call of TransitionTask.
+func (mr *MockTaskMockRecorder) TransitionTask(taskData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopWorkOnTask", reflect.TypeOf((*MockTask)(nil).StopWorkOnTask), workOnTaskData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionTask", reflect.TypeOf((*MockTask)(nil).TransitionTask), taskData, userID, event)
 }
 
 // UpdateTask mocks base method.
-func (m *MockTask) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64) (uint64, error) {
+func (m *MockTask) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64, event *models.Event) (uint64, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", taskData, userID)
+	ret := m.ctrl.Call(m, "UpdateTask", taskData, userID, event)
 	ret0, _ := ret[0].(uint64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskMockRecorder) UpdateTask(taskData, userID interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) UpdateTask(taskData, userID, event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), taskData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTask)(nil).UpdateTask), taskData, userID, event)
 }
 
 // WorkOnTask mocks base method.
-func (m *MockTask) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
+func (m *MockTask) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WorkOnTask", workOnTaskData, userID)
+	ret := m.ctrl.Call(m, "WorkOnTask", workOnTaskData, userID, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WorkOnTask indicates an expected call of WorkOnTask.
-func (mr *MockTaskMockRecorder) WorkOnTask(workOnTaskData, userID interface{}) *gomock.Call {
+func (mr *MockTaskMockRecorder) WorkOnTask(workOnTaskData, userID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkOnTask", reflect.TypeOf((*MockTask)(nil).WorkOnTask), workOnTaskData, userID, event)
+}
+
+// MockWorkflow is a mock of Workflow interface.
+type MockWorkflow struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkflowMockRecorder
+}
+
+// MockWorkflowMockRecorder is the mock recorder for MockWorkflow.
+type MockWorkflowMockRecorder struct {
+	mock *MockWorkflow
+}
+
+// NewMockWorkflow creates a new mock instance.
+func NewMockWorkflow(ctrl *gomock.Controller) *MockWorkflow {
+	mock := &MockWorkflow{ctrl: ctrl}
+	mock.recorder = &MockWorkflowMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkflow) EXPECT() *MockWorkflowMockRecorder {
+	return m.recorder
+}
+
+// AddTransition mocks base method.
+func (m *MockWorkflow) AddTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransition", transitionData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTransition indicates an expected call of AddTransition.
+func (mr *MockWorkflowMockRecorder) AddTransition(transitionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransition", reflect.TypeOf((*MockWorkflow)(nil).AddTransition), transitionData, userID)
+}
+
+// CreateStatus mocks base method.
+func (m *MockWorkflow) CreateStatus(statusData *dto.CreateStatusDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatus", statusData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatus indicates an expected call of CreateStatus.
+func (mr *MockWorkflowMockRecorder) CreateStatus(statusData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatus", reflect.TypeOf((*MockWorkflow)(nil).CreateStatus), statusData, userID)
+}
+
+// DeleteStatus mocks base method.
+func (m *MockWorkflow) DeleteStatus(statusData *dto.DeleteStatusDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStatus", statusData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStatus indicates an expected call of DeleteStatus.
+func (mr *MockWorkflowMockRecorder) DeleteStatus(statusData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStatus", reflect.TypeOf((*MockWorkflow)(nil).DeleteStatus), statusData, userID)
+}
+
+// DeleteTransition mocks base method.
+func (m *MockWorkflow) DeleteTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransition", transitionData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransition indicates an expected call of DeleteTransition.
+func (mr *MockWorkflowMockRecorder) DeleteTransition(transitionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransition", reflect.TypeOf((*MockWorkflow)(nil).DeleteTransition), transitionData, userID)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockWorkflow) GetWorkflow(projectID, userID uint64) (*models.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow", projectID, userID)
+	ret0, _ := ret[0].(*models.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockWorkflowMockRecorder) GetWorkflow(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockWorkflow)(nil).GetWorkflow), projectID, userID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockWorkflow) UpdateStatus(statusData *dto.UpdateStatusDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", statusData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockWorkflowMockRecorder) UpdateStatus(statusData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockWorkflow)(nil).UpdateStatus), statusData, userID)
+}
+
+// MockIssueKind is a mock of IssueKind interface.
+type MockIssueKind struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssueKindMockRecorder
+}
+
+// MockIssueKindMockRecorder is the mock recorder for MockIssueKind.
+type MockIssueKindMockRecorder struct {
+	mock *MockIssueKind
+}
+
+// NewMockIssueKind creates a new mock instance.
+func NewMockIssueKind(ctrl *gomock.Controller) *MockIssueKind {
+	mock := &MockIssueKind{ctrl: ctrl}
+	mock.recorder = &MockIssueKindMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssueKind) EXPECT() *MockIssueKindMockRecorder {
+	return m.recorder
+}
+
+// CreateIssueKind mocks base method.
+func (m *MockIssueKind) CreateIssueKind(kindData *dto.CreateIssueKindDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssueKind", kindData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssueKind indicates an expected call of CreateIssueKind.
+func (mr *MockIssueKindMockRecorder) CreateIssueKind(kindData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueKind", reflect.TypeOf((*MockIssueKind)(nil).CreateIssueKind), kindData, userID)
+}
+
+// DeleteIssueKind mocks base method.
+func (m *MockIssueKind) DeleteIssueKind(kindData *dto.DeleteIssueKindDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIssueKind", kindData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIssueKind indicates an expected call of DeleteIssueKind.
+func (mr *MockIssueKindMockRecorder) DeleteIssueKind(kindData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIssueKind", reflect.TypeOf((*MockIssueKind)(nil).DeleteIssueKind), kindData, userID)
+}
+
+// GetIssueKinds mocks base method.
+func (m *MockIssueKind) GetIssueKinds(projectID, userID uint64) ([]*models.IssueKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIssueKinds", projectID, userID)
+	ret0, _ := ret[0].([]*models.IssueKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIssueKinds indicates an expected call of GetIssueKinds.
+func (mr *MockIssueKindMockRecorder) GetIssueKinds(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIssueKinds", reflect.TypeOf((*MockIssueKind)(nil).GetIssueKinds), projectID, userID)
+}
+
+// UpdateIssueKind mocks base method.
+func (m *MockIssueKind) UpdateIssueKind(kindData *dto.UpdateIssueKindDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIssueKind", kindData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIssueKind indicates an expected call of UpdateIssueKind.
+func (mr *MockIssueKindMockRecorder) UpdateIssueKind(kindData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssueKind", reflect.TypeOf((*MockIssueKind)(nil).UpdateIssueKind), kindData, userID)
+}
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomField mocks base method.
+func (m *MockCustomField) CreateCustomField(fieldData *dto.CreateCustomFieldDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", fieldData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockCustomFieldMockRecorder) CreateCustomField(fieldData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockCustomField)(nil).CreateCustomField), fieldData, userID)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockCustomField) DeleteCustomField(fieldData *dto.DeleteCustomFieldDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", fieldData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockCustomFieldMockRecorder) DeleteCustomField(fieldData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockCustomField)(nil).DeleteCustomField), fieldData, userID)
+}
+
+// GetCustomFields mocks base method.
+func (m *MockCustomField) GetCustomFields(projectID, userID uint64) ([]*models.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFields", projectID, userID)
+	ret0, _ := ret[0].([]*models.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFields indicates an expected call of GetCustomFields.
+func (mr *MockCustomFieldMockRecorder) GetCustomFields(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFields", reflect.TypeOf((*MockCustomField)(nil).GetCustomFields), projectID, userID)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockCustomField) UpdateCustomField(fieldData *dto.UpdateCustomFieldDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", fieldData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockCustomFieldMockRecorder) UpdateCustomField(fieldData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockCustomField)(nil).UpdateCustomField), fieldData, userID)
+}
+
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelMockRecorder
+}
+
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
+}
+
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
+	return m.recorder
+}
+
+// AddTaskLabels mocks base method.
+func (m *MockLabel) AddTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskLabels", labelsData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskLabels indicates an expected call of AddTaskLabels.
+func (mr *MockLabelMockRecorder) AddTaskLabels(labelsData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskLabels", reflect.TypeOf((*MockLabel)(nil).AddTaskLabels), labelsData, userID)
+}
+
+// CreateLabel mocks base method.
+func (m *MockLabel) CreateLabel(labelData *dto.CreateLabelDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", labelData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockLabelMockRecorder) CreateLabel(labelData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockLabel)(nil).CreateLabel), labelData, userID)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockLabel) DeleteLabel(labelData *dto.DeleteLabelDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", labelData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockLabelMockRecorder) DeleteLabel(labelData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockLabel)(nil).DeleteLabel), labelData, userID)
+}
+
+// GetLabels mocks base method.
+func (m *MockLabel) GetLabels(projectID, userID uint64) ([]*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabels", projectID, userID)
+	ret0, _ := ret[0].([]*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabels indicates an expected call of GetLabels.
+func (mr *MockLabelMockRecorder) GetLabels(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabels", reflect.TypeOf((*MockLabel)(nil).GetLabels), projectID, userID)
+}
+
+// RemoveTaskLabels mocks base method.
+func (m *MockLabel) RemoveTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskLabels", labelsData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskLabels indicates an expected call of RemoveTaskLabels.
+func (mr *MockLabelMockRecorder) RemoveTaskLabels(labelsData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskLabels", reflect.TypeOf((*MockLabel)(nil).RemoveTaskLabels), labelsData, userID)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockLabel) UpdateLabel(labelData *dto.UpdateLabelDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", labelData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockLabelMockRecorder) UpdateLabel(labelData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockLabel)(nil).UpdateLabel), labelData, userID)
+}
+
+// MockComponent is a mock of Component interface.
+type MockComponent struct {
+	ctrl     *gomock.Controller
+	recorder *MockComponentMockRecorder
+}
+
+// MockComponentMockRecorder is the mock recorder for MockComponent.
+type MockComponentMockRecorder struct {
+	mock *MockComponent
+}
+
+// NewMockComponent creates a new mock instance.
+func NewMockComponent(ctrl *gomock.Controller) *MockComponent {
+	mock := &MockComponent{ctrl: ctrl}
+	mock.recorder = &MockComponentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComponent) EXPECT() *MockComponentMockRecorder {
+	return m.recorder
+}
+
+// CreateComponent mocks base method.
+func (m *MockComponent) CreateComponent(componentData *dto.CreateComponentDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponent", componentData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComponent indicates an expected call of CreateComponent.
+func (mr *MockComponentMockRecorder) CreateComponent(componentData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponent", reflect.TypeOf((*MockComponent)(nil).CreateComponent), componentData, userID)
+}
+
+// DeleteComponent mocks base method.
+func (m *MockComponent) DeleteComponent(componentData *dto.DeleteComponentDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComponent", componentData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComponent indicates an expected call of DeleteComponent.
+func (mr *MockComponentMockRecorder) DeleteComponent(componentData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComponent", reflect.TypeOf((*MockComponent)(nil).DeleteComponent), componentData, userID)
+}
+
+// GetComponents mocks base method.
+func (m *MockComponent) GetComponents(projectID, userID uint64) ([]*models.Component, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponents", projectID, userID)
+	ret0, _ := ret[0].([]*models.Component)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponents indicates an expected call of GetComponents.
+func (mr *MockComponentMockRecorder) GetComponents(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponents", reflect.TypeOf((*MockComponent)(nil).GetComponents), projectID, userID)
+}
+
+// UpdateComponent mocks base method.
+func (m *MockComponent) UpdateComponent(componentData *dto.UpdateComponentDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponent", componentData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComponent indicates an expected call of UpdateComponent.
+func (mr *MockComponentMockRecorder) UpdateComponent(componentData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponent", reflect.TypeOf((*MockComponent)(nil).UpdateComponent), componentData, userID)
+}
+
+// MockVersion is a mock of Version interface.
+type MockVersion struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionMockRecorder
+}
+
+// MockVersionMockRecorder is the mock recorder for MockVersion.
+type MockVersionMockRecorder struct {
+	mock *MockVersion
+}
+
+// NewMockVersion creates a new mock instance.
+func NewMockVersion(ctrl *gomock.Controller) *MockVersion {
+	mock := &MockVersion{ctrl: ctrl}
+	mock.recorder = &MockVersionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersion) EXPECT() *MockVersionMockRecorder {
+	return m.recorder
+}
+
+// CreateVersion mocks base method.
+func (m *MockVersion) CreateVersion(versionData *dto.CreateVersionDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVersion", versionData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVersion indicates an expected call of CreateVersion.
+func (mr *MockVersionMockRecorder) CreateVersion(versionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVersion", reflect.TypeOf((*MockVersion)(nil).CreateVersion), versionData, userID)
+}
+
+// DeleteVersion mocks base method.
+func (m *MockVersion) DeleteVersion(versionData *dto.DeleteVersionDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVersion", versionData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVersion indicates an expected call of DeleteVersion.
+func (mr *MockVersionMockRecorder) DeleteVersion(versionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVersion", reflect.TypeOf((*MockVersion)(nil).DeleteVersion), versionData, userID)
+}
+
+// GetVersions mocks base method.
+func (m *MockVersion) GetVersions(projectID, userID uint64) ([]*models.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", projectID, userID)
+	ret0, _ := ret[0].([]*models.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockVersionMockRecorder) GetVersions(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockVersion)(nil).GetVersions), projectID, userID)
+}
+
+// ReleaseVersion mocks base method.
+func (m *MockVersion) ReleaseVersion(versionData *dto.ReleaseVersionDto, userID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseVersion", versionData, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseVersion indicates an expected call of ReleaseVersion.
+func (mr *MockVersionMockRecorder) ReleaseVersion(versionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseVersion", reflect.TypeOf((*MockVersion)(nil).ReleaseVersion), versionData, userID)
+}
+
+// UpdateVersion mocks base method.
+func (m *MockVersion) UpdateVersion(versionData *dto.UpdateVersionDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVersion", versionData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVersion indicates an expected call of UpdateVersion.
+func (mr *MockVersionMockRecorder) UpdateVersion(versionData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVersion", reflect.TypeOf((*MockVersion)(nil).UpdateVersion), versionData, userID)
+}
+
+// MockReleaseNotes is a mock of ReleaseNotes interface.
+type MockReleaseNotes struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaseNotesMockRecorder
+}
+
+// MockReleaseNotesMockRecorder is the mock recorder for MockReleaseNotes.
+type MockReleaseNotesMockRecorder struct {
+	mock *MockReleaseNotes
+}
+
+// NewMockReleaseNotes creates a new mock instance.
+func NewMockReleaseNotes(ctrl *gomock.Controller) *MockReleaseNotes {
+	mock := &MockReleaseNotes{ctrl: ctrl}
+	mock.recorder = &MockReleaseNotesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaseNotes) EXPECT() *MockReleaseNotesMockRecorder {
+	return m.recorder
+}
+
+// GetReleaseNotes mocks base method.
+func (m *MockReleaseNotes) GetReleaseNotes(projectID, versionID, userID uint64) (*models.ReleaseNotes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleaseNotes", projectID, versionID, userID)
+	ret0, _ := ret[0].(*models.ReleaseNotes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReleaseNotes indicates an expected call of GetReleaseNotes.
+func (mr *MockReleaseNotesMockRecorder) GetReleaseNotes(projectID, versionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseNotes", reflect.TypeOf((*MockReleaseNotes)(nil).GetReleaseNotes), projectID, versionID, userID)
+}
+
+// RenderReleaseNotes mocks base method.
+func (m *MockReleaseNotes) RenderReleaseNotes(notes *models.ReleaseNotes, format string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderReleaseNotes", notes, format)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderReleaseNotes indicates an expected call of RenderReleaseNotes.
+func (mr *MockReleaseNotesMockRecorder) RenderReleaseNotes(notes, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderReleaseNotes", reflect.TypeOf((*MockReleaseNotes)(nil).RenderReleaseNotes), notes, format)
+}
+
+// SetReleaseNotesTemplate mocks base method.
+func (m *MockReleaseNotes) SetReleaseNotesTemplate(templateData *dto.ReleaseNotesTemplateDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReleaseNotesTemplate", templateData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReleaseNotesTemplate indicates an expected call of SetReleaseNotesTemplate.
+func (mr *MockReleaseNotesMockRecorder) SetReleaseNotesTemplate(templateData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReleaseNotesTemplate", reflect.TypeOf((*MockReleaseNotes)(nil).SetReleaseNotesTemplate), templateData, userID)
+}
+
+// MockSprint is a mock of Sprint interface.
+type MockSprint struct {
+	ctrl     *gomock.Controller
+	recorder *MockSprintMockRecorder
+}
+
+// MockSprintMockRecorder is the mock recorder for MockSprint.
+type MockSprintMockRecorder struct {
+	mock *MockSprint
+}
+
+// NewMockSprint creates a new mock instance.
+func NewMockSprint(ctrl *gomock.Controller) *MockSprint {
+	mock := &MockSprint{ctrl: ctrl}
+	mock.recorder = &MockSprintMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSprint) EXPECT() *MockSprintMockRecorder {
+	return m.recorder
+}
+
+// AddSprintTasks mocks base method.
+func (m *MockSprint) AddSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSprintTasks", tasksData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSprintTasks indicates an expected call of AddSprintTasks.
+func (mr *MockSprintMockRecorder) AddSprintTasks(tasksData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSprintTasks", reflect.TypeOf((*MockSprint)(nil).AddSprintTasks), tasksData, userID)
+}
+
+// CompleteSprint mocks base method.
+func (m *MockSprint) CompleteSprint(sprintData *dto.CompleteSprintDto, userID uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSprint", sprintData, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSprint indicates an expected call of CompleteSprint.
+func (mr *MockSprintMockRecorder) CompleteSprint(sprintData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSprint", reflect.TypeOf((*MockSprint)(nil).CompleteSprint), sprintData, userID)
+}
+
+// CreateSprint mocks base method.
+func (m *MockSprint) CreateSprint(sprintData *dto.CreateSprintDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", sprintData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockSprintMockRecorder) CreateSprint(sprintData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockSprint)(nil).CreateSprint), sprintData, userID)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockSprint) DeleteSprint(sprintData *dto.DeleteSprintDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", sprintData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockSprintMockRecorder) DeleteSprint(sprintData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockSprint)(nil).DeleteSprint), sprintData, userID)
+}
+
+// GetSprints mocks base method.
+func (m *MockSprint) GetSprints(projectID, userID uint64) ([]*models.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSprints", projectID, userID)
+	ret0, _ := ret[0].([]*models.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSprints indicates an expected call of GetSprints.
+func (mr *MockSprintMockRecorder) GetSprints(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSprints", reflect.TypeOf((*MockSprint)(nil).GetSprints), projectID, userID)
+}
+
+// RemoveSprintTasks mocks base method.
+func (m *MockSprint) RemoveSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSprintTasks", tasksData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSprintTasks indicates an expected call of RemoveSprintTasks.
+func (mr *MockSprintMockRecorder) RemoveSprintTasks(tasksData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSprintTasks", reflect.TypeOf((*MockSprint)(nil).RemoveSprintTasks), tasksData, userID)
+}
+
+// StartSprint mocks base method.
+func (m *MockSprint) StartSprint(sprintData *dto.StartSprintDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSprint", sprintData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSprint indicates an expected call of StartSprint.
+func (mr *MockSprintMockRecorder) StartSprint(sprintData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSprint", reflect.TypeOf((*MockSprint)(nil).StartSprint), sprintData, userID)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockSprint) UpdateSprint(sprintData *dto.UpdateSprintDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", sprintData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockSprintMockRecorder) UpdateSprint(sprintData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockSprint)(nil).UpdateSprint), sprintData, userID)
+}
+
+// MockTaskLink is a mock of TaskLink interface.
+type MockTaskLink struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLinkMockRecorder
+}
+
+// MockTaskLinkMockRecorder is the mock recorder for MockTaskLink.
+type MockTaskLinkMockRecorder struct {
+	mock *MockTaskLink
+}
+
+// NewMockTaskLink creates a new mock instance.
+func NewMockTaskLink(ctrl *gomock.Controller) *MockTaskLink {
+	mock := &MockTaskLink{ctrl: ctrl}
+	mock.recorder = &MockTaskLinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLink) EXPECT() *MockTaskLinkMockRecorder {
+	return m.recorder
+}
+
+// CreateTaskLink mocks base method.
+func (m *MockTaskLink) CreateTaskLink(linkData *dto.CreateTaskLinkDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskLink", linkData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaskLink indicates an expected call of CreateTaskLink.
+func (mr *MockTaskLinkMockRecorder) CreateTaskLink(linkData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskLink", reflect.TypeOf((*MockTaskLink)(nil).CreateTaskLink), linkData, userID)
+}
+
+// DeleteTaskLink mocks base method.
+func (m *MockTaskLink) DeleteTaskLink(linkData *dto.DeleteTaskLinkDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskLink", linkData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskLink indicates an expected call of DeleteTaskLink.
+func (mr *MockTaskLinkMockRecorder) DeleteTaskLink(linkData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskLink", reflect.TypeOf((*MockTaskLink)(nil).DeleteTaskLink), linkData, userID)
+}
+
+// GetTaskGraph mocks base method.
+func (m *MockTaskLink) GetTaskGraph(taskID, userID uint64) (*models.TaskGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskGraph", taskID, userID)
+	ret0, _ := ret[0].(*models.TaskGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskGraph indicates an expected call of GetTaskGraph.
+func (mr *MockTaskLinkMockRecorder) GetTaskGraph(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskGraph", reflect.TypeOf((*MockTaskLink)(nil).GetTaskGraph), taskID, userID)
+}
+
+// GetTaskLinks mocks base method.
+func (m *MockTaskLink) GetTaskLinks(taskID, userID uint64) ([]*models.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskLinks", taskID, userID)
+	ret0, _ := ret[0].([]*models.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskLinks indicates an expected call of GetTaskLinks.
+func (mr *MockTaskLinkMockRecorder) GetTaskLinks(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLinks", reflect.TypeOf((*MockTaskLink)(nil).GetTaskLinks), taskID, userID)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockComment) CreateComment(commentData *dto.CreateCommentDto, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", commentData, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentMockRecorder) CreateComment(commentData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockComment)(nil).CreateComment), commentData, userID)
+}
+
+// DeleteComment mocks base method.
+func (m *MockComment) DeleteComment(taskID, commentID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", taskID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentMockRecorder) DeleteComment(taskID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockComment)(nil).DeleteComment), taskID, commentID, userID)
+}
+
+// GetCommentCount mocks base method.
+func (m *MockComment) GetCommentCount(taskID uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCount", taskID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentCount indicates an expected call of GetCommentCount.
+func (mr *MockCommentMockRecorder) GetCommentCount(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCount", reflect.TypeOf((*MockComment)(nil).GetCommentCount), taskID)
+}
+
+// GetCommentHistory mocks base method.
+func (m *MockComment) GetCommentHistory(taskID, commentID, userID uint64) ([]*models.CommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentHistory", taskID, commentID, userID)
+	ret0, _ := ret[0].([]*models.CommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentHistory indicates an expected call of GetCommentHistory.
+func (mr *MockCommentMockRecorder) GetCommentHistory(taskID, commentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentHistory", reflect.TypeOf((*MockComment)(nil).GetCommentHistory), taskID, commentID, userID)
+}
+
+// GetComments mocks base method.
+func (m *MockComment) GetComments(taskID, userID uint64, page *dto.PageDto) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", taskID, userID, page)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentMockRecorder) GetComments(taskID, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockComment)(nil).GetComments), taskID, userID, page)
+}
+
+// UpdateComment mocks base method.
+func (m *MockComment) UpdateComment(commentData *dto.UpdateCommentDto, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", commentData, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockCommentMockRecorder) UpdateComment(commentData, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockComment)(nil).UpdateComment), commentData, userID)
+}
+
+// MockMention is a mock of Mention interface.
+type MockMention struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionMockRecorder
+}
+
+// MockMentionMockRecorder is the mock recorder for MockMention.
+type MockMentionMockRecorder struct {
+	mock *MockMention
+}
+
+// NewMockMention creates a new mock instance.
+func NewMockMention(ctrl *gomock.Controller) *MockMention {
+	mock := &MockMention{ctrl: ctrl}
+	mock.recorder = &MockMentionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMention) EXPECT() *MockMentionMockRecorder {
+	return m.recorder
+}
+
+// GetMentions mocks base method.
+func (m *MockMention) GetMentions(userID uint64, page *dto.PageDto) ([]*models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", userID, page)
+	ret0, _ := ret[0].([]*models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockMentionMockRecorder) GetMentions(userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockMention)(nil).GetMentions), userID, page)
+}
+
+// SaveMentions mocks base method.
+func (m *MockMention) SaveMentions(mentionData *dto.MentionDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMentions", mentionData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMentions indicates an expected call of SaveMentions.
+func (mr *MockMentionMockRecorder) SaveMentions(mentionData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMentions", reflect.TypeOf((*MockMention)(nil).SaveMentions), mentionData)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachment) CreateAttachment(ctx context.Context, attachmentData *dto.CreateAttachmentDto, body io.Reader, userID uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachmentData, body, userID)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentMockRecorder) CreateAttachment(ctx, attachmentData, body, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachment)(nil).CreateAttachment), ctx, attachmentData, body, userID)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachment) DeleteAttachment(ctx context.Context, taskID, attachmentID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, taskID, attachmentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentMockRecorder) DeleteAttachment(ctx, taskID, attachmentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachment)(nil).DeleteAttachment), ctx, taskID, attachmentID, userID)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachment) GetAttachment(ctx context.Context, taskID, attachmentID, userID uint64) (*models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", ctx, taskID, attachmentID, userID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentMockRecorder) GetAttachment(ctx, taskID, attachmentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachment)(nil).GetAttachment), ctx, taskID, attachmentID, userID)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachment) GetAttachments(taskID, userID uint64) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", taskID, userID)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentMockRecorder) GetAttachments(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachment)(nil).GetAttachments), taskID, userID)
+}
+
+// MockActivity is a mock of Activity interface.
+type MockActivity struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityMockRecorder
+}
+
+// MockActivityMockRecorder is the mock recorder for MockActivity.
+type MockActivityMockRecorder struct {
+	mock *MockActivity
+}
+
+// NewMockActivity creates a new mock instance.
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
+	return m.recorder
+}
+
+// GetActivities mocks base method.
+func (m *MockActivity) GetActivities(taskID, userID uint64, page *dto.PageDto) (*models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivities", taskID, userID, page)
+	ret0, _ := ret[0].(*models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivities indicates an expected call of GetActivities.
+func (mr *MockActivityMockRecorder) GetActivities(taskID, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivities", reflect.TypeOf((*MockActivity)(nil).GetActivities), taskID, userID, page)
+}
+
+// MockWatcher is a mock of Watcher interface.
+type MockWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatcherMockRecorder
+}
+
+// MockWatcherMockRecorder is the mock recorder for MockWatcher.
+type MockWatcherMockRecorder struct {
+	mock *MockWatcher
+}
+
+// NewMockWatcher creates a new mock instance.
+func NewMockWatcher(ctrl *gomock.Controller) *MockWatcher {
+	mock := &MockWatcher{ctrl: ctrl}
+	mock.recorder = &MockWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatcher) EXPECT() *MockWatcherMockRecorder {
+	return m.recorder
+}
+
+// GetWatchedTasks mocks base method.
+func (m *MockWatcher) GetWatchedTasks(userID uint64, page *dto.PageDto) ([]*models.WatchedTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchedTasks", userID, page)
+	ret0, _ := ret[0].([]*models.WatchedTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchedTasks indicates an expected call of GetWatchedTasks.
+func (mr *MockWatcherMockRecorder) GetWatchedTasks(userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchedTasks", reflect.TypeOf((*MockWatcher)(nil).GetWatchedTasks), userID, page)
+}
+
+// NotifyWatchers mocks base method.
+func (m *MockWatcher) NotifyWatchers(taskID, actorID uint64, kind string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyWatchers", taskID, actorID, kind)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyWatchers indicates an expected call of NotifyWatchers.
+func (mr *MockWatcherMockRecorder) NotifyWatchers(taskID, actorID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWatchers", reflect.TypeOf((*MockWatcher)(nil).NotifyWatchers), taskID, actorID, kind)
+}
+
+// Unwatch mocks base method.
+func (m *MockWatcher) Unwatch(taskID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockWatcherMockRecorder) Unwatch(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockWatcher)(nil).Unwatch), taskID, userID)
+}
+
+// Watch mocks base method.
+func (m *MockWatcher) Watch(taskID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockWatcherMockRecorder) Watch(taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockWatcher)(nil).Watch), taskID, userID)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotification) GetNotifications(userID uint64, filter *dto.NotificationFilterDto) ([]*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userID, filter)
+	ret0, _ := ret[0].([]*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationMockRecorder) GetNotifications(userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotification)(nil).GetNotifications), userID, filter)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(userID uint64) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userID)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), userID)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockNotification) GetUnreadCount(ctx context.Context, userID uint64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockNotificationMockRecorder) GetUnreadCount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockNotification)(nil).GetUnreadCount), ctx, userID)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(ctx context.Context, notificationID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, notificationID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(ctx, notificationID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), ctx, notificationID, userID)
+}
+
+// Notify mocks base method.
+func (m *MockNotification) Notify(ctx context.Context, notificationData *dto.NotificationDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, notificationData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationMockRecorder) Notify(ctx, notificationData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotification)(nil).Notify), ctx, notificationData)
+}
+
+// SendDigests mocks base method.
+func (m *MockNotification) SendDigests(limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDigests", limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDigests indicates an expected call of SendDigests.
+func (mr *MockNotificationMockRecorder) SendDigests(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDigests", reflect.TypeOf((*MockNotification)(nil).SendDigests), limit)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotification) UpdatePreferences(userID uint64, preferencesData *dto.NotificationPreferencesDto) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", userID, preferencesData)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationMockRecorder) UpdatePreferences(userID, preferencesData interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotification)(nil).UpdatePreferences), userID, preferencesData)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// PurgeOutbox mocks base method.
+func (m *MockOutbox) PurgeOutbox(retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOutbox", retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeOutbox indicates an expected call of PurgeOutbox.
+func (mr *MockOutboxMockRecorder) PurgeOutbox(retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOutbox", reflect.TypeOf((*MockOutbox)(nil).PurgeOutbox), retention)
+}
+
+// QueueMail mocks base method.
+func (m *MockOutbox) QueueMail(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueMail", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueMail indicates an expected call of QueueMail.
+func (mr *MockOutboxMockRecorder) QueueMail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueMail", reflect.TypeOf((*MockOutbox)(nil).QueueMail), email)
+}
+
+// RelayOutbox mocks base method.
+func (m *MockOutbox) RelayOutbox(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutbox", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutbox indicates an expected call of RelayOutbox.
+func (mr *MockOutboxMockRecorder) RelayOutbox(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkOnTask", reflect.TypeOf((*MockTask)(nil).WorkOnTask), workOnTaskData, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutbox", reflect.TypeOf((*MockOutbox)(nil).RelayOutbox), ctx)
 }
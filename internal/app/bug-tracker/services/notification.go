@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+// unreadCountTTL bounds how long a cached unread count can be stale when it
+// is cached at the same time a notification is added.
+const unreadCountTTL = time.Minute * 5
+
+func unreadCountKey(userID uint64) string {
+	return fmt.Sprintf("notifications:unread:%d", userID)
+}
+
+type NotificationService struct {
+	repo  repository.Notification
+	redis redis.Redis
+}
+
+func NewNotification(repo repository.Notification, redis redis.Redis) Notification {
+	return &NotificationService{repo: repo, redis: redis}
+}
+
+// Notify adds the notification to the inbox of every user it is sent to and
+// drops their cached unread counts.
+func (s *NotificationService) Notify(ctx context.Context, notificationData *dto.NotificationDto) error {
+	if len(notificationData.UserIDs) == 0 {
+		return nil
+	}
+
+	if err := s.repo.AddNotifications(notificationData); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(notificationData.UserIDs))
+	for _, userID := range notificationData.UserIDs {
+		keys = append(keys, unreadCountKey(userID))
+	}
+	s.dropUnreadCounts(ctx, keys...)
+
+	return nil
+}
+
+func (s *NotificationService) GetNotifications(
+	userID uint64,
+	filter *dto.NotificationFilterDto,
+) ([]*models.Notification, error) {
+	return s.repo.GetNotifications(userID, filter)
+}
+
+// GetUnreadCount returns the cached unread count of the user, counting and
+// caching it when it is not cached. The count is still returned when Redis
+// cannot be reached.
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID uint64) (int, error) {
+	key := unreadCountKey(userID)
+
+	if cached, err := s.redis.Get(ctx, key); err == nil {
+		if count, err := strconv.Atoi(cached); err == nil {
+			return count, nil
+		}
+	}
+
+	count, err := s.repo.CountUnread(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.redis.Set(ctx, key, strconv.Itoa(count), unreadCountTTL)
+
+	return count, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, notificationID, userID uint64) error {
+	if err := s.repo.MarkRead(notificationID, userID); err != nil {
+		return err
+	}
+	s.dropUnreadCounts(ctx, unreadCountKey(userID))
+
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint64) error {
+	if err := s.repo.MarkAllRead(userID); err != nil {
+		return err
+	}
+	s.dropUnreadCounts(ctx, unreadCountKey(userID))
+
+	return nil
+}
+
+// dropUnreadCounts is called once the inbox is changed, so a failure to drop
+// a cached count, which is logged by Redis, only leaves it stale until it
+// expires.
+func (s *NotificationService) dropUnreadCounts(ctx context.Context, keys ...string) {
+	s.redis.Delete(ctx, keys...)
+}
+
+func (s *NotificationService) GetPreferences(userID uint64) (*models.NotificationPreferences, error) {
+	return s.repo.GetPreferences(userID)
+}
+
+// UpdatePreferences changes the preferences of the user and schedules the
+// next digest of the user by the new digest time and time zone.
+func (s *NotificationService) UpdatePreferences(
+	userID uint64,
+	preferencesData *dto.NotificationPreferencesDto,
+) (*models.NotificationPreferences, error) {
+	preferences, err := s.repo.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for notificationType, channel := range preferencesData.Channels {
+		preferences.Channels[notificationType] = channel
+	}
+	if preferencesData.DigestTime != "" {
+		preferences.DigestTime = preferencesData.DigestTime
+	}
+	if preferencesData.TimeZone != "" {
+		preferences.TimeZone = preferencesData.TimeZone
+	}
+
+	nextDigestAt, err := models.NextDigestAt(time.Now(), preferences.DigestTime, preferences.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SavePreferences(userID, preferences, nextDigestAt); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// SendDigests sends up to limit due digests and returns how many mails were
+// sent. A digest that fails is left due and retried on the next run; the
+// first error is returned after the rest were handled.
+func (s *NotificationService) SendDigests(limit int) (int, error) {
+	schedules, err := s.repo.GetDueDigests(time.Now().UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	sent := 0
+	for _, schedule := range schedules {
+		ok, err := s.sendDigest(schedule)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("error sending digest to user %d: %w", schedule.UserID, err)
+			}
+			continue
+		}
+
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, firstErr
+}
+
+// sendDigest renders the notifications waiting for the digest of the user
+// in one mail and schedules the next digest. It tells whether a mail was
+// sent; none is when nothing waits or another instance sent the digest.
+func (s *NotificationService) sendDigest(schedule *models.DigestSchedule) (bool, error) {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return false, err
+	}
+
+	nextDigestAt, err := models.NextDigestAt(time.Now(), schedule.DigestTime, schedule.TimeZone)
+	if err != nil {
+		return false, err
+	}
+
+	items, err := s.repo.GetDigestItems(schedule.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	var message *models.OutboxMessage
+	var lastItemID uint64
+	if len(items) > 0 {
+		message, err = models.NewDigestMail(schedule.Email, items, location)
+		if err != nil {
+			return false, err
+		}
+		lastItemID = items[len(items)-1].ID
+	}
+
+	completed, err := s.repo.CompleteDigest(schedule, nextDigestAt, lastItemID, message)
+	if err != nil {
+		return false, err
+	}
+
+	return completed && message != nil, nil
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_redis "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis/mocks"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_Notify(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationService
+	err := errors.New("error")
+
+	tests := []struct {
+		name             string
+		notificationData *dto.NotificationDto
+		mockBehaviour    mockBehaviour
+		expectedError    error
+	}{
+		{
+			name:             "No users",
+			notificationData: &dto.NotificationDto{Type: models.NotificationComment, TaskID: 1, ActorID: 1},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationService {
+				return &NotificationService{}
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error in repo.AddNotifications",
+			notificationData: &dto.NotificationDto{
+				UserIDs: []uint64{2},
+				Type:    models.NotificationComment,
+				TaskID:  1,
+				ActorID: 1,
+			},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().AddNotifications(notificationData).Return(err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK cached counts are not dropped",
+			notificationData: &dto.NotificationDto{
+				UserIDs: []uint64{2, 3},
+				Type:    models.NotificationComment,
+				TaskID:  1,
+				ActorID: 1,
+			},
+			mockBehaviour: func(c *gomock.Controller, notificationData *dto.NotificationDto) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				notification.EXPECT().AddNotifications(notificationData).Return(nil)
+				redis.EXPECT().Delete(gomock.Any(), "notifications:unread:2", "notifications:unread:3").Return(err)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.notificationData)
+			err := service.Notify(context.Background(), test.notificationData)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetUnreadCount(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		expectedResult int
+		expectedError  error
+	}{
+		{
+			name: "OK cached",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				redis := mock_redis.NewMockRedis(c)
+
+				redis.EXPECT().Get(gomock.Any(), "notifications:unread:1").Return("4", nil)
+
+				return &NotificationService{redis: redis}
+			},
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+		{
+			name: "Error in repo.CountUnread",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				redis.EXPECT().Get(gomock.Any(), "notifications:unread:1").Return("", err)
+				notification.EXPECT().CountUnread(uint64(1)).Return(0, err)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK not cached",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				redis.EXPECT().Get(gomock.Any(), "notifications:unread:1").Return("", err)
+				notification.EXPECT().CountUnread(uint64(1)).Return(3, nil)
+				redis.EXPECT().Set(gomock.Any(), "notifications:unread:1", "3", unreadCountTTL).Return(nil)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+		{
+			name: "OK cached value is invalid",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				redis.EXPECT().Get(gomock.Any(), "notifications:unread:1").Return("many", nil)
+				notification.EXPECT().CountUnread(uint64(1)).Return(3, nil)
+				redis.EXPECT().Set(gomock.Any(), "notifications:unread:1", "3", unreadCountTTL).Return(err)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedResult: 3,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			res, err := service.GetUnreadCount(context.Background(), 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_MarkRead(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().MarkRead(uint64(5), uint64(1)).Return(err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				notification.EXPECT().MarkRead(uint64(5), uint64(1)).Return(nil)
+				redis.EXPECT().Delete(gomock.Any(), "notifications:unread:1").Return(nil)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			err := service.MarkRead(context.Background(), 5, 1)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_MarkAllRead(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().MarkAllRead(uint64(1)).Return(err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				notification.EXPECT().MarkAllRead(uint64(1)).Return(nil)
+				redis.EXPECT().Delete(gomock.Any(), "notifications:unread:1").Return(nil)
+
+				return &NotificationService{repo: notification, redis: redis}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			err := service.MarkAllRead(context.Background(), 1)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdatePreferences(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, preferencesData *dto.NotificationPreferencesDto) *NotificationService
+	err := errors.New("error")
+
+	tests := []struct {
+		name            string
+		preferencesData *dto.NotificationPreferencesDto
+		mockBehaviour   mockBehaviour
+		expectedResult  *models.NotificationPreferences
+		expectedError   error
+	}{
+		{
+			name:            "Error in repo.GetPreferences",
+			preferencesData: &dto.NotificationPreferencesDto{},
+			mockBehaviour: func(c *gomock.Controller, preferencesData *dto.NotificationPreferencesDto) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().GetPreferences(uint64(1)).Return(nil, err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name:            "Error in repo.SavePreferences",
+			preferencesData: &dto.NotificationPreferencesDto{},
+			mockBehaviour: func(c *gomock.Controller, preferencesData *dto.NotificationPreferencesDto) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().GetPreferences(uint64(1)).Return(models.NewNotificationPreferences(), nil)
+				notification.EXPECT().SavePreferences(uint64(1), gomock.Any(), gomock.Any()).Return(err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			preferencesData: &dto.NotificationPreferencesDto{
+				Channels:   map[string]string{models.NotificationMention: models.ChannelDigest},
+				DigestTime: "18:30",
+				TimeZone:   "Asia/Tokyo",
+			},
+			mockBehaviour: func(c *gomock.Controller, preferencesData *dto.NotificationPreferencesDto) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				current := models.NewNotificationPreferences()
+				current.Channels[models.NotificationStatus] = models.ChannelEmail
+
+				notification.EXPECT().GetPreferences(uint64(1)).Return(current, nil)
+				notification.EXPECT().SavePreferences(uint64(1), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(userID uint64, preferences *models.NotificationPreferences, nextDigestAt time.Time) error {
+						location, err := time.LoadLocation("Asia/Tokyo")
+						require.NoError(t, err)
+
+						local := nextDigestAt.In(location)
+						require.Equal(t, time.UTC, nextDigestAt.Location())
+						require.True(t, nextDigestAt.After(time.Now()))
+						require.True(t, nextDigestAt.Before(time.Now().Add(24*time.Hour)))
+						require.Equal(t, 18, local.Hour())
+						require.Equal(t, 30, local.Minute())
+
+						return nil
+					},
+				)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedResult: &models.NotificationPreferences{
+				Channels: map[string]string{
+					models.NotificationAssignment: models.ChannelInApp,
+					models.NotificationComment:    models.ChannelInApp,
+					models.NotificationStatus:     models.ChannelEmail,
+					models.NotificationMention:    models.ChannelDigest,
+				},
+				DigestTime: "18:30",
+				TimeZone:   "Asia/Tokyo",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.preferencesData)
+			res, err := service.UpdatePreferences(1, test.preferencesData)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_SendDigests(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *NotificationService
+	err := errors.New("error")
+	createdAt := time.Date(2023, 1, 1, 23, 0, 0, 0, time.UTC)
+
+	schedule := func(userID uint64) *models.DigestSchedule {
+		return &models.DigestSchedule{
+			UserID:       userID,
+			Email:        "jane@gmail.com",
+			DigestTime:   "09:00",
+			TimeZone:     "Europe/Berlin",
+			NextDigestAt: time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		expectedSent  int
+		expectedError error
+	}{
+		{
+			name: "Error in repo.GetDueDigests",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().GetDueDigests(gomock.Any(), 10).Return(nil, err)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedSent:  0,
+			expectedError: err,
+		},
+		{
+			name: "Failed digest does not hold back the rest",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().GetDueDigests(gomock.Any(), 10).
+					Return([]*models.DigestSchedule{schedule(1), schedule(2)}, nil)
+				notification.EXPECT().GetDigestItems(uint64(1)).Return(nil, err)
+				notification.EXPECT().GetDigestItems(uint64(2)).Return([]*models.MailItem{{ID: 3}}, nil)
+				notification.EXPECT().CompleteDigest(schedule(2), gomock.Any(), uint64(3), gomock.Any()).Return(true, nil)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedSent:  1,
+			expectedError: errors.New("error sending digest to user 1: error"),
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *NotificationService {
+				notification := mock_repository.NewMockNotification(c)
+
+				notification.EXPECT().GetDueDigests(gomock.Any(), 10).
+					Return([]*models.DigestSchedule{schedule(1), schedule(2), schedule(3)}, nil)
+
+				notification.EXPECT().GetDigestItems(uint64(1)).Return([]*models.MailItem{
+					{ID: 4, Type: models.NotificationStatus, TaskKey: "KEY-1", TaskName: "Login", CreatedAt: createdAt},
+					{ID: 7, Type: models.NotificationComment, TaskKey: "KEY-2", TaskName: "Logout", Actor: "john", CreatedAt: createdAt},
+				}, nil)
+				notification.EXPECT().CompleteDigest(schedule(1), gomock.Any(), uint64(7), gomock.Any()).DoAndReturn(
+					func(
+						schedule *models.DigestSchedule,
+						nextDigestAt time.Time,
+						lastItemID uint64,
+						message *models.OutboxMessage,
+					) (bool, error) {
+						location, err := time.LoadLocation("Europe/Berlin")
+						require.NoError(t, err)
+						require.Equal(t, 9, nextDigestAt.In(location).Hour())
+
+						require.Equal(t, models.MailCategory, message.Category)
+						require.Equal(t, "jane@gmail.com", message.Key)
+						require.Equal(t, map[string]string{models.HeaderMailType: models.MailTypeDigest}, message.Headers)
+						require.JSONEq(t, `{
+							"type": "digest",
+							"to": "jane@gmail.com",
+							"subject": "Daily digest: 2 notifications",
+							"body": "Jan 2 00:00 KEY-1 Login: status changed\nJan 2 00:00 KEY-2 Logout: comments changed by john"
+						}`, string(message.Value))
+
+						return true, nil
+					},
+				)
+
+				notification.EXPECT().GetDigestItems(uint64(2)).Return([]*models.MailItem{}, nil)
+				notification.EXPECT().CompleteDigest(schedule(2), gomock.Any(), uint64(0), nil).Return(true, nil)
+
+				notification.EXPECT().GetDigestItems(uint64(3)).Return([]*models.MailItem{{ID: 5}}, nil)
+				notification.EXPECT().CompleteDigest(schedule(3), gomock.Any(), uint64(5), gomock.Any()).Return(false, nil)
+
+				return &NotificationService{repo: notification}
+			},
+			expectedSent:  1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			sent, err := service.SendDigests(10)
+
+			require.Equal(t, test.expectedSent, sent)
+			if test.expectedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedError.Error())
+			}
+		})
+	}
+}
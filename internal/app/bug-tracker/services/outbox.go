@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+var ErrNoOutboxTopic = errors.New("error no topic is configured for the message")
+
+// OutboxConfig tells the relay where to send the messages of each category,
+// how many to claim at once and for how long, and how long to wait before
+// sending a message that failed again.
+type OutboxConfig struct {
+	Topics          map[string]string
+	BatchSize       int
+	Lease           time.Duration
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+type OutboxService struct {
+	repo   repository.Outbox
+	kafka  kafka.Kafka
+	config *OutboxConfig
+}
+
+func NewOutbox(repo repository.Outbox, kafka kafka.Kafka, config *OutboxConfig) Outbox {
+	return &OutboxService{repo: repo, kafka: kafka, config: config}
+}
+
+func (s *OutboxService) QueueMail(email string) error {
+	return s.repo.AddMessage(models.NewMailMessage(email))
+}
+
+// RelayOutbox sends a batch of messages in the order they were saved and
+// returns how many were sent. Once a message fails, the rest of the messages
+// with its key wait for it to be sent on a later run. The first error of the
+// batch is returned after the whole batch was handled.
+func (s *OutboxService) RelayOutbox(ctx context.Context) (int, error) {
+	messages, err := s.repo.ClaimMessages(s.config.BatchSize, s.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var sendErr error
+	sent := 0
+	failedKeys := make(map[string]bool)
+	unsent := make([]uint64, 0)
+
+	for _, message := range messages {
+		if failedKeys[message.Key] || ctx.Err() != nil {
+			unsent = append(unsent, message.ID)
+			continue
+		}
+
+		if err := s.send(ctx, message); err != nil {
+			failedKeys[message.Key] = true
+			if sendErr == nil {
+				sendErr = fmt.Errorf("error sending outbox message %d: %w", message.ID, err)
+			}
+
+			nextAttemptAt := time.Now().UTC().Add(s.retryBackoff(message.Attempts))
+			if err := s.repo.MarkFailed(message.ID, nextAttemptAt, err.Error()); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if err := s.repo.MarkSent(message.ID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	if len(unsent) > 0 {
+		if err := s.repo.ReleaseMessages(unsent); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, sendErr
+}
+
+func (s *OutboxService) send(ctx context.Context, message *models.OutboxMessage) error {
+	topic, ok := s.config.Topics[message.Category]
+	if !ok || topic == "" {
+		return ErrNoOutboxTopic
+	}
+
+	keys := make([]string, 0, len(message.Headers))
+	for key := range message.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := make([]kafka.Header, 0, len(keys))
+	for _, key := range keys {
+		headers = append(headers, kafka.Header{Key: key, Value: message.Headers[key]})
+	}
+
+	return s.kafka.WriteMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	})
+}
+
+// retryBackoff doubles the delay with every failed attempt up to the max.
+func (s *OutboxService) retryBackoff(attempts int) time.Duration {
+	backoff := s.config.RetryBackoff
+	for i := 0; i < attempts && backoff < s.config.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > s.config.MaxRetryBackoff {
+		return s.config.MaxRetryBackoff
+	}
+
+	return backoff
+}
+
+func (s *OutboxService) PurgeOutbox(retention time.Duration) (int64, error) {
+	return s.repo.PurgeSentMessages(time.Now().UTC().Add(-retention))
+}
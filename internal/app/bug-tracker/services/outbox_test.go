@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka"
+	mock_kafka "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+var testOutboxConfig = &OutboxConfig{
+	Topics:          map[string]string{"task": "task-events", models.MailCategory: "mail"},
+	BatchSize:       10,
+	Lease:           time.Minute,
+	RetryBackoff:    time.Second,
+	MaxRetryBackoff: time.Minute,
+}
+
+func Test_QueueMail(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	outbox := mock_repository.NewMockOutbox(c)
+	outbox.EXPECT().AddMessage(models.NewMailMessage("email@gmail.com")).Return(nil)
+
+	service := &OutboxService{repo: outbox}
+	require.NoError(t, service.QueueMail("email@gmail.com"))
+}
+
+func Test_RelayOutbox(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService
+	err := errors.New("error")
+
+	messages := func() []*models.OutboxMessage {
+		return []*models.OutboxMessage{
+			{ID: 1, Category: "task", Key: "task-1", Value: []byte("1"), Headers: map[string]string{"b": "2", "a": "1"}},
+			{ID: 2, Category: "task", Key: "task-2", Value: []byte("2"), Attempts: 2},
+			{ID: 3, Category: "task", Key: "task-1", Value: []byte("3")},
+			{ID: 4, Category: models.MailCategory, Key: "email@gmail.com", Value: []byte("email@gmail.com")},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		ctx           func() context.Context
+		expectedSent  int
+		expectedError error
+	}{
+		{
+			name: "Error in repo.ClaimMessages",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(nil, err)
+
+				return &OutboxService{repo: outbox, config: testOutboxConfig}
+			},
+			expectedSent:  0,
+			expectedError: err,
+		},
+		{
+			name: "Nothing to send",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return([]*models.OutboxMessage{}, nil)
+
+				return &OutboxService{repo: outbox, config: testOutboxConfig}
+			},
+			expectedSent:  0,
+			expectedError: nil,
+		},
+		{
+			name: "Error in repo.MarkSent",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+				k := mock_kafka.NewMockKafka(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages[:1], nil)
+				k.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil)
+				outbox.EXPECT().MarkSent(uint64(1)).Return(err)
+
+				return &OutboxService{repo: outbox, kafka: k, config: testOutboxConfig}
+			},
+			expectedSent:  0,
+			expectedError: err,
+		},
+		{
+			name: "Error in repo.MarkFailed",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+				k := mock_kafka.NewMockKafka(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages[:1], nil)
+				k.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(err)
+				outbox.EXPECT().MarkFailed(uint64(1), gomock.Any(), err.Error()).Return(err)
+
+				return &OutboxService{repo: outbox, kafka: k, config: testOutboxConfig}
+			},
+			expectedSent:  0,
+			expectedError: err,
+		},
+		{
+			name: "Error in repo.ReleaseMessages",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages, nil)
+				outbox.EXPECT().ReleaseMessages([]uint64{1, 2, 3, 4}).Return(err)
+
+				return &OutboxService{repo: outbox, config: testOutboxConfig}
+			},
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			expectedSent:  0,
+			expectedError: err,
+		},
+		{
+			name: "Failed message holds back its key",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+				k := mock_kafka.NewMockKafka(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages, nil)
+				gomock.InOrder(
+					k.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(err),
+					outbox.EXPECT().MarkFailed(uint64(1), gomock.Any(), err.Error()).DoAndReturn(
+						func(id uint64, nextAttemptAt time.Time, reason string) error {
+							require.WithinDuration(t, time.Now().Add(time.Second), nextAttemptAt, time.Second)
+							return nil
+						},
+					),
+					k.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(2)).Return(nil),
+					k.EXPECT().WriteMessages(gomock.Any(), gomock.Any()).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(4)).Return(nil),
+					outbox.EXPECT().ReleaseMessages([]uint64{3}).Return(nil),
+				)
+
+				return &OutboxService{repo: outbox, kafka: k, config: testOutboxConfig}
+			},
+			expectedSent:  2,
+			expectedError: errors.New("error sending outbox message 1: error"),
+		},
+		{
+			name: "Message without topic",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+				config := &OutboxConfig{Topics: map[string]string{}, BatchSize: 10, Lease: time.Minute}
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages[:1], nil)
+				outbox.EXPECT().MarkFailed(uint64(1), gomock.Any(), ErrNoOutboxTopic.Error()).Return(nil)
+
+				return &OutboxService{repo: outbox, config: config}
+			},
+			expectedSent:  0,
+			expectedError: errors.New("error sending outbox message 1: " + ErrNoOutboxTopic.Error()),
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, messages []*models.OutboxMessage) *OutboxService {
+				outbox := mock_repository.NewMockOutbox(c)
+				k := mock_kafka.NewMockKafka(c)
+
+				outbox.EXPECT().ClaimMessages(10, time.Minute).Return(messages, nil)
+				gomock.InOrder(
+					k.EXPECT().WriteMessages(gomock.Any(), kafka.Message{
+						Topic:   "task-events",
+						Key:     "task-1",
+						Value:   []byte("1"),
+						Headers: []kafka.Header{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+					}).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(1)).Return(nil),
+					k.EXPECT().WriteMessages(gomock.Any(), kafka.Message{
+						Topic:   "task-events",
+						Key:     "task-2",
+						Value:   []byte("2"),
+						Headers: []kafka.Header{},
+					}).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(2)).Return(nil),
+					k.EXPECT().WriteMessages(gomock.Any(), kafka.Message{
+						Topic:   "task-events",
+						Key:     "task-1",
+						Value:   []byte("3"),
+						Headers: []kafka.Header{},
+					}).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(3)).Return(nil),
+					k.EXPECT().WriteMessages(gomock.Any(), kafka.Message{
+						Topic:   "mail",
+						Key:     "email@gmail.com",
+						Value:   []byte("email@gmail.com"),
+						Headers: []kafka.Header{},
+					}).Return(nil),
+					outbox.EXPECT().MarkSent(uint64(4)).Return(nil),
+				)
+
+				return &OutboxService{repo: outbox, kafka: k, config: testOutboxConfig}
+			},
+			expectedSent:  4,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			ctx := context.Background()
+			if test.ctx != nil {
+				ctx = test.ctx()
+			}
+
+			service := test.mockBehaviour(c, messages())
+			sent, err := service.RelayOutbox(ctx)
+
+			require.Equal(t, test.expectedSent, sent)
+			if test.expectedError == nil {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedError.Error())
+			}
+		})
+	}
+}
+
+func Test_retryBackoff(t *testing.T) {
+	service := &OutboxService{config: testOutboxConfig}
+
+	require.Equal(t, time.Second, service.retryBackoff(0))
+	require.Equal(t, 2*time.Second, service.retryBackoff(1))
+	require.Equal(t, 32*time.Second, service.retryBackoff(5))
+	require.Equal(t, time.Minute, service.retryBackoff(6))
+	require.Equal(t, time.Minute, service.retryBackoff(1000))
+}
+
+func Test_PurgeOutbox(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	outbox := mock_repository.NewMockOutbox(c)
+	outbox.EXPECT().PurgeSentMessages(gomock.Any()).DoAndReturn(func(sentBefore time.Time) (int64, error) {
+		require.WithinDuration(t, time.Now().Add(-time.Hour), sentBefore, time.Second)
+		return 2, nil
+	})
+
+	service := &OutboxService{repo: outbox}
+	count, err := service.PurgeOutbox(time.Hour)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
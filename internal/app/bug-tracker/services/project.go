@@ -1,55 +1,107 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+)
+
+const (
+	defaultProjectVisibility = "private"
+	defaultProjectPriority   = "medium"
 )
 
 type ProjectService struct {
-	repo repository.Project
+	repo    repository.Project
+	storage storage.Storage
 }
 
-func NewProject(repo repository.Project) Project {
-	return &ProjectService{repo: repo}
+func NewProject(repo repository.Project, storage storage.Storage) Project {
+	return &ProjectService{repo: repo, storage: storage}
 }
 
-func (s *ProjectService) CreateProject(projectData *dto.CreateProjectDto) (uint64, error) {
-	return s.repo.CreateProject(projectData)
+func (s *ProjectService) CreateProject(projectData *dto.CreateProjectDto, event *models.Event) (uint64, error) {
+	if projectData.Visibility == "" {
+		projectData.Visibility = defaultProjectVisibility
+	}
+	if projectData.DefaultPriority == "" {
+		projectData.DefaultPriority = defaultProjectPriority
+	}
+
+	return s.repo.CreateProject(projectData, event)
 }
 
 func (s *ProjectService) GetProjectById(id uint64) (*models.Project, error) {
 	return s.repo.GetProjectById(id)
 }
 
-func (s *ProjectService) DeleteProject(projectID, userID uint64) error {
-	return s.repo.DeleteProject(projectID, userID)
+func (s *ProjectService) DeleteProject(projectID, userID uint64, event *models.Event) error {
+	return s.repo.DeleteProject(projectID, userID, event)
+}
+
+func (s *ProjectService) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64, event *models.Event) error {
+	return s.repo.UpdateProject(projectData, userID, event)
 }
 
-func (s *ProjectService) UpdateProject(projectData *dto.UpdateProjectDto, userID uint64) error {
-	return s.repo.UpdateProject(projectData, userID)
+func (s *ProjectService) GetProjectAudit(projectID, userID uint64) ([]*models.ProjectAudit, error) {
+	return s.repo.GetProjectAudit(projectID, userID)
 }
 
-func (s *ProjectService) AddMember(memberData *dto.AddMemberDto, userID uint64) error {
-	return s.repo.AddMember(memberData, userID)
+func (s *ProjectService) AddMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
+	return s.repo.AddMember(memberData, userID, event)
 }
 
-func (s *ProjectService) DeleteMember(memberData *dto.AddMemberDto, userID uint64) error {
-	return s.repo.DeleteMember(memberData, userID)
+func (s *ProjectService) DeleteMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error {
+	return s.repo.DeleteMember(memberData, userID, event)
 }
 
 func (s *ProjectService) GetMembers(projectID, userID uint64) ([]*models.User, error) {
 	return s.repo.GetMembers(projectID, userID)
 }
 
-func (s *ProjectService) LeaveProject(projectID, userID uint64) error {
-	return s.repo.LeaveProject(projectID, userID)
+func (s *ProjectService) LeaveProject(projectID, userID uint64, event *models.Event) error {
+	return s.repo.LeaveProject(projectID, userID, event)
 }
 
-func (s *ProjectService) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64) error {
-	return s.repo.SetNewAdmin(newAdminData, adminID)
+func (s *ProjectService) SetNewAdmin(newAdminData *dto.NewAdminDto, adminID uint64, event *models.Event) error {
+	return s.repo.SetNewAdmin(newAdminData, adminID, event)
 }
 
 func (s *ProjectService) GetProjectsByUserId(id uint64) ([]*models.Project, error) {
 	return s.repo.GetProjectsByUserId(id)
 }
+
+func (s *ProjectService) GetArchivedProjectsByUserId(id uint64) ([]*models.Project, error) {
+	return s.repo.GetArchivedProjectsByUserId(id)
+}
+
+func (s *ProjectService) ArchiveProject(projectID, userID uint64, event *models.Event) error {
+	return s.repo.ArchiveProject(projectID, userID, event)
+}
+
+func (s *ProjectService) UnarchiveProject(projectID, userID uint64, event *models.Event) error {
+	return s.repo.UnarchiveProject(projectID, userID, event)
+}
+
+func (s *ProjectService) RestoreProject(projectID, userID uint64, event *models.Event) error {
+	return s.repo.RestoreProject(projectID, userID, event)
+}
+
+func (s *ProjectService) GetTrash(userID uint64) ([]*models.Project, error) {
+	return s.repo.GetTrash(userID)
+}
+
+// PurgeTrash removes the stored contents of the attachments of the purged
+// projects along with them.
+func (s *ProjectService) PurgeTrash(retention time.Duration) (int64, error) {
+	count, storageKeys, err := s.repo.PurgeDeletedProjects(time.Now().UTC().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	return count, deleteObjects(context.Background(), s.storage, storageKeys)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -11,11 +12,13 @@ import (
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+	mock_storage "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage/mocks"
 )
 
 func Test_CreateProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectCreated, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -29,7 +32,7 @@ func Test_CreateProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().CreateProject(projectData).Return(uint64(0), err)
+				project.EXPECT().CreateProject(projectData, event).Return(uint64(0), err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -44,7 +47,7 @@ func Test_CreateProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().CreateProject(projectData).Return(uint64(1), nil)
+				project.EXPECT().CreateProject(projectData, event).Return(uint64(1), nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -56,6 +59,27 @@ func Test_CreateProject(t *testing.T) {
 				AdminID:     1,
 			},
 		},
+		{
+			name: "OK default settings",
+			mockBehaviour: func(c *gomock.Controller, projectData *dto.CreateProjectDto) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().CreateProject(&dto.CreateProjectDto{
+					Name:            "name",
+					AdminID:         1,
+					Visibility:      defaultProjectVisibility,
+					DefaultPriority: defaultProjectPriority,
+				}, event).Return(uint64(1), nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			expectedResult: 1,
+			expectedError:  nil,
+			projectData: &dto.CreateProjectDto{
+				Name:    "name",
+				AdminID: 1,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -64,7 +88,7 @@ func Test_CreateProject(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.projectData)
-			user, err := service.CreateProject(test.projectData)
+			user, err := service.CreateProject(test.projectData, event)
 
 			require.Equal(t, test.expectedResult, user)
 			require.Equal(t, test.expectedError, err)
@@ -128,6 +152,7 @@ func Test_GetProjectById(t *testing.T) {
 func Test_DeleteProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectDeleted, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -141,7 +166,7 @@ func Test_DeleteProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().DeleteProject(projectID, userID).Return(err)
+				project.EXPECT().DeleteProject(projectID, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -154,7 +179,7 @@ func Test_DeleteProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().DeleteProject(projectID, userID).Return(nil)
+				project.EXPECT().DeleteProject(projectID, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -170,7 +195,7 @@ func Test_DeleteProject(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.projectID, test.userID)
-			err := service.DeleteProject(test.projectID, test.userID)
+			err := service.DeleteProject(test.projectID, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -180,6 +205,8 @@ func Test_DeleteProject(t *testing.T) {
 func Test_UpdateProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectUpdated, 1, 1, 0, nil)
+	description := "description"
 
 	tests := []struct {
 		name          string
@@ -193,11 +220,11 @@ func Test_UpdateProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().UpdateProject(projectData, userID).Return(err)
+				project.EXPECT().UpdateProject(projectData, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
-			projectData:   &dto.UpdateProjectDto{Description: "description"},
+			projectData:   &dto.UpdateProjectDto{Description: &description},
 			userID:        1,
 			expectedError: err,
 		},
@@ -206,11 +233,11 @@ func Test_UpdateProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectData *dto.UpdateProjectDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().UpdateProject(projectData, userID).Return(nil)
+				project.EXPECT().UpdateProject(projectData, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
-			projectData:   &dto.UpdateProjectDto{Description: "description"},
+			projectData:   &dto.UpdateProjectDto{Description: &description},
 			userID:        1,
 			expectedError: nil,
 		},
@@ -222,7 +249,7 @@ func Test_UpdateProject(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.projectData, test.userID)
-			err := service.UpdateProject(test.projectData, test.userID)
+			err := service.UpdateProject(test.projectData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -232,6 +259,7 @@ func Test_UpdateProject(t *testing.T) {
 func Test_AddMember(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberAdded, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -245,7 +273,7 @@ func Test_AddMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().AddMember(memberData, userID).Return(err)
+				project.EXPECT().AddMember(memberData, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -258,7 +286,7 @@ func Test_AddMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().AddMember(memberData, userID).Return(nil)
+				project.EXPECT().AddMember(memberData, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -274,7 +302,7 @@ func Test_AddMember(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.memberData, test.userID)
-			err := service.AddMember(test.memberData, test.userID)
+			err := service.AddMember(test.memberData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -284,6 +312,7 @@ func Test_AddMember(t *testing.T) {
 func Test_DeleteMember(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberRemoved, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -297,7 +326,7 @@ func Test_DeleteMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().DeleteMember(memberData, userID).Return(err)
+				project.EXPECT().DeleteMember(memberData, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -310,7 +339,7 @@ func Test_DeleteMember(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, memberData *dto.AddMemberDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().DeleteMember(memberData, userID).Return(nil)
+				project.EXPECT().DeleteMember(memberData, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -326,7 +355,7 @@ func Test_DeleteMember(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.memberData, test.userID)
-			err := service.DeleteMember(test.memberData, test.userID)
+			err := service.DeleteMember(test.memberData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -389,9 +418,66 @@ func Test_GetMembers(t *testing.T) {
 	}
 }
 
+func Test_GetProjectAudit(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedError  error
+		expectedResult []*models.ProjectAudit
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetProjectAudit(projectID, userID).Return(nil, err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedError:  err,
+			expectedResult: nil,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetProjectAudit(projectID, userID).Return([]*models.ProjectAudit{{ID: 1}}, nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedError:  nil,
+			expectedResult: []*models.ProjectAudit{{ID: 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetProjectAudit(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+			require.Equal(t, test.expectedResult, res)
+		})
+	}
+}
+
 func Test_LeaveProject(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventMemberLeft, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -405,7 +491,7 @@ func Test_LeaveProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().LeaveProject(projectID, userID).Return(err)
+				project.EXPECT().LeaveProject(projectID, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -418,7 +504,7 @@ func Test_LeaveProject(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().LeaveProject(projectID, userID).Return(nil)
+				project.EXPECT().LeaveProject(projectID, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -434,7 +520,7 @@ func Test_LeaveProject(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.projectID, test.userID)
-			err := service.LeaveProject(test.projectID, test.userID)
+			err := service.LeaveProject(test.projectID, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -444,6 +530,7 @@ func Test_LeaveProject(t *testing.T) {
 func Test_SetNewAdmin(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, newAdminData *dto.NewAdminDto, userID uint64) *ProjectService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectAdminChanged, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -457,7 +544,7 @@ func Test_SetNewAdmin(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, newAdminData *dto.NewAdminDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().SetNewAdmin(newAdminData, userID).Return(err)
+				project.EXPECT().SetNewAdmin(newAdminData, userID, event).Return(err)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -470,7 +557,7 @@ func Test_SetNewAdmin(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, newAdminData *dto.NewAdminDto, userID uint64) *ProjectService {
 				project := mock_repository.NewMockProject(c)
 
-				project.EXPECT().SetNewAdmin(newAdminData, userID).Return(nil)
+				project.EXPECT().SetNewAdmin(newAdminData, userID, event).Return(nil)
 
 				return &ProjectService{repo: repository.Repository{Project: project}}
 			},
@@ -486,7 +573,7 @@ func Test_SetNewAdmin(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.newAdminData, test.userID)
-			err := service.SetNewAdmin(test.newAdminData, test.userID)
+			err := service.SetNewAdmin(test.newAdminData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -545,3 +632,326 @@ func Test_GetProjectsByMemberId(t *testing.T) {
 		})
 	}
 }
+
+func Test_ArchiveProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
+	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectArchived, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		projectID     uint64
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().ArchiveProject(projectID, userID, event).Return(err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().ArchiveProject(projectID, userID, event).Return(nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			err := service.ArchiveProject(test.projectID, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UnarchiveProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
+	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectUnarchived, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		projectID     uint64
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, event).Return(err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().UnarchiveProject(projectID, userID, event).Return(nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			err := service.UnarchiveProject(test.projectID, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_RestoreProject(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *ProjectService
+	err := errors.New("error")
+	event := models.NewEvent(models.EventProjectRestored, 1, 1, 0, nil)
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		projectID     uint64
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().RestoreProject(projectID, userID, event).Return(err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().RestoreProject(projectID, userID, event).Return(nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			projectID:     1,
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			err := service.RestoreProject(test.projectID, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetArchivedProjectsByUserId(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, id uint64) *ProjectService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		id             uint64
+		expectedResult []*models.Project
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, id uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetArchivedProjectsByUserId(id).Return(nil, err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			id:             1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, id uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetArchivedProjectsByUserId(id).Return([]*models.Project{{ID: 1}}, nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			id:             1,
+			expectedResult: []*models.Project{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.id)
+			user, err := service.GetArchivedProjectsByUserId(test.id)
+
+			require.Equal(t, test.expectedResult, user)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTrash(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, id uint64) *ProjectService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		id             uint64
+		expectedResult []*models.Project
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, id uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetTrash(id).Return(nil, err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			id:             1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, id uint64) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().GetTrash(id).Return([]*models.Project{{ID: 1}}, nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			id:             1,
+			expectedResult: []*models.Project{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.id)
+			user, err := service.GetTrash(test.id)
+
+			require.Equal(t, test.expectedResult, user)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_PurgeTrash(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *ProjectService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		retention      time.Duration
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+
+				project.EXPECT().PurgeDeletedProjects(gomock.Any()).Return(int64(0), nil, err)
+
+				return &ProjectService{repo: repository.Repository{Project: project}}
+			},
+			retention:      time.Hour,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *ProjectService {
+				project := mock_repository.NewMockProject(c)
+				store := mock_storage.NewMockStorage(c)
+
+				project.EXPECT().PurgeDeletedProjects(gomock.Any()).DoAndReturn(func(deletedBefore time.Time) (int64, []string, error) {
+					require.WithinDuration(t, time.Now().Add(-time.Hour), deletedBefore, time.Minute)
+					return 2, []string{"tasks/1/a"}, nil
+				})
+				store.EXPECT().Delete(gomock.Any(), "tasks/1/a").Return(nil)
+
+				return &ProjectService{repo: repository.Repository{Project: project}, storage: store}
+			},
+			retention:      time.Hour,
+			expectedResult: 2,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			count, err := service.PurgeTrash(test.retention)
+
+			require.Equal(t, test.expectedResult, count)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+const (
+	ReleaseNotesMarkdown = "md"
+	ReleaseNotesHTML     = "html"
+	ReleaseNotesJSON     = "json"
+)
+
+var (
+	ErrInvalidReleaseNotesFormat   = errors.New("error release notes format has to be md, html or json")
+	ErrInvalidReleaseNotesTemplate = errors.New("error release notes template is invalid")
+)
+
+const defaultMarkdownTemplate = `# {{.Version.Name}}{{with releaseDate .Version}} ({{.}}){{end}}
+{{with .Version.Description}}
+{{.}}
+{{end}}
+{{- range .Groups}}
+## {{.Kind}}
+
+{{range .Tasks}}- {{.Key}} {{.Name}}
+{{end}}{{end}}`
+
+const htmlTemplate = `<h1>{{.Version.Name}}{{with releaseDate .Version}} ({{.}}){{end}}</h1>
+{{with .Version.Description}}<p>{{.}}</p>
+{{end}}
+{{- range .Groups}}<h2>{{.Kind}}</h2>
+<ul>
+{{range .Tasks}}<li>{{.Key}} {{.Name}}</li>
+{{end}}</ul>
+{{end}}`
+
+var (
+	templateFuncs = map[string]interface{}{
+		"releaseDate": releaseDate,
+	}
+	releaseNotesHTML = htmltemplate.Must(htmltemplate.New("release-notes").Funcs(templateFuncs).Parse(htmlTemplate))
+)
+
+// releaseDate formats the release date of the version, it is empty until the
+// version gets one.
+func releaseDate(version *models.Version) string {
+	if !version.ReleaseDate.Valid {
+		return ""
+	}
+	return version.ReleaseDate.Time.Format("2006-01-02")
+}
+
+type ReleaseNotesService struct {
+	repo repository.ReleaseNotes
+}
+
+func NewReleaseNotes(repo repository.ReleaseNotes) ReleaseNotes {
+	return &ReleaseNotesService{repo: repo}
+}
+
+func (s *ReleaseNotesService) GetReleaseNotes(projectID, versionID, userID uint64) (*models.ReleaseNotes, error) {
+	return s.repo.GetReleaseNotes(projectID, versionID, userID)
+}
+
+func (s *ReleaseNotesService) SetReleaseNotesTemplate(templateData *dto.ReleaseNotesTemplateDto, userID uint64) error {
+	if templateData.Template != "" {
+		if _, err := parseMarkdownTemplate(templateData.Template); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.SetReleaseNotesTemplate(templateData, userID)
+}
+
+// RenderReleaseNotes renders the notes in the given format. Markdown goes
+// through the project's own template when it has one, HTML always uses the
+// built-in template so that the output stays escaped.
+func (s *ReleaseNotesService) RenderReleaseNotes(notes *models.ReleaseNotes, format string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	switch format {
+	case ReleaseNotesJSON:
+		return json.Marshal(notes)
+	case ReleaseNotesHTML:
+		if err := releaseNotesHTML.Execute(buf, notes); err != nil {
+			return nil, err
+		}
+	case ReleaseNotesMarkdown:
+		text := notes.Template
+		if text == "" {
+			text = defaultMarkdownTemplate
+		}
+
+		tmpl, err := parseMarkdownTemplate(text)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := tmpl.Execute(buf, notes); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidReleaseNotesTemplate, err)
+		}
+	default:
+		return nil, ErrInvalidReleaseNotesFormat
+	}
+
+	return buf.Bytes(), nil
+}
+
+func parseMarkdownTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("release-notes").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReleaseNotesTemplate, err)
+	}
+
+	return tmpl, nil
+}
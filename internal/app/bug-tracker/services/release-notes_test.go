@@ -0,0 +1,214 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetReleaseNotes(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		versionID      uint64
+		userID         uint64
+		expectedResult *models.ReleaseNotes
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesService {
+				releaseNotes := mock_repository.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(nil, err)
+
+				return &ReleaseNotesService{repo: releaseNotes}
+			},
+			projectID:      1,
+			versionID:      3,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, versionID, userID uint64) *ReleaseNotesService {
+				releaseNotes := mock_repository.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().GetReleaseNotes(projectID, versionID, userID).Return(&models.ReleaseNotes{Version: &models.Version{ID: 3}}, nil)
+
+				return &ReleaseNotesService{repo: releaseNotes}
+			},
+			projectID:      1,
+			versionID:      3,
+			userID:         1,
+			expectedResult: &models.ReleaseNotes{Version: &models.Version{ID: 3}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.versionID, test.userID)
+			res, err := service.GetReleaseNotes(test.projectID, test.versionID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_SetReleaseNotesTemplate(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesService
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.ReleaseNotesTemplateDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error invalid template",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesService {
+				return &ReleaseNotesService{repo: mock_repository.NewMockReleaseNotes(c)}
+			},
+			data:          &dto.ReleaseNotesTemplateDto{ProjectID: 1, Template: "{{range .Groups}}"},
+			userID:        1,
+			expectedError: ErrInvalidReleaseNotesTemplate,
+		},
+		{
+			name: "OK reset to default",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesService {
+				releaseNotes := mock_repository.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().SetReleaseNotesTemplate(data, userID).Return(nil)
+
+				return &ReleaseNotesService{repo: releaseNotes}
+			},
+			data:          &dto.ReleaseNotesTemplateDto{ProjectID: 1},
+			userID:        1,
+			expectedError: nil,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseNotesTemplateDto, userID uint64) *ReleaseNotesService {
+				releaseNotes := mock_repository.NewMockReleaseNotes(c)
+
+				releaseNotes.EXPECT().SetReleaseNotesTemplate(data, userID).Return(nil)
+
+				return &ReleaseNotesService{repo: releaseNotes}
+			},
+			data:          &dto.ReleaseNotesTemplateDto{ProjectID: 1, Template: "# {{.Version.Name}}"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.SetReleaseNotesTemplate(test.data, test.userID)
+
+			require.ErrorIs(t, err, test.expectedError)
+		})
+	}
+}
+
+func Test_RenderReleaseNotes(t *testing.T) {
+	notes := func(template string) *models.ReleaseNotes {
+		return &models.ReleaseNotes{
+			Version: &models.Version{
+				ID:          3,
+				ProjectID:   1,
+				Name:        "1.2.0",
+				Description: "Spring <release>",
+				ReleaseDate: sql.NullTime{Time: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+				Released:    true,
+			},
+			Groups: []*models.ReleaseNotesGroup{
+				{Kind: "bug", Icon: "bug", Tasks: []*models.ReleaseNotesTask{{ID: 4, Key: "BT-2", Name: "Crash on login"}}},
+				{Kind: "feature", Icon: "lightbulb", Tasks: []*models.ReleaseNotesTask{{ID: 6, Key: "BT-4", Name: "Dark mode"}}},
+			},
+			Template: template,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		notes          *models.ReleaseNotes
+		format         string
+		expectedResult string
+		expectedError  error
+	}{
+		{
+			name:          "Error invalid format",
+			notes:         notes(""),
+			format:        "pdf",
+			expectedError: ErrInvalidReleaseNotesFormat,
+		},
+		{
+			name:          "Error template fails to execute",
+			notes:         notes("{{.Version.Missing}}"),
+			format:        ReleaseNotesMarkdown,
+			expectedError: ErrInvalidReleaseNotesTemplate,
+		},
+		{
+			name:           "OK default markdown",
+			notes:          notes(""),
+			format:         ReleaseNotesMarkdown,
+			expectedResult: "# 1.2.0 (2023-03-01)\n\nSpring <release>\n\n## bug\n\n- BT-2 Crash on login\n\n## feature\n\n- BT-4 Dark mode\n",
+		},
+		{
+			name:           "OK project markdown template",
+			notes:          notes("{{range .Groups}}{{range .Tasks}}* [{{.Key}}] {{.Name}}\n{{end}}{{end}}"),
+			format:         ReleaseNotesMarkdown,
+			expectedResult: "* [BT-2] Crash on login\n* [BT-4] Dark mode\n",
+		},
+		{
+			name:   "OK html",
+			notes:  notes("{{.Version.Name}}"),
+			format: ReleaseNotesHTML,
+			expectedResult: "<h1>1.2.0 (2023-03-01)</h1>\n<p>Spring &lt;release&gt;</p>\n" +
+				"<h2>bug</h2>\n<ul>\n<li>BT-2 Crash on login</li>\n</ul>\n" +
+				"<h2>feature</h2>\n<ul>\n<li>BT-4 Dark mode</li>\n</ul>\n",
+		},
+		{
+			name:   "OK json",
+			notes:  notes(""),
+			format: ReleaseNotesJSON,
+			expectedResult: `{"version":{"id":3,"projectId":1,"name":"1.2.0","description":"Spring \u003crelease\u003e",` +
+				`"releaseDate":{"Time":"2023-03-01T00:00:00Z","Valid":true},"released":true,"archived":false},` +
+				`"groups":[{"kind":"bug","icon":"bug","tasks":[{"id":4,"key":"BT-2","name":"Crash on login"}]},` +
+				`{"kind":"feature","icon":"lightbulb","tasks":[{"id":6,"key":"BT-4","name":"Dark mode"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &ReleaseNotesService{}
+			res, err := service.RenderReleaseNotes(test.notes, test.format)
+
+			require.ErrorIs(t, err, test.expectedError)
+			require.Equal(t, test.expectedResult, string(res))
+		})
+	}
+}
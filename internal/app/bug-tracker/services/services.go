@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
 )
 
 //go:generate mockgen -source=services.go -destination=mocks/services.go
@@ -38,26 +41,156 @@ type Redis interface {
 }
 
 type Project interface {
-	CreateProject(projectData *dto.CreateProjectDto) (uint64, error)
+	CreateProject(projectData *dto.CreateProjectDto, event *models.Event) (uint64, error)
 	GetProjectById(id uint64) (*models.Project, error)
-	DeleteProject(projectID, userID uint64) error
-	UpdateProject(projectData *dto.UpdateProjectDto, userID uint64) error
-	AddMember(memberData *dto.AddMemberDto, userID uint64) error
-	DeleteMember(memberData *dto.AddMemberDto, userID uint64) error
+	DeleteProject(projectID, userID uint64, event *models.Event) error
+	UpdateProject(projectData *dto.UpdateProjectDto, userID uint64, event *models.Event) error
+	GetProjectAudit(projectID, userID uint64) ([]*models.ProjectAudit, error)
+	AddMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error
+	DeleteMember(memberData *dto.AddMemberDto, userID uint64, event *models.Event) error
 	GetMembers(projectID, userID uint64) ([]*models.User, error)
-	LeaveProject(projectID, userID uint64) error
-	SetNewAdmin(newAdmintData *dto.NewAdminDto, adminID uint64) error
+	LeaveProject(projectID, userID uint64, event *models.Event) error
+	SetNewAdmin(newAdmintData *dto.NewAdminDto, adminID uint64, event *models.Event) error
 	GetProjectsByUserId(id uint64) ([]*models.Project, error)
+	GetArchivedProjectsByUserId(id uint64) ([]*models.Project, error)
+	ArchiveProject(projectID, userID uint64, event *models.Event) error
+	UnarchiveProject(projectID, userID uint64, event *models.Event) error
+	RestoreProject(projectID, userID uint64, event *models.Event) error
+	GetTrash(userID uint64) ([]*models.Project, error)
+	PurgeTrash(retention time.Duration) (int64, error)
 }
 
 type Task interface {
-	CreateTask(taskData *dto.CreateTaskDto, userID uint64) (uint64, error)
-	WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error
-	StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error
-	UpdateTask(taskData *dto.UpdateTaskDto, userID uint64) (uint64, error)
-	GetTaskById(id uint64) (*models.Task, error)
-	GetTasksByProjectId(id uint64) ([]*models.Task, error)
-	DeleteTask(taskData *dto.DeleteTaskDto, userID uint64) error
+	CreateTask(taskData *dto.CreateTaskDto, userID uint64, event *models.Event) (uint64, error)
+	WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error
+	StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error
+	UpdateTask(taskData *dto.UpdateTaskDto, userID uint64, event *models.Event) (uint64, string, error)
+	TransitionTask(taskData *dto.TransitionTaskDto, userID uint64, event *models.Event) error
+	GetTaskById(id, userID uint64) (*models.Task, error)
+	GetTaskChildren(id uint64) ([]*models.Task, error)
+	GetTaskIdByKey(taskKey string) (uint64, uint64, error)
+	GetTasksByProjectId(id uint64, filter *dto.TaskFilterDto) ([]*models.Task, error)
+	DeleteTask(taskData *dto.DeleteTaskDto, userID uint64, event *models.Event) error
+}
+
+type Workflow interface {
+	GetWorkflow(projectID, userID uint64) (*models.Workflow, error)
+	CreateStatus(statusData *dto.CreateStatusDto, userID uint64) (uint64, error)
+	UpdateStatus(statusData *dto.UpdateStatusDto, userID uint64) error
+	DeleteStatus(statusData *dto.DeleteStatusDto, userID uint64) error
+	AddTransition(transitionData *dto.TransitionDto, userID uint64) error
+	DeleteTransition(transitionData *dto.TransitionDto, userID uint64) error
+}
+
+type IssueKind interface {
+	GetIssueKinds(projectID, userID uint64) ([]*models.IssueKind, error)
+	CreateIssueKind(kindData *dto.CreateIssueKindDto, userID uint64) (uint64, error)
+	UpdateIssueKind(kindData *dto.UpdateIssueKindDto, userID uint64) error
+	DeleteIssueKind(kindData *dto.DeleteIssueKindDto, userID uint64) error
+}
+
+type CustomField interface {
+	GetCustomFields(projectID, userID uint64) ([]*models.CustomField, error)
+	CreateCustomField(fieldData *dto.CreateCustomFieldDto, userID uint64) (uint64, error)
+	UpdateCustomField(fieldData *dto.UpdateCustomFieldDto, userID uint64) error
+	DeleteCustomField(fieldData *dto.DeleteCustomFieldDto, userID uint64) error
+}
+
+type Label interface {
+	GetLabels(projectID, userID uint64) ([]*models.Label, error)
+	CreateLabel(labelData *dto.CreateLabelDto, userID uint64) (uint64, error)
+	UpdateLabel(labelData *dto.UpdateLabelDto, userID uint64) error
+	DeleteLabel(labelData *dto.DeleteLabelDto, userID uint64) error
+	AddTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error
+	RemoveTaskLabels(labelsData *dto.TaskLabelsDto, userID uint64) error
+}
+
+type Component interface {
+	GetComponents(projectID, userID uint64) ([]*models.Component, error)
+	CreateComponent(componentData *dto.CreateComponentDto, userID uint64) (uint64, error)
+	UpdateComponent(componentData *dto.UpdateComponentDto, userID uint64) error
+	DeleteComponent(componentData *dto.DeleteComponentDto, userID uint64) error
+}
+
+type Version interface {
+	GetVersions(projectID, userID uint64) ([]*models.Version, error)
+	CreateVersion(versionData *dto.CreateVersionDto, userID uint64) (uint64, error)
+	UpdateVersion(versionData *dto.UpdateVersionDto, userID uint64) error
+	DeleteVersion(versionData *dto.DeleteVersionDto, userID uint64) error
+	ReleaseVersion(versionData *dto.ReleaseVersionDto, userID uint64) (int64, error)
+}
+
+type ReleaseNotes interface {
+	GetReleaseNotes(projectID, versionID, userID uint64) (*models.ReleaseNotes, error)
+	SetReleaseNotesTemplate(templateData *dto.ReleaseNotesTemplateDto, userID uint64) error
+	RenderReleaseNotes(notes *models.ReleaseNotes, format string) ([]byte, error)
+}
+
+type Sprint interface {
+	GetSprints(projectID, userID uint64) ([]*models.Sprint, error)
+	CreateSprint(sprintData *dto.CreateSprintDto, userID uint64) (uint64, error)
+	UpdateSprint(sprintData *dto.UpdateSprintDto, userID uint64) error
+	DeleteSprint(sprintData *dto.DeleteSprintDto, userID uint64) error
+	StartSprint(sprintData *dto.StartSprintDto, userID uint64) error
+	CompleteSprint(sprintData *dto.CompleteSprintDto, userID uint64) (int64, error)
+	AddSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error
+	RemoveSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error
+}
+
+type TaskLink interface {
+	CreateTaskLink(linkData *dto.CreateTaskLinkDto, userID uint64) (uint64, error)
+	DeleteTaskLink(linkData *dto.DeleteTaskLinkDto, userID uint64) error
+	GetTaskLinks(taskID, userID uint64) ([]*models.TaskLink, error)
+	GetTaskGraph(taskID, userID uint64) (*models.TaskGraph, error)
+}
+
+type Comment interface {
+	CreateComment(commentData *dto.CreateCommentDto, userID uint64) (uint64, error)
+	UpdateComment(commentData *dto.UpdateCommentDto, userID uint64) error
+	DeleteComment(taskID, commentID, userID uint64) error
+	GetComments(taskID, userID uint64, page *dto.PageDto) (*models.CommentPage, error)
+	GetCommentCount(taskID uint64) (int, error)
+	GetCommentHistory(taskID, commentID, userID uint64) ([]*models.CommentRevision, error)
+}
+
+type Mention interface {
+	SaveMentions(mentionData *dto.MentionDto) error
+	GetMentions(userID uint64, page *dto.PageDto) ([]*models.Mention, error)
+}
+
+type Attachment interface {
+	CreateAttachment(ctx context.Context, attachmentData *dto.CreateAttachmentDto, body io.Reader, userID uint64) (uint64, error)
+	GetAttachments(taskID, userID uint64) ([]*models.Attachment, error)
+	GetAttachment(ctx context.Context, taskID, attachmentID, userID uint64) (*models.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, taskID, attachmentID, userID uint64) error
+}
+
+type Activity interface {
+	GetActivities(taskID, userID uint64, page *dto.PageDto) (*models.ActivityPage, error)
+}
+
+type Watcher interface {
+	Watch(taskID, userID uint64) error
+	Unwatch(taskID, userID uint64) error
+	GetWatchedTasks(userID uint64, page *dto.PageDto) ([]*models.WatchedTask, error)
+	NotifyWatchers(taskID, actorID uint64, kind string) error
+}
+
+type Notification interface {
+	Notify(ctx context.Context, notificationData *dto.NotificationDto) error
+	GetNotifications(userID uint64, filter *dto.NotificationFilterDto) ([]*models.Notification, error)
+	GetUnreadCount(ctx context.Context, userID uint64) (int, error)
+	MarkRead(ctx context.Context, notificationID, userID uint64) error
+	MarkAllRead(ctx context.Context, userID uint64) error
+	GetPreferences(userID uint64) (*models.NotificationPreferences, error)
+	UpdatePreferences(userID uint64, preferencesData *dto.NotificationPreferencesDto) (*models.NotificationPreferences, error)
+	SendDigests(limit int) (int, error)
+}
+
+type Outbox interface {
+	QueueMail(email string) error
+	RelayOutbox(ctx context.Context) (int, error)
+	PurgeOutbox(retention time.Duration) (int64, error)
 }
 
 type Service struct {
@@ -66,14 +199,55 @@ type Service struct {
 	Redis
 	Project
 	Task
+	Workflow
+	IssueKind
+	CustomField
+	Label
+	Component
+	Version
+	ReleaseNotes
+	Sprint
+	TaskLink
+	Comment
+	Mention
+	Attachment
+	Activity
+	Watcher
+	Outbox
+	Notification
 }
 
-func NewService(repo *repository.Repository, redisRepo redis.Redis) *Service {
+func NewService(
+	repo *repository.Repository,
+	redisRepo redis.Redis,
+	outboxWriter kafka.Kafka,
+	outboxConfig *OutboxConfig,
+	store storage.Storage,
+	quota storage.Quota,
+) *Service {
+	notification := NewNotification(repo.Notification, redisRepo)
+
 	return &Service{
-		Auth:    NewAuth(),
-		User:    NewUser(repo.User),
-		Redis:   NewRedis(redisRepo),
-		Project: NewProject(repo.Project),
-		Task:    NewTask(repo.Task),
+		Auth:         NewAuth(),
+		User:         NewUser(repo.User),
+		Redis:        NewRedis(redisRepo),
+		Project:      NewProject(repo.Project, store),
+		Task:         NewTask(repo.Task, store),
+		Workflow:     NewWorkflow(repo.Workflow),
+		IssueKind:    NewIssueKind(repo.IssueKind),
+		CustomField:  NewCustomField(repo.CustomField),
+		Label:        NewLabel(repo.Label),
+		Component:    NewComponent(repo.Component),
+		Version:      NewVersion(repo.Version),
+		ReleaseNotes: NewReleaseNotes(repo.ReleaseNotes),
+		Sprint:       NewSprint(repo.Sprint),
+		TaskLink:     NewTaskLink(repo.TaskLink),
+		Comment:      NewComment(repo.Comment),
+		Mention:      NewMention(repo.Mention, notification),
+		Attachment:   NewAttachment(repo.Attachment, store, quota),
+		Activity:     NewActivity(repo.Activity),
+		Watcher:      NewWatcher(repo.Watcher, notification),
+		Outbox:       NewOutbox(repo.Outbox, outboxWriter, outboxConfig),
+		Notification: notification,
 	}
 }
@@ -6,9 +6,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	mock_kafka "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/kafka/mocks"
 	mock_redis "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis/mocks"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+	mock_storage "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage/mocks"
 )
 
 func Test_NewService(t *testing.T) {
@@ -17,19 +20,57 @@ func Test_NewService(t *testing.T) {
 
 	auth := NewAuth()
 	repo := &repository.Repository{
-		User:    mock_repository.NewMockUser(c),
-		Project: mock_repository.NewMockProject(c),
-		Task:    mock_repository.NewMockTask(c),
+		User:         mock_repository.NewMockUser(c),
+		Project:      mock_repository.NewMockProject(c),
+		Task:         mock_repository.NewMockTask(c),
+		Workflow:     mock_repository.NewMockWorkflow(c),
+		IssueKind:    mock_repository.NewMockIssueKind(c),
+		CustomField:  mock_repository.NewMockCustomField(c),
+		Label:        mock_repository.NewMockLabel(c),
+		Component:    mock_repository.NewMockComponent(c),
+		Version:      mock_repository.NewMockVersion(c),
+		ReleaseNotes: mock_repository.NewMockReleaseNotes(c),
+		Sprint:       mock_repository.NewMockSprint(c),
+		TaskLink:     mock_repository.NewMockTaskLink(c),
+		Comment:      mock_repository.NewMockComment(c),
+		Mention:      mock_repository.NewMockMention(c),
+		Attachment:   mock_repository.NewMockAttachment(c),
+		Activity:     mock_repository.NewMockActivity(c),
+		Watcher:      mock_repository.NewMockWatcher(c),
+		Outbox:       mock_repository.NewMockOutbox(c),
+		Notification: mock_repository.NewMockNotification(c),
 	}
 	redis := mock_redis.NewMockRedis(c)
+	outboxWriter := mock_kafka.NewMockKafka(c)
+	outboxConfig := &OutboxConfig{Topics: map[string]string{"task": "task-events"}, BatchSize: 100}
+	store := mock_storage.NewMockStorage(c)
+	quota := storage.Quota{MaxFileSize: 10, MaxProjectSize: 100}
+
+	notification := NewNotification(repo.Notification, redis)
 
 	expected := &Service{
-		Auth:    auth,
-		Redis:   NewRedis(redis),
-		User:    NewUser(repo.User),
-		Project: NewProject(repo.Project),
-		Task:    NewTask(repo.Task),
+		Auth:         auth,
+		Redis:        NewRedis(redis),
+		User:         NewUser(repo.User),
+		Project:      NewProject(repo.Project, store),
+		Task:         NewTask(repo.Task, store),
+		Workflow:     NewWorkflow(repo.Workflow),
+		IssueKind:    NewIssueKind(repo.IssueKind),
+		CustomField:  NewCustomField(repo.CustomField),
+		Label:        NewLabel(repo.Label),
+		Component:    NewComponent(repo.Component),
+		Version:      NewVersion(repo.Version),
+		ReleaseNotes: NewReleaseNotes(repo.ReleaseNotes),
+		Sprint:       NewSprint(repo.Sprint),
+		TaskLink:     NewTaskLink(repo.TaskLink),
+		Comment:      NewComment(repo.Comment),
+		Mention:      NewMention(repo.Mention, notification),
+		Attachment:   NewAttachment(repo.Attachment, store, quota),
+		Activity:     NewActivity(repo.Activity),
+		Watcher:      NewWatcher(repo.Watcher, notification),
+		Outbox:       NewOutbox(repo.Outbox, outboxWriter, outboxConfig),
+		Notification: notification,
 	}
 
-	require.Equal(t, expected, NewService(repo, redis))
+	require.Equal(t, expected, NewService(repo, redis, outboxWriter, outboxConfig, store, quota))
 }
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type SprintService struct {
+	repo repository.Sprint
+}
+
+func NewSprint(repo repository.Sprint) Sprint {
+	return &SprintService{repo: repo}
+}
+
+func (s *SprintService) GetSprints(projectID, userID uint64) ([]*models.Sprint, error) {
+	return s.repo.GetSprints(projectID, userID)
+}
+
+func (s *SprintService) CreateSprint(sprintData *dto.CreateSprintDto, userID uint64) (uint64, error) {
+	return s.repo.CreateSprint(sprintData, userID)
+}
+
+func (s *SprintService) UpdateSprint(sprintData *dto.UpdateSprintDto, userID uint64) error {
+	return s.repo.UpdateSprint(sprintData, userID)
+}
+
+func (s *SprintService) DeleteSprint(sprintData *dto.DeleteSprintDto, userID uint64) error {
+	return s.repo.DeleteSprint(sprintData, userID)
+}
+
+func (s *SprintService) StartSprint(sprintData *dto.StartSprintDto, userID uint64) error {
+	return s.repo.StartSprint(sprintData, userID)
+}
+
+func (s *SprintService) CompleteSprint(sprintData *dto.CompleteSprintDto, userID uint64) (int64, error) {
+	return s.repo.CompleteSprint(sprintData, userID)
+}
+
+func (s *SprintService) AddSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	return s.repo.AddSprintTasks(tasksData, userID)
+}
+
+func (s *SprintService) RemoveSprintTasks(tasksData *dto.SprintTasksDto, userID uint64) error {
+	return s.repo.RemoveSprintTasks(tasksData, userID)
+}
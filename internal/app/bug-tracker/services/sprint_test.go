@@ -0,0 +1,441 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetSprints(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.Sprint
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().GetSprints(projectID, userID).Return(nil, err)
+
+				return &SprintService{repo: sprint}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().GetSprints(projectID, userID).Return([]*models.Sprint{{ID: 1}}, nil)
+
+				return &SprintService{repo: sprint}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.Sprint{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetSprints(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		sprintData     *dto.CreateSprintDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().CreateSprint(sprintData, userID).Return(uint64(0), err)
+
+				return &SprintService{repo: sprint}
+			},
+			sprintData:     &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, sprintData *dto.CreateSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().CreateSprint(sprintData, userID).Return(uint64(5), nil)
+
+				return &SprintService{repo: sprint}
+			},
+			sprintData:     &dto.CreateSprintDto{ProjectID: 1, Name: "Sprint 1"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.sprintData, test.userID)
+			res, err := service.CreateSprint(test.sprintData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateSprintDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().UpdateSprint(data, userID).Return(err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.UpdateSprintDto{ProjectID: 1, SprintID: 2, Name: "Sprint 2"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().UpdateSprint(data, userID).Return(nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.UpdateSprintDto{ProjectID: 1, SprintID: 2, Name: "Sprint 2"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateSprint(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteSprintDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().DeleteSprint(data, userID).Return(err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.DeleteSprintDto{ProjectID: 1, SprintID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().DeleteSprint(data, userID).Return(nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.DeleteSprintDto{ProjectID: 1, SprintID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteSprint(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CompleteSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		data           *dto.CompleteSprintDto
+		userID         uint64
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().CompleteSprint(data, userID).Return(int64(0), err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:           &dto.CompleteSprintDto{ProjectID: 1, SprintID: 2, MoveToSprintID: 3},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.CompleteSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().CompleteSprint(data, userID).Return(int64(4), nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:           &dto.CompleteSprintDto{ProjectID: 1, SprintID: 2, MoveToSprintID: 3},
+			userID:         1,
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			res, err := service.CompleteSprint(test.data, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_StartSprint(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.StartSprintDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().StartSprint(data, userID).Return(err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.StartSprintDto{ProjectID: 1, SprintID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.StartSprintDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().StartSprint(data, userID).Return(nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.StartSprintDto{ProjectID: 1, SprintID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.StartSprint(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddSprintTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.SprintTasksDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().AddSprintTasks(data, userID).Return(err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().AddSprintTasks(data, userID).Return(nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.AddSprintTasks(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_RemoveSprintTasks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.SprintTasksDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().RemoveSprintTasks(data, userID).Return(err)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.SprintTasksDto, userID uint64) *SprintService {
+				sprint := mock_repository.NewMockSprint(c)
+
+				sprint.EXPECT().RemoveSprintTasks(data, userID).Return(nil)
+
+				return &SprintService{repo: sprint}
+			},
+			data:          &dto.SprintTasksDto{ProjectID: 1, SprintID: 2, TaskIDs: []uint64{5}},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.RemoveSprintTasks(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
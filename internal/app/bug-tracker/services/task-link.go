@@ -0,0 +1,31 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type TaskLinkService struct {
+	repo repository.TaskLink
+}
+
+func NewTaskLink(repo repository.TaskLink) TaskLink {
+	return &TaskLinkService{repo: repo}
+}
+
+func (s *TaskLinkService) CreateTaskLink(linkData *dto.CreateTaskLinkDto, userID uint64) (uint64, error) {
+	return s.repo.CreateTaskLink(linkData, userID)
+}
+
+func (s *TaskLinkService) DeleteTaskLink(linkData *dto.DeleteTaskLinkDto, userID uint64) error {
+	return s.repo.DeleteTaskLink(linkData, userID)
+}
+
+func (s *TaskLinkService) GetTaskLinks(taskID, userID uint64) ([]*models.TaskLink, error) {
+	return s.repo.GetTaskLinks(taskID, userID)
+}
+
+func (s *TaskLinkService) GetTaskGraph(taskID, userID uint64) (*models.TaskGraph, error) {
+	return s.repo.GetTaskGraph(taskID, userID)
+}
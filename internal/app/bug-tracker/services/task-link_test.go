@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_CreateTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		linkData       *dto.CreateTaskLinkDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().CreateTaskLink(linkData, userID).Return(uint64(0), err)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			linkData:       &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlocks},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.CreateTaskLinkDto, userID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().CreateTaskLink(linkData, userID).Return(uint64(5), nil)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			linkData:       &dto.CreateTaskLinkDto{TaskID: 1, ProjectID: 1, TargetID: 2, Type: models.LinkBlocks},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.linkData, test.userID)
+			res, err := service.CreateTaskLink(test.linkData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteTaskLink(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		linkData      *dto.DeleteTaskLinkDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().DeleteTaskLink(linkData, userID).Return(err)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			linkData:      &dto.DeleteTaskLinkDto{TaskID: 1, ProjectID: 1, LinkID: 5},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, linkData *dto.DeleteTaskLinkDto, userID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().DeleteTaskLink(linkData, userID).Return(nil)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			linkData:      &dto.DeleteTaskLinkDto{TaskID: 1, ProjectID: 1, LinkID: 5},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.linkData, test.userID)
+			err := service.DeleteTaskLink(test.linkData, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskLinks(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID uint64) *TaskLinkService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		taskID         uint64
+		expectedResult []*models.TaskLink
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().GetTaskLinks(taskID, uint64(1)).Return(nil, err)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			taskID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().GetTaskLinks(taskID, uint64(1)).Return([]*models.TaskLink{{ID: 5, Type: models.LinkBlocks}}, nil)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			taskID:         1,
+			expectedResult: []*models.TaskLink{{ID: 5, Type: models.LinkBlocks}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.taskID)
+			res, err := service.GetTaskLinks(test.taskID, 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskGraph(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskID uint64) *TaskLinkService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		taskID         uint64
+		expectedResult *models.TaskGraph
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().GetTaskGraph(taskID, uint64(1)).Return(nil, err)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			taskID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskID uint64) *TaskLinkService {
+				taskLink := mock_repository.NewMockTaskLink(c)
+
+				taskLink.EXPECT().GetTaskGraph(taskID, uint64(1)).Return(&models.TaskGraph{Nodes: []*models.LinkedTask{{ID: 1}}}, nil)
+
+				return &TaskLinkService{repo: taskLink}
+			},
+			taskID:         1,
+			expectedResult: &models.TaskGraph{Nodes: []*models.LinkedTask{{ID: 1}}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.taskID)
+			res, err := service.GetTaskGraph(test.taskID, 1)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -1,43 +1,94 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage"
+)
+
+var (
+	errInvalidTaskKey = errors.New("error invalid task key")
 )
 
 type TaskService struct {
-	repo repository.Task
+	repo    repository.Task
+	storage storage.Storage
+}
+
+func NewTask(repo repository.Task, storage storage.Storage) Task {
+	return &TaskService{repo: repo, storage: storage}
+}
+
+func (s *TaskService) CreateTask(taskData *dto.CreateTaskDto, userID uint64, event *models.Event) (uint64, error) {
+	return s.repo.CreateTask(taskData, userID, event)
 }
 
-func NewTask(repo repository.Task) Task {
-	return &TaskService{repo: repo}
+func (s *TaskService) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
+	return s.repo.WorkOnTask(workOnTaskData, userID, event)
 }
 
-func (s *TaskService) CreateTask(taskData *dto.CreateTaskDto, userID uint64) (uint64, error) {
-	return s.repo.CreateTask(taskData, userID)
+func (s *TaskService) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64, event *models.Event) error {
+	return s.repo.StopWorkOnTask(workOnTaskData, userID, event)
 }
 
-func (s *TaskService) WorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
-	return s.repo.WorkOnTask(workOnTaskData, userID)
+func (s *TaskService) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64, event *models.Event) (uint64, string, error) {
+	return s.repo.UpdateTask(taskData, userID, event)
 }
 
-func (s *TaskService) StopWorkOnTask(workOnTaskData *dto.WorkOnTaskDto, userID uint64) error {
-	return s.repo.StopWorkOnTask(workOnTaskData, userID)
+func (s *TaskService) TransitionTask(taskData *dto.TransitionTaskDto, userID uint64, event *models.Event) error {
+	return s.repo.TransitionTask(taskData, userID, event)
 }
 
-func (s *TaskService) UpdateTask(taskData *dto.UpdateTaskDto, userID uint64) (uint64, error) {
-	return s.repo.UpdateTask(taskData, userID)
+func (s *TaskService) GetTaskById(id, userID uint64) (*models.Task, error) {
+	return s.repo.GetTaskById(id, userID)
 }
 
-func (s *TaskService) GetTaskById(id uint64) (*models.Task, error) {
-	return s.repo.GetTaskById(id)
+func (s *TaskService) GetTaskChildren(id uint64) ([]*models.Task, error) {
+	return s.repo.GetTaskChildren(id)
 }
 
-func (s *TaskService) GetTasksByProjectId(id uint64) ([]*models.Task, error) {
-	return s.repo.GetTasksByProjectId(id)
+// GetTaskIdByKey returns the task a key such as API-42 stands for and the
+// project it is in.
+func (s *TaskService) GetTaskIdByKey(taskKey string) (uint64, uint64, error) {
+	projectKey, number, err := parseTaskKey(taskKey)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return s.repo.GetTaskIdByKey(projectKey, number)
 }
 
-func (s *TaskService) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64) error {
-	return s.repo.DeleteTask(taskData, userID)
+func parseTaskKey(taskKey string) (string, uint64, error) {
+	separator := strings.LastIndex(taskKey, "-")
+	if separator <= 0 {
+		return "", 0, errInvalidTaskKey
+	}
+
+	number, err := strconv.ParseUint(taskKey[separator+1:], 10, 64)
+	if err != nil || number == 0 {
+		return "", 0, errInvalidTaskKey
+	}
+
+	return strings.ToUpper(taskKey[:separator]), number, nil
+}
+
+func (s *TaskService) GetTasksByProjectId(id uint64, filter *dto.TaskFilterDto) ([]*models.Task, error) {
+	return s.repo.GetTasksByProjectId(id, filter)
+}
+
+// DeleteTask removes the stored contents of the attachments of the task along
+// with it.
+func (s *TaskService) DeleteTask(taskData *dto.DeleteTaskDto, userID uint64, event *models.Event) error {
+	storageKeys, err := s.repo.DeleteTask(taskData, userID, event)
+	if err != nil {
+		return err
+	}
+
+	return deleteObjects(context.Background(), s.storage, storageKeys)
 }
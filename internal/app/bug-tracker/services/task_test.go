@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,11 +12,13 @@ import (
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
 	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
 	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+	mock_storage "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/storage/mocks"
 )
 
 func Test_CreateTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskCreated, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -30,7 +33,7 @@ func Test_CreateTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().CreateTask(taskData, userID).Return(uint64(0), err)
+				task.EXPECT().CreateTask(taskData, userID, event).Return(uint64(0), err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -48,7 +51,7 @@ func Test_CreateTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.CreateTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().CreateTask(taskData, userID).Return(uint64(1), nil)
+				task.EXPECT().CreateTask(taskData, userID, event).Return(uint64(1), nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -69,7 +72,7 @@ func Test_CreateTask(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.taskData, test.userID)
-			user, err := service.CreateTask(test.taskData, test.userID)
+			user, err := service.CreateTask(test.taskData, test.userID, event)
 
 			require.Equal(t, test.expectedResult, user)
 			require.Equal(t, test.expectedError, err)
@@ -80,6 +83,7 @@ func Test_CreateTask(t *testing.T) {
 func Test_WorkOnTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskAssigned, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -93,7 +97,7 @@ func Test_WorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().WorkOnTask(workOnTaskData, userID).Return(err)
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, event).Return(err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -109,7 +113,7 @@ func Test_WorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().WorkOnTask(workOnTaskData, userID).Return(nil)
+				task.EXPECT().WorkOnTask(workOnTaskData, userID, event).Return(nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -128,7 +132,7 @@ func Test_WorkOnTask(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.workOnTaskData, test.userID)
-			err := service.WorkOnTask(test.workOnTaskData, test.userID)
+			err := service.WorkOnTask(test.workOnTaskData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -138,6 +142,7 @@ func Test_WorkOnTask(t *testing.T) {
 func Test_StopWorkOnTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskUnassigned, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
@@ -151,7 +156,7 @@ func Test_StopWorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().StopWorkOnTask(workOnTaskData, userID).Return(err)
+				task.EXPECT().StopWorkOnTask(workOnTaskData, userID, event).Return(err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -167,7 +172,7 @@ func Test_StopWorkOnTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, workOnTaskData *dto.WorkOnTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().StopWorkOnTask(workOnTaskData, userID).Return(nil)
+				task.EXPECT().StopWorkOnTask(workOnTaskData, userID, event).Return(nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -186,7 +191,7 @@ func Test_StopWorkOnTask(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.workOnTaskData, test.userID)
-			err := service.StopWorkOnTask(test.workOnTaskData, test.userID)
+			err := service.StopWorkOnTask(test.workOnTaskData, test.userID, event)
 
 			require.Equal(t, test.expectedError, err)
 		})
@@ -196,12 +201,14 @@ func Test_StopWorkOnTask(t *testing.T) {
 func Test_UpdateTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskUpdated, 1, 1, 0, nil)
 
 	tests := []struct {
 		name           string
 		mockBehaviour  mockBehaviour
 		userID         uint64
 		expectedResult uint64
+		expectedKind   string
 		expectedError  error
 		taskData       *dto.UpdateTaskDto
 	}{
@@ -210,7 +217,7 @@ func Test_UpdateTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().UpdateTask(taskData, userID).Return(uint64(0), err)
+				task.EXPECT().UpdateTask(taskData, userID, event).Return(uint64(0), "", err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -229,12 +236,13 @@ func Test_UpdateTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.UpdateTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().UpdateTask(taskData, userID).Return(uint64(1), nil)
+				task.EXPECT().UpdateTask(taskData, userID, event).Return(uint64(1), models.ActivityStatus, nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
 			userID:         1,
 			expectedResult: 1,
+			expectedKind:   models.ActivityStatus,
 			expectedError:  nil,
 			taskData: &dto.UpdateTaskDto{
 				Name:        "name",
@@ -251,9 +259,64 @@ func Test_UpdateTask(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.taskData, test.userID)
-			user, err := service.UpdateTask(test.taskData, test.userID)
+			user, kind, err := service.UpdateTask(test.taskData, test.userID, event)
 
 			require.Equal(t, test.expectedResult, user)
+			require.Equal(t, test.expectedKind, kind)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_TransitionTask(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskService
+	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskTransitioned, 1, 1, 0, nil)
+	taskData := &dto.TransitionTaskDto{TaskID: 1, ProjectID: 1, StatusID: 2}
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		userID        uint64
+		expectedError error
+		taskData      *dto.TransitionTaskDto
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().TransitionTask(taskData, userID, event).Return(err)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			userID:        1,
+			expectedError: err,
+			taskData:      taskData,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.TransitionTaskDto, userID uint64) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().TransitionTask(taskData, userID, event).Return(nil)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			userID:        1,
+			expectedError: nil,
+			taskData:      taskData,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.taskData, test.userID)
+			err := service.TransitionTask(test.taskData, test.userID, event)
+
 			require.Equal(t, test.expectedError, err)
 		})
 	}
@@ -275,7 +338,7 @@ func Test_GetTaskById(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().GetTaskById(id).Return(nil, err)
+				task.EXPECT().GetTaskById(id, uint64(2)).Return(nil, err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -288,7 +351,7 @@ func Test_GetTaskById(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().GetTaskById(id).Return(&models.Task{ID: 1}, nil)
+				task.EXPECT().GetTaskById(id, uint64(2)).Return(&models.Task{ID: 1}, nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -304,7 +367,7 @@ func Test_GetTaskById(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.id)
-			user, err := service.GetTaskById(test.id)
+			user, err := service.GetTaskById(test.id, 2)
 
 			require.Equal(t, test.expectedResult, user)
 			require.Equal(t, test.expectedError, err)
@@ -312,7 +375,7 @@ func Test_GetTaskById(t *testing.T) {
 	}
 }
 
-func Test_GetTasksByProjectId(t *testing.T) {
+func Test_GetTaskChildren(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, id uint64) *TaskService
 	err := errors.New("error")
 
@@ -328,7 +391,7 @@ func Test_GetTasksByProjectId(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().GetTasksByProjectId(id).Return(nil, err)
+				task.EXPECT().GetTaskChildren(id).Return(nil, err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -341,12 +404,12 @@ func Test_GetTasksByProjectId(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, id uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().GetTasksByProjectId(id).Return([]*models.Task{{ID: 1}}, nil)
+				task.EXPECT().GetTaskChildren(id).Return([]*models.Task{{ID: 2}}, nil)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
 			id:             1,
-			expectedResult: []*models.Task{{ID: 1}},
+			expectedResult: []*models.Task{{ID: 2}},
 			expectedError:  nil,
 		},
 	}
@@ -357,7 +420,63 @@ func Test_GetTasksByProjectId(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.id)
-			user, err := service.GetTasksByProjectId(test.id)
+			children, err := service.GetTaskChildren(test.id)
+
+			require.Equal(t, test.expectedResult, children)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTasksByProjectId(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		id             uint64
+		filter         *dto.TaskFilterDto
+		expectedResult []*models.Task
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().GetTasksByProjectId(id, filter).Return(nil, err)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			id:             1,
+			filter:         &dto.TaskFilterDto{Kind: "bug"},
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, id uint64, filter *dto.TaskFilterDto) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().GetTasksByProjectId(id, filter).Return([]*models.Task{{ID: 1}}, nil)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			id:             1,
+			filter:         &dto.TaskFilterDto{Kind: "bug"},
+			expectedResult: []*models.Task{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.id, test.filter)
+			user, err := service.GetTasksByProjectId(test.id, test.filter)
 
 			require.Equal(t, test.expectedResult, user)
 			require.Equal(t, test.expectedError, err)
@@ -368,6 +487,7 @@ func Test_GetTasksByProjectId(t *testing.T) {
 func Test_DeleteTask(t *testing.T) {
 	type mockBehaviour func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskService
 	err := errors.New("error")
+	event := models.NewEvent(models.EventTaskDeleted, 1, 1, 0, nil)
 
 	tests := []struct {
 		name          string
@@ -381,7 +501,7 @@ func Test_DeleteTask(t *testing.T) {
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
 
-				task.EXPECT().DeleteTask(taskData, userID).Return(err)
+				task.EXPECT().DeleteTask(taskData, userID, event).Return(nil, err)
 
 				return &TaskService{repo: repository.Repository{Task: task}}
 			},
@@ -392,14 +512,35 @@ func Test_DeleteTask(t *testing.T) {
 				TaskID:    1,
 			},
 		},
+		{
+			name: "Error deleting stored attachments",
+			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskService {
+				task := mock_repository.NewMockTask(c)
+				store := mock_storage.NewMockStorage(c)
+
+				task.EXPECT().DeleteTask(taskData, userID, event).Return([]string{"tasks/1/a", "tasks/1/b"}, nil)
+				store.EXPECT().Delete(gomock.Any(), "tasks/1/a").Return(err)
+				store.EXPECT().Delete(gomock.Any(), "tasks/1/b").Return(nil)
+
+				return &TaskService{repo: repository.Repository{Task: task}, storage: store}
+			},
+			userID:        1,
+			expectedError: fmt.Errorf("error deleting stored object %s: %w", "tasks/1/a", err),
+			taskData: &dto.DeleteTaskDto{
+				ProjectID: 1,
+				TaskID:    1,
+			},
+		},
 		{
 			name: "OK",
 			mockBehaviour: func(c *gomock.Controller, taskData *dto.DeleteTaskDto, userID uint64) *TaskService {
 				task := mock_repository.NewMockTask(c)
+				store := mock_storage.NewMockStorage(c)
 
-				task.EXPECT().DeleteTask(taskData, userID).Return(nil)
+				task.EXPECT().DeleteTask(taskData, userID, event).Return([]string{"tasks/1/a"}, nil)
+				store.EXPECT().Delete(gomock.Any(), "tasks/1/a").Return(nil)
 
-				return &TaskService{repo: repository.Repository{Task: task}}
+				return &TaskService{repo: repository.Repository{Task: task}, storage: store}
 			},
 			userID:        1,
 			expectedError: nil,
@@ -416,8 +557,82 @@ func Test_DeleteTask(t *testing.T) {
 			defer c.Finish()
 
 			service := test.mockBehaviour(c, test.taskData, test.userID)
-			err := service.DeleteTask(test.taskData, test.userID)
+			err := service.DeleteTask(test.taskData, test.userID, event)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_GetTaskIdByKey(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *TaskService
+	err := errors.New("error")
+
+	tests := []struct {
+		name            string
+		mockBehaviour   mockBehaviour
+		taskKey         string
+		expectedResult  uint64
+		expectedProject uint64
+		expectedError   error
+	}{
+		{
+			name: "Error invalid task key",
+			mockBehaviour: func(c *gomock.Controller) *TaskService {
+				return &TaskService{}
+			},
+			taskKey:        "KEY",
+			expectedResult: 0,
+			expectedError:  errInvalidTaskKey,
+		},
+		{
+			name: "Error invalid task number",
+			mockBehaviour: func(c *gomock.Controller) *TaskService {
+				return &TaskService{}
+			},
+			taskKey:        "KEY-0",
+			expectedResult: 0,
+			expectedError:  errInvalidTaskKey,
+		},
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().GetTaskIdByKey("KEY", uint64(42)).Return(uint64(0), uint64(0), err)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			taskKey:        "KEY-42",
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller) *TaskService {
+				task := mock_repository.NewMockTask(c)
+
+				task.EXPECT().GetTaskIdByKey("KEY", uint64(42)).Return(uint64(1), uint64(3), nil)
+
+				return &TaskService{repo: repository.Repository{Task: task}}
+			},
+			taskKey:         "key-42",
+			expectedResult:  1,
+			expectedProject: 3,
+			expectedError:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			id, projectID, err := service.GetTaskIdByKey(test.taskKey)
 
+			require.Equal(t, test.expectedResult, id)
+			require.Equal(t, test.expectedProject, projectID)
 			require.Equal(t, test.expectedError, err)
 		})
 	}
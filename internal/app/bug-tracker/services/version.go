@@ -0,0 +1,35 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type VersionService struct {
+	repo repository.Version
+}
+
+func NewVersion(repo repository.Version) Version {
+	return &VersionService{repo: repo}
+}
+
+func (s *VersionService) GetVersions(projectID, userID uint64) ([]*models.Version, error) {
+	return s.repo.GetVersions(projectID, userID)
+}
+
+func (s *VersionService) CreateVersion(versionData *dto.CreateVersionDto, userID uint64) (uint64, error) {
+	return s.repo.CreateVersion(versionData, userID)
+}
+
+func (s *VersionService) UpdateVersion(versionData *dto.UpdateVersionDto, userID uint64) error {
+	return s.repo.UpdateVersion(versionData, userID)
+}
+
+func (s *VersionService) DeleteVersion(versionData *dto.DeleteVersionDto, userID uint64) error {
+	return s.repo.DeleteVersion(versionData, userID)
+}
+
+func (s *VersionService) ReleaseVersion(versionData *dto.ReleaseVersionDto, userID uint64) (int64, error) {
+	return s.repo.ReleaseVersion(versionData, userID)
+}
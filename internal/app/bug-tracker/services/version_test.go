@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetVersions(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *VersionService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult []*models.Version
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().GetVersions(projectID, userID).Return(nil, err)
+
+				return &VersionService{repo: version}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().GetVersions(projectID, userID).Return([]*models.Version{{ID: 1}}, nil)
+
+				return &VersionService{repo: version}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: []*models.Version{{ID: 1}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetVersions(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		versionData    *dto.CreateVersionDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().CreateVersion(versionData, userID).Return(uint64(0), err)
+
+				return &VersionService{repo: version}
+			},
+			versionData:    &dto.CreateVersionDto{ProjectID: 1, Name: "1.0.0"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, versionData *dto.CreateVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().CreateVersion(versionData, userID).Return(uint64(5), nil)
+
+				return &VersionService{repo: version}
+			},
+			versionData:    &dto.CreateVersionDto{ProjectID: 1, Name: "1.0.0"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.versionData, test.userID)
+			res, err := service.CreateVersion(test.versionData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateVersionDto, userID uint64) *VersionService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateVersionDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().UpdateVersion(data, userID).Return(err)
+
+				return &VersionService{repo: version}
+			},
+			data:          &dto.UpdateVersionDto{ProjectID: 1, VersionID: 2, Name: "1.0.1"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().UpdateVersion(data, userID).Return(nil)
+
+				return &VersionService{repo: version}
+			},
+			data:          &dto.UpdateVersionDto{ProjectID: 1, VersionID: 2, Name: "1.0.1"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateVersion(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteVersionDto, userID uint64) *VersionService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteVersionDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().DeleteVersion(data, userID).Return(err)
+
+				return &VersionService{repo: version}
+			},
+			data:          &dto.DeleteVersionDto{ProjectID: 1, VersionID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().DeleteVersion(data, userID).Return(nil)
+
+				return &VersionService{repo: version}
+			},
+			data:          &dto.DeleteVersionDto{ProjectID: 1, VersionID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteVersion(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_ReleaseVersion(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.ReleaseVersionDto, userID uint64) *VersionService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		data           *dto.ReleaseVersionDto
+		userID         uint64
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().ReleaseVersion(data, userID).Return(int64(0), err)
+
+				return &VersionService{repo: version}
+			},
+			data:           &dto.ReleaseVersionDto{ProjectID: 1, VersionID: 2, MoveToVersionID: 3},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.ReleaseVersionDto, userID uint64) *VersionService {
+				version := mock_repository.NewMockVersion(c)
+
+				version.EXPECT().ReleaseVersion(data, userID).Return(int64(4), nil)
+
+				return &VersionService{repo: version}
+			},
+			data:           &dto.ReleaseVersionDto{ProjectID: 1, VersionID: 2, MoveToVersionID: 3},
+			userID:         1,
+			expectedResult: 4,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			res, err := service.ReleaseVersion(test.data, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type WatcherService struct {
+	repo         repository.Watcher
+	notification Notification
+}
+
+func NewWatcher(repo repository.Watcher, notification Notification) Watcher {
+	return &WatcherService{repo: repo, notification: notification}
+}
+
+func (s *WatcherService) Watch(taskID, userID uint64) error {
+	return s.repo.Watch(taskID, userID)
+}
+
+func (s *WatcherService) Unwatch(taskID, userID uint64) error {
+	return s.repo.Unwatch(taskID, userID)
+}
+
+func (s *WatcherService) GetWatchedTasks(userID uint64, page *dto.PageDto) ([]*models.WatchedTask, error) {
+	return s.repo.GetWatchedTasks(userID, page)
+}
+
+// NotifyWatchers adds assignments, comments and status changes to the inbox
+// of every watcher of the task but the actor who changed it. The repository
+// saves the events for the watchers with the change itself.
+func (s *WatcherService) NotifyWatchers(taskID, actorID uint64, kind string) error {
+	if !models.IsNotified(kind) {
+		return nil
+	}
+
+	userIDs, err := s.repo.GetWatchers(taskID, actorID)
+	if err != nil {
+		return err
+	}
+
+	return s.notification.Notify(context.Background(), &dto.NotificationDto{
+		UserIDs: userIDs,
+		Type:    kind,
+		TaskID:  taskID,
+		ActorID: actorID,
+	})
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_redis "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/redis/mocks"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_NotifyWatchers(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller) *WatcherService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		kind          string
+		mockBehaviour mockBehaviour
+		expectedError error
+	}{
+		{
+			name: "OK kind without notifications",
+			kind: models.ActivityField,
+			mockBehaviour: func(c *gomock.Controller) *WatcherService {
+				return &WatcherService{}
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Error in repo.GetWatchers",
+			kind: models.ActivityStatus,
+			mockBehaviour: func(c *gomock.Controller) *WatcherService {
+				watcher := mock_repository.NewMockWatcher(c)
+
+				watcher.EXPECT().GetWatchers(uint64(1), uint64(1)).Return(nil, err)
+
+				return &WatcherService{repo: watcher}
+			},
+			expectedError: err,
+		},
+		{
+			name: "Error in notification.Notify",
+			kind: models.ActivityStatus,
+			mockBehaviour: func(c *gomock.Controller) *WatcherService {
+				watcher := mock_repository.NewMockWatcher(c)
+				notification := mock_repository.NewMockNotification(c)
+
+				watcher.EXPECT().GetWatchers(uint64(1), uint64(1)).Return([]uint64{2}, nil)
+				notification.EXPECT().AddNotifications(&dto.NotificationDto{
+					UserIDs: []uint64{2},
+					Type:    models.NotificationStatus,
+					TaskID:  1,
+					ActorID: 1,
+				}).Return(err)
+
+				return &WatcherService{repo: watcher, notification: &NotificationService{repo: notification}}
+			},
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			kind: models.ActivityStatus,
+			mockBehaviour: func(c *gomock.Controller) *WatcherService {
+				watcher := mock_repository.NewMockWatcher(c)
+				notification := mock_repository.NewMockNotification(c)
+				redis := mock_redis.NewMockRedis(c)
+
+				watcher.EXPECT().GetWatchers(uint64(1), uint64(1)).Return([]uint64{2, 3}, nil)
+				notification.EXPECT().AddNotifications(&dto.NotificationDto{
+					UserIDs: []uint64{2, 3},
+					Type:    models.NotificationStatus,
+					TaskID:  1,
+					ActorID: 1,
+				}).Return(nil)
+				redis.EXPECT().Delete(gomock.Any(), "notifications:unread:2", "notifications:unread:3").Return(nil)
+
+				return &WatcherService{
+					repo:         watcher,
+					notification: &NotificationService{repo: notification, redis: redis},
+				}
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c)
+			err := service.NotifyWatchers(1, 1, test.kind)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository"
+)
+
+type WorkflowService struct {
+	repo repository.Workflow
+}
+
+func NewWorkflow(repo repository.Workflow) Workflow {
+	return &WorkflowService{repo: repo}
+}
+
+func (s *WorkflowService) GetWorkflow(projectID, userID uint64) (*models.Workflow, error) {
+	return s.repo.GetWorkflow(projectID, userID)
+}
+
+func (s *WorkflowService) CreateStatus(statusData *dto.CreateStatusDto, userID uint64) (uint64, error) {
+	return s.repo.CreateStatus(statusData, userID)
+}
+
+func (s *WorkflowService) UpdateStatus(statusData *dto.UpdateStatusDto, userID uint64) error {
+	return s.repo.UpdateStatus(statusData, userID)
+}
+
+func (s *WorkflowService) DeleteStatus(statusData *dto.DeleteStatusDto, userID uint64) error {
+	return s.repo.DeleteStatus(statusData, userID)
+}
+
+func (s *WorkflowService) AddTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	return s.repo.AddTransition(transitionData, userID)
+}
+
+func (s *WorkflowService) DeleteTransition(transitionData *dto.TransitionDto, userID uint64) error {
+	return s.repo.DeleteTransition(transitionData, userID)
+}
@@ -0,0 +1,333 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/dto"
+	"github.com/samuraivf/bug-tracker/internal/app/bug-tracker/models"
+	mock_repository "github.com/samuraivf/bug-tracker/internal/app/bug-tracker/repository/mocks"
+)
+
+func Test_GetWorkflow(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, projectID, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		projectID      uint64
+		userID         uint64
+		expectedResult *models.Workflow
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().GetWorkflow(projectID, userID).Return(nil, err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: nil,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, projectID, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().GetWorkflow(projectID, userID).Return(&models.Workflow{Statuses: []*models.Status{{ID: 1}}}, nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			projectID:      1,
+			userID:         1,
+			expectedResult: &models.Workflow{Statuses: []*models.Status{{ID: 1}}},
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.projectID, test.userID)
+			res, err := service.GetWorkflow(test.projectID, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_CreateStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name           string
+		mockBehaviour  mockBehaviour
+		statusData     *dto.CreateStatusDto
+		userID         uint64
+		expectedResult uint64
+		expectedError  error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().CreateStatus(statusData, userID).Return(uint64(0), err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			statusData:     &dto.CreateStatusDto{ProjectID: 1, Name: "Needs Info", Category: "todo"},
+			userID:         1,
+			expectedResult: 0,
+			expectedError:  err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, statusData *dto.CreateStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().CreateStatus(statusData, userID).Return(uint64(5), nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			statusData:     &dto.CreateStatusDto{ProjectID: 1, Name: "Needs Info", Category: "todo"},
+			userID:         1,
+			expectedResult: 5,
+			expectedError:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.statusData, test.userID)
+			res, err := service.CreateStatus(test.statusData, test.userID)
+
+			require.Equal(t, test.expectedResult, res)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_UpdateStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.UpdateStatusDto, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.UpdateStatusDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().UpdateStatus(data, userID).Return(err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.UpdateStatusDto{ProjectID: 1, StatusID: 2, Name: "Verified", Category: "done"},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.UpdateStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().UpdateStatus(data, userID).Return(nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.UpdateStatusDto{ProjectID: 1, StatusID: 2, Name: "Verified", Category: "done"},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.UpdateStatus(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteStatus(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.DeleteStatusDto, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.DeleteStatusDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().DeleteStatus(data, userID).Return(err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.DeleteStatusDto{ProjectID: 1, StatusID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.DeleteStatusDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().DeleteStatus(data, userID).Return(nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.DeleteStatusDto{ProjectID: 1, StatusID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteStatus(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_AddTransition(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.TransitionDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().AddTransition(data, userID).Return(err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.TransitionDto{ProjectID: 1, FromStatusID: 1, ToStatusID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().AddTransition(data, userID).Return(nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.TransitionDto{ProjectID: 1, FromStatusID: 1, ToStatusID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.AddTransition(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_DeleteTransition(t *testing.T) {
+	type mockBehaviour func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService
+	err := errors.New("error")
+
+	tests := []struct {
+		name          string
+		mockBehaviour mockBehaviour
+		data          *dto.TransitionDto
+		userID        uint64
+		expectedError error
+	}{
+		{
+			name: "Error",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().DeleteTransition(data, userID).Return(err)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.TransitionDto{ProjectID: 1, FromStatusID: 1, ToStatusID: 2},
+			userID:        1,
+			expectedError: err,
+		},
+		{
+			name: "OK",
+			mockBehaviour: func(c *gomock.Controller, data *dto.TransitionDto, userID uint64) *WorkflowService {
+				workflow := mock_repository.NewMockWorkflow(c)
+
+				workflow.EXPECT().DeleteTransition(data, userID).Return(nil)
+
+				return &WorkflowService{repo: workflow}
+			},
+			data:          &dto.TransitionDto{ProjectID: 1, FromStatusID: 1, ToStatusID: 2},
+			userID:        1,
+			expectedError: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			service := test.mockBehaviour(c, test.data, test.userID)
+			err := service.DeleteTransition(test.data, test.userID)
+
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package storage
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+type Config struct {
+	Backend string
+	Path    string
+	S3      S3Config
+	Quota   Quota
+}
+
+// S3Config points at an S3 compatible service. Objects are addressed in the
+// path style, so the endpoint does not need a DNS name per bucket.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Quota limits the size of a single file and of all the files of a project.
+type Quota struct {
+	MaxFileSize    int64
+	MaxProjectSize int64
+}
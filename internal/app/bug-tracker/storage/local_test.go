@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LocalStorage(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocal(root)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, store.Put(ctx, "tasks/1/screenshot", strings.NewReader("png"), 3, "image/png"))
+
+	content, err := os.ReadFile(filepath.Join(root, "tasks", "1", "screenshot"))
+	require.NoError(t, err)
+	require.Equal(t, "png", string(content))
+
+	body, err := store.Get(ctx, "tasks/1/screenshot")
+	require.NoError(t, err)
+	content, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "png", string(content))
+
+	require.NoError(t, store.Delete(ctx, "tasks/1/screenshot"))
+	require.NoError(t, store.Delete(ctx, "tasks/1/screenshot"))
+
+	_, err = store.Get(ctx, "tasks/1/screenshot")
+	require.Equal(t, ErrObjectNotFound, err)
+}
+
+func Test_LocalStoragePath(t *testing.T) {
+	store := &LocalStorage{root: "/data"}
+
+	tests := []struct {
+		name          string
+		key           string
+		expectedPath  string
+		expectedError error
+	}{
+		{
+			name:          "Error empty key",
+			key:           "",
+			expectedError: errInvalidKey,
+		},
+		{
+			name:          "Error absolute key",
+			key:           "/etc/passwd",
+			expectedError: errInvalidKey,
+		},
+		{
+			name:          "Error key leaves the root",
+			key:           "tasks/../../etc/passwd",
+			expectedError: errInvalidKey,
+		},
+		{
+			name:         "OK",
+			key:          "tasks/1/log",
+			expectedPath: filepath.Join("/data", "tasks", "1", "log"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := store.path(test.key)
+
+			require.Equal(t, test.expectedPath, path)
+			require.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	store, err := New(&Config{Backend: BackendLocal, Path: t.TempDir()})
+	require.NoError(t, err)
+	require.IsType(t, &LocalStorage{}, store)
+
+	_, err = New(&Config{Backend: "ftp"})
+	require.Error(t, err)
+}
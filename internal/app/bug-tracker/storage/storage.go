@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//go:generate mockgen -source=storage.go -destination=mocks/storage.go
+
+var ErrObjectNotFound = errors.New("error stored object is not found")
+
+// Storage keeps the contents of uploaded files under the keys they are
+// stored with.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func New(cfg *Config) (Storage, error) {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocal(cfg.Path)
+	case BackendS3:
+		return NewS3(&cfg.S3)
+	default:
+		return nil, fmt.Errorf("error unknown storage backend %q", cfg.Backend)
+	}
+}
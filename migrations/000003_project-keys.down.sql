@@ -0,0 +1,6 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_project_id_number_key;
+ALTER TABLE tasks DROP COLUMN IF EXISTS number;
+
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS projects_key_key;
+ALTER TABLE projects DROP COLUMN IF EXISTS task_counter;
+ALTER TABLE projects DROP COLUMN IF EXISTS key;
@@ -0,0 +1,20 @@
+ALTER TABLE projects ADD COLUMN key VARCHAR(10);
+ALTER TABLE projects ADD COLUMN task_counter BIGINT NOT NULL DEFAULT 0;
+
+UPDATE projects SET key = 'P' || id;
+
+ALTER TABLE projects ALTER COLUMN key SET NOT NULL;
+ALTER TABLE projects ADD CONSTRAINT projects_key_key UNIQUE (key);
+
+ALTER TABLE tasks ADD COLUMN number BIGINT;
+
+UPDATE tasks SET number = numbered.number FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY id) AS number FROM tasks
+) AS numbered WHERE tasks.id = numbered.id;
+
+UPDATE projects SET task_counter = (
+    SELECT COALESCE(MAX(number), 0) FROM tasks WHERE tasks.project_id = projects.id
+);
+
+ALTER TABLE tasks ALTER COLUMN number SET NOT NULL;
+ALTER TABLE tasks ADD CONSTRAINT tasks_project_id_number_key UNIQUE (project_id, number);
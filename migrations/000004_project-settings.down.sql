@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS projects_audit CASCADE;
+
+DROP INDEX IF EXISTS projects_admin_name_key;
+
+ALTER TABLE projects DROP COLUMN IF EXISTS default_priority;
+ALTER TABLE projects DROP COLUMN IF EXISTS visibility;
+
+DROP TYPE IF EXISTS visibility;
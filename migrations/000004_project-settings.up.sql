@@ -0,0 +1,28 @@
+CREATE TYPE visibility AS ENUM ('private', 'public');
+
+ALTER TABLE projects ADD COLUMN visibility visibility NOT NULL DEFAULT 'private';
+ALTER TABLE projects ADD COLUMN default_priority priority NOT NULL DEFAULT 'medium';
+
+-- Project names become unique per admin. Of projects an admin already has
+-- under the same name (ignoring case), all but the oldest get their id
+-- appended, e.g. "Backend (42)".
+UPDATE projects SET name = name || ' (' || id || ')' WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY admin, LOWER(name) ORDER BY id) AS position
+        FROM projects WHERE deleted_at IS NULL
+    ) AS duplicates WHERE position > 1
+);
+
+CREATE UNIQUE INDEX projects_admin_name_key ON projects (admin, LOWER(name)) WHERE deleted_at IS NULL;
+
+CREATE TABLE projects_audit (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    field TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX projects_audit_project_id_idx ON projects_audit (project_id, changed_at);
@@ -0,0 +1,21 @@
+CREATE TYPE progress AS ENUM ('TO DO', 'IN PROGRESS', 'IN REVIEW', 'DONE');
+
+ALTER TABLE tasks ADD COLUMN task_type progress;
+
+UPDATE tasks SET task_type = (
+    CASE
+        WHEN statuses.name IN ('TO DO', 'IN PROGRESS', 'IN REVIEW', 'DONE') THEN statuses.name
+        WHEN statuses.category = 'todo' THEN 'TO DO'
+        WHEN statuses.category = 'in_progress' THEN 'IN PROGRESS'
+        ELSE 'DONE'
+    END
+)::progress
+FROM statuses WHERE statuses.id = tasks.status_id;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_id_fkey;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status_id;
+
+DROP TABLE IF EXISTS status_transitions CASCADE;
+DROP TABLE IF EXISTS statuses CASCADE;
+
+DROP TYPE IF EXISTS status_category;
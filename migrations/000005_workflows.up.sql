@@ -0,0 +1,47 @@
+CREATE TYPE status_category AS ENUM ('todo', 'in_progress', 'done');
+
+CREATE TABLE statuses (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    category status_category NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    is_initial BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT statuses_project_id_name_key UNIQUE (project_id, name),
+    CONSTRAINT statuses_id_project_id_key UNIQUE (id, project_id)
+);
+
+CREATE UNIQUE INDEX statuses_project_id_initial_key ON statuses (project_id) WHERE is_initial;
+
+CREATE TABLE status_transitions (
+    from_status_id BIGINT REFERENCES statuses(id) ON DELETE CASCADE NOT NULL,
+    to_status_id BIGINT REFERENCES statuses(id) ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (from_status_id, to_status_id),
+    CHECK (from_status_id <> to_status_id)
+);
+
+INSERT INTO statuses (project_id, name, category, position, is_initial)
+SELECT projects.id, defaults.name, defaults.category::status_category, defaults.position, defaults.position = 0
+FROM projects CROSS JOIN (VALUES
+    ('TO DO', 'todo', 0),
+    ('IN PROGRESS', 'in_progress', 1),
+    ('IN REVIEW', 'in_progress', 2),
+    ('DONE', 'done', 3)
+) AS defaults (name, category, position);
+
+INSERT INTO status_transitions (from_status_id, to_status_id)
+SELECT source.id, target.id FROM statuses AS source
+JOIN statuses AS target ON target.project_id = source.project_id AND target.id <> source.id;
+
+ALTER TABLE tasks ADD COLUMN status_id BIGINT;
+
+UPDATE tasks SET status_id = statuses.id FROM statuses
+WHERE statuses.project_id = tasks.project_id AND statuses.name = COALESCE(tasks.task_type::TEXT, 'TO DO');
+
+ALTER TABLE tasks ALTER COLUMN status_id SET NOT NULL;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_id_fkey
+    FOREIGN KEY (status_id, project_id) REFERENCES statuses(id, project_id);
+
+ALTER TABLE tasks DROP COLUMN task_type;
+
+DROP TYPE progress;
@@ -0,0 +1,4 @@
+ALTER TABLE status_transitions DROP COLUMN IF EXISTS guards;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS resolution;
+ALTER TABLE tasks DROP COLUMN IF EXISTS reviewer;
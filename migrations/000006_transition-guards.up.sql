@@ -0,0 +1,4 @@
+ALTER TABLE tasks ADD COLUMN reviewer INT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN resolution TEXT;
+
+ALTER TABLE status_transitions ADD COLUMN guards TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_kind_id_fkey;
+ALTER TABLE tasks DROP COLUMN IF EXISTS kind_id;
+
+DROP TABLE IF EXISTS issue_kinds CASCADE;
@@ -0,0 +1,30 @@
+CREATE TABLE issue_kinds (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    icon TEXT NOT NULL DEFAULT '',
+    position INT NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT issue_kinds_project_id_name_key UNIQUE (project_id, name),
+    CONSTRAINT issue_kinds_id_project_id_key UNIQUE (id, project_id)
+);
+
+CREATE UNIQUE INDEX issue_kinds_project_id_default_key ON issue_kinds (project_id) WHERE is_default;
+
+INSERT INTO issue_kinds (project_id, name, icon, position, is_default)
+SELECT projects.id, defaults.name, defaults.icon, defaults.position, defaults.name = 'feature'
+FROM projects CROSS JOIN (VALUES
+    ('bug', 'bug', 0),
+    ('feature', 'lightbulb', 1),
+    ('chore', 'wrench', 2)
+) AS defaults (name, icon, position);
+
+ALTER TABLE tasks ADD COLUMN kind_id BIGINT;
+
+-- Nothing recorded the kind of existing tasks, so they take the project default.
+UPDATE tasks SET kind_id = issue_kinds.id FROM issue_kinds
+WHERE issue_kinds.project_id = tasks.project_id AND issue_kinds.is_default;
+
+ALTER TABLE tasks ALTER COLUMN kind_id SET NOT NULL;
+ALTER TABLE tasks ADD CONSTRAINT tasks_kind_id_fkey
+    FOREIGN KEY (kind_id, project_id) REFERENCES issue_kinds(id, project_id);
@@ -0,0 +1,14 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS required_bug_fields;
+
+ALTER TABLE issue_kinds DROP COLUMN IF EXISTS is_bug;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS reproducibility;
+ALTER TABLE tasks DROP COLUMN IF EXISTS actual_result;
+ALTER TABLE tasks DROP COLUMN IF EXISTS expected_result;
+ALTER TABLE tasks DROP COLUMN IF EXISTS steps_to_reproduce;
+ALTER TABLE tasks DROP COLUMN IF EXISTS affected_version;
+ALTER TABLE tasks DROP COLUMN IF EXISTS environment;
+ALTER TABLE tasks DROP COLUMN IF EXISTS severity;
+
+DROP TYPE IF EXISTS bug_reproducibility;
+DROP TYPE IF EXISTS bug_severity;
@@ -0,0 +1,16 @@
+CREATE TYPE bug_severity AS ENUM ('trivial', 'minor', 'major', 'critical', 'blocker');
+CREATE TYPE bug_reproducibility AS ENUM ('always', 'sometimes', 'rarely', 'unable');
+
+ALTER TABLE tasks ADD COLUMN severity bug_severity;
+ALTER TABLE tasks ADD COLUMN environment TEXT;
+ALTER TABLE tasks ADD COLUMN affected_version TEXT;
+ALTER TABLE tasks ADD COLUMN steps_to_reproduce TEXT;
+ALTER TABLE tasks ADD COLUMN expected_result TEXT;
+ALTER TABLE tasks ADD COLUMN actual_result TEXT;
+ALTER TABLE tasks ADD COLUMN reproducibility bug_reproducibility;
+
+ALTER TABLE issue_kinds ADD COLUMN is_bug BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE issue_kinds SET is_bug = TRUE WHERE name = 'bug';
+
+ALTER TABLE projects ADD COLUMN required_bug_fields TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_custom_values;
+DROP TABLE IF EXISTS custom_fields;
+
+DROP TYPE IF EXISTS custom_field_type;
@@ -0,0 +1,25 @@
+CREATE TYPE custom_field_type AS ENUM ('text', 'number', 'date', 'select', 'multiselect', 'user');
+
+CREATE TABLE custom_fields (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    field_type custom_field_type NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
+    CONSTRAINT custom_fields_project_id_name_key UNIQUE (project_id, name)
+);
+
+-- Every value keeps a text form for filtering; numbers and users also keep a
+-- numeric one so they sort numerically, and multi-selects keep their options.
+CREATE TABLE task_custom_values (
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    field_id BIGINT REFERENCES custom_fields(id) ON DELETE CASCADE NOT NULL,
+    value_text TEXT,
+    value_number DOUBLE PRECISION,
+    value_options TEXT[],
+    PRIMARY KEY (task_id, field_id)
+);
+
+CREATE INDEX task_custom_values_field_id_value_text_idx ON task_custom_values (field_id, value_text);
@@ -0,0 +1,18 @@
+CREATE TABLE labels (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    color VARCHAR(7) NOT NULL DEFAULT '#808080',
+    description TEXT NOT NULL DEFAULT '',
+    CONSTRAINT labels_project_id_name_key UNIQUE (project_id, name)
+);
+
+-- Tasks point at labels by id, so a rename shows up everywhere and deleting a
+-- label only removes it from the tasks that carried it.
+CREATE TABLE task_labels (
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    label_id BIGINT REFERENCES labels(id) ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX task_labels_label_id_idx ON task_labels (label_id);
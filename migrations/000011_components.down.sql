@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS component_id;
+
+DROP TABLE IF EXISTS components;
@@ -0,0 +1,14 @@
+CREATE TABLE components (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    lead INT REFERENCES users(id) ON DELETE SET NULL,
+    default_assignee INT REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT components_project_id_name_key UNIQUE (project_id, name)
+);
+
+-- Removing a component leaves its tasks without one.
+ALTER TABLE tasks ADD COLUMN component_id BIGINT REFERENCES components(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_component_id_idx ON tasks (component_id);
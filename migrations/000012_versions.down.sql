@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_versions;
+DROP TABLE IF EXISTS versions;
+
+DROP TYPE IF EXISTS task_version_relation;
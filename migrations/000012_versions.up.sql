@@ -0,0 +1,21 @@
+CREATE TYPE task_version_relation AS ENUM ('affects', 'fix');
+
+CREATE TABLE versions (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    release_date DATE,
+    released BOOLEAN NOT NULL DEFAULT FALSE,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT versions_project_id_name_key UNIQUE (project_id, name)
+);
+
+CREATE TABLE task_versions (
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    version_id BIGINT REFERENCES versions(id) ON DELETE CASCADE NOT NULL,
+    relation task_version_relation NOT NULL,
+    PRIMARY KEY (task_id, version_id, relation)
+);
+
+CREATE INDEX task_versions_version_id_idx ON task_versions (version_id, relation);
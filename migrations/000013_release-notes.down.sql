@@ -0,0 +1 @@
+ALTER TABLE projects DROP COLUMN release_notes_template;
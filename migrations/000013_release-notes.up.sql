@@ -0,0 +1 @@
+ALTER TABLE projects ADD COLUMN release_notes_template TEXT NOT NULL DEFAULT '';
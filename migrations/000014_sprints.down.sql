@@ -0,0 +1,5 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS sprint_id;
+
+DROP TABLE IF EXISTS sprints;
+
+DROP TYPE IF EXISTS sprint_state;
@@ -0,0 +1,22 @@
+CREATE TYPE sprint_state AS ENUM ('planned', 'active', 'closed');
+
+CREATE TABLE sprints (
+    id BIGSERIAL PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    goal TEXT NOT NULL DEFAULT '',
+    start_date DATE,
+    end_date DATE,
+    state sprint_state NOT NULL DEFAULT 'planned',
+    completed_at TIMESTAMP,
+    CONSTRAINT sprints_project_id_name_key UNIQUE (project_id, name),
+    CONSTRAINT sprints_dates_check CHECK (end_date >= start_date)
+);
+
+-- A project is a single board, so it runs at most one sprint at a time.
+CREATE UNIQUE INDEX sprints_project_id_active_key ON sprints (project_id) WHERE state = 'active';
+
+-- Tasks without a sprint are in the backlog.
+ALTER TABLE tasks ADD COLUMN sprint_id BIGINT REFERENCES sprints(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_sprint_id_idx ON tasks (sprint_id);
@@ -0,0 +1,3 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS max_task_depth;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,9 @@
+-- Epics contain stories and stories contain subtasks. Deleting a parent
+-- turns its children into top-level tasks.
+ALTER TABLE tasks ADD COLUMN parent_id BIGINT REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_parent_id_idx ON tasks (parent_id);
+
+-- How many levels a task hierarchy may have, counting the top-level task.
+ALTER TABLE projects ADD COLUMN max_task_depth INT NOT NULL DEFAULT 3
+    CONSTRAINT projects_max_task_depth_check CHECK (max_task_depth BETWEEN 1 AND 10);
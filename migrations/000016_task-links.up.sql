@@ -0,0 +1,16 @@
+CREATE TYPE task_link_type AS ENUM ('blocks', 'duplicates', 'relates-to', 'caused-by');
+
+-- A link reads from the source to the target: the source blocks, duplicates,
+-- relates to or is caused by the target.
+CREATE TABLE task_links (
+    id BIGSERIAL PRIMARY KEY,
+    source_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    target_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    link_type task_link_type NOT NULL,
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT task_links_source_id_target_id_link_type_key UNIQUE (source_id, target_id, link_type),
+    CONSTRAINT task_links_self_check CHECK (source_id <> target_id)
+);
+
+CREATE INDEX task_links_target_id_idx ON task_links (target_id);
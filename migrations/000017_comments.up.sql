@@ -0,0 +1,26 @@
+-- Comment bodies are Markdown. Replies always point at the first comment of
+-- their thread, so threads are one level deep.
+CREATE TABLE comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    parent_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+    author_id INT REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    edited_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX comments_task_id_idx ON comments (task_id, created_at);
+CREATE INDEX comments_parent_id_idx ON comments (parent_id);
+
+-- Every edit keeps the body the comment had before it.
+CREATE TABLE comment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT REFERENCES comments(id) ON DELETE CASCADE NOT NULL,
+    body TEXT NOT NULL,
+    edited_by INT REFERENCES users(id) ON DELETE SET NULL,
+    edited_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX comment_revisions_comment_id_idx ON comment_revisions (comment_id);
@@ -0,0 +1,13 @@
+-- A mention is stored once per user and per text it appears in: the task
+-- description when comment_id is NULL, otherwise the comment.
+CREATE TABLE mentions (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    comment_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+    mentioned_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX mentions_source_user_key ON mentions (task_id, COALESCE(comment_id, 0), user_id);
+CREATE INDEX mentions_user_id_idx ON mentions (user_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_activities;
+
+DROP TYPE IF EXISTS task_activity_kind;
@@ -0,0 +1,17 @@
+CREATE TYPE task_activity_kind AS ENUM ('created', 'field', 'assignment', 'status', 'comment', 'attachment');
+
+-- The activity log of a task is append-only: rows are only ever inserted, in
+-- the same transaction as the change they describe. Field changes name the
+-- field the way the API does and keep both values as JSON.
+CREATE TABLE task_activities (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    kind task_activity_kind NOT NULL,
+    field TEXT,
+    old_value JSONB,
+    new_value JSONB,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX task_activities_task_id_idx ON task_activities (task_id, created_at, id);
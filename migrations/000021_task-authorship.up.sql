@@ -0,0 +1,28 @@
+ALTER TABLE tasks ADD COLUMN reporter INT REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN updated_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN updated_by INT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Only the project admin could create tasks, so tasks created before the
+-- activity log took note of it were reported by the admin.
+UPDATE tasks SET reporter = COALESCE(
+    (SELECT actor_id FROM task_activities WHERE task_id = tasks.id AND kind = 'created' ORDER BY id LIMIT 1),
+    (SELECT admin FROM projects WHERE id = tasks.project_id)
+);
+
+-- The last logged change of the task itself is its last modification;
+-- tasks without one were last modified when they were created.
+UPDATE tasks SET
+    updated_at = COALESCE(last_change.created_at, tasks.created_at),
+    updated_by = COALESCE(last_change.actor_id, tasks.reporter)
+FROM tasks AS task
+LEFT JOIN LATERAL (
+    SELECT created_at, actor_id FROM task_activities
+    WHERE task_id = task.id AND kind IN ('created', 'field', 'assignment', 'status')
+    ORDER BY created_at DESC, id DESC LIMIT 1
+) AS last_change ON TRUE
+WHERE task.id = tasks.id;
+
+ALTER TABLE tasks ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX tasks_reporter_idx ON tasks (reporter);
+CREATE INDEX tasks_updated_at_idx ON tasks (project_id, updated_at);
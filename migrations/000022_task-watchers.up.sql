@@ -0,0 +1,21 @@
+-- Watchers get a notification for every change of the task made by someone
+-- else. The reporter, the assignee and the commenters start watching a task
+-- on their own; anyone taking part in the project can watch or unwatch it.
+CREATE TABLE task_watchers (
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX task_watchers_user_id_idx ON task_watchers (user_id, created_at);
+
+INSERT INTO task_watchers (task_id, user_id, created_at)
+SELECT task_id, user_id, MIN(created_at) FROM (
+    SELECT id AS task_id, reporter AS user_id, created_at FROM tasks WHERE reporter IS NOT NULL
+    UNION ALL
+    SELECT id, assignee, updated_at FROM tasks WHERE assignee IS NOT NULL
+    UNION ALL
+    SELECT task_id, author_id, created_at FROM comments WHERE author_id IS NOT NULL
+) AS watchers
+GROUP BY task_id, user_id;
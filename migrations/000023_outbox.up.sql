@@ -0,0 +1,21 @@
+-- Messages for Kafka are saved here in the same transaction as the change
+-- they tell about and sent later by the relay, so a message is sent at least
+-- once for every committed change and never for a rolled back one.
+-- A relay leases the messages it sends with locked_until; a message that
+-- failed waits until next_attempt_at. Sent messages are kept for a while.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    category VARCHAR(64) NOT NULL,
+    message_key VARCHAR(255) NOT NULL,
+    value BYTEA NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    last_error TEXT,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (message_key, id) WHERE sent_at IS NULL;
+CREATE INDEX outbox_sent_at_idx ON outbox (sent_at) WHERE sent_at IS NOT NULL;
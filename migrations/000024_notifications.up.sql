@@ -0,0 +1,16 @@
+-- Notifications are the in-app inbox of a user. Watchers get one for every
+-- assignment, comment and status change of a task made by someone else, and
+-- a mentioned user gets one for every new mention.
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE NOT NULL,
+    comment_id BIGINT REFERENCES comments(id) ON DELETE CASCADE,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    read_at TIMESTAMP
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, created_at);
+CREATE INDEX notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS notifications_digest_idx;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS digested_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS channel;
+DROP TABLE IF EXISTS digest_schedules;
+DROP TABLE IF EXISTS notification_preferences;
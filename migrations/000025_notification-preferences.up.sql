@@ -0,0 +1,27 @@
+-- A user picks how each type of notification is delivered: by email right
+-- away, in the daily digest, in the in-app inbox only, or not at all. Email
+-- and digest notifications are added to the inbox too. Types without a
+-- preference are delivered in-app only.
+CREATE TABLE notification_preferences (
+    user_id INT REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
+
+-- The digest of a user is sent every day at send_at in the time zone of the
+-- user. next_digest_at is the next such moment in UTC; moving it forward is
+-- what claims a digest, so it is sent once however many instances run.
+CREATE TABLE digest_schedules (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    time_zone VARCHAR(64) NOT NULL,
+    send_at TIME NOT NULL,
+    next_digest_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX digest_schedules_next_digest_at_idx ON digest_schedules (next_digest_at);
+
+ALTER TABLE notifications ADD COLUMN channel VARCHAR(16) NOT NULL DEFAULT 'in_app';
+ALTER TABLE notifications ADD COLUMN digested_at TIMESTAMP;
+
+CREATE INDEX notifications_digest_idx ON notifications (user_id, id) WHERE channel = 'digest' AND digested_at IS NULL;
@@ -0,0 +1,10 @@
+-- Keys a project was known by before a rename, so that task keys such as
+-- API-42 keep resolving after the project key changes. A key replaced by
+-- another project later moves to that project.
+CREATE TABLE project_key_history (
+    key VARCHAR(20) PRIMARY KEY,
+    project_id INT REFERENCES projects(id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    replaced_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX project_key_history_project_id_idx ON project_key_history (project_id);
@@ -0,0 +1 @@
+ALTER TABLE projects ALTER COLUMN key TYPE VARCHAR(10);
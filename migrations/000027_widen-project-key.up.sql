@@ -0,0 +1,3 @@
+-- Backfilled keys are 'P' followed by the project id, which outgrows the
+-- original ten characters once ids get long enough.
+ALTER TABLE projects ALTER COLUMN key TYPE VARCHAR(20);